@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/config"
+	"go-crawler/web/BE/internal/infrastructure/database"
+	"go-crawler/web/BE/internal/usecases"
+)
+
+// runCommand executes an admin subcommand, e.g. `server annotate-domains -batch 500`.
+func runCommand(name string, args []string, db *database.Database, cfg *config.Config, proteinUseCases usecases.ProteinUseCases, domainUseCases usecases.DomainAnnotationUseCases, uniProtUseCases usecases.UniProtUseCases, goUseCases usecases.GeneOntologyUseCases, taxonomyUseCases usecases.TaxonomyUseCases, diseaseUseCases usecases.DiseaseUseCases) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch name {
+	case "migrate":
+		return migrateDatabase(ctx, args, db)
+	case "annotate-domains":
+		return annotateDomains(ctx, args, domainUseCases)
+	case "import-uniprot":
+		return importUniProt(ctx, args, uniProtUseCases)
+	case "import-go":
+		return importGO(ctx, args, goUseCases)
+	case "import-gaf":
+		return importGAF(ctx, args, goUseCases)
+	case "import-taxonomy":
+		return importTaxonomy(ctx, args, taxonomyUseCases)
+	case "import-diseases":
+		return importDiseases(ctx, args, diseaseUseCases)
+	case "purge-trash":
+		return purgeTrash(ctx, args, proteinUseCases, cfg.Trash)
+	default:
+		return fmt.Errorf("unknown command %q (available: migrate, annotate-domains, import-uniprot, import-go, import-gaf, import-taxonomy, import-diseases, purge-trash)", name)
+	}
+}
+
+// migrateDatabase runs `migrate up`, `migrate down` (revert the last applied group) or
+// `migrate status`.
+func migrateDatabase(ctx context.Context, args []string, db *database.Database) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: migrate up|down|status")
+	}
+
+	switch args[0] {
+	case "up":
+		group, err := db.Migrate(ctx)
+		if err != nil {
+			return err
+		}
+		if group.IsZero() {
+			log.Printf("No pending migrations")
+			return nil
+		}
+		log.Printf("Applied %s", group)
+	case "down":
+		group, err := db.Rollback(ctx)
+		if err != nil {
+			return err
+		}
+		if group.IsZero() {
+			log.Printf("No migrations to roll back")
+			return nil
+		}
+		log.Printf("Rolled back %s", group)
+	case "status":
+		status, err := db.MigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		for _, migration := range status {
+			if migration.IsApplied() {
+				log.Printf("%s %-24s applied in group %d at %s", migration.Name, migration.Comment, migration.GroupID, migration.MigratedAt.Format(time.RFC3339))
+			} else {
+				log.Printf("%s %-24s pending", migration.Name, migration.Comment)
+			}
+		}
+		log.Printf("%d applied, %d pending", len(status.Applied()), len(status.Unapplied()))
+	default:
+		return fmt.Errorf("unknown migrate command %q (available: up, down, status)", args[0])
+	}
+	return nil
+}
+
+func annotateDomains(ctx context.Context, args []string, domainUseCases usecases.DomainAnnotationUseCases) error {
+	fs := flag.NewFlagSet("annotate-domains", flag.ContinueOnError)
+	batchSize := fs.Int("batch", 200, "number of proteins loaded per batch")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	log.Printf("Re-annotating all proteins against %d profile HMMs", len(domainUseCases.ListModels(ctx)))
+	summary, err := domainUseCases.AnnotateAll(ctx, *batchSize, func(scanned int) {
+		log.Printf("Annotated %d proteins", scanned)
+	})
+	if summary != nil {
+		log.Printf("Scanned %d proteins, %d annotated with %d domain hits in %s",
+			summary.ProteinsScanned, summary.ProteinsAnnotated, summary.Hits, summary.FinishedAt.Sub(summary.StartedAt))
+	}
+	return err
+}
+
+func importUniProt(ctx context.Context, args []string, uniProtUseCases usecases.UniProtUseCases) error {
+	fs := flag.NewFlagSet("import-uniprot", flag.ContinueOnError)
+	file := fs.String("file", "", "UniProtKB flat file (.dat) to import")
+	batchSize := fs.Int("batch", 500, "number of entries committed per transaction")
+	restart := fs.Bool("restart", false, "ignore the stored checkpoint and start from the beginning")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("import-uniprot: -file is required")
+	}
+
+	opts := usecases.UniProtImportOptions{BatchSize: *batchSize, Restart: *restart}
+	report, err := uniProtUseCases.ImportDatFile(ctx, *file, opts, func(report *entities.ImportReport) {
+		log.Printf("Imported %d entries (%d created, %d updated, %d failed)", report.Total, report.Created, report.Updated, report.Failed)
+	})
+	if report != nil {
+		for _, record := range report.Records {
+			log.Printf("line %d: %s: %s", record.Line, record.ID, record.Error)
+		}
+		log.Printf("Done: %d created, %d updated, %d failed", report.Created, report.Updated, report.Failed)
+	}
+	if err != nil {
+		return fmt.Errorf("import stopped, rerun to resume from the last committed batch: %w", err)
+	}
+	return nil
+}
+
+func importGO(ctx context.Context, args []string, goUseCases usecases.GeneOntologyUseCases) error {
+	fs := flag.NewFlagSet("import-go", flag.ContinueOnError)
+	file := fs.String("file", "", "Gene Ontology OBO file (go-basic.obo) to import")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("import-go: -file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	summary, err := goUseCases.ImportOntology(ctx, f)
+	if err != nil {
+		return err
+	}
+	log.Printf("Imported GO %s: %d terms (%d obsolete), %d relationships in %s",
+		summary.DataVersion, summary.Terms, summary.Obsolete, summary.Relationships, summary.FinishedAt.Sub(summary.StartedAt))
+	return nil
+}
+
+func importGAF(ctx context.Context, args []string, goUseCases usecases.GeneOntologyUseCases) error {
+	fs := flag.NewFlagSet("import-gaf", flag.ContinueOnError)
+	file := fs.String("file", "", "GO annotation file (GAF 2.x) to import")
+	replace := fs.Bool("replace", true, "replace the GAF annotations stored earlier for each protein in the file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("import-gaf: -file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, err := goUseCases.ImportGAF(ctx, f, usecases.GAFImportOptions{Replace: *replace})
+	if err != nil {
+		return err
+	}
+	for _, record := range report.Records {
+		if record.Status == entities.ImportFailed {
+			log.Printf("line %d: %s: %s", record.Line, record.ID, record.Error)
+		}
+	}
+	log.Printf("Done: %d annotations stored, %d skipped, %d failed", report.Created, report.Skipped, report.Failed)
+	return nil
+}
+
+func importTaxonomy(ctx context.Context, args []string, taxonomyUseCases usecases.TaxonomyUseCases) error {
+	fs := flag.NewFlagSet("import-taxonomy", flag.ContinueOnError)
+	dir := fs.String("dir", "", "directory of the extracted NCBI taxdump (nodes.dmp, names.dmp)")
+	link := fs.Bool("link", true, "link proteins without a taxon by their organism name afterwards")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("import-taxonomy: -dir is required")
+	}
+
+	nodes, err := os.Open(filepath.Join(*dir, "nodes.dmp"))
+	if err != nil {
+		return err
+	}
+	defer nodes.Close()
+	names, err := os.Open(filepath.Join(*dir, "names.dmp"))
+	if err != nil {
+		return err
+	}
+	defer names.Close()
+
+	summary, err := taxonomyUseCases.ImportTaxonomy(ctx, nodes, names)
+	if err != nil {
+		return err
+	}
+	log.Printf("Imported %d taxa and %d names in %s", summary.Nodes, summary.Names, summary.FinishedAt.Sub(summary.StartedAt))
+
+	if *link {
+		linked, err := taxonomyUseCases.LinkProteins(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Linked %d proteins to taxa", linked)
+	}
+	return nil
+}
+
+func importDiseases(ctx context.Context, args []string, diseaseUseCases usecases.DiseaseUseCases) error {
+	fs := flag.NewFlagSet("import-diseases", flag.ContinueOnError)
+	file := fs.String("file", "", "OMIM- or DisGeNET-style TSV file of protein–disease associations")
+	source := fs.String("source", "", "source of lines without a source column (default TSV)")
+	evidence := fs.String("evidence", "", "evidence type of lines without an evidence column (default curated)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("import-diseases: -file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, err := diseaseUseCases.ImportTSV(ctx, f, usecases.DiseaseImportOptions{Source: *source, EvidenceType: *evidence})
+	if err != nil {
+		return err
+	}
+	for _, record := range report.Records {
+		if record.Status == entities.ImportFailed {
+			log.Printf("line %d: %s: %s", record.Line, record.ID, record.Error)
+		}
+	}
+	log.Printf("Done: %d associations stored, %d skipped, %d failed", report.Created, report.Skipped, report.Failed)
+	return nil
+}
+
+func trashRetention(cfg config.TrashConfig) time.Duration {
+	return time.Duration(cfg.RetentionDays) * 24 * time.Hour
+}
+
+// purgeTrash deletes for good the proteins deleted longer ago than -days, which
+// defaults to the configured retention period.
+func purgeTrash(ctx context.Context, args []string, proteinUseCases usecases.ProteinUseCases, cfg config.TrashConfig) error {
+	fs := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	days := fs.Int("days", cfg.RetentionDays, "days a deleted protein stays in the trash")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg.RetentionDays = *days
+
+	purged, err := proteinUseCases.PurgeDeletedProteins(ctx, trashRetention(cfg))
+	if err != nil {
+		return err
+	}
+	log.Printf("Purged %d proteins deleted more than %d days ago", purged, *days)
+	return nil
+}
+
+// purgeTrashEvery purges the trash at every interval until ctx is done. Failures are
+// logged and retried at the next tick.
+func purgeTrashEvery(ctx context.Context, proteinUseCases usecases.ProteinUseCases, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := proteinUseCases.PurgeDeletedProteins(ctx, retention)
+		switch {
+		case err != nil:
+			log.Printf("Failed to purge the protein trash: %v", err)
+		case purged > 0:
+			log.Printf("Purged %d proteins from the trash", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
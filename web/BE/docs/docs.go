@@ -24,9 +24,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/proteins": {
-            "get": {
-                "description": "Search for proteins based on various filters like name, gene, family, etc.",
+        "/api/align": {
+            "post": {
+                "description": "Proxy request to ML service for sequence alignment",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,79 +34,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "ml"
                 ],
-                "summary": "Search proteins with filters",
+                "summary": "Align protein sequences",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Protein ID",
-                        "name": "id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Protein name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gene name",
-                        "name": "gene",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Protein family",
-                        "name": "family",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit results",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order by field",
-                        "name": "order_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "ASC",
-                        "description": "Order direction (ASC/DESC)",
-                        "name": "order_direction",
-                        "in": "query"
+                        "description": "Alignment request with sequences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SuccessResponse"
+                            "type": "object"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/calculate-properties": {
             "post": {
-                "description": "Create a new protein entry",
+                "description": "Proxy request to ML service for protein property calculation",
                 "consumes": [
                     "application/json"
                 ],
@@ -114,51 +80,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "ml"
                 ],
-                "summary": "Create a new protein",
+                "summary": "Calculate protein properties",
                 "parameters": [
                     {
-                        "description": "Protein data",
-                        "name": "protein",
+                        "description": "Sequence for property calculation",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.ProteinCreateRequest"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object"
                         }
                     }
                 }
             }
         },
-        "/api/v1/proteins/analyze": {
+        "/api/predict": {
             "post": {
-                "description": "Analyze a protein sequence for various properties",
+                "description": "Proxy request to ML service for disease prediction",
                 "consumes": [
                     "application/json"
                 ],
@@ -166,17 +114,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "ml"
                 ],
-                "summary": "Analyze protein sequence",
+                "summary": "Predict disease from protein sequence",
                 "parameters": [
                     {
-                        "description": "Sequence analysis request",
-                        "name": "analysis",
+                        "description": "Prediction request with sequence",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.SequenceAnalysisRequest"
+                            "type": "object"
                         }
                     }
                 ],
@@ -184,7 +132,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SuccessResponse"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -193,8 +141,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -202,9 +150,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/proteins/bulk": {
+        "/api/similarity": {
             "post": {
-                "description": "Create multiple proteins in a single request",
+                "description": "Proxy request to ML service for sequence similarity calculation",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,20 +160,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "ml"
                 ],
-                "summary": "Bulk create proteins",
+                "summary": "Calculate sequence similarity",
                 "parameters": [
                     {
-                        "description": "Array of protein data",
-                        "name": "proteins",
+                        "description": "Similarity request with sequences",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/usecases.ProteinCreateRequest"
-                            }
+                            "type": "object"
                         }
                     }
                 ],
@@ -233,7 +178,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SuccessResponse"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -242,8 +187,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -251,9 +196,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/proteins/compare": {
+        "/api/v1/conservation": {
             "post": {
-                "description": "Compare multiple proteins for analysis",
+                "description": "Compute Shannon entropy, Jensen-Shannon divergence and property-group conservation for every column of an alignment (FASTA, Clustal or Stockholm text) or of stored proteins aligned to the first ID. Returns per-column scores and the consensus sequence.",
                 "consumes": [
                     "application/json"
                 ],
@@ -261,17 +206,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "conservation"
                 ],
-                "summary": "Compare proteins",
+                "summary": "Score per-column conservation",
                 "parameters": [
                     {
-                        "description": "Comparison request",
-                        "name": "comparison",
+                        "description": "Alignment text or protein IDs; sequences are Henikoff-weighted unless weighted is false",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.ComparisonRequest"
+                            "$ref": "#/definitions/usecases.ConservationRequest"
                         }
                     }
                 ],
@@ -303,19 +248,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/proteins/stats": {
-            "get": {
-                "description": "Get statistical information about proteins in the database",
+        "/api/v1/conservation/upload": {
+            "post": {
+                "description": "Upload a FASTA, Clustal or Stockholm alignment as the request body and score per-column conservation. The format is detected when not given.",
                 "consumes": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "conservation"
+                ],
+                "summary": "Score conservation of an alignment file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alignment format (fasta, clustal, stockholm)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Use Henikoff sequence weights",
+                        "name": "weighted",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Alignment file content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 ],
-                "summary": "Get protein statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -323,6 +292,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -332,26 +307,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/proteins/{id}": {
+        "/api/v1/diseases": {
             "get": {
-                "description": "Get a specific protein by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Find diseases by ID, or by text in the name or a synonym",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "diseases"
                 ],
-                "summary": "Get protein by ID",
+                "summary": "Search diseases",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Protein ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Disease ID (e.g. OMIM:114480) or text in the name or a synonym",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disease category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source the disease was imported from",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of diseases (at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of diseases to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -367,12 +364,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -381,8 +372,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing protein by ID",
+            "post": {
+                "description": "Create a disease. Bare OMIM numbers and UMLS CUIs are stored with an OMIM: or UMLS: prefix.",
                 "consumes": [
                     "application/json"
                 ],
@@ -390,30 +381,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "diseases"
                 ],
-                "summary": "Update a protein",
+                "summary": "Create a disease",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Protein ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated protein data",
-                        "name": "protein",
+                        "description": "Disease",
+                        "name": "disease",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.ProteinUpdateRequest"
+                            "$ref": "#/definitions/usecases.DiseaseRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/handlers.SuccessResponse"
                         }
@@ -424,8 +408,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -437,40 +421,63 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a protein by ID",
+            }
+        },
+        "/api/v1/diseases/import": {
+            "post": {
+                "description": "Import diseases and protein associations from a tab-separated file with a header line (raw body or multipart \"file\" field), such as a DisGeNET gene–disease file or an OMIM morbid map. Proteins are named by a protein_id or uniprot_id column, or by gene symbol; lines for unknown proteins are skipped. Diseases are upserted, and an association already recorded for the same protein, disease and source is updated.",
                 "consumes": [
-                    "application/json"
+                    "text/plain",
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "proteins"
+                    "diseases"
                 ],
-                "summary": "Delete a protein",
+                "summary": "Import protein–disease associations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Protein ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "TSV",
+                        "description": "Source of lines without a source column",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "curated",
+                        "description": "Evidence type of lines without an evidence column",
+                        "name": "evidence_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "TSV content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.SuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -483,14 +490,5564 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
+        },
+        "/api/v1/diseases/{id}": {
+            "get": {
+                "description": "Get a disease with its synonyms",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diseases"
+                ],
+                "summary": "Get a disease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the name, description, category, source and synonyms of a disease",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diseases"
+                ],
+                "summary": "Update a disease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Disease",
+                        "name": "disease",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.DiseaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a disease with its synonyms and protein associations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diseases"
+                ],
+                "summary": "Delete a disease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/diseases/{id}/proteins": {
+            "get": {
+                "description": "Get the protein associations of a disease, highest score first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diseases"
+                ],
+                "summary": "Get the proteins associated with a disease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of associations (at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of associations to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/families": {
+            "get": {
+                "description": "List protein families by name with the number of proteins linked to each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "List protein families",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text in the protein family name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of protein families (at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of protein families to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a protein family. Proteins are linked to protein families by name, so a protein whose family is set to the name links to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "Create a protein family",
+                "parameters": [
+                    {
+                        "description": "Protein family",
+                        "name": "family",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.GeneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/families/{id}": {
+            "get": {
+                "description": "Get a protein family with the number of proteins linked to it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "Get a protein family",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Protein family ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename a protein family; the family of every linked protein is renamed with it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "Rename a protein family",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Protein family ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Protein family",
+                        "name": "family",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.GeneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a protein family no protein links to. While proteins, in the trash or not, still carry its name the family is kept and 409 is returned; change or clear their family first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "Delete a protein family",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Protein family ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/families/{id}/proteins": {
+            "get": {
+                "description": "Page through the proteins linked to a protein family, ordered by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "families"
+                ],
+                "summary": "Get the proteins of a protein family",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Protein family ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of proteins to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/features": {
+            "get": {
+                "description": "Find features across proteins by type, source and position range; a range matches the features overlapping it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "annotations"
+                ],
+                "summary": "Search sequence features",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "protein_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feature type (case-insensitive)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feature source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start of the range (1-based)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the range (1-based, inclusive)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of features (at most 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of features to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/genes": {
+            "get": {
+                "description": "List genes by name with the number of proteins linked to each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genes"
+                ],
+                "summary": "List genes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text in the gene name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of genes (at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of genes to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a gene. Proteins are linked to genes by name, so a protein whose gene is set to the name links to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genes"
+                ],
+                "summary": "Create a gene",
+                "parameters": [
+                    {
+                        "description": "Gene",
+                        "name": "gene",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.GeneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/genes/{id}": {
+            "get": {
+                "description": "Get a gene with the number of proteins linked to it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genes"
+                ],
+                "summary": "Get a gene",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gene ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename a gene; the gene of every linked protein is renamed with it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genes"
+                ],
+                "summary": "Rename a gene",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gene ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gene",
+                        "name": "gene",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.GeneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a gene no protein links to. While proteins, in the trash or not, still carry its name the gene is kept and 409 is returned; change or clear their gene first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genes"
+                ],
+                "summary": "Delete a gene",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gene ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/genes/{id}/proteins": {
+            "get": {
+                "description": "Page through the proteins linked to a gene, ordered by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genes"
+                ],
+                "summary": "Get the proteins of a gene",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gene ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of proteins to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/go/annotations/import": {
+            "post": {
+                "description": "Import protein GO annotations from a GAF 2.x file (raw body or multipart \"file\" field). Object IDs are matched to protein IDs or cross-references of the line's database, such as UniProtKB accessions; lines for unknown proteins are skipped, lines with an unknown GO term or evidence code fail. By default the GAF annotations stored earlier for each protein in the file are replaced.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Import GO annotations from GAF",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Replace earlier GAF annotations of the same protein",
+                        "name": "replace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "GAF content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/go/import": {
+            "post": {
+                "description": "Load GO terms and their relationships from an OBO file such as go-basic.obo (raw body or multipart \"file\" field). Terms are upserted and the relationship graph is replaced.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Import the Gene Ontology",
+                "parameters": [
+                    {
+                        "description": "OBO content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/go/terms": {
+            "get": {
+                "description": "Find current GO terms by ID, name or synonym; exact name matches come first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Search GO terms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GO ID or text in the name or a synonym",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "biological_process, molecular_function or cellular_component",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of terms (at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of terms to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/go/terms/{id}": {
+            "get": {
+                "description": "Get a GO term by ID, alternative ID or exact name, with its direct parents and children",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Get a GO term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GO ID (e.g. GO:0016301) or term name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/go/terms/{id}/ancestors": {
+            "get": {
+                "description": "Get every term above a GO term along is_a and part_of",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Get the ancestors of a GO term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GO ID or term name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/go/terms/{id}/descendants": {
+            "get": {
+                "description": "Get every term below a GO term along is_a and part_of",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Get the descendants of a GO term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GO ID or term name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/go/terms/{id}/proteins": {
+            "get": {
+                "description": "Get the proteins annotated to a GO term or any of its descendants along is_a and part_of, so \"kinase activity\" also finds protein kinases. NOT annotations are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Get the proteins annotated to a GO term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GO ID or term name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of proteins to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/hmm-models": {
+            "get": {
+                "description": "List the HMMER3 models loaded from the local model directory at startup",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "List loaded profile HMMs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jobs/{id}": {
+            "get": {
+                "description": "Get the status, progress and, once completed, the result of a background job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get background job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins": {
+            "get": {
+                "description": "Search for proteins based on various filters like name, gene, family, etc. With q, results are ordered by relevance unless order_by is given, and each carries its rank and the snippets of the fields that matched, with matched words in \u003cmark\u003e tags. With fuzzy, results are ordered by similarity unless order_by is given. When nothing matches a text search, suggestions lists similar stored names, genes and aliases. Pages can be walked with offset, or with the next_cursor and prev_cursor of each page, which stay stable while proteins are added and are as fast on deep pages as on the first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Search proteins with filters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text query over names, gene, aliases, family, domains, function, biological process and comments, in web search syntax (\\",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Typo-tolerant match on protein name, gene and aliases (e.g. hemoglobn, BRAC1)",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.3,
+                        "description": "Trigram similarity a fuzzy match needs, between 0 and 1",
+                        "name": "min_similarity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gene name",
+                        "name": "gene",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein family",
+                        "name": "family",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum sequence length",
+                        "name": "min_length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum sequence length",
+                        "name": "max_length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum molecular weight",
+                        "name": "min_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum molecular weight",
+                        "name": "max_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum isoelectric point",
+                        "name": "min_pi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum isoelectric point",
+                        "name": "max_pi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum number of interactors",
+                        "name": "min_n_interactors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of interactors",
+                        "name": "max_n_interactors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum disease rank",
+                        "name": "min_d_rank",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum disease rank",
+                        "name": "max_d_rank",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "GO ID or term name; matches proteins annotated to it or any descendant term",
+                        "name": "go_term",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "NCBI taxon ID; matches proteins of the taxon or any taxon below it (40674 for all mammals)",
+                        "name": "taxon_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Gene ID (see /api/v1/genes)",
+                        "name": "gene_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Protein family ID (see /api/v1/families)",
+                        "name": "family_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression over every protein field, e.g. family:kinase AND mw:[30000 TO 80000] AND NOT taxo:\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field; rank (the default with q) orders by relevance and similarity (the default with fuzzy) by fuzzy match",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "ASC",
+                        "description": "Order direction (ASC/DESC)",
+                        "name": "order_direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of an earlier page of the same search; replaces offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "exact",
+                        "description": "Total to return: exact, estimated (from planner statistics) or none",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new protein entry. The ID of a protein in the trash is still taken: restore it instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Create a new protein",
+                "parameters": [
+                    {
+                        "description": "Protein data",
+                        "name": "protein",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProteinCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded on the revision",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the change is made, recorded on the revision",
+                        "name": "X-Change-Note",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/analyze": {
+            "post": {
+                "description": "Analyze a protein sequence for various properties",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Analyze protein sequence",
+                "parameters": [
+                    {
+                        "description": "Sequence analysis request",
+                        "name": "analysis",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.SequenceAnalysisRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/autocomplete": {
+            "get": {
+                "description": "Complete the text typed in a search box to stored protein names, genes and aliases that start with it or have a word that does. Whole-name prefixes come first, then the closest and most common terms.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Autocomplete protein search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Typed text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of completions (at most 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/bulk": {
+            "post": {
+                "description": "Create proteins from an array and report every item by its position, from 1, with its status and error. The mode decides how invalid items and stored IDs are handled: all_or_nothing writes every item or, when any fails, none; best_effort writes the valid new items; upsert also updates stored proteins with the given values, keeping the stored value of optional fields an item leaves out; skip_existing leaves stored proteins alone. In upsert mode an item may carry the version of the stored protein it updates, and fails with a version conflict when the protein is at another version. Items of proteins in the trash fail in every mode and the trashed protein is left alone; restore it from the trash first. Items are written in batches of batch_size, each batch in one transaction, and all of them in one transaction for all_or_nothing. An item repeating an earlier ID is skipped, or failed for all_or_nothing. When an all_or_nothing create fails nothing is written and the report comes with status 422. With dry_run nothing is written.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Bulk create proteins",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "all_or_nothing",
+                        "description": "How to handle invalid items and stored IDs (all_or_nothing, best_effort, upsert, skip_existing)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 500,
+                        "description": "Items written per statement",
+                        "name": "batch_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Array of protein data",
+                        "name": "proteins",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/usecases.BulkProteinRequest"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded on the revision",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the change is made, recorded on the revision",
+                        "name": "X-Change-Note",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "all_or_nothing failed, nothing was written; data is the report",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/compare": {
+            "post": {
+                "description": "Compare two proteins by sequence similarity; with include_structure, also superpose the linked structure chain of each that models the most residues (RMSD, TM-score, GDT-TS)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Compare proteins",
+                "parameters": [
+                    {
+                        "description": "Comparison request",
+                        "name": "comparison",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ComparisonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/export/fasta": {
+            "get": {
+                "description": "Export every protein matching the search filters as FASTA. Unlike search, no limit applies unless one is given. Header templates may use {id}, {name}, {gene}, {taxo}, {family}, {length}, {mw} and {pi}.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Export proteins as FASTA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text query over names, gene, aliases, family, domains, function, biological process and comments, in web search syntax (\\",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Typo-tolerant match on protein name, gene and aliases (e.g. hemoglobn, BRAC1)",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.3,
+                        "description": "Trigram similarity a fuzzy match needs, between 0 and 1",
+                        "name": "min_similarity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gene name",
+                        "name": "gene",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein family",
+                        "name": "family",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum sequence length",
+                        "name": "min_length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum sequence length",
+                        "name": "max_length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "GO ID or term name; matches proteins annotated to it or any descendant term",
+                        "name": "go_term",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "NCBI taxon ID; matches proteins of the taxon or any taxon below it (40674 for all mammals)",
+                        "name": "taxon_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Gene ID (see /api/v1/genes)",
+                        "name": "gene_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Protein family ID (see /api/v1/families)",
+                        "name": "family_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression over every protein field, e.g. family:kinase AND mw:[30000 TO 80000] AND NOT taxo:\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "Residues per line, 0 for unwrapped",
+                        "name": "line_width",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "{id} {name}",
+                        "description": "Header template",
+                        "name": "header",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/export/gff3": {
+            "get": {
+                "description": "Export the features matching the filters as GFF3, grouped by protein with a ##sequence-region directive each. Features of unknown position are left out.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "annotations"
+                ],
+                "summary": "Export features as GFF3",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "protein_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feature type (case-insensitive)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feature source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start of the range (1-based)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the range (1-based, inclusive)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of features",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/facets": {
+            "get": {
+                "description": "Count the proteins matching the search filters by family, gene and taxon (the largest buckets first) and in length and pI histograms. Proteins without a family, gene or taxon are left out of that facet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Count search results by facet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text query over names, gene, aliases, family, domains, function, biological process and comments, in web search syntax (\\",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Typo-tolerant match on protein name, gene and aliases (e.g. hemoglobn, BRAC1)",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.3,
+                        "description": "Trigram similarity a fuzzy match needs, between 0 and 1",
+                        "name": "min_similarity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gene name",
+                        "name": "gene",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein family",
+                        "name": "family",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum sequence length",
+                        "name": "min_length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum sequence length",
+                        "name": "max_length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum molecular weight",
+                        "name": "min_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum molecular weight",
+                        "name": "max_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum isoelectric point",
+                        "name": "min_pi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum isoelectric point",
+                        "name": "max_pi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum number of interactors",
+                        "name": "min_n_interactors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of interactors",
+                        "name": "max_n_interactors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum disease rank",
+                        "name": "min_d_rank",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum disease rank",
+                        "name": "max_d_rank",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "GO ID or term name; matches proteins annotated to it or any descendant term",
+                        "name": "go_term",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "NCBI taxon ID; matches proteins of the taxon or any taxon below it (40674 for all mammals)",
+                        "name": "taxon_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Gene ID (see /api/v1/genes)",
+                        "name": "gene_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Protein family ID (see /api/v1/families)",
+                        "name": "family_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression over every protein field, e.g. family:kinase AND mw:[30000 TO 80000] AND NOT taxo:\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Facets to count, comma-separated (family, gene, taxon, length, pi); all by default",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Buckets kept per family, gene and taxon facet (at most 100)",
+                        "name": "facet_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Width of the length histogram buckets in residues (at least 10)",
+                        "name": "length_width",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 1,
+                        "description": "Width of the pI histogram buckets (at least 0.05)",
+                        "name": "pi_width",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/import": {
+            "post": {
+                "description": "Stream a UniProt XML or NCBI GenPept file (raw body or multipart \"file\" field) and create or update one protein per entry, with its features and cross-references. Existing IDs are handled by the dedup policy: skip keeps the stored protein, update replaces the imported fields, fail reports the record as failed. With dry_run nothing is written. Returns a report listing every record with its status and reason.",
+                "consumes": [
+                    "text/xml",
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Import proteins from UniProt XML or GenPept",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File format (uniprot_xml, genpept)",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "skip",
+                        "description": "Policy for existing IDs (skip, update, fail)",
+                        "name": "dedup",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "File content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/import/fasta": {
+            "post": {
+                "description": "Stream a multi-FASTA file (raw body or multipart \"file\" field) and create one protein per record. UniProt headers (\u003esp|P04637|P53_HUMAN Cellular tumor antigen p53 OS=Homo sapiens OX=9606 GN=TP53) fill ID, Name, Taxo and Gene. Existing IDs are handled by the dedup policy; with dry_run nothing is written. Returns a per-record report.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Import proteins from FASTA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "entry_name",
+                        "description": "Header field used as protein ID (entry_name, accession)",
+                        "name": "id_field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "skip",
+                        "description": "Policy for existing IDs (skip, update, fail)",
+                        "name": "dedup",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "FASTA file content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/import/gff3": {
+            "post": {
+                "description": "Import positional features from a GFF3 file (raw body or multipart \"file\" field). The seqid column names the protein; lines for unknown proteins are skipped. Note and evidence attributes become the description and evidence, other attributes are kept. By default the features a source recorded earlier for each protein in the file are replaced, so re-importing a file does not duplicate them.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "annotations"
+                ],
+                "summary": "Import features from GFF3",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Replace earlier features of the same protein and source",
+                        "name": "replace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "GFF3",
+                        "description": "Source for lines whose source column is empty",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "description": "GFF3 content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/stats": {
+            "get": {
+                "description": "Get statistical information about proteins in the database. With rank, proteins linked to a taxon are also counted by their ancestor of that rank.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Get protein statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Taxonomic rank to break counts down by (species, genus, family, ...)",
+                        "name": "rank",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/trash": {
+            "get": {
+                "description": "List the deleted proteins that have not been purged yet, most recently deleted first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "List the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/trash/{id}/restore": {
+            "post": {
+                "description": "Take a protein out of the trash, with its annotations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Restore a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}": {
+            "get": {
+                "description": "Get a specific protein by its ID. A protein in the trash answers 410 Gone rather than 404.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Get protein by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related data to embed, comma-separated (features, diseases)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the protein, for If-Match on writes"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing protein by ID. With If-Match set to the ETag the protein was read with, the update is refused with 412 if the protein has changed since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Update a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated protein data",
+                        "name": "protein",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.ProteinUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the protein as read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded on the revision",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the change is made, recorded on the revision",
+                        "name": "X-Change-Note",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the protein"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a protein to the trash, with its annotations. It can be restored until it is purged, once the trash retention period has passed. With If-Match set to the ETag the protein was read with, the delete is refused with 412 if the protein has changed since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Delete a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the protein as read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded on the revision",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the change is made, recorded on the revision",
+                        "name": "X-Change-Note",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change some fields of a protein with a JSON Merge Patch (RFC 7396, application/merge-patch+json) or a JSON Patch (RFC 6902, application/json-patch+json) of its JSON. A null or removed field is cleared. name, gene, aliases, seq, taxo, taxon_id, cc, domain, family, bio_process, function, n_interactors, nc_7_4, d_rank, l_rank and f_rank can be patched; the values computed from the sequence (length, mw, pi, hydrophobicity_gravy) are computed again, and patching them or other server-set fields is refused. A failed JSON Patch test answers 409. With If-Match set to the ETag the protein was read with, the patch is refused with 412 if the protein has changed since.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proteins"
+                ],
+                "summary": "Patch a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch object or array of JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the protein as read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded on the revision",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the change is made, recorded on the revision",
+                        "name": "X-Change-Note",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the protein"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/diseases": {
+            "get": {
+                "description": "Get the disease associations of a protein, highest score first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diseases"
+                ],
+                "summary": "Get the diseases associated with a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Associate a protein with a stored disease. The evidence type is one of curated, genetic_association, literature, animal_model, inferred or predicted; the score, if given, is between 0 and 1.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diseases"
+                ],
+                "summary": "Associate a protein with a disease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Association",
+                        "name": "association",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.DiseaseAssociationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/diseases/{association_id}": {
+            "delete": {
+                "description": "Delete a disease association of a protein",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "diseases"
+                ],
+                "summary": "Delete a disease association",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Association ID",
+                        "name": "association_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/domains": {
+            "get": {
+                "description": "Get the stored profile HMM domain hits of a protein, ordered by sequence position",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Get domain hits of a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/domains/annotate": {
+            "post": {
+                "description": "Scan the protein against every loaded profile HMM and replace its stored domain hits",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Annotate a protein with domain hits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/features": {
+            "get": {
+                "description": "Get the annotated sequence regions of a protein, ordered by position. With start and/or end, only features overlapping that range are returned (start=end=15 finds features covering residue 15).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "annotations"
+                ],
+                "summary": "Get sequence features of a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feature type (case-insensitive)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feature source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start of the range (1-based)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the range (1-based, inclusive)",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an annotated region to a protein; the range must lie within its sequence",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "annotations"
+                ],
+                "summary": "Add a sequence feature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Feature",
+                        "name": "feature",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateFeatureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/features/{feature_id}": {
+            "delete": {
+                "description": "Delete a feature of a protein",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "annotations"
+                ],
+                "summary": "Delete a sequence feature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature ID",
+                        "name": "feature_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/go": {
+            "get": {
+                "description": "Get the GO terms a protein is annotated to, with evidence codes, ordered by namespace and term",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Get the GO annotations of a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a GO annotation to a protein. The term may be given by ID, alternative ID or name; the evidence code must be a GO evidence code (IDA, IEA, ISS, ...).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Annotate a protein with a GO term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Annotation",
+                        "name": "annotation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGOAnnotationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/go/{annotation_id}": {
+            "delete": {
+                "description": "Delete a GO annotation of a protein",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gene-ontology"
+                ],
+                "summary": "Delete a GO annotation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Annotation ID",
+                        "name": "annotation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/revisions": {
+            "get": {
+                "description": "List the history of a protein, newest first and without snapshots. Every create, update, delete, restore and purge leaves a revision with the fields it changed, its actor (the X-Actor header, or else the basic auth user) and its note (the X-Change-Note header). The history outlives a purged protein.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "List protein revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of revisions (at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of revisions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/revisions/diff": {
+            "get": {
+                "description": "Compare two revisions of a protein: the fields that differ, and the residue substitutions, insertions and deletions when the sequence differs. Either revision may be older.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Diff two protein revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare from (default the one before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare to (default the latest)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/revisions/{revision}": {
+            "get": {
+                "description": "Get a revision of a protein with the snapshot of the protein as it was written",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Get a protein revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/revisions/{revision}/revert": {
+            "post": {
+                "description": "Write the snapshot of an earlier revision back over a protein. The revert is itself recorded as a new revision, noted with the revision it reverts to, so the history is never rewritten. With If-Match set to the ETag the protein was read with, the revert is refused with 412 if the protein has changed since.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Revert a protein to a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number to revert to",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the protein as read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the change is made",
+                        "name": "X-Change-Note",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the protein"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/saturation-scan": {
+            "post": {
+                "description": "Mutate every position of the stored sequence (or the given region) to all 19 alternative residues in a background job. Poll the returned job for progress and the resulting heatmap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mutagenesis"
+                ],
+                "summary": "Start an in-silico saturation mutagenesis scan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scan region (1-based, inclusive) and heatmap metric (severity, delta_mw, delta_pi, delta_gravy)",
+                        "name": "scan",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.SaturationScanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/structures": {
+            "get": {
+                "description": "Get the structure chains linked to a protein",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Get structures of a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/taxon": {
+            "put": {
+                "description": "Set the NCBI taxon of a protein; its organism name is filled from the taxon when empty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Link a protein to a taxon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Taxon",
+                        "name": "taxon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LinkTaxonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/proteins/{id}/xrefs": {
+            "get": {
+                "description": "Get the external database links of a protein (UniProt DR lines and accessions)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "annotations"
+                ],
+                "summary": "Get cross-references of a protein",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Protein ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pssms": {
+            "get": {
+                "description": "List stored profiles without their matrices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "List PSSMs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Build a position-specific scoring matrix from stored proteins (by ID or curated family) and/or raw sequences, using Henikoff sequence weights and BLOSUM62 pseudocounts. The first sequence defines the profile positions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Build a PSSM",
+                "parameters": [
+                    {
+                        "description": "Profile sources",
+                        "name": "pssm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.PSSMBuildRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pssms/import": {
+            "post": {
+                "description": "Import a profile from the JSON representation or a PSI-BLAST ASCII PSSM (psiblast -out_ascii_pssm)",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Import a PSSM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile name (required for ASCII files)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Input format (json, ascii)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Profile file content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pssms/{id}": {
+            "get": {
+                "description": "Get a stored profile including its score matrix",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Get a PSSM",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "PSSM ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a stored profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Delete a PSSM",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "PSSM ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pssms/{id}/export": {
+            "get": {
+                "description": "Export a stored profile as JSON or as a PSI-BLAST ASCII PSSM",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Export a PSSM",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "PSSM ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format (json, ascii)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pssms/{id}/family-check": {
+            "get": {
+                "description": "Score the members of a curated family against the profile, flag members below the threshold and list non-members that score above it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Re-check family assignments with a PSSM",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "PSSM ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Family name (defaults to the family the profile was built from)",
+                        "name": "family",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum window score in bits (default: half the maximum attainable score)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of candidates",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pssms/{id}/score": {
+            "post": {
+                "description": "Slide the profile along the sequence and return the best window with per-position and per-window scores (bits)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Score a sequence against a PSSM",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "PSSM ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sequence to score",
+                        "name": "sequence",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.PSSMScoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pssms/{id}/search": {
+            "get": {
+                "description": "Scan the proteins table for high-scoring profile windows",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pssm"
+                ],
+                "summary": "Search proteins with a PSSM",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "PSSM ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum window score in bits (default: half the maximum attainable score)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of hits",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/structures": {
+            "get": {
+                "description": "List uploaded structures, newest first, without chains",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "List structures",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a structure file (raw body or multipart \"file\" field). The first model is parsed; every polypeptide chain is stored with its SEQRES and ATOM sequences and CA trace. Chains can be linked to stored proteins with chains=A:P53_HUMAN,B:MDM2_HUMAN or protein_id (all chains); residue numbering is mapped by local alignment. Uploading the same file again returns 409.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Upload a PDB or mmCIF structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File format (pdb, mmcif); detected from the file name or content when empty",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name, for raw uploads",
+                        "name": "file_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chain links as CHAIN:PROTEIN_ID pairs separated by commas",
+                        "name": "chains",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Protein linked to every chain not listed in chains",
+                        "name": "protein_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Structure file content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/structures/compare": {
+            "post": {
+                "description": "Pair the CA atoms of two chains by aligning their modelled sequences and superpose chain 1 onto chain 2 with the Kabsch algorithm. Reports RMSD over all aligned pairs, TM-score and GDT-TS normalised by the length of chain 2, the rotation and translation, and both CA traces in the frame of chain 2 for display.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Superpose two structure chains",
+                "parameters": [
+                    {
+                        "description": "Chains to compare",
+                        "name": "comparison",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CompareChainsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/structures/{id}": {
+            "get": {
+                "description": "Get an uploaded structure with its chains, sequences and protein links",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Get a structure",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an uploaded structure, its chains and the stored file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Delete a structure",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/structures/{id}/chains/{chain}/contacts": {
+            "get": {
+                "description": "List the residue pairs of a chain whose CA (or CB; CA for glycine) atoms are within the distance threshold. Indexes refer to the chain's modelled residues, listed with their author numbers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Get the contact map of a chain",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ca",
+                        "description": "Atom to measure between (ca, cb)",
+                        "name": "atom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 8,
+                        "description": "Distance threshold in Å (at most 30)",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Minimum sequence separation of a pair",
+                        "name": "min_separation",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/structures/{id}/chains/{chain}/protein": {
+            "put": {
+                "description": "Link (or relink) a structure chain to a stored protein; its modelled residues are mapped to protein positions by local alignment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Link a chain to a protein",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Protein to link",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LinkChainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/structures/{id}/chains/{chain}/trace": {
+            "get": {
+                "description": "Get the alpha-carbon coordinates of a chain's modelled residues, with author residue numbers and positions in the linked protein",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Get the CA trace of a chain",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/structures/{id}/file": {
+            "get": {
+                "description": "Download the uploaded PDB or mmCIF file as stored",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "structures"
+                ],
+                "summary": "Download a structure file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/taxonomy": {
+            "get": {
+                "description": "Find taxa whose scientific name, synonym or common name contains q; exact matches and scientific names come first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Search taxa by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name or part of a name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only taxa of this rank (species, genus, ...)",
+                        "name": "rank",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of matches (at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of matches to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/taxonomy/import": {
+            "post": {
+                "description": "Load nodes.dmp and names.dmp of an NCBI taxdump (multipart fields \"nodes\" and \"names\"). Taxa are upserted and all names replaced; proteins are not linked automatically, see POST /api/v1/taxonomy/link.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Import the NCBI taxonomy",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "nodes.dmp",
+                        "name": "nodes",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "names.dmp",
+                        "name": "names",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/taxonomy/link": {
+            "post": {
+                "description": "Set the taxon of every protein that has an organism name but no taxon, matching scientific names first and then synonyms and common names. Names shared by several taxa are not used.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Link proteins to taxa by organism name",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/taxonomy/{id}": {
+            "get": {
+                "description": "Get an NCBI taxon with all its names",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Get a taxon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NCBI taxon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/taxonomy/{id}/children": {
+            "get": {
+                "description": "Get the direct children of a taxon, by scientific name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Get the children of a taxon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NCBI taxon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of taxa (at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of taxa to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/taxonomy/{id}/lineage": {
+            "get": {
+                "description": "Get the taxa from the root of the taxonomy down to the given taxon",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Get the lineage of a taxon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NCBI taxon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/taxonomy/{id}/proteins": {
+            "get": {
+                "description": "Get the proteins linked to a taxon or any taxon below it, e.g. 40674 for all mammals",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "taxonomy"
+                ],
+                "summary": "Get the proteins of a taxon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NCBI taxon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of proteins to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.CompareChainsRequest": {
+            "type": "object",
+            "required": [
+                "chain_1",
+                "chain_2",
+                "structure_id_1",
+                "structure_id_2"
+            ],
+            "properties": {
+                "chain_1": {
+                    "type": "string"
+                },
+                "chain_2": {
+                    "type": "string"
+                },
+                "structure_id_1": {
+                    "type": "integer"
+                },
+                "structure_id_2": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CreateFeatureRequest": {
+            "type": "object",
+            "required": [
+                "end",
+                "start",
+                "type"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end": {
+                    "type": "integer"
+                },
+                "evidence": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateGOAnnotationRequest": {
+            "type": "object",
+            "required": [
+                "evidence_code",
+                "term_id"
+            ],
+            "properties": {
+                "assigned_by": {
+                    "type": "string"
+                },
+                "evidence_code": {
+                    "type": "string"
+                },
+                "negated": {
+                    "type": "boolean"
+                },
+                "qualifier": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "term_id": {
+                    "type": "string"
+                },
+                "with_from": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
                 "error": {
                     "type": "string"
@@ -500,6 +6057,28 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.LinkChainRequest": {
+            "type": "object",
+            "required": [
+                "protein_id"
+            ],
+            "properties": {
+                "protein_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LinkTaxonRequest": {
+            "type": "object",
+            "required": [
+                "taxon_id"
+            ],
+            "properties": {
+                "taxon_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "handlers.SuccessResponse": {
             "type": "object",
             "properties": {
@@ -509,6 +6088,58 @@ const docTemplate = `{
                 }
             }
         },
+        "usecases.BulkProteinRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "seq"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cc": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "family": {
+                    "type": "string"
+                },
+                "function": {
+                    "type": "string"
+                },
+                "gene": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "taxo": {
+                    "type": "string"
+                },
+                "taxon_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
         "usecases.ComparisonRequest": {
             "type": "object",
             "required": [
@@ -516,6 +6147,9 @@ const docTemplate = `{
                 "protein_id_2"
             ],
             "properties": {
+                "include_structure": {
+                    "type": "boolean"
+                },
                 "protein_id_1": {
                     "type": "string"
                 },
@@ -524,6 +6158,140 @@ const docTemplate = `{
                 }
             }
         },
+        "usecases.ConservationRequest": {
+            "type": "object",
+            "properties": {
+                "alignment": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "protein_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weighted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "usecases.DiseaseAssociationRequest": {
+            "type": "object",
+            "required": [
+                "disease_id",
+                "evidence_type"
+            ],
+            "properties": {
+                "disease_id": {
+                    "type": "string"
+                },
+                "evidence_type": {
+                    "type": "string"
+                },
+                "references": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.DiseaseRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecases.GeneRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.PSSMBuildRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "family": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "protein_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pseudocount_weight": {
+                    "type": "number"
+                },
+                "sequences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecases.PSSMScoreRequest": {
+            "type": "object",
+            "required": [
+                "sequence"
+            ],
+            "properties": {
+                "sequence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "usecases.ProteinCreateRequest": {
             "type": "object",
             "required": [
@@ -532,6 +6300,12 @@ const docTemplate = `{
                 "seq"
             ],
             "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "cc": {
                     "type": "string"
                 },
@@ -561,12 +6335,21 @@ const docTemplate = `{
                 },
                 "taxo": {
                     "type": "string"
+                },
+                "taxon_id": {
+                    "type": "integer"
                 }
             }
         },
         "usecases.ProteinUpdateRequest": {
             "type": "object",
             "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "cc": {
                     "type": "string"
                 },
@@ -593,6 +6376,23 @@ const docTemplate = `{
                 },
                 "taxo": {
                     "type": "string"
+                },
+                "taxon_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecases.SaturationScanRequest": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "integer"
                 }
             }
         },
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecordChange puts who makes the request and why into its context, so that the
+// revisions its writes leave record them. The actor is the X-Actor header, or else the
+// basic auth user; the note is the X-Change-Note header.
+func RecordChange() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := strings.TrimSpace(c.GetHeader("X-Actor"))
+		if actor == "" {
+			actor, _, _ = c.Request.BasicAuth()
+		}
+		change := entities.Change{
+			Actor: actor,
+			Note:  strings.TrimSpace(c.GetHeader("X-Change-Note")),
+		}
+		c.Request = c.Request.WithContext(entities.WithChange(c.Request.Context(), change))
+		c.Next()
+	}
+}
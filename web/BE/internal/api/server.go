@@ -7,7 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetUpRoutes(r *gin.Engine, proteinHandler *handlers.ProteinHandler, mlHandler *handlers.MLHandler) {
+func SetUpRoutes(r *gin.Engine, proteinHandler *handlers.ProteinHandler, mlHandler *handlers.MLHandler, jobHandler *handlers.JobHandler, mutagenesisHandler *handlers.MutagenesisHandler, pssmHandler *handlers.PSSMHandler, domainHandler *handlers.DomainHandler, conservationHandler *handlers.ConservationHandler, fastaHandler *handlers.FASTAHandler, importHandler *handlers.ImportHandler, annotationHandler *handlers.AnnotationHandler, structureHandler *handlers.StructureHandler, goHandler *handlers.GeneOntologyHandler, taxonomyHandler *handlers.TaxonomyHandler, diseaseHandler *handlers.DiseaseHandler, geneHandler *handlers.GeneHandler, familyHandler *handlers.FamilyHandler, revisionHandler *handlers.RevisionHandler) {
 	r.Use(CORSMiddleware())
 	r.Use(ErrorHandlingMiddleware())
 
@@ -25,6 +25,7 @@ func SetUpRoutes(r *gin.Engine, proteinHandler *handlers.ProteinHandler, mlHandl
 	}
 
 	apiV1 := r.Group("/api/v1")
+	apiV1.Use(http.RecordChange())
 	{
 		proteins := apiV1.Group("/proteins")
 		{
@@ -32,12 +33,134 @@ func SetUpRoutes(r *gin.Engine, proteinHandler *handlers.ProteinHandler, mlHandl
 			proteins.POST("", proteinHandler.CreateProtein)
 			proteins.GET("/:id", proteinHandler.GetProteinByID)
 			proteins.PUT("/:id", proteinHandler.UpdateProtein)
+			proteins.PATCH("/:id", proteinHandler.PatchProtein)
 			proteins.DELETE("/:id", proteinHandler.DeleteProtein)
 			proteins.POST("/compare", proteinHandler.CompareProteins)
 			proteins.POST("/analyze", proteinHandler.AnalyzeSequence)
 			proteins.GET("/stats", proteinHandler.GetProteinStats)
-			proteins.POST("/bulk", proteinHandler.BulkCreateProteins)
+			proteins.GET("/autocomplete", proteinHandler.AutocompleteProteins)
+			proteins.GET("/facets", proteinHandler.GetProteinFacets)
+			proteins.GET("/trash", proteinHandler.ListDeletedProteins)
+			proteins.POST("/trash/:id/restore", proteinHandler.RestoreProtein)
+			proteins.GET("/:id/revisions", revisionHandler.ListRevisions)
+			proteins.GET("/:id/revisions/diff", revisionHandler.DiffRevisions)
+			proteins.GET("/:id/revisions/:revision", revisionHandler.GetRevision)
+			proteins.POST("/:id/revisions/:revision/revert", revisionHandler.RevertProtein)
+			proteins.POST("/bulk", importHandler.BulkCreateProteins)
+			proteins.POST("/import", importHandler.ImportRecords)
+			proteins.POST("/import/fasta", fastaHandler.ImportFASTA)
+			proteins.GET("/export/fasta", fastaHandler.ExportFASTA)
+			proteins.POST("/import/gff3", annotationHandler.ImportGFF3)
+			proteins.GET("/export/gff3", annotationHandler.ExportGFF3)
+			proteins.POST("/:id/saturation-scan", mutagenesisHandler.StartSaturationScan)
+			proteins.GET("/:id/domains", domainHandler.GetProteinDomains)
+			proteins.POST("/:id/domains/annotate", domainHandler.AnnotateProtein)
+			proteins.GET("/:id/xrefs", annotationHandler.GetCrossReferences)
+			proteins.GET("/:id/features", annotationHandler.GetFeatures)
+			proteins.POST("/:id/features", annotationHandler.CreateFeature)
+			proteins.DELETE("/:id/features/:feature_id", annotationHandler.DeleteFeature)
+			proteins.GET("/:id/structures", structureHandler.GetProteinStructures)
+			proteins.GET("/:id/go", goHandler.GetProteinAnnotations)
+			proteins.POST("/:id/go", goHandler.CreateAnnotation)
+			proteins.DELETE("/:id/go/:annotation_id", goHandler.DeleteAnnotation)
+			proteins.PUT("/:id/taxon", taxonomyHandler.LinkProtein)
+			proteins.GET("/:id/diseases", diseaseHandler.GetProteinDiseases)
+			proteins.POST("/:id/diseases", diseaseHandler.CreateAssociation)
+			proteins.DELETE("/:id/diseases/:association_id", diseaseHandler.DeleteAssociation)
 		}
+
+		features := apiV1.Group("/features")
+		{
+			features.GET("", annotationHandler.SearchFeatures)
+		}
+
+		geneOntology := apiV1.Group("/go")
+		{
+			geneOntology.POST("/import", goHandler.ImportOntology)
+			geneOntology.GET("/terms", goHandler.SearchTerms)
+			geneOntology.GET("/terms/:id", goHandler.GetTerm)
+			geneOntology.GET("/terms/:id/ancestors", goHandler.GetAncestors)
+			geneOntology.GET("/terms/:id/descendants", goHandler.GetDescendants)
+			geneOntology.GET("/terms/:id/proteins", goHandler.GetTermProteins)
+			geneOntology.POST("/annotations/import", goHandler.ImportGAF)
+		}
+
+		taxonomy := apiV1.Group("/taxonomy")
+		{
+			taxonomy.GET("", taxonomyHandler.SearchTaxa)
+			taxonomy.POST("/import", taxonomyHandler.ImportTaxonomy)
+			taxonomy.POST("/link", taxonomyHandler.LinkProteins)
+			taxonomy.GET("/:id", taxonomyHandler.GetTaxon)
+			taxonomy.GET("/:id/lineage", taxonomyHandler.GetLineage)
+			taxonomy.GET("/:id/children", taxonomyHandler.GetChildren)
+			taxonomy.GET("/:id/proteins", taxonomyHandler.GetTaxonProteins)
+		}
+
+		genes := apiV1.Group("/genes")
+		{
+			genes.GET("", geneHandler.ListGenes)
+			genes.POST("", geneHandler.CreateGene)
+			genes.GET("/:id", geneHandler.GetGene)
+			genes.PUT("/:id", geneHandler.UpdateGene)
+			genes.DELETE("/:id", geneHandler.DeleteGene)
+			genes.GET("/:id/proteins", geneHandler.GetGeneProteins)
+		}
+
+		families := apiV1.Group("/families")
+		{
+			families.GET("", familyHandler.ListFamilies)
+			families.POST("", familyHandler.CreateFamily)
+			families.GET("/:id", familyHandler.GetFamily)
+			families.PUT("/:id", familyHandler.UpdateFamily)
+			families.DELETE("/:id", familyHandler.DeleteFamily)
+			families.GET("/:id/proteins", familyHandler.GetFamilyProteins)
+		}
+
+		diseases := apiV1.Group("/diseases")
+		{
+			diseases.GET("", diseaseHandler.SearchDiseases)
+			diseases.POST("", diseaseHandler.CreateDisease)
+			diseases.POST("/import", diseaseHandler.ImportDiseases)
+			diseases.GET("/:id", diseaseHandler.GetDisease)
+			diseases.PUT("/:id", diseaseHandler.UpdateDisease)
+			diseases.DELETE("/:id", diseaseHandler.DeleteDisease)
+			diseases.GET("/:id/proteins", diseaseHandler.GetDiseaseProteins)
+		}
+
+		structures := apiV1.Group("/structures")
+		{
+			structures.POST("", structureHandler.UploadStructure)
+			structures.GET("", structureHandler.ListStructures)
+			structures.POST("/compare", structureHandler.CompareChains)
+			structures.GET("/:id", structureHandler.GetStructure)
+			structures.DELETE("/:id", structureHandler.DeleteStructure)
+			structures.GET("/:id/file", structureHandler.DownloadStructure)
+			structures.PUT("/:id/chains/:chain/protein", structureHandler.LinkChain)
+			structures.GET("/:id/chains/:chain/trace", structureHandler.GetCATrace)
+			structures.GET("/:id/chains/:chain/contacts", structureHandler.GetContactMap)
+		}
+
+		pssms := apiV1.Group("/pssms")
+		{
+			pssms.GET("", pssmHandler.ListPSSMs)
+			pssms.POST("", pssmHandler.BuildPSSM)
+			pssms.POST("/import", pssmHandler.ImportPSSM)
+			pssms.GET("/:id", pssmHandler.GetPSSM)
+			pssms.DELETE("/:id", pssmHandler.DeletePSSM)
+			pssms.GET("/:id/export", pssmHandler.ExportPSSM)
+			pssms.POST("/:id/score", pssmHandler.ScoreSequence)
+			pssms.GET("/:id/search", pssmHandler.SearchProteins)
+			pssms.GET("/:id/family-check", pssmHandler.CheckFamily)
+		}
+
+		conservation := apiV1.Group("/conservation")
+		{
+			conservation.POST("", conservationHandler.ScoreConservation)
+			conservation.POST("/upload", conservationHandler.UploadAlignment)
+		}
+
+		apiV1.GET("/hmm-models", domainHandler.ListModels)
+		apiV1.GET("/jobs/:id", jobHandler.GetJob)
 	}
 }
 
@@ -45,8 +168,9 @@ func CORSMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, ngrok-skip-browser-warning")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Actor, X-Change-Note, If-Match, ngrok-skip-browser-warning")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -0,0 +1,16 @@
+package entities
+
+// PairwiseAlignment is the result of aligning sequence A against sequence B.
+// Start and end positions are 1-based and inclusive; gaps are written as '-'.
+type PairwiseAlignment struct {
+	AlignedA string  `json:"aligned_a"`
+	AlignedB string  `json:"aligned_b"`
+	Score    int     `json:"score"`
+	StartA   int     `json:"start_a"`
+	EndA     int     `json:"end_a"`
+	StartB   int     `json:"start_b"`
+	EndB     int     `json:"end_b"`
+	Length   int     `json:"length"`
+	Matches  int     `json:"matches"`
+	Identity float64 `json:"identity"`
+}
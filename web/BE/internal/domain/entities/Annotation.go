@@ -0,0 +1,73 @@
+package entities
+
+import "time"
+
+// CrossReference links a protein to a record in another database (UniProt DR lines).
+type CrossReference struct {
+	ID         int64    `json:"id,omitempty"`
+	ProteinID  string   `json:"protein_id"`
+	Database   string   `json:"database"`
+	Accession  string   `json:"accession"`
+	Properties []string `json:"properties,omitempty"`
+	Source     string   `json:"source,omitempty"`
+}
+
+// ProteinFeature is an annotated sequence region. Start and End are 1-based and
+// inclusive; 0 marks an unknown position. Attributes holds the GFF3 attributes other
+// than Note and evidence, which map to Description and Evidence.
+type ProteinFeature struct {
+	ID          int64               `json:"id,omitempty"`
+	ProteinID   string              `json:"protein_id"`
+	Type        string              `json:"type"`
+	Start       int                 `json:"start"`
+	End         int                 `json:"end"`
+	Score       *float64            `json:"score,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Evidence    string              `json:"evidence,omitempty"`
+	Source      string              `json:"source,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
+}
+
+// Overlaps reports whether the feature shares at least one residue with start..end.
+func (f *ProteinFeature) Overlaps(start, end int) bool {
+	return f.Start > 0 && f.Start <= end && f.End >= start
+}
+
+// FeatureFilter selects features. Start and End select the features overlapping that
+// range; either may be given alone for an open-ended range. Type matches
+// case-insensitively.
+type FeatureFilter struct {
+	ProteinID string `json:"protein_id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Start     *int   `json:"start,omitempty"`
+	End       *int   `json:"end,omitempty"`
+	Limit     int    `json:"limit"`
+	Offset    int    `json:"offset"`
+}
+
+// FeatureSource names the features one source recorded for one protein.
+type FeatureSource struct {
+	ProteinID string
+	Source    string
+}
+
+// ProteinRecord is a protein together with the secondary data an importer stores for it.
+type ProteinRecord struct {
+	Protein         *Protein
+	Accessions      []string
+	TaxonID         string
+	CrossReferences []CrossReference
+	Features        []ProteinFeature
+}
+
+// ImportCheckpoint records how far a file import got, so that it can resume after an
+// interruption. Offset is the byte offset just past the last committed record.
+type ImportCheckpoint struct {
+	Source   string    `json:"source"`
+	Format   string    `json:"format"`
+	FileSize int64     `json:"file_size"`
+	Offset   int64     `json:"offset"`
+	Records  int       `json:"records"`
+	Updated  time.Time `json:"updated"`
+}
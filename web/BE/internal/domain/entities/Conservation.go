@@ -0,0 +1,38 @@
+package entities
+
+import "errors"
+
+var (
+	ErrEmptyAlignment  = errors.New("alignment must contain at least two sequences")
+	ErrRaggedAlignment = errors.New("aligned sequences must all have the same length")
+)
+
+// AlignedSequence is one row of a multiple sequence alignment; gaps are '-'.
+type AlignedSequence struct {
+	ID       string `json:"id"`
+	Sequence string `json:"sequence"`
+}
+
+// ColumnConservation holds the conservation scores of one alignment column. Scores
+// are scaled to [0, 1] where 1 is fully conserved, and penalised by the gap fraction.
+type ColumnConservation struct {
+	Position             int                `json:"position"`
+	Consensus            string             `json:"consensus"`
+	GapFraction          float64            `json:"gap_fraction"`
+	Entropy              float64            `json:"entropy"`
+	EntropyScore         float64            `json:"entropy_score"`
+	JensenShannon        float64            `json:"jensen_shannon"`
+	PropertyConservation float64            `json:"property_conservation"`
+	Frequencies          map[string]float64 `json:"frequencies"`
+}
+
+// ConservationProfile is the per-column conservation of an alignment. Entropy is in
+// bits; the consensus uses '-' for columns that are mostly gaps.
+type ConservationProfile struct {
+	Sequences    []AlignedSequence    `json:"sequences"`
+	NumSequences int                  `json:"num_sequences"`
+	Length       int                  `json:"length"`
+	Weighted     bool                 `json:"weighted"`
+	Consensus    string               `json:"consensus"`
+	Columns      []ColumnConservation `json:"columns"`
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidDiseaseID   = errors.New("disease ID cannot be empty")
+	ErrInvalidDiseaseName = errors.New("disease name cannot be empty")
+)
+
+// Evidence types of a protein–disease association.
+const (
+	DiseaseEvidenceCurated     = "curated"
+	DiseaseEvidenceGenetic     = "genetic_association"
+	DiseaseEvidenceLiterature  = "literature"
+	DiseaseEvidenceAnimalModel = "animal_model"
+	DiseaseEvidenceInferred    = "inferred"
+	DiseaseEvidencePredicted   = "predicted"
+)
+
+var diseaseEvidenceTypes = map[string]bool{
+	DiseaseEvidenceCurated:     true,
+	DiseaseEvidenceGenetic:     true,
+	DiseaseEvidenceLiterature:  true,
+	DiseaseEvidenceAnimalModel: true,
+	DiseaseEvidenceInferred:    true,
+	DiseaseEvidencePredicted:   true,
+}
+
+// IsDiseaseEvidenceType reports whether evidence is a known association evidence type.
+func IsDiseaseEvidenceType(evidence string) bool {
+	return diseaseEvidenceTypes[evidence]
+}
+
+// Disease is a disease or phenotype, identified by a prefixed ID such as OMIM:114480 or
+// UMLS:C0006142.
+type Disease struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	Synonyms    []string  `json:"synonyms,omitempty"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+}
+
+// DiseaseAssociation links a protein to a disease. Score is the source's confidence,
+// when it gives one. DiseaseName is filled when associations are listed.
+type DiseaseAssociation struct {
+	ID           int64     `json:"id,omitempty"`
+	ProteinID    string    `json:"protein_id"`
+	DiseaseID    string    `json:"disease_id"`
+	DiseaseName  string    `json:"disease_name,omitempty"`
+	EvidenceType string    `json:"evidence_type"`
+	Source       string    `json:"source,omitempty"`
+	Score        *float64  `json:"score,omitempty"`
+	References   []string  `json:"references,omitempty"`
+	Created      time.Time `json:"created"`
+}
+
+// DiseaseFilter selects diseases. Query matches the ID exactly, or the name or a
+// synonym in part.
+type DiseaseFilter struct {
+	Query    string `json:"query,omitempty"`
+	Category string `json:"category,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Limit    int    `json:"limit"`
+	Offset   int    `json:"offset"`
+}
@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+// Plan7 transition columns, in the order HMMER3 writes them.
+const (
+	TransitionMM = iota
+	TransitionMI
+	TransitionMD
+	TransitionIM
+	TransitionII
+	TransitionDM
+	TransitionDD
+)
+
+// ProfileHMM is a HMMER3 Plan7 profile HMM. Emissions and transitions are stored as
+// probabilities; Transitions has one row per node from 0 (begin) to Length.
+type ProfileHMM struct {
+	Name           string      `json:"name"`
+	Accession      string      `json:"accession,omitempty"`
+	Description    string      `json:"description,omitempty"`
+	Length         int         `json:"length"`
+	Alphabet       string      `json:"alphabet"`
+	MatchEmissions [][]float64 `json:"-"`
+	Transitions    [][]float64 `json:"-"`
+	ViterbiMu      float64     `json:"viterbi_mu"`
+	ViterbiLambda  float64     `json:"viterbi_lambda"`
+	ForwardTau     float64     `json:"forward_tau"`
+	ForwardLambda  float64     `json:"forward_lambda"`
+	SourceFile     string      `json:"source_file,omitempty"`
+}
+
+// DomainHit is a profile HMM match on a protein sequence. Sequence and model
+// coordinates are 1-based and inclusive; scores are in bits.
+type DomainHit struct {
+	ID             int64     `json:"id,omitempty"`
+	ProteinID      string    `json:"protein_id"`
+	ModelName      string    `json:"model_name"`
+	ModelAccession string    `json:"model_accession,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	Start          int       `json:"start"`
+	End            int       `json:"end"`
+	HMMStart       int       `json:"hmm_start"`
+	HMMEnd         int       `json:"hmm_end"`
+	HMMLength      int       `json:"hmm_length"`
+	Score          float64   `json:"score"`
+	EValue         float64   `json:"evalue"`
+	SequenceScore  float64   `json:"sequence_score"`
+	SequenceEValue float64   `json:"sequence_evalue"`
+	Created        time.Time `json:"created"`
+}
+
+type DomainAnnotationSummary struct {
+	ProteinsScanned   int       `json:"proteins_scanned"`
+	ProteinsAnnotated int       `json:"proteins_annotated"`
+	Hits              int       `json:"hits"`
+	Models            int       `json:"models"`
+	StartedAt         time.Time `json:"started_at"`
+	FinishedAt        time.Time `json:"finished_at"`
+}
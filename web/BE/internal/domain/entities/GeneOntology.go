@@ -0,0 +1,113 @@
+package entities
+
+import "time"
+
+// GO namespaces (aspects).
+const (
+	GONamespaceBiologicalProcess = "biological_process"
+	GONamespaceMolecularFunction = "molecular_function"
+	GONamespaceCellularComponent = "cellular_component"
+)
+
+// Relationship types between GO terms. Ontology closures follow is_a and part_of
+// only; other relationships such as regulates are stored but not traversed.
+const (
+	GORelationIsA    = "is_a"
+	GORelationPartOf = "part_of"
+)
+
+// goEvidenceCodes are the GO evidence codes, the same codes carried by the
+// EvidenceCode of a function prediction.
+var goEvidenceCodes = map[string]string{
+	"EXP": "Inferred from Experiment",
+	"IDA": "Inferred from Direct Assay",
+	"IPI": "Inferred from Physical Interaction",
+	"IMP": "Inferred from Mutant Phenotype",
+	"IGI": "Inferred from Genetic Interaction",
+	"IEP": "Inferred from Expression Pattern",
+	"HTP": "Inferred from High Throughput Experiment",
+	"HDA": "Inferred from High Throughput Direct Assay",
+	"HMP": "Inferred from High Throughput Mutant Phenotype",
+	"HGI": "Inferred from High Throughput Genetic Interaction",
+	"HEP": "Inferred from High Throughput Expression Pattern",
+	"IBA": "Inferred from Biological aspect of Ancestor",
+	"IBD": "Inferred from Biological aspect of Descendant",
+	"IKR": "Inferred from Key Residues",
+	"IRD": "Inferred from Rapid Divergence",
+	"ISS": "Inferred from Sequence or structural Similarity",
+	"ISO": "Inferred from Sequence Orthology",
+	"ISA": "Inferred from Sequence Alignment",
+	"ISM": "Inferred from Sequence Model",
+	"IGC": "Inferred from Genomic Context",
+	"RCA": "Inferred from Reviewed Computational Analysis",
+	"TAS": "Traceable Author Statement",
+	"NAS": "Non-traceable Author Statement",
+	"IC":  "Inferred by Curator",
+	"ND":  "No biological Data available",
+	"IEA": "Inferred from Electronic Annotation",
+}
+
+// IsGOEvidenceCode reports whether code is a GO evidence code.
+func IsGOEvidenceCode(code string) bool {
+	_, ok := goEvidenceCodes[code]
+	return ok
+}
+
+// GOTerm is a term of the Gene Ontology. Parents and Children list the direct
+// relationships and are only filled for a single-term lookup.
+type GOTerm struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Namespace  string          `json:"namespace"`
+	Definition string          `json:"definition,omitempty"`
+	Synonyms   []string        `json:"synonyms,omitempty"`
+	AltIDs     []string        `json:"alt_ids,omitempty"`
+	Obsolete   bool            `json:"obsolete"`
+	ReplacedBy string          `json:"replaced_by,omitempty"`
+	Parents    []GORelatedTerm `json:"parents,omitempty"`
+	Children   []GORelatedTerm `json:"children,omitempty"`
+}
+
+// GORelationship states that TermID relates to ParentID, e.g. "GO:0004672 is_a GO:0016301".
+type GORelationship struct {
+	TermID   string `json:"term_id"`
+	Relation string `json:"relation"`
+	ParentID string `json:"parent_id"`
+}
+
+// GORelatedTerm is a term reached through one relationship.
+type GORelatedTerm struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Relation  string `json:"relation"`
+}
+
+// GOAnnotation links a protein to a GO term. Negated annotations (GAF qualifier NOT)
+// state that the protein does not have the function and are left out of closure
+// queries. TermName and Namespace are filled when annotations are listed.
+type GOAnnotation struct {
+	ID           int64     `json:"id,omitempty"`
+	ProteinID    string    `json:"protein_id"`
+	TermID       string    `json:"term_id"`
+	TermName     string    `json:"term_name,omitempty"`
+	Namespace    string    `json:"namespace,omitempty"`
+	EvidenceCode string    `json:"evidence_code"`
+	Qualifier    string    `json:"qualifier,omitempty"`
+	Negated      bool      `json:"negated"`
+	Reference    string    `json:"reference,omitempty"`
+	WithFrom     string    `json:"with_from,omitempty"`
+	AssignedBy   string    `json:"assigned_by,omitempty"`
+	Source       string    `json:"source,omitempty"`
+	Created      time.Time `json:"created"`
+}
+
+// OntologyImportSummary reports the result of loading an OBO file.
+type OntologyImportSummary struct {
+	DataVersion   string    `json:"data_version,omitempty"`
+	Terms         int       `json:"terms"`
+	Obsolete      int       `json:"obsolete"`
+	Relationships int       `json:"relationships"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrImportRolledBack reports an all-or-nothing write that saved nothing, as one of its
+// records could not be written.
+var ErrImportRolledBack = errors.New("import rolled back, a record could not be written")
+
+type ImportStatus string
+
+const (
+	ImportCreated ImportStatus = "created"
+	ImportUpdated ImportStatus = "updated"
+	ImportSkipped ImportStatus = "skipped"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// SaveOutcome is what writing an imported record did to the stored protein.
+type SaveOutcome int
+
+const (
+	SaveCreated SaveOutcome = iota
+	SaveUpdated
+	// SaveExists leaves alone the protein stored under the ID, as the write only creates.
+	SaveExists
+	// SaveVersionConflict leaves alone a stored protein at another version than the
+	// record's.
+	SaveVersionConflict
+	// SaveTrashed leaves alone a protein in the trash.
+	SaveTrashed
+)
+
+// ImportRecordResult is the outcome of one record of an import file. Line is the line
+// number where the record starts, or the position of the item in a bulk create, from 1.
+// Reason explains why a record was skipped or updated; Error why it failed.
+type ImportRecordResult struct {
+	Line   int          `json:"line"`
+	ID     string       `json:"id,omitempty"`
+	Status ImportStatus `json:"status"`
+	Reason string       `json:"reason,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// ImportReport summarises an import. In a dry run the statuses are those the records
+// would have had; nothing is written.
+type ImportReport struct {
+	DryRun     bool                 `json:"dry_run"`
+	Total      int                  `json:"total"`
+	Created    int                  `json:"created"`
+	Updated    int                  `json:"updated"`
+	Skipped    int                  `json:"skipped"`
+	Failed     int                  `json:"failed"`
+	Records    []ImportRecordResult `json:"records"`
+	StartedAt  time.Time            `json:"started_at"`
+	FinishedAt time.Time            `json:"finished_at"`
+}
+
+func NewImportReport() *ImportReport {
+	return &ImportReport{Records: []ImportRecordResult{}, StartedAt: time.Now()}
+}
+
+// Tally updates the counters without keeping a per-record entry, for imports too large
+// to list every record.
+func (r *ImportReport) Tally(status ImportStatus) {
+	r.Total++
+	switch status {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportSkipped:
+		r.Skipped++
+	case ImportFailed:
+		r.Failed++
+	}
+}
+
+// Add records a result and updates the counters.
+func (r *ImportReport) Add(result ImportRecordResult) {
+	r.Tally(result.Status)
+	r.Records = append(r.Records, result)
+}
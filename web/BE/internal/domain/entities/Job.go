@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+)
+
+// Job tracks a long-running background computation and its progress.
+type Job struct {
+	ID          string      `json:"id"`
+	Kind        string      `json:"kind"`
+	Status      JobStatus   `json:"status"`
+	Total       int         `json:"total"`
+	Completed   int         `json:"completed"`
+	Progress    float64     `json:"progress"`
+	Error       string      `json:"error,omitempty"`
+	Result      interface{} `json:"result,omitempty"`
+	SubmittedAt time.Time   `json:"submitted_at"`
+	StartedAt   *time.Time  `json:"started_at,omitempty"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+}
+
+func (j *Job) IsFinished() bool {
+	return j.Status == JobCompleted || j.Status == JobFailed
+}
@@ -0,0 +1,54 @@
+package entities
+
+const (
+	MutationConservative = "conservative"
+	MutationModerate     = "moderate"
+	MutationRadical      = "radical"
+)
+
+const (
+	HeatmapSeverity   = "severity"
+	HeatmapDeltaMW    = "delta_mw"
+	HeatmapDeltaPI    = "delta_pi"
+	HeatmapDeltaGravy = "delta_gravy"
+)
+
+// SequenceProperties holds the physico-chemical properties computed for a sequence.
+type SequenceProperties struct {
+	MolecularWeight  float64 `json:"molecular_weight"`
+	IsoelectricPoint float64 `json:"isoelectric_point"`
+	Hydrophobicity   float64 `json:"hydrophobicity"`
+}
+
+// MutationEffect describes a single amino acid substitution relative to the wild type.
+type MutationEffect struct {
+	Position   int     `json:"position"`
+	WildType   string  `json:"wild_type"`
+	Mutant     string  `json:"mutant"`
+	Notation   string  `json:"notation"`
+	BLOSUM62   int     `json:"blosum62"`
+	Severity   float64 `json:"severity"`
+	Class      string  `json:"class"`
+	DeltaMW    float64 `json:"delta_mw"`
+	DeltaPI    float64 `json:"delta_pi"`
+	DeltaGravy float64 `json:"delta_gravy"`
+}
+
+// MutationHeatmap is a position x residue matrix of one metric; wild-type cells are zero.
+type MutationHeatmap struct {
+	Metric    string      `json:"metric"`
+	Positions []int       `json:"positions"`
+	WildTypes []string    `json:"wild_types"`
+	Residues  []string    `json:"residues"`
+	Values    [][]float64 `json:"values"`
+}
+
+type SaturationScan struct {
+	ProteinID string             `json:"protein_id,omitempty"`
+	Start     int                `json:"start"`
+	End       int                `json:"end"`
+	Length    int                `json:"length"`
+	WildType  SequenceProperties `json:"wild_type"`
+	Variants  []MutationEffect   `json:"variants"`
+	Heatmap   MutationHeatmap    `json:"heatmap"`
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidPSSMName            = errors.New("PSSM name cannot be empty")
+	ErrInvalidPSSMShape           = errors.New("PSSM scores must have one row of alphabet size per position")
+	ErrSequenceTooShortForProfile = errors.New("sequence is shorter than the profile")
+)
+
+// PSSM is a position-specific scoring matrix. Scores are log-odds in bits against the
+// background frequencies; rows are profile positions and columns follow Alphabet.
+type PSSM struct {
+	ID                 int64       `json:"id,omitempty"`
+	Name               string      `json:"name"`
+	Description        *string     `json:"description,omitempty"`
+	Family             *string     `json:"family,omitempty"`
+	Alphabet           string      `json:"alphabet"`
+	Length             int         `json:"length"`
+	Consensus          string      `json:"consensus"`
+	Scores             [][]float64 `json:"scores"`
+	Frequencies        [][]float64 `json:"frequencies,omitempty"`
+	Information        []float64   `json:"information,omitempty"`
+	NumSequences       int         `json:"num_sequences"`
+	EffectiveSequences float64     `json:"effective_sequences"`
+	PseudocountWeight  float64     `json:"pseudocount_weight"`
+	SourceIDs          []string    `json:"source_ids,omitempty"`
+	Created            time.Time   `json:"created"`
+	Updated            time.Time   `json:"updated"`
+}
+
+func (p *PSSM) Validate() error {
+	if p.Name == "" {
+		return ErrInvalidPSSMName
+	}
+	if p.Length == 0 || len(p.Scores) != p.Length {
+		return ErrInvalidPSSMShape
+	}
+	for _, row := range p.Scores {
+		if len(row) != len(p.Alphabet) {
+			return ErrInvalidPSSMShape
+		}
+	}
+	return nil
+}
+
+// MaxScore is the highest score any window can reach against the profile.
+func (p *PSSM) MaxScore() float64 {
+	total := 0.0
+	for _, row := range p.Scores {
+		best := row[0]
+		for _, s := range row[1:] {
+			if s > best {
+				best = s
+			}
+		}
+		total += best
+	}
+	return total
+}
+
+// PSSMHit is the best-scoring window of a sequence against a profile.
+type PSSMHit struct {
+	ProteinID       string  `json:"protein_id,omitempty"`
+	Name            string  `json:"name,omitempty"`
+	Family          *string `json:"family,omitempty"`
+	Start           int     `json:"start"`
+	End             int     `json:"end"`
+	Window          string  `json:"window"`
+	Score           float64 `json:"score"`
+	NormalizedScore float64 `json:"normalized_score"`
+}
+
+type PSSMScore struct {
+	PSSMHit
+	PositionScores []float64 `json:"position_scores"`
+	WindowScores   []float64 `json:"window_scores"`
+}
+
+// FamilyProfileCheck compares curated Family assignments with profile scores.
+type FamilyProfileCheck struct {
+	Family       string    `json:"family"`
+	Threshold    float64   `json:"threshold"`
+	Confirmed    []PSSMHit `json:"confirmed"`
+	Questionable []PSSMHit `json:"questionable"`
+	Candidates   []PSSMHit `json:"candidates"`
+}
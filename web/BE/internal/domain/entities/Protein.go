@@ -2,6 +2,7 @@ package entities
 
 import (
 	"errors"
+	"go-crawler/web/BE/internal/domain/querylang"
 	"strings"
 	"time"
 )
@@ -11,17 +12,26 @@ var (
 	ErrInvalidProteinName    = errors.New("protein name cannot be empty")
 	ErrSequenceTooShort      = errors.New("protein sequence is too short")
 	ErrInvalidSequenceFormat = errors.New("protein sequence contains invalid characters")
+	ErrInvalidCursor         = errors.New("invalid cursor, or one from a search in another order")
+	ErrProteinDeleted        = errors.New("protein is in the trash")
+	ErrVersionConflict       = errors.New("protein was changed since it was read")
+	ErrGeneInUse             = errors.New("gene is still linked to proteins")
+	ErrFamilyInUse           = errors.New("protein family is still linked to proteins")
 )
 
 type Protein struct {
 	ID                  string    `json:"id" db:"id"`
 	Name                string    `json:"name" db:"name"`
 	Gene                *string   `json:"gene,omitempty" db:"gene"`
+	Aliases             []string  `json:"aliases,omitempty" db:"aliases"`
+	GeneID              *int      `json:"gene_id,omitempty" db:"gene_id"`
 	Taxo                *string   `json:"taxo,omitempty" db:"taxo"`
+	TaxonID             *int      `json:"taxon_id,omitempty" db:"taxon_id"`
 	CC                  *string   `json:"cc,omitempty" db:"cc"`
 	Length              *int      `json:"length,omitempty" db:"length"`
 	Domain              *string   `json:"domain,omitempty" db:"domain"`
 	Family              *string   `json:"family,omitempty" db:"family"`
+	FamilyID            *int      `json:"family_id,omitempty" db:"family_id"`
 	BioProcess          *string   `json:"bio_process,omitempty" db:"bio_process"`
 	Function            *string   `json:"function,omitempty" db:"function"`
 	MW                  *float64  `json:"mw,omitempty" db:"mw"`
@@ -35,6 +45,21 @@ type Protein struct {
 	FRank               *string   `json:"f_rank,omitempty" db:"f_rank"`
 	Created             time.Time `json:"created" db:"created"`
 	Updated             time.Time `json:"updated" db:"updated"`
+	// Version is bumped by every write to the protein.
+	Version int `json:"version" db:"version"`
+	// DeletedAt is only set on proteins listed from the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Features and Diseases are only loaded when requested.
+	Features []ProteinFeature     `json:"features,omitempty" db:"-"`
+	Diseases []DiseaseAssociation `json:"diseases,omitempty" db:"-"`
+
+	// Rank and Highlights are only filled by a full-text search: Highlights maps each
+	// matching field to a snippet with the matched words wrapped in <mark> tags.
+	// Similarity is only filled by a fuzzy search.
+	Rank       *float64          `json:"rank,omitempty" db:"-"`
+	Highlights map[string]string `json:"highlights,omitempty" db:"-"`
+	Similarity *float64          `json:"similarity,omitempty" db:"-"`
 }
 
 func NewProtein(id, name string, seq []string) (*Protein, error) {
@@ -93,6 +118,25 @@ func (p *Protein) SetGene(gene string) {
 	}
 }
 
+// SetAliases replaces the alternative names, trimmed and without duplicates of each
+// other, the name or the gene.
+func (p *Protein) SetAliases(aliases []string) {
+	seen := map[string]bool{strings.ToLower(p.Name): true}
+	if p.Gene != nil {
+		seen[strings.ToLower(*p.Gene)] = true
+	}
+	p.Aliases = nil
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		p.Aliases = append(p.Aliases, alias)
+	}
+	p.Updated = time.Now()
+}
+
 func (p *Protein) SetTaxonomy(taxo string) {
 	if strings.TrimSpace(taxo) != "" {
 		taxoVal := strings.TrimSpace(taxo)
@@ -101,6 +145,14 @@ func (p *Protein) SetTaxonomy(taxo string) {
 	}
 }
 
+// SetTaxonID links the protein to an NCBI taxon; non-positive IDs are ignored.
+func (p *Protein) SetTaxonID(taxonID int) {
+	if taxonID > 0 {
+		p.TaxonID = &taxonID
+		p.Updated = time.Now()
+	}
+}
+
 func (p *Protein) SetMolecularWeight(mw float64) {
 	if mw > 0 {
 		p.MW = &mw
@@ -108,21 +160,42 @@ func (p *Protein) SetMolecularWeight(mw float64) {
 	}
 }
 
+// Gene and ProteinFamily are the normalized values of Protein.Gene and Protein.Family,
+// which link to them through GeneID and FamilyID. ProteinCount is filled when listed.
 type Gene struct {
-	ID   int    `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	ID           int    `json:"id" db:"id"`
+	Name         string `json:"name" db:"name"`
+	ProteinCount int    `json:"protein_count" db:"-"`
 }
 
 type ProteinFamily struct {
-	ID   int    `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	ID           int    `json:"id" db:"id"`
+	Name         string `json:"name" db:"name"`
+	ProteinCount int    `json:"protein_count" db:"-"`
 }
 
+// ProteinFilter selects proteins. GOTerm (a GO ID or exact term name) matches proteins
+// annotated to the term or any term below it along is_a and part_of; TaxonID matches
+// proteins of the taxon or any taxon below it. GeneID and FamilyID match the linked gene
+// or family exactly. Query is a web-search style full-text query ("kinase -receptor",
+// "\"heme binding\" or oxygen") over the names, gene, aliases, family, domains,
+// function, biological process and comments; results are ranked by relevance unless
+// ordered otherwise. Fuzzy matches misspelled names, genes and aliases by trigram
+// similarity of at least MinSimilarity (0 to 1), most similar first unless ordered
+// otherwise.
+//
+// A page starts at Offset, or next to the row a Cursor from an earlier page points at;
+// Count is CountExact (the default), CountEstimated or CountNone.
 type ProteinFilter struct {
+	Query           *string  `json:"q,omitempty"`
+	Fuzzy           *string  `json:"fuzzy,omitempty"`
+	MinSimilarity   *float64 `json:"min_similarity,omitempty"`
 	ID              *string  `json:"id,omitempty"`
 	Name            *string  `json:"name,omitempty"`
 	Gene            *string  `json:"gene,omitempty"`
 	Family          *string  `json:"family,omitempty"`
+	GeneID          *int     `json:"gene_id,omitempty"`
+	FamilyID        *int     `json:"family_id,omitempty"`
 	MinLength       *int     `json:"min_length,omitempty"`
 	MaxLength       *int     `json:"max_length,omitempty"`
 	MinMW           *float64 `json:"min_mw,omitempty"`
@@ -133,18 +206,123 @@ type ProteinFilter struct {
 	MaxNInteractors *int     `json:"max_n_interactors,omitempty"`
 	MinDRank        *int     `json:"min_d_rank,omitempty"`
 	MaxDRank        *int     `json:"max_d_rank,omitempty"`
-	Limit           int      `json:"limit"`
-	Offset          int      `json:"offset"`
-	OrderBy         string   `json:"order_by"`
-	OrderDirection  string   `json:"order_direction"`
+	GOTerm          *string  `json:"go_term,omitempty"`
+	TaxonID         *int     `json:"taxon_id,omitempty"`
+	// Expr is a filter expression over ProteinQueryFields; the use cases parse it
+	// into Where, which is what the repository applies.
+	Expr           *string        `json:"filter,omitempty"`
+	Where          querylang.Node `json:"-"`
+	Limit          int            `json:"limit"`
+	Offset         int            `json:"offset"`
+	OrderBy        string         `json:"order_by"`
+	OrderDirection string         `json:"order_direction"`
+	Cursor         string         `json:"cursor,omitempty"`
+	Count          string         `json:"count,omitempty"`
 }
 
+// ProteinQueryFields are the fields of a ProteinFilter expression: every protein column
+// under its JSON name, and a few shorter aliases.
+var ProteinQueryFields = querylang.Schema{
+	"id":                   {Name: "id", Kind: querylang.Text},
+	"name":                 {Name: "name", Kind: querylang.Text},
+	"gene":                 {Name: "gene", Kind: querylang.Text},
+	"gene_id":              {Name: "gene_id", Kind: querylang.Integer},
+	"aliases":              {Name: "aliases", Kind: querylang.TextList},
+	"alias":                {Name: "aliases", Kind: querylang.TextList},
+	"taxo":                 {Name: "taxo", Kind: querylang.Text},
+	"taxon_id":             {Name: "taxon_id", Kind: querylang.Integer},
+	"cc":                   {Name: "cc", Kind: querylang.Text},
+	"length":               {Name: "length", Kind: querylang.Integer},
+	"domain":               {Name: "domain", Kind: querylang.Text},
+	"family":               {Name: "family", Kind: querylang.Text},
+	"family_id":            {Name: "family_id", Kind: querylang.Integer},
+	"bio_process":          {Name: "bio_process", Kind: querylang.Text},
+	"function":             {Name: "function", Kind: querylang.Text},
+	"mw":                   {Name: "mw", Kind: querylang.Number},
+	"seq":                  {Name: "seq", Kind: querylang.Text},
+	"n_interactors":        {Name: "n_interactors", Kind: querylang.Integer},
+	"pi":                   {Name: "pi", Kind: querylang.Number},
+	"nc_7_4":               {Name: "nc_7_4", Kind: querylang.Number},
+	"nc74":                 {Name: "nc_7_4", Kind: querylang.Number},
+	"hydrophobicity_gravy": {Name: "hydrophobicity_gravy", Kind: querylang.Number},
+	"gravy":                {Name: "hydrophobicity_gravy", Kind: querylang.Number},
+	"d_rank":               {Name: "d_rank", Kind: querylang.Integer},
+	"drank":                {Name: "d_rank", Kind: querylang.Integer},
+	"l_rank":               {Name: "l_rank", Kind: querylang.Text},
+	"lrank":                {Name: "l_rank", Kind: querylang.Text},
+	"f_rank":               {Name: "f_rank", Kind: querylang.Text},
+	"frank":                {Name: "f_rank", Kind: querylang.Text},
+	"created":              {Name: "created", Kind: querylang.Time},
+	"updated":              {Name: "updated", Kind: querylang.Time},
+}
+
+// How ProteinFilter.Count totals the matching proteins: CountEstimated takes the query
+// planner's estimate, which costs no scan.
+const (
+	CountExact     = "exact"
+	CountEstimated = "estimated"
+	CountNone      = "none"
+)
+
+// PaginatedProteins is a page of search results. Total is missing when the count was
+// skipped. NextCursor and PrevCursor address the pages after and before this one.
 type PaginatedProteins struct {
-	Proteins []Protein `json:"proteins"`
-	Total    int       `json:"total"`
-	Limit    int       `json:"limit"`
-	Offset   int       `json:"offset"`
-	HasMore  bool      `json:"has_more"`
+	Proteins       []Protein `json:"proteins"`
+	Total          *int      `json:"total,omitempty"`
+	TotalEstimated bool      `json:"total_estimated,omitempty"`
+	Limit          int       `json:"limit"`
+	Offset         int       `json:"offset"`
+	HasMore        bool      `json:"has_more"`
+	NextCursor     string    `json:"next_cursor,omitempty"`
+	PrevCursor     string    `json:"prev_cursor,omitempty"`
+
+	// Suggestions are stored terms close to the searched text, offered when nothing
+	// matched.
+	Suggestions []SearchSuggestion `json:"suggestions,omitempty"`
+}
+
+// SearchSuggestion is a protein name, gene or alias (Field) close to some typed text,
+// with the number of proteins carrying it.
+type SearchSuggestion struct {
+	Text       string  `json:"text"`
+	Field      string  `json:"field"`
+	Proteins   int     `json:"proteins"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Facets ProteinFacetOptions can count: proteins per family, gene and taxon, and
+// histograms of length and isoelectric point.
+const (
+	FacetFamily = "family"
+	FacetGene   = "gene"
+	FacetTaxon  = "taxon"
+	FacetLength = "length"
+	FacetPI     = "pi"
+)
+
+// ProteinFacetOptions picks the facets to count, how many of the largest buckets a term
+// facet keeps (Size) and the bucket widths of the length and pI histograms.
+type ProteinFacetOptions struct {
+	Fields      []string `json:"fields"`
+	Size        int      `json:"size"`
+	LengthWidth int      `json:"length_width"`
+	PIWidth     float64  `json:"pi_width"`
+}
+
+// FacetBucket counts the matching proteins with one family, gene or taxon (ID, Value)
+// or in one histogram range [From, To).
+type FacetBucket struct {
+	ID    *int     `json:"id,omitempty"`
+	Value string   `json:"value,omitempty"`
+	From  *float64 `json:"from,omitempty"`
+	To    *float64 `json:"to,omitempty"`
+	Count int      `json:"count"`
+}
+
+// ProteinFacets are the facet counts of the proteins matching a filter, keyed by facet.
+type ProteinFacets struct {
+	Total  int                      `json:"total"`
+	Facets map[string][]FacetBucket `json:"facets"`
 }
 
 type ProteinStats struct {
@@ -156,4 +334,8 @@ type ProteinStats struct {
 	AvgHydrophobicity float64 `json:"avg_hydrophobicity"`
 	TotalGenes        int     `json:"total_genes"`
 	TotalFamilies     int     `json:"total_families"`
+
+	// Rank and ByRank are only filled when a breakdown by taxonomic rank is requested.
+	Rank   string      `json:"rank,omitempty"`
+	ByRank []RankCount `json:"by_rank,omitempty"`
 }
@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// Revision actions: a protein is created, updated, moved to the trash (delete), taken
+// out of it (restore) or purged for good.
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+	RevisionPurge   = "purge"
+)
+
+// ProteinRevision is an immutable record of one write to a protein, numbered from 1 per
+// protein. Snapshot is the protein as written (as it was, for a purge); lists of
+// revisions leave it out.
+type ProteinRevision struct {
+	ID            int64     `json:"id"`
+	ProteinID     string    `json:"protein_id"`
+	Revision      int       `json:"revision"`
+	Action        string    `json:"action"`
+	ChangedFields []string  `json:"changed_fields"`
+	Snapshot      *Protein  `json:"snapshot,omitempty"`
+	Actor         *string   `json:"actor,omitempty"`
+	Note          *string   `json:"note,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// PaginatedRevisions is a page of the history of a protein, newest first.
+type PaginatedRevisions struct {
+	Revisions []ProteinRevision `json:"revisions"`
+	Total     int               `json:"total"`
+	Limit     int               `json:"limit"`
+	Offset    int               `json:"offset"`
+}
+
+// FieldChange is a field that differs between two revisions.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// Sequence edit operations.
+const (
+	EditSubstitution = "substitution"
+	EditInsertion    = "insertion"
+	EditDeletion     = "deletion"
+)
+
+// SequenceEdit is a run of changed residues. Position is 1-based in the older sequence
+// and NewPosition in the newer one; an insertion goes before Position.
+type SequenceEdit struct {
+	Op          string `json:"op"`
+	Position    int    `json:"position"`
+	NewPosition int    `json:"new_position"`
+	From        string `json:"from,omitempty"`
+	To          string `json:"to,omitempty"`
+}
+
+// SequenceDiff is the residue-level difference between two sequences, with the number
+// of residues substituted, inserted and deleted.
+type SequenceDiff struct {
+	LengthFrom    int            `json:"length_from"`
+	LengthTo      int            `json:"length_to"`
+	Substitutions int            `json:"substitutions"`
+	Insertions    int            `json:"insertions"`
+	Deletions     int            `json:"deletions"`
+	Edits         []SequenceEdit `json:"edits"`
+}
+
+// RevisionDiff compares two revisions of a protein. Sequence is only set when the
+// sequence changed.
+type RevisionDiff struct {
+	ProteinID string        `json:"protein_id"`
+	From      int           `json:"from"`
+	To        int           `json:"to"`
+	Changes   []FieldChange `json:"changes"`
+	Sequence  *SequenceDiff `json:"sequence,omitempty"`
+}
+
+// Change says who makes a write and why. It travels in the context down to the
+// repository, which records it on the revisions the write leaves.
+type Change struct {
+	Actor string
+	Note  string
+}
+
+type changeKey struct{}
+
+func WithChange(ctx context.Context, change Change) context.Context {
+	return context.WithValue(ctx, changeKey{}, change)
+}
+
+// ChangeFrom returns the change of the context, or a zero one for writes made by the
+// system itself.
+func ChangeFrom(ctx context.Context) Change {
+	change, _ := ctx.Value(changeKey{}).(Change)
+	return change
+}
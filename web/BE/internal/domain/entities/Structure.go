@@ -0,0 +1,230 @@
+package entities
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoStructureChains     = errors.New("structure contains no polymer chains")
+	ErrTooFewAlignedResidues = errors.New("fewer than three aligned residues to superpose")
+)
+
+// residueCodes maps PDB residue names to one-letter codes, including the modified
+// residues most often deposited as HETATM in place of a standard amino acid.
+var residueCodes = map[string]byte{
+	"ALA": 'A', "ARG": 'R', "ASN": 'N', "ASP": 'D', "CYS": 'C',
+	"GLN": 'Q', "GLU": 'E', "GLY": 'G', "HIS": 'H', "ILE": 'I',
+	"LEU": 'L', "LYS": 'K', "MET": 'M', "PHE": 'F', "PRO": 'P',
+	"SER": 'S', "THR": 'T', "TRP": 'W', "TYR": 'Y', "VAL": 'V',
+	"MSE": 'M', "SEP": 'S', "TPO": 'T', "PTR": 'Y', "CSO": 'C',
+	"HYP": 'P', "MLY": 'K', "KCX": 'K', "CME": 'C', "SEC": 'U',
+	"PYL": 'O', "ASX": 'B', "GLX": 'Z', "UNK": 'X',
+}
+
+// ResidueCode returns the one-letter code of a residue name and whether it is an
+// amino acid.
+func ResidueCode(name string) (byte, bool) {
+	code, ok := residueCodes[strings.ToUpper(name)]
+	return code, ok
+}
+
+// Atom is one ATOM or HETATM record. Only the first alternate location is kept.
+type Atom struct {
+	Serial    int     `json:"serial"`
+	Name      string  `json:"name"`
+	Element   string  `json:"element,omitempty"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Z         float64 `json:"z"`
+	Occupancy float64 `json:"occupancy"`
+	BFactor   float64 `json:"b_factor"`
+}
+
+// Residue is a residue with coordinates. Number and InsertionCode are the author
+// numbering used in the file.
+type Residue struct {
+	Name          string `json:"name"`
+	Number        int    `json:"number"`
+	InsertionCode string `json:"insertion_code,omitempty"`
+	Hetero        bool   `json:"hetero"`
+	Atoms         []Atom `json:"atoms"`
+}
+
+// Label is the author residue number with its insertion code, e.g. "52A".
+func (r *Residue) Label() string {
+	return strconv.Itoa(r.Number) + r.InsertionCode
+}
+
+// Atom returns the atom with the given name, or nil.
+func (r *Residue) Atom(name string) *Atom {
+	for i := range r.Atoms {
+		if r.Atoms[i].Name == name {
+			return &r.Atoms[i]
+		}
+	}
+	return nil
+}
+
+// CA returns the alpha carbon, or nil.
+func (r *Residue) CA() *Atom {
+	return r.Atom("CA")
+}
+
+// IsAminoAcid reports whether the residue is a (possibly modified) amino acid with an
+// alpha carbon; water and ligands are not.
+func (r *Residue) IsAminoAcid() bool {
+	_, ok := ResidueCode(r.Name)
+	return ok && r.CA() != nil
+}
+
+// Chain is a chain of the first model. SeqRes lists the residue names of the deposited
+// sequence (SEQRES or _pdbx_poly_seq_scheme); Residues are those with coordinates.
+type Chain struct {
+	ID       string    `json:"id"`
+	SeqRes   []string  `json:"seqres,omitempty"`
+	Residues []Residue `json:"residues"`
+}
+
+// SeqResSequence is the deposited sequence in one-letter codes; unknown residues are X.
+func (c *Chain) SeqResSequence() string {
+	seq := make([]byte, len(c.SeqRes))
+	for i, name := range c.SeqRes {
+		code, ok := ResidueCode(name)
+		if !ok {
+			code = 'X'
+		}
+		seq[i] = code
+	}
+	return string(seq)
+}
+
+// AminoAcids returns the residues with coordinates that belong to the polypeptide.
+func (c *Chain) AminoAcids() []Residue {
+	residues := make([]Residue, 0, len(c.Residues))
+	for _, residue := range c.Residues {
+		if residue.IsAminoAcid() {
+			residues = append(residues, residue)
+		}
+	}
+	return residues
+}
+
+// AtomSequence is the sequence of the modelled amino acids.
+func (c *Chain) AtomSequence() string {
+	residues := c.AminoAcids()
+	seq := make([]byte, len(residues))
+	for i := range residues {
+		seq[i], _ = ResidueCode(residues[i].Name)
+	}
+	return string(seq)
+}
+
+// Structure is a parsed macromolecular structure. Only the first model is kept.
+type Structure struct {
+	Format     string   `json:"format"`
+	PDBID      string   `json:"pdb_id,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Method     string   `json:"method,omitempty"`
+	Resolution *float64 `json:"resolution,omitempty"`
+	Chains     []Chain  `json:"chains"`
+}
+
+// Chain returns the chain with the given ID, or nil.
+func (s *Structure) Chain(id string) *Chain {
+	for i := range s.Chains {
+		if s.Chains[i].ID == id {
+			return &s.Chains[i]
+		}
+	}
+	return nil
+}
+
+// StructureEntry is an uploaded structure file in the store. Path is relative to the
+// store directory.
+type StructureEntry struct {
+	ID         int64             `json:"id"`
+	PDBID      string            `json:"pdb_id,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	Method     string            `json:"method,omitempty"`
+	Resolution *float64          `json:"resolution,omitempty"`
+	Format     string            `json:"format"`
+	FileName   string            `json:"file_name,omitempty"`
+	Path       string            `json:"-"`
+	Checksum   string            `json:"checksum"`
+	FileSize   int64             `json:"file_size"`
+	Chains     []*StructureChain `json:"chains,omitempty"`
+	Created    time.Time         `json:"created"`
+}
+
+// StructureChain is a stored chain. ResidueNumbers, CACoords and ProteinPositions run
+// over the modelled amino acids (AtomSequence); CACoords holds x, y, z triples and
+// ProteinPositions the 1-based position in the linked protein, 0 where unaligned.
+type StructureChain struct {
+	ID               int64     `json:"id"`
+	StructureID      int64     `json:"structure_id"`
+	ChainID          string    `json:"chain_id"`
+	ProteinID        *string   `json:"protein_id,omitempty"`
+	SeqResSequence   string    `json:"seqres_sequence,omitempty"`
+	AtomSequence     string    `json:"atom_sequence"`
+	ResidueNumbers   []string  `json:"residue_numbers,omitempty"`
+	CACoords         []float64 `json:"-"`
+	ProteinPositions []int     `json:"protein_positions,omitempty"`
+	Identity         float64   `json:"identity,omitempty"`
+	Coverage         float64   `json:"coverage,omitempty"`
+}
+
+// CAPoints returns the CA coordinates, one per modelled residue.
+func (c *StructureChain) CAPoints() [][3]float64 {
+	points := make([][3]float64, len(c.CACoords)/3)
+	for i := range points {
+		points[i] = [3]float64{c.CACoords[3*i], c.CACoords[3*i+1], c.CACoords[3*i+2]}
+	}
+	return points
+}
+
+// Superposition is the rigid-body fit of a mobile chain onto a target chain over
+// sequence-aligned CA atoms. RMSD is over all aligned pairs after a least-squares fit;
+// Rotation and Translation are the transform maximising TM-score, which is normalised
+// by the target length. GDTTS is the mean fraction of target residues within 1, 2, 4
+// and 8 Å, each under its own best fit.
+type Superposition struct {
+	AlignedPairs int           `json:"aligned_pairs"`
+	RMSD         float64       `json:"rmsd"`
+	TMScore      float64       `json:"tm_score"`
+	GDTTS        float64       `json:"gdt_ts"`
+	Rotation     [3][3]float64 `json:"rotation"`
+	Translation  [3]float64    `json:"translation"`
+}
+
+// Apply transforms a mobile point onto the target frame.
+func (s *Superposition) Apply(p [3]float64) [3]float64 {
+	var out [3]float64
+	for i := 0; i < 3; i++ {
+		out[i] = s.Rotation[i][0]*p[0] + s.Rotation[i][1]*p[1] + s.Rotation[i][2]*p[2] + s.Translation[i]
+	}
+	return out
+}
+
+// Contact is a residue pair closer than the contact threshold. I and J index the
+// residues of the contact map, with I < J.
+type Contact struct {
+	I        int     `json:"i"`
+	J        int     `json:"j"`
+	Distance float64 `json:"distance"`
+}
+
+// ContactMap lists the contacts of a chain between CA or CB atoms (CA for glycine and
+// residues without CB).
+type ContactMap struct {
+	StructureID   int64     `json:"structure_id"`
+	ChainID       string    `json:"chain_id"`
+	Atom          string    `json:"atom"`
+	Threshold     float64   `json:"threshold"`
+	MinSeparation int       `json:"min_separation"`
+	Sequence      string    `json:"sequence"`
+	Residues      []string  `json:"residues"`
+	Contacts      []Contact `json:"contacts"`
+}
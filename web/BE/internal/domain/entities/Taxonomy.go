@@ -0,0 +1,58 @@
+package entities
+
+import "time"
+
+// TaxonRootID is the root of the NCBI taxonomy; it is its own parent.
+const TaxonRootID = 1
+
+// Name classes of names.dmp used when resolving organism names.
+const (
+	TaxonNameScientific = "scientific name"
+	TaxonNameSynonym    = "synonym"
+	TaxonNameCommon     = "common name"
+	TaxonNameGenBank    = "genbank common name"
+)
+
+// Taxon is a node of the NCBI taxonomy. Names is only filled for a single-taxon lookup.
+type Taxon struct {
+	ID             int         `json:"id"`
+	ParentID       int         `json:"parent_id"`
+	Rank           string      `json:"rank"`
+	ScientificName string      `json:"scientific_name"`
+	DivisionID     int         `json:"division_id"`
+	GeneticCode    int         `json:"genetic_code"`
+	Names          []TaxonName `json:"names,omitempty"`
+}
+
+// TaxonName is one line of names.dmp: a scientific name, synonym, common name, etc.
+type TaxonName struct {
+	TaxonID    int    `json:"taxon_id"`
+	Name       string `json:"name"`
+	UniqueName string `json:"unique_name,omitempty"`
+	NameClass  string `json:"name_class"`
+}
+
+// TaxonMatch is a taxon found by one of its names.
+type TaxonMatch struct {
+	TaxonID        int    `json:"taxon_id"`
+	Name           string `json:"name"`
+	NameClass      string `json:"name_class"`
+	ScientificName string `json:"scientific_name"`
+	Rank           string `json:"rank"`
+}
+
+// RankCount is the number of proteins whose lineage passes through a taxon of the
+// requested rank.
+type RankCount struct {
+	TaxonID int    `json:"taxon_id"`
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+}
+
+// TaxonomyImportSummary reports the result of loading a taxdump.
+type TaxonomyImportSummary struct {
+	Nodes      int       `json:"nodes"`
+	Names      int       `json:"names"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
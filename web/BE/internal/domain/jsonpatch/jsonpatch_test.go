@@ -0,0 +1,176 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", s, err)
+	}
+	return v
+}
+
+func TestParsePointer(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    Pointer
+		wantErr bool
+	}{
+		{pointer: "", want: Pointer{}},
+		{pointer: "/", want: Pointer{""}},
+		{pointer: "/aliases/0", want: Pointer{"aliases", "0"}},
+		{pointer: "/a~1b/m~0n", want: Pointer{"a/b", "m~n"}},
+		{pointer: "/~01", want: Pointer{"~1"}},
+		{pointer: "aliases", wantErr: true},
+		{pointer: "/a~2", wantErr: true},
+		{pointer: "/a~", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePointer(tt.pointer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePointer(%q) error %v, want error %v", tt.pointer, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParsePointer(%q) = %q, want %q", tt.pointer, got, tt.want)
+		}
+		if got.String() != tt.pointer {
+			t.Errorf("ParsePointer(%q).String() = %q", tt.pointer, got.String())
+		}
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// The examples of RFC 7396, appendix A.
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		doc := decode(t, tt.doc)
+		got := MergePatch(doc, decode(t, tt.patch))
+		if !reflect.DeepEqual(got, decode(t, tt.want)) {
+			t.Errorf("MergePatch(%s, %s) = %v, want %s", tt.doc, tt.patch, got, tt.want)
+		}
+		if !reflect.DeepEqual(doc, decode(t, tt.doc)) {
+			t.Errorf("MergePatch(%s, %s) changed the document", tt.doc, tt.patch)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		want     string
+		wantErr  bool
+		wantTest bool
+	}{
+		{name: "add member", doc: `{"a":1}`, patch: `[{"op":"add","path":"/b","value":2}]`, want: `{"a":1,"b":2}`},
+		{name: "add replaces member", doc: `{"a":1}`, patch: `[{"op":"add","path":"/a","value":[1]}]`, want: `{"a":[1]}`},
+		{name: "add inserts into array", doc: `{"a":["x","z"]}`, patch: `[{"op":"add","path":"/a/1","value":"y"}]`, want: `{"a":["x","y","z"]}`},
+		{name: "add appends with -", doc: `{"a":["x"]}`, patch: `[{"op":"add","path":"/a/-","value":"y"}]`, want: `{"a":["x","y"]}`},
+		{name: "add at the array length", doc: `{"a":["x"]}`, patch: `[{"op":"add","path":"/a/1","value":"y"}]`, want: `{"a":["x","y"]}`},
+		{name: "add past the array end", doc: `{"a":["x"]}`, patch: `[{"op":"add","path":"/a/2","value":"y"}]`, wantErr: true},
+		{name: "add with a leading zero", doc: `{"a":["x"]}`, patch: `[{"op":"add","path":"/a/01","value":"y"}]`, wantErr: true},
+		{name: "add to a missing parent", doc: `{}`, patch: `[{"op":"add","path":"/a/b","value":1}]`, wantErr: true},
+		{name: "add null", doc: `{"a":1}`, patch: `[{"op":"add","path":"/a","value":null}]`, want: `{"a":null}`},
+		{name: "add without value", doc: `{"a":1}`, patch: `[{"op":"add","path":"/a"}]`, wantErr: true},
+		{name: "add escaped member", doc: `{}`, patch: `[{"op":"add","path":"/a~1b~0c","value":1}]`, want: `{"a/b~c":1}`},
+		{name: "remove member", doc: `{"a":1,"b":2}`, patch: `[{"op":"remove","path":"/a"}]`, want: `{"b":2}`},
+		{name: "remove null member", doc: `{"a":null}`, patch: `[{"op":"remove","path":"/a"}]`, want: `{}`},
+		{name: "remove missing member", doc: `{}`, patch: `[{"op":"remove","path":"/a"}]`, wantErr: true},
+		{name: "remove array element", doc: `{"a":[1,2,3]}`, patch: `[{"op":"remove","path":"/a/1"}]`, want: `{"a":[1,3]}`},
+		{name: "remove with -", doc: `{"a":[1]}`, patch: `[{"op":"remove","path":"/a/-"}]`, wantErr: true},
+		{name: "replace null member", doc: `{"a":null}`, patch: `[{"op":"replace","path":"/a","value":"x"}]`, want: `{"a":"x"}`},
+		{name: "replace missing member", doc: `{}`, patch: `[{"op":"replace","path":"/a","value":"x"}]`, wantErr: true},
+		{name: "replace array element", doc: `{"a":[1,2]}`, patch: `[{"op":"replace","path":"/a/0","value":9}]`, want: `{"a":[9,2]}`},
+		{name: "replace whole document", doc: `{"a":1}`, patch: `[{"op":"replace","path":"","value":[1]}]`, want: `[1]`},
+		{name: "move member", doc: `{"a":{"b":1},"c":{}}`, patch: `[{"op":"move","from":"/a/b","path":"/c/d"}]`, want: `{"a":{},"c":{"d":1}}`},
+		{name: "move array element", doc: `{"a":[1,2,3]}`, patch: `[{"op":"move","from":"/a/0","path":"/a/-"}]`, want: `{"a":[2,3,1]}`},
+		{name: "move into itself", doc: `{"a":{"b":{}}}`, patch: `[{"op":"move","from":"/a","path":"/a/b/c"}]`, wantErr: true},
+		{name: "copy is deep", doc: `{"a":{"b":1}}`, patch: `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`, want: `{"a":{"b":1},"c":{"b":2}}`},
+		{name: "test equal", doc: `{"a":[1,{"b":"c"}]}`, patch: `[{"op":"test","path":"/a","value":[1,{"b":"c"}]}]`, want: `{"a":[1,{"b":"c"}]}`},
+		{name: "test number forms", doc: `{"a":1}`, patch: `[{"op":"test","path":"/a","value":1.0}]`, want: `{"a":1}`},
+		{name: "test null member", doc: `{"a":null}`, patch: `[{"op":"test","path":"/a","value":null}]`, want: `{"a":null}`},
+		{name: "test different", doc: `{"a":1}`, patch: `[{"op":"test","path":"/a","value":2}]`, wantErr: true, wantTest: true},
+		{name: "test missing member", doc: `{}`, patch: `[{"op":"test","path":"/a","value":null}]`, wantErr: true},
+		{name: "unknown op", doc: `{}`, patch: `[{"op":"merge","path":"/a","value":1}]`, wantErr: true},
+		{name: "failure applies nothing", doc: `{"a":1}`, patch: `[{"op":"add","path":"/b","value":2},{"op":"remove","path":"/c"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []Operation
+			if err := json.Unmarshal([]byte(tt.patch), &ops); err != nil {
+				t.Fatal(err)
+			}
+			doc := decode(t, tt.doc)
+			got, err := Apply(doc, ops)
+			if !reflect.DeepEqual(doc, decode(t, tt.doc)) {
+				t.Errorf("Apply changed the document to %v", doc)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Apply = %v, want an error", got)
+				}
+				var opErr *OperationError
+				if !errors.As(err, &opErr) {
+					t.Errorf("error %v is not an OperationError", err)
+				}
+				if errors.Is(err, ErrTestFailed) != tt.wantTest {
+					t.Errorf("error %v, want ErrTestFailed %v", err, tt.wantTest)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply error %v", err)
+			}
+			if !reflect.DeepEqual(got, decode(t, tt.want)) {
+				t.Errorf("Apply = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargets(t *testing.T) {
+	tests := []struct {
+		op   Operation
+		want []Pointer
+	}{
+		{op: Operation{Op: OpTest, Path: "/a"}},
+		{op: Operation{Op: OpAdd, Path: "/a/0"}, want: []Pointer{{"a", "0"}}},
+		{op: Operation{Op: OpCopy, From: "/b", Path: "/a"}, want: []Pointer{{"a"}}},
+		{op: Operation{Op: OpMove, From: "/b", Path: "/a"}, want: []Pointer{{"a"}, {"b"}}},
+	}
+	for _, tt := range tests {
+		got, err := tt.op.Targets()
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Targets of %+v = %v, %v, want %v", tt.op, got, err, tt.want)
+		}
+	}
+}
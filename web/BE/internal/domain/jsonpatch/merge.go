@@ -0,0 +1,48 @@
+package jsonpatch
+
+// MergePatch applies a JSON Merge Patch to a document and returns the result: the
+// members of a patch object replace those of the document, recursively for objects,
+// and null members remove them. A patch that is not an object replaces the document.
+// The document is left as it was.
+func MergePatch(doc, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return deepCopy(patch)
+	}
+	docObject, ok := doc.(map[string]any)
+	if !ok {
+		docObject = map[string]any{}
+	}
+
+	merged := make(map[string]any, len(docObject)+len(patchObject))
+	for key, value := range docObject {
+		merged[key] = value
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = MergePatch(merged[key], value)
+	}
+	return merged
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, member := range v {
+			copied[key] = deepCopy(member)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, element := range v {
+			copied[i] = deepCopy(element)
+		}
+		return copied
+	default:
+		return v
+	}
+}
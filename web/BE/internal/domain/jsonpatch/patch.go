@@ -0,0 +1,244 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// JSON Patch operations.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// ErrTestFailed is wrapped by the error of a test operation whose value differs.
+var ErrTestFailed = errors.New("test failed")
+
+// Operation is one operation of a JSON Patch. Value is nil when the operation has
+// none, which is not the same as a null value.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// OperationError reports the operation of a patch that could not be applied, by its
+// index from 0.
+type OperationError struct {
+	Index int
+	Op    string
+	Path  string
+	Err   error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %d (%s %s): %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// Targets returns the pointers an operation writes to: its path, and for a move the
+// location it moves from. A test writes nothing.
+func (o Operation) Targets() ([]Pointer, error) {
+	if o.Op == OpTest {
+		return nil, nil
+	}
+	path, err := ParsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+	if o.Op != OpMove {
+		return []Pointer{path}, nil
+	}
+	from, err := ParsePointer(o.From)
+	if err != nil {
+		return nil, err
+	}
+	return []Pointer{path, from}, nil
+}
+
+// Apply applies the operations of a JSON Patch in order and returns the result. The
+// patch applies as a whole or not at all: the document is left as it was either way.
+func Apply(doc any, ops []Operation) (any, error) {
+	doc = deepCopy(doc)
+	for i, op := range ops {
+		var err error
+		if doc, err = applyOperation(doc, op); err != nil {
+			return nil, &OperationError{Index: i, Op: op.Op, Path: op.Path, Err: err}
+		}
+	}
+	return doc, nil
+}
+
+func applyOperation(doc any, op Operation) (any, error) {
+	path, err := ParsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case OpAdd, OpReplace, OpTest:
+		if op.Value == nil {
+			return nil, errors.New("value is missing")
+		}
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("invalid value: %v", err)
+		}
+		switch op.Op {
+		case OpAdd:
+			return add(doc, path, value)
+		case OpReplace:
+			if len(path) == 0 {
+				return value, nil
+			}
+			if doc, _, err = remove(doc, path); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("%w: the value at %s differs", ErrTestFailed, path)
+			}
+			return doc, nil
+		}
+	case OpRemove:
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case OpMove, OpCopy:
+		from, err := ParsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if op.Op == OpMove {
+			if len(path) > len(from) && path.HasPrefix(from) {
+				return nil, fmt.Errorf("cannot move %s into itself", from)
+			}
+			if doc, value, err = remove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = get(doc, from); err != nil {
+				return nil, err
+			}
+			value = deepCopy(value)
+		}
+		return add(doc, path, value)
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+func get(doc any, path Pointer) (any, error) {
+	for i, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			member, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%s does not exist", path[:i+1])
+			}
+			doc = member
+		case []any:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+		default:
+			return nil, fmt.Errorf("%s is not an object or array", path[:i])
+		}
+	}
+	return doc, nil
+}
+
+// add returns doc with value added at path: set as an object member, or inserted into
+// an array before the element at the index.
+func add(doc any, path Pointer, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[token] = value
+		return doc, nil
+	case []any:
+		index, err := arrayIndex(token, len(node), true)
+		if err != nil {
+			return nil, err
+		}
+		node = append(node, nil)
+		copy(node[index+1:], node[index:])
+		node[index] = value
+		return replaceNode(doc, path[:len(path)-1], node), nil
+	default:
+		return nil, fmt.Errorf("%s is not an object or array", path[:len(path)-1])
+	}
+}
+
+// remove returns doc without the value at path, and the value.
+func remove(doc any, path Pointer) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		value, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s does not exist", path)
+		}
+		delete(node, token)
+		return doc, value, nil
+	case []any:
+		index, err := arrayIndex(token, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[index]
+		node = append(node[:index:index], node[index+1:]...)
+		return replaceNode(doc, path[:len(path)-1], node), value, nil
+	default:
+		return nil, nil, fmt.Errorf("%s is not an object or array", path[:len(path)-1])
+	}
+}
+
+// replaceNode returns doc with the node at an existing path replaced, for arrays,
+// which change identity as they grow and shrink.
+func replaceNode(doc any, path Pointer, node any) any {
+	if len(path) == 0 {
+		return node
+	}
+	parent, _ := get(doc, path[:len(path)-1])
+	token := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]any:
+		p[token] = node
+	case []any:
+		index, _ := arrayIndex(token, len(p), false)
+		p[index] = node
+	}
+	return doc
+}
@@ -0,0 +1,77 @@
+// Package jsonpatch applies JSON Merge Patches (RFC 7396) and JSON Patches (RFC 6902)
+// to JSON documents decoded into any: objects are map[string]any, arrays []any, and
+// numbers float64.
+package jsonpatch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pointer is a parsed JSON Pointer (RFC 6901): the unescaped reference tokens, none
+// for the whole document.
+type Pointer []string
+
+var (
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+)
+
+// ParsePointer parses a JSON Pointer such as /aliases/0.
+func ParsePointer(s string) (Pointer, error) {
+	if s == "" {
+		return Pointer{}, nil
+	}
+	if s[0] != '/' {
+		return nil, fmt.Errorf("pointer %q does not start with /", s)
+	}
+	tokens := strings.Split(s[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf("pointer %q has a ~ that is not ~0 or ~1", s)
+			}
+		}
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
+
+func (p Pointer) String() string {
+	var b strings.Builder
+	for _, token := range p {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(token))
+	}
+	return b.String()
+}
+
+// HasPrefix reports whether p is prefix or lies below it.
+func (p Pointer) HasPrefix(prefix Pointer) bool {
+	if len(prefix) > len(p) {
+		return false
+	}
+	for i, token := range prefix {
+		if p[i] != token {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex reads the index of an element of an array of n elements. With end, the
+// index may also be n, or - for it, to add an element at the end.
+func arrayIndex(token string, n int, end bool) (int, error) {
+	if end && token == "-" {
+		return n, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not an array index", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i > n || (i == n && !end) {
+		return 0, fmt.Errorf("array index %s is out of range", token)
+	}
+	return i, nil
+}
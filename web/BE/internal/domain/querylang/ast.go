@@ -0,0 +1,115 @@
+// Package querylang parses filter expressions such as
+//
+//	family:kinase AND mw:[30000 TO 80000] AND NOT taxo:"Mus musculus" OR pi>9
+//
+// into a syntax tree whose fields and values are checked against a Schema. NOT binds
+// tighter than AND, and AND tighter than OR; terms side by side are ANDed.
+package querylang
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind is the type of a field, which decides how its values are read and compared.
+type Kind int
+
+const (
+	Text Kind = iota
+	Integer
+	Number
+	Time
+	// TextList fields hold several texts; a term matches when any of them does.
+	TextList
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Integer:
+		return "an integer"
+	case Number:
+		return "a number"
+	case Time:
+		return "a date or RFC 3339 time"
+	default:
+		return "a text"
+	}
+}
+
+// Field is a filterable attribute. Name is its canonical name, which a Schema may
+// reach under several aliases.
+type Field struct {
+	Name string
+	Kind Kind
+}
+
+// Schema maps every name a query may use, in lower case, to its field.
+type Schema map[string]Field
+
+// Op is the operator of a Comparison.
+type Op string
+
+const (
+	// OpMatch is field:value. Texts match when they contain the value, or when they
+	// match it as a pattern if it has * or ? wildcards; other kinds test equality.
+	OpMatch Op = ":"
+	OpEq    Op = "="
+	OpLt    Op = "<"
+	OpLe    Op = "<="
+	OpGt    Op = ">"
+	OpGe    Op = ">="
+)
+
+// Node is a node of the syntax tree: *And, *Or, *Not, *Comparison, *Range or *Exists.
+type Node interface {
+	node()
+}
+
+type And struct{ Left, Right Node }
+
+type Or struct{ Left, Right Node }
+
+type Not struct{ Expr Node }
+
+// Comparison compares a field with a value of its kind: a string for Text and
+// TextList, an int64 for Integer, a float64 for Number and a time.Time for Time.
+// Pattern is set when a text value has wildcards.
+type Comparison struct {
+	Field   Field
+	Op      Op
+	Value   any
+	Pattern bool
+}
+
+// Range bounds a field on both sides, field:[from TO to]; a nil bound is open. Square
+// brackets include a bound and curly ones exclude it.
+type Range struct {
+	Field                  Field
+	From, To               any
+	IncludeFrom, IncludeTo bool
+}
+
+// Exists is field:*, which matches when the field has a value.
+type Exists struct {
+	Field Field
+}
+
+func (*And) node()        {}
+func (*Or) node()         {}
+func (*Not) node()        {}
+func (*Comparison) node() {}
+func (*Range) node()      {}
+func (*Exists) node()     {}
+
+// SyntaxError is a malformed query. Pos is the 1-based character position it was found at.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// timeLayouts are the accepted forms of a Time value; a date alone covers the whole day.
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
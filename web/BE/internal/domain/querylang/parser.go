@@ -0,0 +1,402 @@
+package querylang
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxDepth bounds the nesting of parentheses and NOTs.
+const maxDepth = 32
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokColon
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokLBrace
+	tokRBrace
+	tokAnd
+	tokOr
+	tokNot
+	tokTo
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// describe names the token in an error message.
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "the end of the query"
+	case tokAnd, tokOr, tokNot, tokTo:
+		return t.text
+	default:
+		return strconv.Quote(t.text)
+	}
+}
+
+var keywords = map[string]tokenKind{"AND": tokAnd, "OR": tokOr, "NOT": tokNot, "TO": tokTo}
+
+var punctuation = map[rune]tokenKind{
+	':': tokColon, '(': tokLParen, ')': tokRParen,
+	'[': tokLBracket, ']': tokRBracket, '{': tokLBrace, '}': tokRBrace,
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`():[]{}"<>=`, r)
+}
+
+// lex splits the input into tokens. Keywords are upper case, so "and" is a word.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r, pos := runes[i], i+1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case punctuation[r] != 0:
+			tokens = append(tokens, token{kind: punctuation[r], text: string(r), pos: pos})
+			i++
+		case r == '<' || r == '>' || r == '=':
+			op := string(r)
+			if r != '=' && i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: pos})
+			i += len(op)
+		case r == '"':
+			var sb strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, &SyntaxError{Pos: pos, Msg: "unterminated quoted string"}
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: pos})
+			i++
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			kind, ok := keywords[word]
+			if !ok {
+				kind = tokWord
+			}
+			tokens = append(tokens, token{kind: kind, text: word, pos: pos})
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes) + 1}), nil
+}
+
+type parser struct {
+	schema Schema
+	tokens []token
+	i      int
+	depth  int
+}
+
+// Parse reads a query against the schema. An empty query parses to a nil Node.
+func Parse(input string, schema Schema) (Node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{schema: schema, tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, nil
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind == tokRParen {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: `")" has no matching "("`}
+	} else if tok.kind != tokEOF {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "unexpected " + tok.describe()}
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd reads terms joined by AND, or by nothing at all.
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokAnd:
+			p.next()
+		case tokWord, tokString, tokLParen, tokNot:
+		default:
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseNot() (Node, error) {
+	tok := p.peek()
+	if tok.kind != tokNot {
+		return p.parsePrimary()
+	}
+	if p.depth++; p.depth > maxDepth {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "query is nested too deeply"}
+	}
+	defer func() { p.depth-- }()
+
+	p.next()
+	expr, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return &Not{Expr: expr}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		if p.depth++; p.depth > maxDepth {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "query is nested too deeply"}
+		}
+		defer func() { p.depth-- }()
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &SyntaxError{Pos: closing.pos, Msg: fmt.Sprintf(`expected ")" to close the "(" at position %d, found %s`, tok.pos, closing.describe())}
+		}
+		return expr, nil
+	case tokWord:
+		return p.parseTerm(tok)
+	case tokString:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("%s has no field; write e.g. name:%s", tok.describe(), tok.describe())}
+	default:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "expected a field:value term, found " + tok.describe()}
+	}
+}
+
+// parseTerm reads what follows a field name: field:value, field op value,
+// field:op value, field:[from TO to] or field:*.
+func (p *parser) parseTerm(name token) (Node, error) {
+	sep := p.peek()
+	if sep.kind != tokColon && sep.kind != tokOp {
+		return nil, &SyntaxError{Pos: name.pos, Msg: fmt.Sprintf("expected field:value, found the bare word %q (AND, OR, NOT and TO are written in upper case)", name.text)}
+	}
+	field, ok := p.schema[strings.ToLower(name.text)]
+	if !ok {
+		return nil, &SyntaxError{Pos: name.pos, Msg: fmt.Sprintf("unknown field %q; fields are %s", name.text, strings.Join(p.fieldNames(), ", "))}
+	}
+	p.next()
+
+	op := Op(sep.text)
+	if sep.kind == tokColon {
+		switch tok := p.peek(); {
+		case tok.kind == tokOp:
+			p.next()
+			op = Op(tok.text)
+		case tok.kind == tokLBracket || tok.kind == tokLBrace:
+			return p.parseRange(field)
+		case tok.kind == tokWord && tok.text == "*":
+			p.next()
+			return &Exists{Field: field}, nil
+		}
+	}
+
+	tok := p.next()
+	if tok.kind != tokWord && tok.kind != tokString {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected a value after %q, found %s", name.text+string(op), tok.describe())}
+	}
+	return comparison(field, op, tok)
+}
+
+func (p *parser) parseRange(field Field) (Node, error) {
+	open := p.next()
+	if field.Kind == TextList {
+		return nil, &SyntaxError{Pos: open.pos, Msg: fmt.Sprintf("field %s does not take a range", field.Name)}
+	}
+	bound := func() (any, bool, error) {
+		tok := p.next()
+		switch {
+		case tok.kind == tokWord && tok.text == "*":
+			return nil, false, nil
+		case tok.kind == tokWord || tok.kind == tokString:
+			return value(field, tok)
+		default:
+			return nil, false, &SyntaxError{Pos: tok.pos, Msg: "expected a range bound or *, found " + tok.describe()}
+		}
+	}
+
+	from, fromDate, err := bound()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok.kind != tokTo {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "expected TO between the range bounds, found " + tok.describe()}
+	}
+	to, toDate, err := bound()
+	if err != nil {
+		return nil, err
+	}
+	closing := p.next()
+	if closing.kind != tokRBracket && closing.kind != tokRBrace {
+		return nil, &SyntaxError{Pos: closing.pos, Msg: fmt.Sprintf(`expected "]" or "}" to close the range at position %d, found %s`, open.pos, closing.describe())}
+	}
+	if from == nil && to == nil {
+		return &Exists{Field: field}, nil
+	}
+
+	r := &Range{Field: field, From: from, To: to, IncludeFrom: open.kind == tokLBracket, IncludeTo: closing.kind == tokRBracket}
+	// A date bound stands for the whole day.
+	if fromDate && !r.IncludeFrom {
+		r.From, r.IncludeFrom = from.(time.Time).AddDate(0, 0, 1), true
+	}
+	if toDate && r.IncludeTo {
+		r.To, r.IncludeTo = to.(time.Time).AddDate(0, 0, 1), false
+	}
+	return r, nil
+}
+
+// comparison builds the term for field op value. Only texts keep OpMatch; other kinds
+// test equality, and a date compares as its whole day.
+func comparison(field Field, op Op, tok token) (Node, error) {
+	v, date, err := value(field, tok)
+	if err != nil {
+		return nil, err
+	}
+
+	switch field.Kind {
+	case Text:
+		return &Comparison{Field: field, Op: op, Value: v, Pattern: op == OpMatch && isPattern(tok)}, nil
+	case TextList:
+		if op != OpMatch && op != OpEq {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("field %s takes only : and =", field.Name)}
+		}
+		return &Comparison{Field: field, Op: op, Value: v, Pattern: op == OpMatch && isPattern(tok)}, nil
+	}
+
+	if op == OpMatch {
+		op = OpEq
+	}
+	if !date {
+		return &Comparison{Field: field, Op: op, Value: v}, nil
+	}
+	day, next := v.(time.Time), v.(time.Time).AddDate(0, 0, 1)
+	switch op {
+	case OpEq:
+		return &Range{Field: field, From: day, To: next, IncludeFrom: true}, nil
+	case OpGt:
+		return &Comparison{Field: field, Op: OpGe, Value: next}, nil
+	case OpLe:
+		return &Comparison{Field: field, Op: OpLt, Value: next}, nil
+	default:
+		return &Comparison{Field: field, Op: op, Value: day}, nil
+	}
+}
+
+func isPattern(tok token) bool {
+	return tok.kind == tokWord && strings.ContainsAny(tok.text, "*?")
+}
+
+// value reads a token as a value of the field's kind, reporting whether a Time value
+// was a date alone.
+func value(field Field, tok token) (any, bool, error) {
+	invalid := &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("field %s takes %s, not %s", field.Name, field.Kind, tok.describe())}
+	switch field.Kind {
+	case Integer:
+		v, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, false, invalid
+		}
+		return v, false, nil
+	case Number:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, false, invalid
+		}
+		return v, false, nil
+	case Time:
+		for _, layout := range timeLayouts {
+			if v, err := time.Parse(layout, tok.text); err == nil {
+				return v, len(tok.text) == len("2006-01-02"), nil
+			}
+		}
+		if tok.kind == tokWord {
+			invalid.Msg += ` (quote times, which contain ":")`
+		}
+		return nil, false, invalid
+	default:
+		return tok.text, false, nil
+	}
+}
+
+// fieldNames lists the canonical field names of the schema.
+func (p *parser) fieldNames() []string {
+	var names []string
+	for _, field := range p.schema {
+		if !slices.Contains(names, field.Name) {
+			names = append(names, field.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,156 @@
+package querylang
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"name":         {Name: "name", Kind: Text},
+	"protein_name": {Name: "name", Kind: Text},
+	"gene":         {Name: "gene", Kind: Text},
+	"family":       {Name: "family", Kind: Text},
+	"aliases":      {Name: "aliases", Kind: TextList},
+	"length":       {Name: "length", Kind: Integer},
+	"mw":           {Name: "mw", Kind: Number},
+	"created":      {Name: "created", Kind: Time},
+}
+
+// render writes a syntax tree back as a fully parenthesized query, marking patterns
+// with ~ and writing times as dates.
+func render(node Node) string {
+	value := func(v any) string {
+		switch v := v.(type) {
+		case nil:
+			return "*"
+		case time.Time:
+			return v.Format("2006-01-02")
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	switch n := node.(type) {
+	case nil:
+		return ""
+	case *And:
+		return "(" + render(n.Left) + " AND " + render(n.Right) + ")"
+	case *Or:
+		return "(" + render(n.Left) + " OR " + render(n.Right) + ")"
+	case *Not:
+		return "NOT " + render(n.Expr)
+	case *Comparison:
+		s := n.Field.Name + string(n.Op) + value(n.Value)
+		if n.Pattern {
+			s += "~"
+		}
+		return s
+	case *Range:
+		open, closing := "{", "}"
+		if n.IncludeFrom {
+			open = "["
+		}
+		if n.IncludeTo {
+			closing = "]"
+		}
+		return n.Field.Name + ":" + open + value(n.From) + " TO " + value(n.To) + closing
+	case *Exists:
+		return n.Field.Name + ":*"
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "empty", query: "  ", want: ""},
+		{name: "AND over OR", query: "name:a OR gene:b AND family:c", want: "(name:a OR (gene:b AND family:c))"},
+		{name: "AND over OR on the left", query: "name:a AND gene:b OR family:c", want: "((name:a AND gene:b) OR family:c)"},
+		{name: "NOT over AND", query: "NOT name:a AND gene:b", want: "(NOT name:a AND gene:b)"},
+		{name: "NOT over OR", query: "name:a OR NOT gene:b", want: "(name:a OR NOT gene:b)"},
+		{name: "double NOT", query: "NOT NOT name:a", want: "NOT NOT name:a"},
+		{name: "side by side is AND", query: "name:a gene:b OR family:c", want: "((name:a AND gene:b) OR family:c)"},
+		{name: "left-associative", query: "name:a OR gene:b OR family:c", want: "((name:a OR gene:b) OR family:c)"},
+		{name: "parentheses", query: "(name:a OR gene:b) AND family:c", want: "((name:a OR gene:b) AND family:c)"},
+		{name: "NOT of parentheses", query: "NOT (name:a OR gene:b)", want: "NOT (name:a OR gene:b)"},
+		{name: "nested parentheses", query: "((name:a))", want: "name:a"},
+		{name: "quoted value", query: `name:"Mus musculus"`, want: "name:Mus musculus"},
+		{name: "escaped quote", query: `name:"say \"hi\""`, want: `name:say "hi"`},
+		{name: "quoted keyword", query: `name:"AND"`, want: "name:AND"},
+		{name: "lower-case keyword is a value", query: "name:and", want: "name:and"},
+		{name: "pattern", query: "name:kin*", want: "name:kin*~"},
+		{name: "quoted wildcard is literal", query: `name:"kin*"`, want: "name:kin*"},
+		{name: "alias in upper case", query: "PROTEIN_NAME:a", want: "name:a"},
+		{name: "text equality", query: "gene=TP53", want: "gene=TP53"},
+		{name: "number match is equality", query: "mw:9.5", want: "mw=9.5"},
+		{name: "operator", query: "mw>9", want: "mw>9"},
+		{name: "operator after colon", query: "length:>=10", want: "length>=10"},
+		{name: "range", query: "mw:[30000 TO 80000]", want: "mw:[30000 TO 80000]"},
+		{name: "half-open range", query: "length:{10 TO *]", want: "length:{10 TO *]"},
+		{name: "open range is exists", query: "mw:[* TO *]", want: "mw:*"},
+		{name: "exists", query: "gene:*", want: "gene:*"},
+		{name: "date is its whole day", query: "created:2026-01-02", want: "created:[2026-01-02 TO 2026-01-03}"},
+		{name: "date range includes the last day", query: "created:[2026-01-01 TO 2026-01-31]", want: "created:[2026-01-01 TO 2026-02-01}"},
+		{name: "after a date", query: "created>2026-01-02", want: "created>=2026-01-03"},
+		{name: "quoted time", query: `created>="2026-01-02T10:00:00Z"`, want: "created>=2026-01-02"},
+		{name: "list match", query: "aliases:p53", want: "aliases:p53"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.query, testSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := render(node); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantPos int
+		wantMsg string
+	}{
+		{name: "unknown field", query: "colour:red", wantPos: 1, wantMsg: `unknown field "colour"`},
+		{name: "unknown field later", query: "name:a AND colour:red", wantPos: 12, wantMsg: `unknown field "colour"`},
+		{name: "bare word", query: "kinase", wantPos: 1, wantMsg: "bare word"},
+		{name: "lower-case keyword", query: "name:a and gene:b", wantPos: 8, wantMsg: "upper case"},
+		{name: "quoted term without field", query: `"kinase"`, wantPos: 1, wantMsg: "has no field"},
+		{name: "unterminated quote", query: `name:"Mus`, wantPos: 6, wantMsg: "unterminated"},
+		{name: "unclosed parenthesis", query: "(name:a", wantPos: 8, wantMsg: `expected ")" to close the "(" at position 1`},
+		{name: "unmatched parenthesis", query: "name:a)", wantPos: 7, wantMsg: "no matching"},
+		{name: "empty parentheses", query: "()", wantPos: 2, wantMsg: "expected a field:value term"},
+		{name: "dangling OR", query: "name:a OR", wantPos: 10, wantMsg: "expected a field:value term"},
+		{name: "missing value", query: "name:", wantPos: 6, wantMsg: `expected a value after "name:"`},
+		{name: "malformed number", query: "mw:abc", wantPos: 4, wantMsg: "takes a number"},
+		{name: "malformed integer", query: "length:1.5", wantPos: 8, wantMsg: "takes an integer"},
+		{name: "unquoted time", query: "created:2026-01-02T10:00:00", wantPos: 9, wantMsg: "quote times"},
+		{name: "range without TO", query: "mw:[1 2]", wantPos: 7, wantMsg: "expected TO"},
+		{name: "unclosed range", query: "mw:[1 TO 2", wantPos: 11, wantMsg: "close the range at position 4"},
+		{name: "range of a list", query: "aliases:[a TO b]", wantPos: 9, wantMsg: "does not take a range"},
+		{name: "operator on a list", query: "aliases>a", wantPos: 9, wantMsg: "takes only : and ="},
+		{name: "too deep", query: strings.Repeat("(", 40) + "name:a" + strings.Repeat(")", 40), wantPos: 33, wantMsg: "nested too deeply"},
+		{name: "too many NOTs", query: strings.Repeat("NOT ", 40) + "name:a", wantPos: 129, wantMsg: "nested too deeply"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.query, testSchema)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) = %s, %v; want a syntax error", tt.query, render(node), err)
+			}
+			if syntaxErr.Pos != tt.wantPos || !strings.Contains(syntaxErr.Msg, tt.wantMsg) {
+				t.Errorf("Parse(%q) error %q at %d, want %q at %d", tt.query, syntaxErr.Msg, syntaxErr.Pos, tt.wantMsg, tt.wantPos)
+			}
+		})
+	}
+}
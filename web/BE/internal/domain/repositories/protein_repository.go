@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"go-crawler/web/BE/internal/domain/entities"
+	"time"
 )
 
 type IProteinRepository interface {
@@ -10,10 +11,32 @@ type IProteinRepository interface {
 	GetByID(ctx context.Context, id string) (*entities.Protein, error)
 	GetByName(ctx context.Context, name string) ([]*entities.Protein, error)
 	Search(ctx context.Context, filter *entities.ProteinFilter) (*entities.PaginatedProteins, error)
+	Suggest(ctx context.Context, term string, minSimilarity float64, limit int) ([]entities.SearchSuggestion, error)
+	Complete(ctx context.Context, prefix string, limit int) ([]entities.SearchSuggestion, error)
+	Facets(ctx context.Context, filter *entities.ProteinFilter, opts *entities.ProteinFacetOptions) (*entities.ProteinFacets, error)
 	Update(ctx context.Context, protein *entities.Protein) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, version int) error
+	ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Protein, int, error)
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 	GetStats(ctx context.Context) (*entities.ProteinStats, error)
+	GetRankCounts(ctx context.Context, rank string) ([]entities.RankCount, error)
 	BulkCreate(ctx context.Context, proteins []*entities.Protein) error
+	GetByIDs(ctx context.Context, ids []string) ([]*entities.Protein, error)
+	GetByFamily(ctx context.Context, family string) ([]*entities.Protein, error)
+	ForEachBatch(ctx context.Context, batchSize int, fn func(proteins []*entities.Protein) error) error
+	ForEachMatch(ctx context.Context, filter *entities.ProteinFilter, batchSize int, fn func(proteins []*entities.Protein) error) error
+	GetStoredVersions(ctx context.Context, ids []string) (map[string]*entities.Protein, error)
+	ResolveIDs(ctx context.Context, db string, ids []string) (map[string]string, error)
+	ResolveGenes(ctx context.Context, genes []string) (map[string][]string, error)
+}
+
+// RevisionRepository reads the history the protein triggers record; Get returns
+// sql.ErrNoRows when there is no such revision.
+type RevisionRepository interface {
+	List(ctx context.Context, proteinID string, limit, offset int) ([]*entities.ProteinRevision, int, error)
+	Get(ctx context.Context, proteinID string, revision int) (*entities.ProteinRevision, error)
+	Latest(ctx context.Context, proteinID string) (int, error)
 }
 
 type GeneRepository interface {
@@ -23,6 +46,7 @@ type GeneRepository interface {
 	GetAll(ctx context.Context) ([]*entities.Gene, error)
 	Update(ctx context.Context, gene *entities.Gene) error
 	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, query string, limit, offset int) ([]*entities.Gene, error)
 }
 
 type ProteinFamilyRepository interface {
@@ -32,4 +56,85 @@ type ProteinFamilyRepository interface {
 	GetAll(ctx context.Context) ([]*entities.ProteinFamily, error)
 	Update(ctx context.Context, family *entities.ProteinFamily) error
 	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, query string, limit, offset int) ([]*entities.ProteinFamily, error)
+}
+
+type PSSMRepository interface {
+	Create(ctx context.Context, pssm *entities.PSSM) error
+	GetByID(ctx context.Context, id int64) (*entities.PSSM, error)
+	GetAll(ctx context.Context) ([]*entities.PSSM, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type DomainHitRepository interface {
+	ReplaceForProtein(ctx context.Context, proteinID string, hits []entities.DomainHit) error
+	GetByProtein(ctx context.Context, proteinID string) ([]entities.DomainHit, error)
+}
+
+type ImportRepository interface {
+	SaveRecords(ctx context.Context, records []*entities.ProteinRecord, source string, upsert bool, checkpoint *entities.ImportCheckpoint) (map[string]entities.SaveOutcome, error)
+	SaveBatches(ctx context.Context, batches [][]*entities.ProteinRecord, source string, upsert bool) (map[string]entities.SaveOutcome, error)
+	GetCheckpoint(ctx context.Context, source string) (*entities.ImportCheckpoint, error)
+	DeleteCheckpoint(ctx context.Context, source string) error
+}
+
+type AnnotationRepository interface {
+	GetCrossReferences(ctx context.Context, proteinID string) ([]entities.CrossReference, error)
+	GetFeatures(ctx context.Context, proteinID string) ([]entities.ProteinFeature, error)
+	SearchFeatures(ctx context.Context, filter *entities.FeatureFilter) ([]entities.ProteinFeature, error)
+	CreateFeature(ctx context.Context, feature *entities.ProteinFeature) error
+	DeleteFeature(ctx context.Context, proteinID string, id int64) error
+	SaveFeatures(ctx context.Context, features []entities.ProteinFeature, replace []entities.FeatureSource) error
+	ProteinLengths(ctx context.Context, ids []string) (map[string]int, error)
+}
+
+type StructureRepository interface {
+	Create(ctx context.Context, entry *entities.StructureEntry) error
+	GetByID(ctx context.Context, id int64) (*entities.StructureEntry, error)
+	GetByChecksum(ctx context.Context, checksum string) (*entities.StructureEntry, error)
+	GetAll(ctx context.Context) ([]*entities.StructureEntry, error)
+	Delete(ctx context.Context, id int64) error
+	GetChain(ctx context.Context, structureID int64, chainID string) (*entities.StructureChain, error)
+	UpdateChainLink(ctx context.Context, chain *entities.StructureChain) error
+	GetChainsByProtein(ctx context.Context, proteinID string) ([]*entities.StructureChain, error)
+}
+
+type OntologyRepository interface {
+	SaveOntology(ctx context.Context, terms []*entities.GOTerm, relationships []entities.GORelationship) error
+	GetTerm(ctx context.Context, id string) (*entities.GOTerm, error)
+	SearchTerms(ctx context.Context, q, namespace string, limit, offset int) ([]*entities.GOTerm, error)
+	GetRelatedTerms(ctx context.Context, id string) ([]entities.GORelatedTerm, []entities.GORelatedTerm, error)
+	GetAncestors(ctx context.Context, id string) ([]*entities.GOTerm, error)
+	GetDescendants(ctx context.Context, id string) ([]*entities.GOTerm, error)
+	ResolveTermIDs(ctx context.Context, ids []string) (map[string]string, error)
+	SaveAnnotations(ctx context.Context, annotations []entities.GOAnnotation, source string, replace []string) error
+	CreateAnnotation(ctx context.Context, annotation *entities.GOAnnotation) error
+	DeleteAnnotation(ctx context.Context, proteinID string, id int64) error
+	GetProteinAnnotations(ctx context.Context, proteinID string) ([]entities.GOAnnotation, error)
+}
+
+type TaxonomyRepository interface {
+	SaveTaxa(ctx context.Context, taxa []*entities.Taxon) error
+	DeleteTaxonNames(ctx context.Context) error
+	SaveTaxonNames(ctx context.Context, names []entities.TaxonName) error
+	RefreshScientificNames(ctx context.Context) error
+	GetTaxon(ctx context.Context, id int) (*entities.Taxon, error)
+	GetTaxonNames(ctx context.Context, id int) ([]entities.TaxonName, error)
+	GetLineage(ctx context.Context, id int) ([]*entities.Taxon, error)
+	GetChildren(ctx context.Context, id, limit, offset int) ([]*entities.Taxon, error)
+	SearchNames(ctx context.Context, q, rank string, limit, offset int) ([]entities.TaxonMatch, error)
+	LinkProteins(ctx context.Context) (int, error)
+}
+
+type DiseaseRepository interface {
+	CreateDisease(ctx context.Context, disease *entities.Disease) error
+	UpdateDisease(ctx context.Context, disease *entities.Disease) error
+	DeleteDisease(ctx context.Context, id string) error
+	GetDisease(ctx context.Context, id string) (*entities.Disease, error)
+	SearchDiseases(ctx context.Context, filter *entities.DiseaseFilter) ([]*entities.Disease, error)
+	SaveImport(ctx context.Context, diseases []*entities.Disease, associations []entities.DiseaseAssociation) error
+	CreateAssociation(ctx context.Context, association *entities.DiseaseAssociation) error
+	DeleteAssociation(ctx context.Context, proteinID string, id int64) error
+	GetProteinAssociations(ctx context.Context, proteinID string) ([]entities.DiseaseAssociation, error)
+	GetDiseaseAssociations(ctx context.Context, diseaseID string, limit, offset int) ([]entities.DiseaseAssociation, error)
 }
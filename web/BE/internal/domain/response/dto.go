@@ -77,6 +77,43 @@ type StructurePoint struct {
 	Z float64 `json:"z"`
 }
 
+// CATrace is the alpha-carbon trace of a structure chain. Residues (author numbering)
+// and ProteinPositions run parallel to Points; a protein position of 0 means the residue
+// is not aligned to the linked protein.
+type CATrace struct {
+	StructureID      int64            `json:"structure_id"`
+	PDBID            string           `json:"pdb_id,omitempty"`
+	ChainID          string           `json:"chain_id"`
+	ProteinID        *string          `json:"protein_id,omitempty"`
+	Sequence         string           `json:"sequence"`
+	Residues         []string         `json:"residues"`
+	ProteinPositions []int            `json:"protein_positions,omitempty"`
+	Points           []StructurePoint `json:"points"`
+}
+
+// StructureComparison is the superposition of chain 1 (mobile) onto chain 2 (target)
+// over the CA atoms paired by aligning their modelled sequences. Mobile holds every CA
+// of chain 1 moved into the frame of chain 2, so both traces can be drawn together;
+// Pairs index the aligned residues into Mobile and Target.
+type StructureComparison struct {
+	StructureID1     int64            `json:"structure_id_1"`
+	ChainID1         string           `json:"chain_id_1"`
+	ProteinID1       *string          `json:"protein_id_1,omitempty"`
+	StructureID2     int64            `json:"structure_id_2"`
+	ChainID2         string           `json:"chain_id_2"`
+	ProteinID2       *string          `json:"protein_id_2,omitempty"`
+	SequenceIdentity float64          `json:"sequence_identity"`
+	AlignedPairs     int              `json:"aligned_pairs"`
+	RMSD             float64          `json:"rmsd"`
+	TMScore          float64          `json:"tm_score"`
+	GDTTS            float64          `json:"gdt_ts"`
+	Rotation         [3][3]float64    `json:"rotation"`
+	Translation      [3]float64       `json:"translation"`
+	Pairs            [][2]int         `json:"pairs"`
+	Mobile           []StructurePoint `json:"mobile"`
+	Target           []StructurePoint `json:"target"`
+}
+
 type SecondaryStructureElement struct {
 	Type       string  `json:"type"`
 	Start      int     `json:"start"`
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"math"
+	"strings"
+)
+
+type AlignmentMode int
+
+const (
+	GlobalAlignment AlignmentMode = iota
+	LocalAlignment
+)
+
+// BLAST defaults for BLOSUM62: a gap of length k costs GapOpen + k*GapExtend.
+const (
+	GapOpen   = 11
+	GapExtend = 1
+)
+
+const (
+	fromM byte = iota
+	fromX
+	fromY
+	fromStart
+)
+
+var negInf = math.MinInt32 / 2
+
+// AlignSequences aligns a and b with BLOSUM62 and affine gap penalties (Gotoh).
+// Global mode aligns the full length of both sequences; local mode finds the best
+// scoring pair of subsequences.
+func AlignSequences(a, b string, mode AlignmentMode) *entities.PairwiseAlignment {
+	a, b = strings.ToUpper(a), strings.ToUpper(b)
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return &entities.PairwiseAlignment{}
+	}
+
+	cols := m + 1
+	size := (n + 1) * cols
+	M := make([]int, size)
+	X := make([]int, size) // a[i] aligned to a gap
+	Y := make([]int, size) // b[j] aligned to a gap
+	tbM := make([]byte, size)
+	tbX := make([]byte, size)
+	tbY := make([]byte, size)
+
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= m; j++ {
+			k := i*cols + j
+			M[k], X[k], Y[k] = negInf, negInf, negInf
+		}
+	}
+
+	M[0] = 0
+	tbM[0] = fromStart
+	for i := 1; i <= n; i++ {
+		k := i * cols
+		if mode == LocalAlignment {
+			M[k], tbM[k] = 0, fromStart
+		} else {
+			X[k] = -(GapOpen + i*GapExtend)
+			tbX[k] = fromX
+			if i == 1 {
+				tbX[k] = fromM
+			}
+		}
+	}
+	for j := 1; j <= m; j++ {
+		if mode == LocalAlignment {
+			M[j], tbM[j] = 0, fromStart
+		} else {
+			Y[j] = -(GapOpen + j*GapExtend)
+			tbY[j] = fromY
+			if j == 1 {
+				tbY[j] = fromM
+			}
+		}
+	}
+
+	bestScore, bestI, bestJ := negInf, n, m
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			k := i*cols + j
+			diag := k - cols - 1
+			up := k - cols
+			left := k - 1
+
+			score, from := best3(M[diag], X[diag], Y[diag])
+			score += BLOSUM62(a[i-1], b[j-1])
+			if mode == LocalAlignment && score <= 0 {
+				score, from = 0, fromStart
+			}
+			M[k], tbM[k] = score, from
+
+			X[k], tbX[k] = best3(M[up]-GapOpen-GapExtend, X[up]-GapExtend, Y[up]-GapOpen-GapExtend)
+			Y[k], tbY[k] = best3(M[left]-GapOpen-GapExtend, X[left]-GapOpen-GapExtend, Y[left]-GapExtend)
+
+			if mode == LocalAlignment && M[k] > bestScore {
+				bestScore, bestI, bestJ = M[k], i, j
+			}
+		}
+	}
+
+	state := fromM
+	if mode == GlobalAlignment {
+		k := n*cols + m
+		bestScore, state = best3(M[k], X[k], Y[k])
+	}
+
+	if bestScore < 0 {
+		bestScore = 0
+	}
+
+	var alignedA, alignedB []byte
+	i, j := bestI, bestJ
+	endA, endB := i, j
+trace:
+	for i > 0 || j > 0 {
+		k := i*cols + j
+		switch state {
+		case fromM:
+			if tbM[k] == fromStart {
+				break trace
+			}
+			state = tbM[k]
+			alignedA = append(alignedA, a[i-1])
+			alignedB = append(alignedB, b[j-1])
+			i--
+			j--
+		case fromX:
+			state = tbX[k]
+			alignedA = append(alignedA, a[i-1])
+			alignedB = append(alignedB, '-')
+			i--
+		case fromY:
+			state = tbY[k]
+			alignedA = append(alignedA, '-')
+			alignedB = append(alignedB, b[j-1])
+			j--
+		}
+	}
+	reverseBytes(alignedA)
+	reverseBytes(alignedB)
+
+	result := &entities.PairwiseAlignment{
+		AlignedA: string(alignedA),
+		AlignedB: string(alignedB),
+		Score:    bestScore,
+		StartA:   i + 1,
+		EndA:     endA,
+		StartB:   j + 1,
+		EndB:     endB,
+		Length:   len(alignedA),
+	}
+	for x := range alignedA {
+		if alignedA[x] == alignedB[x] && alignedA[x] != '-' {
+			result.Matches++
+		}
+	}
+	if result.Length > 0 {
+		result.Identity = float64(result.Matches) / float64(result.Length)
+	}
+	return result
+}
+
+// MapAlignedPositions returns, for every residue of the first sequence (0-based), the
+// 0-based index of the residue of the second sequence it is aligned to, or -1 if none.
+func MapAlignedPositions(alignment *entities.PairwiseAlignment, lengthA int) []int {
+	mapping := make([]int, lengthA)
+	for x := range mapping {
+		mapping[x] = -1
+	}
+	ia, ib := alignment.StartA-1, alignment.StartB-1
+	for x := 0; x < len(alignment.AlignedA); x++ {
+		ca, cb := alignment.AlignedA[x], alignment.AlignedB[x]
+		if ca != '-' && cb != '-' && ia < lengthA {
+			mapping[ia] = ib
+		}
+		if ca != '-' {
+			ia++
+		}
+		if cb != '-' {
+			ib++
+		}
+	}
+	return mapping
+}
+
+func best3(m, x, y int) (int, byte) {
+	if m >= x && m >= y {
+		return m, fromM
+	}
+	if x >= y {
+		return x, fromX
+	}
+	return y, fromY
+}
+
+func reverseBytes(s []byte) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"reflect"
+	"testing"
+)
+
+func TestAlignSequences(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      string
+		mode      AlignmentMode
+		want      entities.PairwiseAlignment
+		positions []int
+	}{
+		{
+			name:      "identical",
+			a:         "MKVL",
+			b:         "mkvl",
+			want:      entities.PairwiseAlignment{AlignedA: "MKVL", AlignedB: "MKVL", Score: 18, StartA: 1, EndA: 4, StartB: 1, EndB: 4, Length: 4, Matches: 4, Identity: 1},
+			positions: []int{0, 1, 2, 3},
+		},
+		{
+			// One gap of two residues costs GapOpen + 2*GapExtend, less than two gaps.
+			name:      "affine gap",
+			a:         "MKVGGL",
+			b:         "MKVL",
+			want:      entities.PairwiseAlignment{AlignedA: "MKVGGL", AlignedB: "MKV--L", Score: 5, StartA: 1, EndA: 6, StartB: 1, EndB: 4, Length: 6, Matches: 4, Identity: 4.0 / 6},
+			positions: []int{0, 1, 2, -1, -1, 3},
+		},
+		{
+			name:      "substitution",
+			a:         "MKVL",
+			b:         "MKIL",
+			want:      entities.PairwiseAlignment{AlignedA: "MKVL", AlignedB: "MKIL", Score: 17, StartA: 1, EndA: 4, StartB: 1, EndB: 4, Length: 4, Matches: 3, Identity: 0.75},
+			positions: []int{0, 1, 2, 3},
+		},
+		{
+			name:      "local",
+			a:         "PPPWWWPPP",
+			b:         "DDWWWDD",
+			mode:      LocalAlignment,
+			want:      entities.PairwiseAlignment{AlignedA: "WWW", AlignedB: "WWW", Score: 33, StartA: 4, EndA: 6, StartB: 3, EndB: 5, Length: 3, Matches: 3, Identity: 1},
+			positions: []int{-1, -1, -1, 2, 3, 4, -1, -1, -1},
+		},
+		{
+			name:      "empty",
+			a:         "",
+			b:         "MKVL",
+			positions: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AlignSequences(tt.a, tt.b, tt.mode)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("AlignSequences = %+v, want %+v", *got, tt.want)
+			}
+			if positions := MapAlignedPositions(got, len(tt.a)); !reflect.DeepEqual(positions, tt.positions) {
+				t.Errorf("MapAlignedPositions = %v, want %v", positions, tt.positions)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"math"
+	"strings"
+)
+
+// propertyGroups partitions the amino acids into the six physico-chemical classes of
+// Mirny & Shakhnovich (1999): aliphatic, aromatic, polar, positive, negative, special.
+var propertyGroups = []string{"AVLIMC", "FWYH", "STNQ", "KR", "DE", "GP"}
+
+var propertyGroupIndex = func() [256]int {
+	var idx [256]int
+	for i := range idx {
+		idx[i] = -1
+	}
+	for g, members := range propertyGroups {
+		for i := 0; i < len(members); i++ {
+			idx[members[i]] = g
+		}
+	}
+	return idx
+}()
+
+// jsdPseudocount keeps the divergence finite for unobserved residues (Capra & Singh, 2007).
+const jsdPseudocount = 1e-6
+
+type ConservationDomainService interface {
+	AlignToReference(sequences []entities.AlignedSequence) []entities.AlignedSequence
+	ScoreAlignment(rows []entities.AlignedSequence, weighted bool) (*entities.ConservationProfile, error)
+}
+
+type ConservationService struct{}
+
+func NewConservationService() ConservationDomainService {
+	return &ConservationService{}
+}
+
+// AlignToReference star-aligns unaligned sequences to the first one. Columns follow the
+// reference, so residues that other sequences insert relative to it are dropped.
+func (s *ConservationService) AlignToReference(sequences []entities.AlignedSequence) []entities.AlignedSequence {
+	if len(sequences) == 0 {
+		return nil
+	}
+
+	raw := make([]string, len(sequences))
+	for i, seq := range sequences {
+		raw[i] = strings.ToUpper(seq.Sequence)
+	}
+	columns := starAlign(raw[0], raw)
+
+	rows := make([]entities.AlignedSequence, len(sequences))
+	for k := range sequences {
+		row := make([]byte, len(columns))
+		for col, column := range columns {
+			row[col] = column[k]
+		}
+		rows[k] = entities.AlignedSequence{ID: sequences[k].ID, Sequence: string(row)}
+	}
+	return rows
+}
+
+// ScoreAlignment computes Shannon entropy, Jensen-Shannon divergence against the BLAST
+// background frequencies and property-group conservation for every column. With weighted set,
+// sequences get Henikoff position-based weights so that redundant orthologs do not
+// dominate the scores.
+func (s *ConservationService) ScoreAlignment(rows []entities.AlignedSequence, weighted bool) (*entities.ConservationProfile, error) {
+	if len(rows) < 2 {
+		return nil, entities.ErrEmptyAlignment
+	}
+	length := len(rows[0].Sequence)
+	if length == 0 {
+		return nil, entities.ErrEmptyAlignment
+	}
+	for _, row := range rows {
+		if len(row.Sequence) != length {
+			return nil, entities.ErrRaggedAlignment
+		}
+	}
+
+	columns := make([][]byte, length)
+	for col := range columns {
+		columns[col] = make([]byte, len(rows))
+	}
+	for k, row := range rows {
+		seq := strings.ToUpper(row.Sequence)
+		for col := range columns {
+			columns[col][k] = seq[col]
+		}
+	}
+
+	weights := make([]float64, len(rows))
+	if weighted {
+		weights = henikoffWeights(columns, len(rows))
+	} else {
+		for k := range weights {
+			weights[k] = 1 / float64(len(rows))
+		}
+	}
+
+	profile := &entities.ConservationProfile{
+		Sequences:    rows,
+		NumSequences: len(rows),
+		Length:       length,
+		Weighted:     weighted,
+		Columns:      make([]entities.ColumnConservation, length),
+	}
+	consensus := make([]byte, length)
+	for col, column := range columns {
+		profile.Columns[col] = scoreColumn(column, weights)
+		profile.Columns[col].Position = col + 1
+		consensus[col] = profile.Columns[col].Consensus[0]
+	}
+	profile.Consensus = string(consensus)
+	return profile, nil
+}
+
+func scoreColumn(column []byte, weights []float64) entities.ColumnConservation {
+	var freqs [20]float64
+	var groups [6]float64
+	gap, residues := 0.0, 0.0
+	for k, residue := range column {
+		if residue == '-' {
+			gap += weights[k]
+			continue
+		}
+		idx := blosumIndex[residue]
+		if idx < 0 {
+			continue
+		}
+		freqs[idx] += weights[k]
+		groups[propertyGroupIndex[residue]] += weights[k]
+		residues += weights[k]
+	}
+
+	result := entities.ColumnConservation{Consensus: "X", Frequencies: map[string]float64{}}
+	total := gap + residues
+	if total > 0 {
+		result.GapFraction = gap / total
+	}
+	if residues == 0 {
+		if result.GapFraction >= 0.5 {
+			result.Consensus = "-"
+		}
+		return result
+	}
+
+	best := 0
+	for a := range freqs {
+		freqs[a] /= residues
+		if freqs[a] > 0 {
+			result.Frequencies[string(blosumOrder[a])] = freqs[a]
+		}
+		if freqs[a] > freqs[best] {
+			best = a
+		}
+	}
+	for g := range groups {
+		groups[g] /= residues
+	}
+
+	coverage := 1 - result.GapFraction
+	result.Entropy = shannonEntropy(freqs[:])
+	result.EntropyScore = (1 - result.Entropy/math.Log2(20)) * coverage
+	result.JensenShannon = jensenShannon(freqs) * coverage
+	result.PropertyConservation = (1 - shannonEntropy(groups[:])/math.Log2(float64(len(propertyGroups)))) * coverage
+
+	if result.GapFraction >= 0.5 {
+		result.Consensus = "-"
+	} else {
+		result.Consensus = string(blosumOrder[best])
+	}
+	return result
+}
+
+func shannonEntropy(p []float64) float64 {
+	h := 0.0
+	for _, v := range p {
+		if v > 0 {
+			h -= v * math.Log2(v)
+		}
+	}
+	return h
+}
+
+// jensenShannon is the divergence (in bits, within [0, 1]) between the column
+// distribution and the background amino acid frequencies.
+func jensenShannon(freqs [20]float64) float64 {
+	var p [20]float64
+	total := 0.0
+	for a := range freqs {
+		p[a] = freqs[a] + jsdPseudocount
+		total += p[a]
+	}
+
+	d := 0.0
+	for a := range p {
+		p[a] /= total
+		q := backgroundFrequencies[a]
+		m := (p[a] + q) / 2
+		d += 0.5*p[a]*math.Log2(p[a]/m) + 0.5*q*math.Log2(q/m)
+	}
+	return d
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"math"
+	"testing"
+)
+
+func TestScoreAlignment(t *testing.T) {
+	rows := []entities.AlignedSequence{
+		{ID: "a", Sequence: "MKDW-"},
+		{ID: "b", Sequence: "MKEW-"},
+		{ID: "c", Sequence: "MRDWA"},
+		{ID: "d", Sequence: "mkef-"},
+	}
+	tests := []struct {
+		position                                  int
+		consensus                                 string
+		gap, entropy, entropyScore, jsd, property float64
+	}{
+		{position: 1, consensus: "M", entropyScore: 1, jsd: 0.922039, property: 1},
+		{position: 2, consensus: "K", entropy: 0.811278, entropyScore: 0.812288, jsd: 0.751249, property: 1},
+		{position: 3, consensus: "D", entropy: 1, entropyScore: 0.768622, jsd: 0.730523, property: 1},
+		{position: 4, consensus: "W", entropy: 0.811278, entropyScore: 0.812288, jsd: 0.869632, property: 1},
+		// Three gaps in four rows scale every score by the coverage of 0.25.
+		{position: 5, consensus: "-", gap: 0.75, entropyScore: 0.25, jsd: 0.199452, property: 0.25},
+	}
+
+	profile, err := NewConservationService().ScoreAlignment(rows, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Consensus != "MKDW-" || profile.Length != 5 || profile.NumSequences != 4 {
+		t.Errorf("consensus %q of length %d over %d sequences, want %q of 5 over 4", profile.Consensus, profile.Length, profile.NumSequences, "MKDW-")
+	}
+	for i, tt := range tests {
+		got := profile.Columns[i]
+		if got.Position != tt.position || got.Consensus != tt.consensus {
+			t.Errorf("column %d: position %d with consensus %q, want %d with %q", i, got.Position, got.Consensus, tt.position, tt.consensus)
+		}
+		scores := []struct {
+			name      string
+			got, want float64
+		}{
+			{"gap fraction", got.GapFraction, tt.gap},
+			{"entropy", got.Entropy, tt.entropy},
+			{"entropy score", got.EntropyScore, tt.entropyScore},
+			{"Jensen-Shannon", got.JensenShannon, tt.jsd},
+			{"property conservation", got.PropertyConservation, tt.property},
+		}
+		for _, score := range scores {
+			if math.Abs(score.got-score.want) > 1e-5 {
+				t.Errorf("column %d: %s %f, want %f", tt.position, score.name, score.got, score.want)
+			}
+		}
+	}
+}
+
+func TestScoreAlignmentWeights(t *testing.T) {
+	// Henikoff weights give the three identical rows together as much weight as the
+	// distinct one.
+	rows := []entities.AlignedSequence{{Sequence: "AA"}, {Sequence: "AA"}, {Sequence: "AA"}, {Sequence: "CC"}}
+	tests := []struct {
+		weighted     bool
+		frequencyA   float64
+		entropy, jsd float64
+	}{
+		{weighted: false, frequencyA: 0.75, entropy: 0.811278, jsd: 0.763311},
+		{weighted: true, frequencyA: 0.5, entropy: 1, jsd: 0.775422},
+	}
+	for _, tt := range tests {
+		profile, err := NewConservationService().ScoreAlignment(rows, tt.weighted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		column := profile.Columns[0]
+		if math.Abs(column.Frequencies["A"]-tt.frequencyA) > 1e-9 || math.Abs(column.Entropy-tt.entropy) > 1e-5 || math.Abs(column.JensenShannon-tt.jsd) > 1e-5 {
+			t.Errorf("weighted %v: frequency of A %f, entropy %f, Jensen-Shannon %f, want %f, %f, %f",
+				tt.weighted, column.Frequencies["A"], column.Entropy, column.JensenShannon, tt.frequencyA, tt.entropy, tt.jsd)
+		}
+	}
+}
+
+func TestScoreAlignmentErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []entities.AlignedSequence
+		wantErr error
+	}{
+		{name: "one row", rows: []entities.AlignedSequence{{Sequence: "MKVL"}}, wantErr: entities.ErrEmptyAlignment},
+		{name: "empty rows", rows: []entities.AlignedSequence{{}, {}}, wantErr: entities.ErrEmptyAlignment},
+		{name: "ragged rows", rows: []entities.AlignedSequence{{Sequence: "MKVL"}, {Sequence: "MKV"}}, wantErr: entities.ErrRaggedAlignment},
+	}
+	for _, tt := range tests {
+		if _, err := NewConservationService().ScoreAlignment(tt.rows, false); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
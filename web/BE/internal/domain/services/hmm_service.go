@@ -0,0 +1,426 @@
+package services
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// HMMER's default amino acid background frequencies, in alphabetical order (ACDEFGHIKLMNPQRSTVWY).
+var hmmBackground = map[byte]float64{
+	'A': 0.0787945, 'C': 0.0151600, 'D': 0.0535222, 'E': 0.0668298, 'F': 0.0397062,
+	'G': 0.0695071, 'H': 0.0229198, 'I': 0.0590092, 'K': 0.0594422, 'L': 0.0963728,
+	'M': 0.0237718, 'N': 0.0414386, 'P': 0.0482904, 'Q': 0.0395639, 'R': 0.0540978,
+	'S': 0.0683364, 'T': 0.0540687, 'V': 0.0673417, 'W': 0.0114135, 'Y': 0.0304133,
+}
+
+// viterbiFilterPValue mirrors HMMER's F2 filter: Forward is only run for sequences
+// whose Viterbi score reaches this P-value.
+const viterbiFilterPValue = 1e-3
+
+type HMMDomainService interface {
+	ScanSequence(models []*entities.ProfileHMM, sequence string, evalueThreshold float64) []entities.DomainHit
+}
+
+type HMMService struct {
+	profiles sync.Map // *entities.ProfileHMM -> *hmmProfile
+}
+
+func NewHMMService() HMMDomainService {
+	return &HMMService{}
+}
+
+// hmmProfile is a model configured for local multihit alignment, in natural log space.
+type hmmProfile struct {
+	model    *entities.ProfileHMM
+	m        int
+	match    [][256]float64 // match[k][residue]: emission log-odds for node k (1-based)
+	trans    [][7]float64   // trans[k]: transition log probabilities out of node k
+	entry    []float64      // entry[k]: log probability of B -> Mk
+	alphabet string
+}
+
+// ScanSequence runs every model against the sequence in the spirit of hmmscan: Viterbi
+// as a filter, Forward for the sequence E-value, and the Viterbi trace to locate
+// domains. The number of models is the database size used for E-values. Scores are
+// close to, but not identical with, HMMER's (no null2 or bias correction).
+func (s *HMMService) ScanSequence(models []*entities.ProfileHMM, sequence string, evalueThreshold float64) []entities.DomainHit {
+	sequence = strings.ToUpper(sequence)
+	if len(sequence) == 0 || len(models) == 0 {
+		return nil
+	}
+	z := float64(len(models))
+
+	var hits []entities.DomainHit
+	for _, model := range models {
+		profile := s.profile(model)
+
+		vitBits := profile.viterbiScore(sequence)
+		if gumbelSurvival(vitBits, model.ViterbiMu, model.ViterbiLambda) > viterbiFilterPValue {
+			continue
+		}
+
+		fwdBits := profile.forwardScore(sequence)
+		seqEValue := exponentialSurvival(fwdBits, model.ForwardTau, model.ForwardLambda) * z
+		if seqEValue > evalueThreshold {
+			continue
+		}
+
+		for _, domain := range profile.viterbiDomains(sequence) {
+			domain.EValue = gumbelSurvival(domain.Score, model.ViterbiMu, model.ViterbiLambda) * z
+			if domain.EValue > evalueThreshold {
+				continue
+			}
+			domain.SequenceScore = fwdBits
+			domain.SequenceEValue = seqEValue
+			hits = append(hits, domain)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Start < hits[j].Start })
+	return hits
+}
+
+func (s *HMMService) profile(model *entities.ProfileHMM) *hmmProfile {
+	if cached, ok := s.profiles.Load(model); ok {
+		return cached.(*hmmProfile)
+	}
+	profile := configureProfile(model)
+	s.profiles.Store(model, profile)
+	return profile
+}
+
+func configureProfile(model *entities.ProfileHMM) *hmmProfile {
+	m := model.Length
+	p := &hmmProfile{
+		model:    model,
+		m:        m,
+		match:    make([][256]float64, m+1),
+		trans:    make([][7]float64, m+1),
+		entry:    make([]float64, m+1),
+		alphabet: model.Alphabet,
+	}
+
+	for k := 1; k <= m; k++ {
+		// Residues outside the model alphabet score as background (log-odds 0).
+		for a := 0; a < len(model.Alphabet); a++ {
+			residue := model.Alphabet[a]
+			bg, ok := hmmBackground[residue]
+			if !ok {
+				continue
+			}
+			p.match[k][residue] = safeLog(model.MatchEmissions[k-1][a] / bg)
+		}
+	}
+	for k := 0; k <= m; k++ {
+		for t := 0; t < 7; t++ {
+			p.trans[k][t] = safeLog(model.Transitions[k][t])
+		}
+	}
+
+	// Local entry weighted by match state occupancy, as in HMMER's p7_ProfileConfig.
+	occ := make([]float64, m+1)
+	occ[1] = model.Transitions[0][entities.TransitionMI] + model.Transitions[0][entities.TransitionMM]
+	for k := 2; k <= m; k++ {
+		prev := model.Transitions[k-1]
+		occ[k] = occ[k-1]*(prev[entities.TransitionMM]+prev[entities.TransitionMI]) + (1-occ[k-1])*prev[entities.TransitionDM]
+	}
+	z := 0.0
+	for k := 1; k <= m; k++ {
+		z += occ[k] * float64(m-k+1)
+	}
+	for k := 1; k <= m; k++ {
+		p.entry[k] = safeLog(occ[k] / z)
+	}
+	return p
+}
+
+// specials holds the length-dependent N/C/J/E transition log probabilities for a
+// target of length L (multihit mode).
+type specials struct {
+	loop, move, eToJ, eToC float64
+}
+
+func lengthModel(l int) specials {
+	pl := float64(l) / float64(l+3)
+	return specials{loop: math.Log(pl), move: math.Log(1 - pl), eToJ: math.Log(0.5), eToC: math.Log(0.5)}
+}
+
+// nullScore is the log likelihood of the sequence under the null1 model.
+func nullScore(l int) float64 {
+	p1 := float64(l) / float64(l+1)
+	return float64(l)*math.Log(p1) + math.Log(1-p1)
+}
+
+type combine func(a, b float64) float64
+
+func (p *hmmProfile) viterbiScore(seq string) float64 {
+	return p.fill(seq, math.Max)
+}
+
+func (p *hmmProfile) forwardScore(seq string) float64 {
+	return p.fill(seq, logSum)
+}
+
+// fill runs the Viterbi (max) or Forward (log-sum) recursion with rolling rows and
+// returns the score in bits.
+func (p *hmmProfile) fill(seq string, op combine) float64 {
+	l, m := len(seq), p.m
+	sp := lengthModel(l)
+	inf := math.Inf(-1)
+
+	prevM, prevI, prevD := negInfRow(m+1), negInfRow(m+1), negInfRow(m+1)
+	curM, curI, curD := negInfRow(m+1), negInfRow(m+1), negInfRow(m+1)
+
+	n := 0.0
+	b := n + sp.move
+	j, c := inf, inf
+
+	for i := 1; i <= l; i++ {
+		x := seq[i-1]
+		e := inf
+		curM[0], curI[0], curD[0] = inf, inf, inf
+		for k := 1; k <= m; k++ {
+			t := &p.trans[k-1]
+			sc := b + p.entry[k]
+			if k > 1 {
+				sc = op(sc, op(prevM[k-1]+t[entities.TransitionMM], op(prevI[k-1]+t[entities.TransitionIM], prevD[k-1]+t[entities.TransitionDM])))
+			}
+			curM[k] = sc + p.match[k][x]
+
+			if k < m {
+				tk := &p.trans[k]
+				curI[k] = op(prevM[k]+tk[entities.TransitionMI], prevI[k]+tk[entities.TransitionII])
+			} else {
+				curI[k] = inf
+			}
+
+			if k > 1 {
+				curD[k] = op(curM[k-1]+t[entities.TransitionMD], curD[k-1]+t[entities.TransitionDD])
+			} else {
+				curD[k] = inf
+			}
+
+			e = op(e, op(curM[k], curD[k]))
+		}
+
+		j = op(j+sp.loop, e+sp.eToJ)
+		c = op(c+sp.loop, e+sp.eToC)
+		n = n + sp.loop
+		b = op(n+sp.move, j+sp.move)
+
+		prevM, curM = curM, prevM
+		prevI, curI = curI, prevI
+		prevD, curD = curD, prevD
+	}
+
+	score := c + sp.move
+	return (score - nullScore(l)) / math.Ln2
+}
+
+const (
+	stM byte = iota
+	stI
+	stD
+	stB
+	stN
+	stJ
+	stE
+	stC
+)
+
+// viterbiDomains runs Viterbi with a full traceback and returns one hit per pass
+// through the model (B ... E). Each domain's score is the log-odds of its own path
+// segment, in bits.
+func (p *hmmProfile) viterbiDomains(seq string) []entities.DomainHit {
+	l, m := len(seq), p.m
+	sp := lengthModel(l)
+	inf := math.Inf(-1)
+	cols := m + 1
+
+	vM, vI, vD := negInfRow((l+1)*cols), negInfRow((l+1)*cols), negInfRow((l+1)*cols)
+	tbM := make([]byte, (l+1)*cols)
+	tbI := make([]byte, (l+1)*cols)
+	tbD := make([]byte, (l+1)*cols)
+	vN, vB, vE, vJ, vC := negInfRow(l+1), negInfRow(l+1), negInfRow(l+1), negInfRow(l+1), negInfRow(l+1)
+	tbB, tbE, tbJ, tbC := make([]byte, l+1), make([]int, l+1), make([]byte, l+1), make([]byte, l+1)
+
+	vN[0] = 0
+	vB[0], tbB[0] = sp.move, stN
+
+	for i := 1; i <= l; i++ {
+		x := seq[i-1]
+		row, prev := i*cols, (i-1)*cols
+		for k := 1; k <= m; k++ {
+			t := &p.trans[k-1]
+			best, from := vB[i-1]+p.entry[k], stB
+			if k > 1 {
+				if v := vM[prev+k-1] + t[entities.TransitionMM]; v > best {
+					best, from = v, stM
+				}
+				if v := vI[prev+k-1] + t[entities.TransitionIM]; v > best {
+					best, from = v, stI
+				}
+				if v := vD[prev+k-1] + t[entities.TransitionDM]; v > best {
+					best, from = v, stD
+				}
+			}
+			vM[row+k], tbM[row+k] = best+p.match[k][x], from
+
+			if k < m {
+				tk := &p.trans[k]
+				mi, ii := vM[prev+k]+tk[entities.TransitionMI], vI[prev+k]+tk[entities.TransitionII]
+				if mi >= ii {
+					vI[row+k], tbI[row+k] = mi, stM
+				} else {
+					vI[row+k], tbI[row+k] = ii, stI
+				}
+			}
+
+			if k > 1 {
+				md, dd := vM[row+k-1]+t[entities.TransitionMD], vD[row+k-1]+t[entities.TransitionDD]
+				if md >= dd {
+					vD[row+k], tbD[row+k] = md, stM
+				} else {
+					vD[row+k], tbD[row+k] = dd, stD
+				}
+			}
+
+			// E stores the source node; negative values mark a D state.
+			if vM[row+k] > vE[i] {
+				vE[i], tbE[i] = vM[row+k], k
+			}
+			if vD[row+k] > vE[i] {
+				vE[i], tbE[i] = vD[row+k], -k
+			}
+		}
+
+		if vJ[i-1]+sp.loop >= vE[i]+sp.eToJ {
+			vJ[i], tbJ[i] = vJ[i-1]+sp.loop, stJ
+		} else {
+			vJ[i], tbJ[i] = vE[i]+sp.eToJ, stE
+		}
+		if vC[i-1]+sp.loop >= vE[i]+sp.eToC {
+			vC[i], tbC[i] = vC[i-1]+sp.loop, stC
+		} else {
+			vC[i], tbC[i] = vE[i]+sp.eToC, stE
+		}
+		vN[i] = vN[i-1] + sp.loop
+		if vN[i]+sp.move >= vJ[i]+sp.move {
+			vB[i], tbB[i] = vN[i]+sp.move, stN
+		} else {
+			vB[i], tbB[i] = vJ[i]+sp.move, stJ
+		}
+	}
+
+	if vC[l] == inf {
+		return nil
+	}
+
+	var domains []entities.DomainHit
+	var current entities.DomainHit
+	state, i, k := stC, l, 0
+	endScore := 0.0
+
+	for i > 0 || state != stN {
+		switch state {
+		case stC:
+			if tbC[i] == stC {
+				i--
+			} else {
+				state = stE
+			}
+		case stJ:
+			if tbJ[i] == stJ {
+				i--
+			} else {
+				state = stE
+			}
+		case stE:
+			endScore = vE[i]
+			current = entities.DomainHit{End: i}
+			if tbE[i] > 0 {
+				state, k = stM, tbE[i]
+			} else {
+				state, k = stD, -tbE[i]
+			}
+			current.HMMEnd = k
+		case stM:
+			from := tbM[i*cols+k]
+			if from == stB {
+				current.Start = i
+				current.HMMStart = k
+				current.Score = (endScore - vB[i-1]) / math.Ln2
+				domains = append(domains, current)
+				i--
+				state = stB
+			} else {
+				state = from
+				i--
+				k--
+			}
+		case stI:
+			state = tbI[i*cols+k]
+			i--
+		case stD:
+			state = tbD[i*cols+k]
+			k--
+		case stB:
+			state = tbB[i]
+		case stN:
+			i = 0
+		}
+	}
+
+	for x, y := 0, len(domains)-1; x < y; x, y = x+1, y-1 {
+		domains[x], domains[y] = domains[y], domains[x]
+	}
+	for x := range domains {
+		domains[x].ModelName = p.model.Name
+		domains[x].ModelAccession = p.model.Accession
+		domains[x].Description = p.model.Description
+		domains[x].HMMLength = p.m
+	}
+	return domains
+}
+
+func negInfRow(n int) []float64 {
+	row := make([]float64, n)
+	for i := range row {
+		row[i] = math.Inf(-1)
+	}
+	return row
+}
+
+func safeLog(v float64) float64 {
+	if v <= 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(v)
+}
+
+func logSum(a, b float64) float64 {
+	if math.IsInf(a, -1) {
+		return b
+	}
+	if math.IsInf(b, -1) {
+		return a
+	}
+	if a > b {
+		return a + math.Log1p(math.Exp(b-a))
+	}
+	return b + math.Log1p(math.Exp(a-b))
+}
+
+// gumbelSurvival is P(S > x) for HMMER's Viterbi score distribution.
+func gumbelSurvival(x, mu, lambda float64) float64 {
+	return -math.Expm1(-math.Exp(-lambda * (x - mu)))
+}
+
+// exponentialSurvival is P(S > x) for the exponential tail of Forward scores.
+func exponentialSurvival(x, tau, lambda float64) float64 {
+	p := math.Exp(-lambda * (x - tau))
+	return math.Min(p, 1)
+}
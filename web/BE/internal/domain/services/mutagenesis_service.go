@@ -0,0 +1,168 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"strings"
+)
+
+var (
+	ErrInvalidRegion        = errors.New("scan region is outside the sequence")
+	ErrUnknownHeatmapMetric = errors.New("unknown heatmap metric")
+)
+
+type MutagenesisDomainService interface {
+	ValidateScan(sequence, metric string) error
+	SaturationScan(sequence string, start, end int, metric string, progress func(completed, total int)) (*entities.SaturationScan, error)
+}
+
+type MutagenesisService struct {
+	proteinService ProteinDomainService
+}
+
+func NewMutagenesisService(proteinService ProteinDomainService) MutagenesisDomainService {
+	return &MutagenesisService{proteinService: proteinService}
+}
+
+// SaturationScan substitutes every residue in [start, end] (1-based, inclusive) with each of
+// the other 19 standard amino acids and reports property deltas and BLOSUM62 severity.
+func (m *MutagenesisService) SaturationScan(sequence string, start, end int, metric string, progress func(completed, total int)) (*entities.SaturationScan, error) {
+	sequence = strings.ToUpper(sequence)
+	if err := m.ValidateScan(sequence, metric); err != nil {
+		return nil, err
+	}
+	if start < 1 || end > len(sequence) || start > end {
+		return nil, ErrInvalidRegion
+	}
+	if metric == "" {
+		metric = entities.HeatmapSeverity
+	}
+
+	wildType := m.properties(sequence)
+	total := end - start + 1
+
+	scan := &entities.SaturationScan{
+		Start:    start,
+		End:      end,
+		Length:   len(sequence),
+		WildType: wildType,
+		Variants: make([]entities.MutationEffect, 0, total*(len(AminoAcids)-1)),
+		Heatmap: entities.MutationHeatmap{
+			Metric:    metric,
+			Positions: make([]int, 0, total),
+			WildTypes: make([]string, 0, total),
+			Residues:  strings.Split(AminoAcids, ""),
+			Values:    make([][]float64, 0, total),
+		},
+	}
+
+	mutated := []byte(sequence)
+	for pos := start; pos <= end; pos++ {
+		wt := sequence[pos-1]
+		row := make([]float64, len(AminoAcids))
+
+		for col := 0; col < len(AminoAcids); col++ {
+			aa := AminoAcids[col]
+			if aa == wt {
+				continue
+			}
+
+			mutated[pos-1] = aa
+			props := m.properties(string(mutated))
+			score := BLOSUM62(wt, aa)
+
+			effect := entities.MutationEffect{
+				Position:   pos,
+				WildType:   string(wt),
+				Mutant:     string(aa),
+				Notation:   fmt.Sprintf("%c%d%c", wt, pos, aa),
+				BLOSUM62:   score,
+				Severity:   substitutionSeverity(wt, aa),
+				Class:      substitutionClass(score),
+				DeltaMW:    props.MolecularWeight - wildType.MolecularWeight,
+				DeltaPI:    props.IsoelectricPoint - wildType.IsoelectricPoint,
+				DeltaGravy: props.Hydrophobicity - wildType.Hydrophobicity,
+			}
+			scan.Variants = append(scan.Variants, effect)
+			row[col] = heatmapValue(effect, metric)
+		}
+		mutated[pos-1] = wt
+
+		scan.Heatmap.Positions = append(scan.Heatmap.Positions, pos)
+		scan.Heatmap.WildTypes = append(scan.Heatmap.WildTypes, string(wt))
+		scan.Heatmap.Values = append(scan.Heatmap.Values, row)
+
+		if progress != nil {
+			progress(pos-start+1, total)
+		}
+	}
+
+	return scan, nil
+}
+
+func (m *MutagenesisService) ValidateScan(sequence, metric string) error {
+	if err := m.proteinService.ValidateSequence([]string{sequence}); err != nil {
+		return err
+	}
+	if metric != "" && !isHeatmapMetric(metric) {
+		return fmt.Errorf("%w: %s", ErrUnknownHeatmapMetric, metric)
+	}
+	return nil
+}
+
+func (m *MutagenesisService) properties(sequence string) entities.SequenceProperties {
+	return entities.SequenceProperties{
+		MolecularWeight:  m.proteinService.CalculateMolecularWeight(sequence),
+		IsoelectricPoint: m.proteinService.CalculateIsoelectricPoint(sequence),
+		Hydrophobicity:   m.proteinService.CalculateHydrophobicity(sequence),
+	}
+}
+
+// substitutionSeverity scales the BLOSUM62 loss relative to the wild type's self score
+// into [0, 1], where 1 is the least favourable substitution for that residue.
+func substitutionSeverity(wt, aa byte) float64 {
+	self := BLOSUM62(wt, wt)
+	worst := self
+	for i := 0; i < len(AminoAcids); i++ {
+		if s := BLOSUM62(wt, AminoAcids[i]); s < worst {
+			worst = s
+		}
+	}
+	if self == worst {
+		return 0
+	}
+	return float64(self-BLOSUM62(wt, aa)) / float64(self-worst)
+}
+
+func substitutionClass(score int) string {
+	switch {
+	case score > 0:
+		return entities.MutationConservative
+	case score >= -1:
+		return entities.MutationModerate
+	default:
+		return entities.MutationRadical
+	}
+}
+
+func heatmapValue(effect entities.MutationEffect, metric string) float64 {
+	switch metric {
+	case entities.HeatmapDeltaMW:
+		return effect.DeltaMW
+	case entities.HeatmapDeltaPI:
+		return effect.DeltaPI
+	case entities.HeatmapDeltaGravy:
+		return effect.DeltaGravy
+	default:
+		return effect.Severity
+	}
+}
+
+func isHeatmapMetric(metric string) bool {
+	switch metric {
+	case entities.HeatmapSeverity, entities.HeatmapDeltaMW, entities.HeatmapDeltaPI, entities.HeatmapDeltaGravy:
+		return true
+	}
+	return false
+}
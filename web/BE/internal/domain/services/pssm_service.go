@@ -0,0 +1,279 @@
+package services
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"math"
+	"strings"
+	"time"
+)
+
+var ErrNoProfileSequences = errors.New("at least one sequence is required to build a profile")
+
+// DefaultPseudocountWeight is the PSI-BLAST default weight (beta) given to pseudocounts.
+const DefaultPseudocountWeight = 10.0
+
+// backgroundFrequencies are the Robinson & Robinson amino acid frequencies used by BLAST,
+// in blosumOrder.
+var backgroundFrequencies = [20]float64{
+	0.07805, 0.05129, 0.04487, 0.05364, 0.01925, 0.04264, 0.06295, 0.07377, 0.02199, 0.05142,
+	0.09019, 0.05744, 0.02243, 0.03856, 0.05203, 0.07120, 0.05841, 0.01330, 0.03216, 0.06441,
+}
+
+// substitutionProbabilities[b][a] is q(a|b), the probability of observing a in a column
+// where b was seen, derived from BLOSUM62 (scores are in half-bits).
+var substitutionProbabilities = func() [20][20]float64 {
+	var q [20][20]float64
+	for b := 0; b < 20; b++ {
+		total := 0.0
+		for a := 0; a < 20; a++ {
+			q[b][a] = backgroundFrequencies[a] * math.Pow(2, float64(blosum62Matrix[a][b])/2)
+			total += q[b][a]
+		}
+		for a := 0; a < 20; a++ {
+			q[b][a] /= total
+		}
+	}
+	return q
+}()
+
+type ProfileDomainService interface {
+	BuildPSSM(sequences []string, pseudocountWeight float64) (*entities.PSSM, error)
+	ScoreSequence(pssm *entities.PSSM, sequence string) (*entities.PSSMScore, error)
+}
+
+type ProfileService struct{}
+
+func NewProfileService() ProfileDomainService {
+	return &ProfileService{}
+}
+
+// BuildPSSM star-aligns every sequence to the first one, weights them with Henikoff
+// position-based weights and mixes the observed frequencies with BLOSUM62 pseudocounts.
+// The profile has one position per residue of the first sequence.
+func (s *ProfileService) BuildPSSM(sequences []string, pseudocountWeight float64) (*entities.PSSM, error) {
+	if len(sequences) == 0 {
+		return nil, ErrNoProfileSequences
+	}
+	if pseudocountWeight <= 0 {
+		pseudocountWeight = DefaultPseudocountWeight
+	}
+
+	rows := make([]string, len(sequences))
+	for i, seq := range sequences {
+		seq = strings.ToUpper(seq)
+		if seq == "" {
+			return nil, entities.ErrSequenceTooShort
+		}
+		for k := 0; k < len(seq); k++ {
+			if !IsStandardResidue(seq[k]) {
+				return nil, entities.ErrInvalidSequenceFormat
+			}
+		}
+		rows[i] = seq
+	}
+
+	reference := rows[0]
+	columns := starAlign(reference, rows)
+	weights := henikoffWeights(columns, len(rows))
+
+	length := len(reference)
+	pssm := &entities.PSSM{
+		Alphabet:          blosumOrder,
+		Length:            length,
+		Scores:            make([][]float64, length),
+		Frequencies:       make([][]float64, length),
+		Information:       make([]float64, length),
+		NumSequences:      len(rows),
+		PseudocountWeight: pseudocountWeight,
+		Created:           time.Now(),
+		Updated:           time.Now(),
+	}
+
+	distinctTotal := 0
+	consensus := make([]byte, length)
+	for col := 0; col < length; col++ {
+		var observed [20]float64
+		weightSum := 0.0
+		distinct := 0
+		for k, residue := range columns[col] {
+			if residue == '-' {
+				continue
+			}
+			idx := blosumIndex[residue]
+			if observed[idx] == 0 {
+				distinct++
+			}
+			observed[idx] += weights[k]
+			weightSum += weights[k]
+		}
+		distinctTotal += distinct
+
+		best := 0
+		for a := 0; a < 20; a++ {
+			observed[a] /= weightSum
+			if observed[a] > observed[best] {
+				best = a
+			}
+		}
+		consensus[col] = blosumOrder[best]
+		pssm.Frequencies[col] = observed[:]
+	}
+	pssm.Consensus = string(consensus)
+
+	// Effective number of sequences, estimated as the mean number of distinct residues per column.
+	neff := float64(distinctTotal) / float64(length)
+	neff = math.Max(1, math.Min(neff, float64(len(rows))))
+	pssm.EffectiveSequences = neff
+	alpha := neff - 1
+
+	for col := 0; col < length; col++ {
+		observed := pssm.Frequencies[col]
+		scores := make([]float64, 20)
+		info := 0.0
+		for a := 0; a < 20; a++ {
+			pseudo := 0.0
+			for b := 0; b < 20; b++ {
+				pseudo += observed[b] * substitutionProbabilities[b][a]
+			}
+			prob := (alpha*observed[a] + pseudocountWeight*pseudo) / (alpha + pseudocountWeight)
+			scores[a] = math.Log2(prob / backgroundFrequencies[a])
+			info += prob * scores[a]
+		}
+		pssm.Scores[col] = scores
+		pssm.Information[col] = info
+	}
+
+	return pssm, nil
+}
+
+// ScoreSequence slides the profile along the sequence without gaps and reports the best window.
+func (s *ProfileService) ScoreSequence(pssm *entities.PSSM, sequence string) (*entities.PSSMScore, error) {
+	if err := pssm.Validate(); err != nil {
+		return nil, err
+	}
+	sequence = strings.ToUpper(sequence)
+	if len(sequence) < pssm.Length {
+		return nil, entities.ErrSequenceTooShortForProfile
+	}
+
+	index := alphabetIndex(pssm.Alphabet)
+	columnMin := make([]float64, pssm.Length)
+	for i, row := range pssm.Scores {
+		columnMin[i] = row[0]
+		for _, v := range row[1:] {
+			columnMin[i] = math.Min(columnMin[i], v)
+		}
+	}
+
+	windows := len(sequence) - pssm.Length + 1
+	result := &entities.PSSMScore{WindowScores: make([]float64, windows)}
+	bestStart := 0
+	for start := 0; start < windows; start++ {
+		total := 0.0
+		for i := 0; i < pssm.Length; i++ {
+			if col := index[sequence[start+i]]; col >= 0 {
+				total += pssm.Scores[i][col]
+			} else {
+				total += columnMin[i]
+			}
+		}
+		result.WindowScores[start] = total
+		if total > result.WindowScores[bestStart] {
+			bestStart = start
+		}
+	}
+
+	result.PositionScores = make([]float64, pssm.Length)
+	for i := 0; i < pssm.Length; i++ {
+		if col := index[sequence[bestStart+i]]; col >= 0 {
+			result.PositionScores[i] = pssm.Scores[i][col]
+		} else {
+			result.PositionScores[i] = columnMin[i]
+		}
+	}
+
+	result.Start = bestStart + 1
+	result.End = bestStart + pssm.Length
+	result.Window = sequence[bestStart : bestStart+pssm.Length]
+	result.Score = result.WindowScores[bestStart]
+	if maxScore := pssm.MaxScore(); maxScore > 0 {
+		result.NormalizedScore = result.Score / maxScore
+	}
+	return result, nil
+}
+
+// starAlign aligns every row globally to the reference and returns, per reference
+// position, the residue of each row aligned to it ('-' for gaps). Insertions relative
+// to the reference are dropped.
+func starAlign(reference string, rows []string) [][]byte {
+	columns := make([][]byte, len(reference))
+	for col := range columns {
+		columns[col] = make([]byte, len(rows))
+	}
+
+	for k, row := range rows {
+		if row == reference {
+			for col := range columns {
+				columns[col][k] = reference[col]
+			}
+			continue
+		}
+		alignment := AlignSequences(reference, row, GlobalAlignment)
+		mapping := MapAlignedPositions(alignment, len(reference))
+		for col, pos := range mapping {
+			if pos < 0 {
+				columns[col][k] = '-'
+			} else {
+				columns[col][k] = row[pos]
+			}
+		}
+	}
+	return columns
+}
+
+// henikoffWeights computes position-based sequence weights (Henikoff & Henikoff, 1994),
+// normalised to sum to one.
+func henikoffWeights(columns [][]byte, numRows int) []float64 {
+	weights := make([]float64, numRows)
+	for _, column := range columns {
+		counts := make(map[byte]int)
+		for _, residue := range column {
+			if residue != '-' {
+				counts[residue]++
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		for k, residue := range column {
+			if residue != '-' {
+				weights[k] += 1 / float64(len(counts)*counts[residue])
+			}
+		}
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	for k := range weights {
+		if total > 0 {
+			weights[k] /= total
+		} else {
+			weights[k] = 1 / float64(numRows)
+		}
+	}
+	return weights
+}
+
+func alphabetIndex(alphabet string) [256]int {
+	var idx [256]int
+	for i := range idx {
+		idx[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		idx[alphabet[i]] = i
+	}
+	return idx
+}
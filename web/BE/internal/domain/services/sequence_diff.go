@@ -0,0 +1,125 @@
+package services
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"strings"
+)
+
+// maxDiffAlignmentCells bounds the alignment of the changed region of a sequence diff,
+// which takes some 27 bytes a cell; a larger region is compared position by position.
+const maxDiffAlignmentCells = 1 << 18
+
+// DiffSequences lists the residue edits that turn sequence a into b. The common prefix
+// and suffix are set aside and the region between them is aligned globally with
+// BLOSUM62, so that substitutions line up with the residues they replace. A region too
+// large to align is compared position by position instead.
+func DiffSequences(a, b string) *entities.SequenceDiff {
+	a, b = strings.ToUpper(a), strings.ToUpper(b)
+	diff := &entities.SequenceDiff{LengthFrom: len(a), LengthTo: len(b), Edits: []entities.SequenceEdit{}}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	switch {
+	case midA == "" && midB == "":
+	case midA == "" || midB == "":
+		op := entities.EditSubstitution
+		if midA == "" {
+			op = entities.EditInsertion
+		} else if midB == "" {
+			op = entities.EditDeletion
+		}
+		addSequenceEdit(diff, op, prefix, prefix, midA, midB)
+	case len(midA)*len(midB) > maxDiffAlignmentCells:
+		alignedA, alignedB := positionalAlignment(midA, midB)
+		addAlignedEdits(diff, alignedA, alignedB, prefix)
+	default:
+		alignment := AlignSequences(midA, midB, GlobalAlignment)
+		addAlignedEdits(diff, alignment.AlignedA, alignment.AlignedB, prefix)
+	}
+	return diff
+}
+
+// positionalAlignment lines up two sequences residue by residue, the shorter one ending
+// in gaps.
+func positionalAlignment(a, b string) (string, string) {
+	n := max(len(a), len(b))
+	return a + strings.Repeat("-", n-len(a)), b + strings.Repeat("-", n-len(b))
+}
+
+// addAlignedEdits turns the columns of a global alignment into edits, merging runs of
+// columns of the same kind. offset is the number of residues before the aligned region.
+func addAlignedEdits(diff *entities.SequenceDiff, alignedA, alignedB string, offset int) {
+	posA, posB := offset, offset
+	op, startA, startB := "", 0, 0
+	var from, to strings.Builder
+	flush := func() {
+		if op != "" {
+			addSequenceEdit(diff, op, startA, startB, from.String(), to.String())
+		}
+		op = ""
+		from.Reset()
+		to.Reset()
+	}
+
+	for x := 0; x < len(alignedA); x++ {
+		ca, cb := alignedA[x], alignedB[x]
+		column := entities.EditSubstitution
+		switch {
+		case ca == cb:
+			column = ""
+		case ca == '-':
+			column = entities.EditInsertion
+		case cb == '-':
+			column = entities.EditDeletion
+		}
+
+		if column != op {
+			flush()
+			op, startA, startB = column, posA, posB
+		}
+		if ca != '-' {
+			if column != "" {
+				from.WriteByte(ca)
+			}
+			posA++
+		}
+		if cb != '-' {
+			if column != "" {
+				to.WriteByte(cb)
+			}
+			posB++
+		}
+	}
+	flush()
+}
+
+// addSequenceEdit appends an edit that starts after before residues of the older
+// sequence and beforeNew residues of the newer one.
+func addSequenceEdit(diff *entities.SequenceDiff, op string, before, beforeNew int, from, to string) {
+	switch op {
+	case entities.EditSubstitution:
+		common := min(len(from), len(to))
+		diff.Substitutions += common
+		diff.Deletions += len(from) - common
+		diff.Insertions += len(to) - common
+	case entities.EditInsertion:
+		diff.Insertions += len(to)
+	case entities.EditDeletion:
+		diff.Deletions += len(from)
+	}
+	diff.Edits = append(diff.Edits, entities.SequenceEdit{
+		Op:          op,
+		Position:    before + 1,
+		NewPosition: beforeNew + 1,
+		From:        from,
+		To:          to,
+	})
+}
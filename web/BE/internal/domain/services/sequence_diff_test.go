@@ -0,0 +1,87 @@
+package services
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffSequences(t *testing.T) {
+	tests := []struct {
+		name                                 string
+		a, b                                 string
+		substitutions, insertions, deletions int
+		edits                                []entities.SequenceEdit
+	}{
+		{
+			name:  "identical",
+			a:     "MKVL",
+			b:     "mkvl",
+			edits: []entities.SequenceEdit{},
+		},
+		{
+			name:          "substitution",
+			a:             "MKVL",
+			b:             "MKAL",
+			substitutions: 1,
+			edits:         []entities.SequenceEdit{{Op: entities.EditSubstitution, Position: 3, NewPosition: 3, From: "V", To: "A"}},
+		},
+		{
+			name:       "insertion",
+			a:          "MKVL",
+			b:          "MKVGGL",
+			insertions: 2,
+			edits:      []entities.SequenceEdit{{Op: entities.EditInsertion, Position: 4, NewPosition: 4, To: "GG"}},
+		},
+		{
+			name:      "deletion",
+			a:         "MKVGGL",
+			b:         "MKVL",
+			deletions: 2,
+			edits:     []entities.SequenceEdit{{Op: entities.EditDeletion, Position: 4, NewPosition: 4, From: "GG"}},
+		},
+		{
+			name:      "aligned deletion",
+			a:         "MKWVTFISLLFL",
+			b:         "MKWVTFLLFL",
+			deletions: 2,
+			edits:     []entities.SequenceEdit{{Op: entities.EditDeletion, Position: 7, NewPosition: 7, From: "IS"}},
+		},
+		{
+			name:          "separate substitutions",
+			a:             "MHHWKLQCC",
+			b:             "MAHWKLQNC",
+			substitutions: 2,
+			edits: []entities.SequenceEdit{
+				{Op: entities.EditSubstitution, Position: 2, NewPosition: 2, From: "H", To: "A"},
+				{Op: entities.EditSubstitution, Position: 8, NewPosition: 8, From: "C", To: "N"},
+			},
+		},
+		{
+			name:          "region too large to align",
+			a:             "M" + strings.Repeat("A", 600) + "W",
+			b:             "M" + strings.Repeat("C", 598) + "W",
+			substitutions: 598,
+			deletions:     2,
+			edits: []entities.SequenceEdit{
+				{Op: entities.EditSubstitution, Position: 2, NewPosition: 2, From: strings.Repeat("A", 598), To: strings.Repeat("C", 598)},
+				{Op: entities.EditDeletion, Position: 600, NewPosition: 600, From: "AA"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffSequences(tt.a, tt.b)
+			if diff.LengthFrom != len(tt.a) || diff.LengthTo != len(tt.b) {
+				t.Errorf("lengths %d, %d, want %d, %d", diff.LengthFrom, diff.LengthTo, len(tt.a), len(tt.b))
+			}
+			if diff.Substitutions != tt.substitutions || diff.Insertions != tt.insertions || diff.Deletions != tt.deletions {
+				t.Errorf("counts %d/%d/%d, want %d/%d/%d", diff.Substitutions, diff.Insertions, diff.Deletions, tt.substitutions, tt.insertions, tt.deletions)
+			}
+			if !reflect.DeepEqual(diff.Edits, tt.edits) {
+				t.Errorf("edits %+v, want %+v", diff.Edits, tt.edits)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"math"
+)
+
+// gdtCutoffs are the distance cutoffs (Å) averaged into GDT-TS.
+var gdtCutoffs = []float64{1, 2, 4, 8}
+
+const (
+	// superpositionIterations bounds the refinement of each seed fragment.
+	superpositionIterations = 20
+	// minSeedLength is the shortest fragment used to seed a superposition search.
+	minSeedLength = 4
+)
+
+type StructureDomainService interface {
+	Superpose(mobile, target [][3]float64, targetLength int) (*entities.Superposition, error)
+	Contacts(coords [][3]float64, threshold float64, minSeparation int) []entities.Contact
+}
+
+type StructureService struct{}
+
+func NewStructureService() StructureDomainService {
+	return &StructureService{}
+}
+
+// Superpose fits mobile onto target, where mobile[i] and target[i] are aligned CA
+// atoms. targetLength is the number of residues of the whole target chain, which
+// normalises TM-score and GDT-TS so that partial alignments score lower.
+//
+// TM-score and each GDT cutoff are maximised with the heuristic of the TM-score
+// program: Kabsch fits seeded on aligned fragments of decreasing length are refined by
+// refitting on the pairs within the cutoff until the selection no longer changes.
+func (s *StructureService) Superpose(mobile, target [][3]float64, targetLength int) (*entities.Superposition, error) {
+	n := len(mobile)
+	if n < 3 || len(target) != n {
+		return nil, entities.ErrTooFewAlignedResidues
+	}
+	if targetLength < n {
+		targetLength = n
+	}
+
+	all := make([]int, n)
+	for i := range all {
+		all[i] = i
+	}
+	fit := kabsch(mobile, target, all)
+	result := &entities.Superposition{AlignedPairs: n, RMSD: fit.rmsd(mobile, target, all)}
+
+	d0 := tmScoreD0(targetLength)
+	d0Search := math.Min(math.Max(d0, 4.5), 8)
+	tmScore := func(distances []float64) float64 {
+		score := 0.0
+		for _, d := range distances {
+			score += 1 / (1 + (d/d0)*(d/d0))
+		}
+		return score / float64(targetLength)
+	}
+	best, bestScore := searchSuperposition(mobile, target, d0Search, tmScore)
+	result.TMScore = bestScore
+	result.Rotation, result.Translation = best.rotation, best.translation
+
+	for _, cutoff := range gdtCutoffs {
+		cutoff := cutoff
+		within := func(distances []float64) float64 {
+			count := 0
+			for _, d := range distances {
+				if d <= cutoff {
+					count++
+				}
+			}
+			return float64(count) / float64(targetLength)
+		}
+		_, fraction := searchSuperposition(mobile, target, cutoff, within)
+		result.GDTTS += fraction / float64(len(gdtCutoffs))
+	}
+	return result, nil
+}
+
+// Contacts returns the pairs closer than threshold whose sequence separation is at
+// least minSeparation.
+func (s *StructureService) Contacts(coords [][3]float64, threshold float64, minSeparation int) []entities.Contact {
+	if minSeparation < 1 {
+		minSeparation = 1
+	}
+	contacts := []entities.Contact{}
+	for i := range coords {
+		for j := i + minSeparation; j < len(coords); j++ {
+			if d := distance(coords[i], coords[j]); d <= threshold {
+				contacts = append(contacts, entities.Contact{I: i, J: j, Distance: d})
+			}
+		}
+	}
+	return contacts
+}
+
+// tmScoreD0 is the length-dependent distance scale of TM-score (Zhang & Skolnick, 2004).
+func tmScoreD0(length int) float64 {
+	if length <= 21 {
+		return 0.5
+	}
+	return math.Max(1.24*math.Cbrt(float64(length-15))-1.8, 0.5)
+}
+
+type rigidTransform struct {
+	rotation    [3][3]float64
+	translation [3]float64
+}
+
+func (t *rigidTransform) apply(p [3]float64) [3]float64 {
+	var out [3]float64
+	for i := 0; i < 3; i++ {
+		out[i] = t.rotation[i][0]*p[0] + t.rotation[i][1]*p[1] + t.rotation[i][2]*p[2] + t.translation[i]
+	}
+	return out
+}
+
+func (t *rigidTransform) distances(mobile, target [][3]float64) []float64 {
+	distances := make([]float64, len(mobile))
+	for i := range mobile {
+		distances[i] = distance(t.apply(mobile[i]), target[i])
+	}
+	return distances
+}
+
+func (t *rigidTransform) rmsd(mobile, target [][3]float64, idx []int) float64 {
+	sum := 0.0
+	for _, i := range idx {
+		d := distance(t.apply(mobile[i]), target[i])
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(idx)))
+}
+
+// searchSuperposition returns the transform with the highest score over all pairs.
+func searchSuperposition(mobile, target [][3]float64, cutoff float64, score func(distances []float64) float64) (*rigidTransform, float64) {
+	n := len(mobile)
+	var best *rigidTransform
+	bestScore := math.Inf(-1)
+
+	for length := n; ; length /= 2 {
+		length = max(length, min(minSeedLength, n))
+		step := max(1, length/2)
+		for start := 0; start+length <= n; start += step {
+			idx := make([]int, length)
+			for i := range idx {
+				idx[i] = start + i
+			}
+			for iter := 0; iter < superpositionIterations; iter++ {
+				fit := kabsch(mobile, target, idx)
+				distances := fit.distances(mobile, target)
+				if value := score(distances); value > bestScore {
+					best, bestScore = fit, value
+				}
+
+				// Refit on the pairs within the cutoff, relaxing it until three qualify.
+				var selected []int
+				for limit := cutoff; len(selected) < 3; limit += 0.5 {
+					selected = selected[:0]
+					for i, d := range distances {
+						if d < limit {
+							selected = append(selected, i)
+						}
+					}
+				}
+				if equalIndexes(selected, idx) {
+					break
+				}
+				idx = selected
+			}
+		}
+		if length <= minSeedLength {
+			break
+		}
+	}
+	return best, bestScore
+}
+
+func equalIndexes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// kabsch returns the least-squares rotation and translation of mobile onto target over
+// the pairs in idx. The optimal rotation is taken from the quaternion eigenvector of
+// Horn (1987), which solves the Kabsch problem without a reflection correction.
+func kabsch(mobile, target [][3]float64, idx []int) *rigidTransform {
+	var cm, ct [3]float64
+	for _, i := range idx {
+		for k := 0; k < 3; k++ {
+			cm[k] += mobile[i][k]
+			ct[k] += target[i][k]
+		}
+	}
+	for k := 0; k < 3; k++ {
+		cm[k] /= float64(len(idx))
+		ct[k] /= float64(len(idx))
+	}
+
+	var s [3][3]float64
+	for _, i := range idx {
+		for a := 0; a < 3; a++ {
+			for b := 0; b < 3; b++ {
+				s[a][b] += (mobile[i][a] - cm[a]) * (target[i][b] - ct[b])
+			}
+		}
+	}
+
+	k := [4][4]float64{
+		{s[0][0] + s[1][1] + s[2][2], s[1][2] - s[2][1], s[2][0] - s[0][2], s[0][1] - s[1][0]},
+		{s[1][2] - s[2][1], s[0][0] - s[1][1] - s[2][2], s[0][1] + s[1][0], s[2][0] + s[0][2]},
+		{s[2][0] - s[0][2], s[0][1] + s[1][0], -s[0][0] + s[1][1] - s[2][2], s[1][2] + s[2][1]},
+		{s[0][1] - s[1][0], s[2][0] + s[0][2], s[1][2] + s[2][1], -s[0][0] - s[1][1] + s[2][2]},
+	}
+	q := largestEigenvector(k)
+	q0, q1, q2, q3 := q[0], q[1], q[2], q[3]
+
+	t := &rigidTransform{rotation: [3][3]float64{
+		{q0*q0 + q1*q1 - q2*q2 - q3*q3, 2 * (q1*q2 - q0*q3), 2 * (q1*q3 + q0*q2)},
+		{2 * (q1*q2 + q0*q3), q0*q0 - q1*q1 + q2*q2 - q3*q3, 2 * (q2*q3 - q0*q1)},
+		{2 * (q1*q3 - q0*q2), 2 * (q2*q3 + q0*q1), q0*q0 - q1*q1 - q2*q2 + q3*q3},
+	}}
+	rotated := t.apply(cm)
+	for i := 0; i < 3; i++ {
+		t.translation[i] = ct[i] - rotated[i]
+	}
+	return t
+}
+
+// largestEigenvector diagonalises a symmetric 4x4 matrix with cyclic Jacobi rotations
+// and returns the unit eigenvector of its largest eigenvalue.
+func largestEigenvector(a [4][4]float64) [4]float64 {
+	var v [4][4]float64
+	for i := 0; i < 4; i++ {
+		v[i][i] = 1
+	}
+
+	for sweep := 0; sweep < 50; sweep++ {
+		off := 0.0
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				off += a[p][q] * a[p][q]
+			}
+		}
+		if off < 1e-22 {
+			break
+		}
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				if math.Abs(a[p][q]) < 1e-300 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 4; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < 4; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				for k := 0; k < 4; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	largest := 0
+	for i := 1; i < 4; i++ {
+		if a[i][i] > a[largest][largest] {
+			largest = i
+		}
+	}
+	return [4]float64{v[0][largest], v[1][largest], v[2][largest], v[3][largest]}
+}
+
+func distance(a, b [3]float64) float64 {
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
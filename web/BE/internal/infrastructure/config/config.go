@@ -9,21 +9,28 @@ type Config struct {
 	Server   ServerConfig   `json:"server"`
 	Database DatabaseConfig `json:"database"`
 	ML       MLConfig       `json:"ml"`
+	HMM      HMMConfig      `json:"hmm"`
+	Storage  StorageConfig  `json:"storage"`
+	Trash    TrashConfig    `json:"trash"`
 }
 
+// ServerConfig.RequireIfMatch makes writes to a protein send the ETag they were made
+// from in If-Match; otherwise the header is checked when sent.
 type ServerConfig struct {
-	Host string `json:"host"`
-	Port string `json:"port"`
-	Mode string `json:"mode"`
+	Host           string `json:"host"`
+	Port           string `json:"port"`
+	Mode           string `json:"mode"`
+	RequireIfMatch bool   `json:"require_if_match"`
 }
 
 type DatabaseConfig struct {
-	Host     string `json:"host"`
-	Port     string `json:"port"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Database string `json:"database"`
-	SSLMode  string `json:"ssl_mode"`
+	Host        string `json:"host"`
+	Port        string `json:"port"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	Database    string `json:"database"`
+	SSLMode     string `json:"ssl_mode"`
+	AutoMigrate bool   `json:"auto_migrate"`
 }
 
 type MLConfig struct {
@@ -31,25 +38,57 @@ type MLConfig struct {
 	Timeout int    `json:"timeout"`
 }
 
+type HMMConfig struct {
+	ModelDir string  `json:"model_dir"`
+	EValue   float64 `json:"evalue"`
+}
+
+// StorageConfig locates the local file store for uploaded structure files.
+type StorageConfig struct {
+	StructureDir       string `json:"structure_dir"`
+	MaxStructureSizeMB int    `json:"max_structure_size_mb"`
+}
+
+// TrashConfig sets how long deleted proteins can be restored, and how often the ones
+// past that are purged; an interval of 0 leaves purging to the purge-trash command.
+type TrashConfig struct {
+	RetentionDays        int `json:"retention_days"`
+	PurgeIntervalMinutes int `json:"purge_interval_minutes"`
+}
+
 func Load() (*Config, error) {
 	return &Config{
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "localhost"),
-			Port: getEnv("SERVER_PORT", "8080"),
-			Mode: getEnv("GIN_MODE", "debug"),
+			Host:           getEnv("SERVER_HOST", "localhost"),
+			Port:           getEnv("SERVER_PORT", "8080"),
+			Mode:           getEnv("GIN_MODE", "debug"),
+			RequireIfMatch: getEnvBool("REQUIRE_IF_MATCH", false),
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			Username: getEnv("DB_USERNAME", "postgres"),
-			Password: getEnv("DB_PASSWORD", "password"),
-			Database: getEnv("DB_DATABASE", "postgres"),
-			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Host:        getEnv("DB_HOST", "localhost"),
+			Port:        getEnv("DB_PORT", "5432"),
+			Username:    getEnv("DB_USERNAME", "postgres"),
+			Password:    getEnv("DB_PASSWORD", "password"),
+			Database:    getEnv("DB_DATABASE", "postgres"),
+			SSLMode:     getEnv("DB_SSL_MODE", "disable"),
+			AutoMigrate: getEnvBool("DB_AUTO_MIGRATE", false),
 		},
 		ML: MLConfig{
 			BaseURL: getEnv("ML_BASE_URL", "http://localhost:5000"),
 			Timeout: getEnvInt("ML_TIMEOUT", 30),
 		},
+		HMM: HMMConfig{
+			ModelDir: getEnv("HMM_MODEL_DIR", "./data/hmm"),
+			EValue:   getEnvFloat("HMM_EVALUE", 0.01),
+		},
+		Storage: StorageConfig{
+			StructureDir:       getEnv("STRUCTURE_DIR", "./data/structures"),
+			MaxStructureSizeMB: getEnvInt("STRUCTURE_MAX_SIZE_MB", 200),
+		},
+		Trash: TrashConfig{
+			RetentionDays:        getEnvInt("TRASH_RETENTION_DAYS", 30),
+			PurgeIntervalMinutes: getEnvInt("TRASH_PURGE_INTERVAL_MINUTES", 60),
+		},
 	}, nil
 }
 
@@ -68,3 +107,21 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -13,14 +14,20 @@ type Protein struct {
 	ID   string `bun:"id,pk" json:"id"`
 	Name string `bun:"name" json:"name"`
 
-	Gene       *string `bun:"gene" json:"gene,omitempty"`
-	Taxo       *string `bun:"taxo" json:"taxo,omitempty"`
-	CC         *string `bun:"cc" json:"cc,omitempty"`
-	Length     *int    `bun:"length" json:"length,omitempty"`
-	Domain     *string `bun:"domain" json:"domain,omitempty"`
-	Family     *string `bun:"family" json:"family,omitempty"`
-	BioProcess *string `bun:"bio_process" json:"bio_process,omitempty"`
-	Function   *string `bun:"function" json:"function,omitempty"`
+	Gene       *string  `bun:"gene" json:"gene,omitempty"`
+	Aliases    []string `bun:"aliases,array" json:"aliases,omitempty"`
+	Taxo       *string  `bun:"taxo" json:"taxo,omitempty"`
+	TaxonID    *int     `bun:"taxon_id" json:"taxon_id,omitempty"`
+	CC         *string  `bun:"cc" json:"cc,omitempty"`
+	Length     *int     `bun:"length" json:"length,omitempty"`
+	Domain     *string  `bun:"domain" json:"domain,omitempty"`
+	Family     *string  `bun:"family" json:"family,omitempty"`
+	BioProcess *string  `bun:"bio_process" json:"bio_process,omitempty"`
+	Function   *string  `bun:"function" json:"function,omitempty"`
+
+	// GeneID and FamilyID are set from Gene and Family by a database trigger on every write
+	GeneID   *int `bun:"gene_id" json:"gene_id,omitempty"`
+	FamilyID *int `bun:"family_id" json:"family_id,omitempty"`
 
 	MW  *float64 `bun:"mw" json:"mw,omitempty"` // numeric(12,4)
 	Seq []string `bun:"seq,array" json:"seq"`
@@ -36,18 +43,29 @@ type Protein struct {
 
 	Created time.Time `bun:"created,default:current_timestamp" json:"created"`
 	Updated time.Time `bun:"updated,default:current_timestamp" json:"updated"`
+
+	// Version is bumped by a database trigger on every update.
+	Version int `bun:"version,nullzero,notnull,default:1" json:"version"`
+
+	// DeletedAt puts the protein in the trash: queries on the model skip it unless they
+	// ask for deleted rows, and a delete only sets it unless forced.
+	DeletedAt time.Time `bun:",soft_delete,nullzero" json:"deleted_at,omitempty"`
 }
 
 // Gene represents a gene entity in the database
 type Gene struct {
-	ID   int    `bun:"id" json:"id"`
-	Name string `bun:"name" json:"name"`
+	bun.BaseModel `bun:"table:genes"`
+
+	ID   int    `bun:"id,pk,autoincrement" json:"id"`
+	Name string `bun:"name,notnull,unique" json:"name"`
 }
 
 // ProteinFamily represents a protein family entity in the database
 type ProteinFamily struct {
-	ID   int    `bun:"id" json:"id"`
-	Name string `bun:"name" json:"name"`
+	bun.BaseModel `bun:"table:protein_families"`
+
+	ID   int    `bun:"id,pk,autoincrement" json:"id"`
+	Name string `bun:"name,notnull,unique" json:"name"`
 }
 
 // TableName specifies the table name for Protein model
@@ -64,3 +82,246 @@ func (Gene) TableName() string {
 func (ProteinFamily) TableName() string {
 	return "protein_families"
 }
+
+// PSSMProfile represents a stored position-specific scoring matrix
+type PSSMProfile struct {
+	bun.BaseModel `bun:"table:pssm_profiles"`
+
+	ID                 int64       `bun:"id,pk,autoincrement" json:"id"`
+	Name               string      `bun:"name,unique" json:"name"`
+	Description        *string     `bun:"description" json:"description,omitempty"`
+	Family             *string     `bun:"family" json:"family,omitempty"`
+	Alphabet           string      `bun:"alphabet" json:"alphabet"`
+	Length             int         `bun:"length" json:"length"`
+	Consensus          string      `bun:"consensus" json:"consensus"`
+	Scores             [][]float64 `bun:"scores,type:jsonb" json:"scores"`
+	Frequencies        [][]float64 `bun:"frequencies,type:jsonb" json:"frequencies,omitempty"`
+	Information        []float64   `bun:"information,type:jsonb" json:"information,omitempty"`
+	NumSequences       int         `bun:"num_sequences" json:"num_sequences"`
+	EffectiveSequences float64     `bun:"effective_sequences" json:"effective_sequences"`
+	PseudocountWeight  float64     `bun:"pseudocount_weight" json:"pseudocount_weight"`
+	SourceIDs          []string    `bun:"source_ids,array" json:"source_ids,omitempty"`
+
+	Created time.Time `bun:"created,default:current_timestamp" json:"created"`
+	Updated time.Time `bun:"updated,default:current_timestamp" json:"updated"`
+}
+
+// ProteinDomainHit represents a profile HMM domain hit on a protein
+type ProteinDomainHit struct {
+	bun.BaseModel `bun:"table:protein_domain_hits"`
+
+	ID             int64   `bun:"id,pk,autoincrement" json:"id"`
+	ProteinID      string  `bun:"protein_id,notnull" json:"protein_id"` // references proteins(id) on delete cascade
+	ModelName      string  `bun:"model_name" json:"model_name"`
+	ModelAccession string  `bun:"model_accession" json:"model_accession,omitempty"`
+	Description    string  `bun:"description" json:"description,omitempty"`
+	SeqStart       int     `bun:"seq_start" json:"seq_start"`
+	SeqEnd         int     `bun:"seq_end" json:"seq_end"`
+	HMMStart       int     `bun:"hmm_start" json:"hmm_start"`
+	HMMEnd         int     `bun:"hmm_end" json:"hmm_end"`
+	HMMLength      int     `bun:"hmm_length" json:"hmm_length"`
+	Score          float64 `bun:"score" json:"score"`
+	EValue         float64 `bun:"evalue" json:"evalue"`
+	SequenceScore  float64 `bun:"sequence_score" json:"sequence_score"`
+	SequenceEValue float64 `bun:"sequence_evalue" json:"sequence_evalue"`
+
+	Created time.Time `bun:"created,default:current_timestamp" json:"created"`
+}
+
+// ProteinCrossReference represents a link from a protein to an external database record
+type ProteinCrossReference struct {
+	bun.BaseModel `bun:"table:protein_cross_references"`
+
+	ID         int64    `bun:"id,pk,autoincrement" json:"id"`
+	ProteinID  string   `bun:"protein_id,notnull" json:"protein_id"` // references proteins(id) on delete cascade
+	Database   string   `bun:"database,notnull" json:"database"`
+	Accession  string   `bun:"accession,notnull" json:"accession"`
+	Properties []string `bun:"properties,array" json:"properties,omitempty"`
+	Source     string   `bun:"source" json:"source,omitempty"`
+}
+
+// ProteinFeature represents an annotated region of a protein sequence; range queries
+// use an index on (protein_id, seq_start, seq_end)
+type ProteinFeature struct {
+	bun.BaseModel `bun:"table:protein_features"`
+
+	ID          int64               `bun:"id,pk,autoincrement" json:"id"`
+	ProteinID   string              `bun:"protein_id,notnull" json:"protein_id"` // references proteins(id) on delete cascade
+	Type        string              `bun:"type,notnull" json:"type"`
+	SeqStart    int                 `bun:"seq_start" json:"seq_start"`
+	SeqEnd      int                 `bun:"seq_end" json:"seq_end"`
+	Score       *float64            `bun:"score" json:"score,omitempty"`
+	Description string              `bun:"description" json:"description,omitempty"`
+	Evidence    string              `bun:"evidence" json:"evidence,omitempty"`
+	Source      string              `bun:"source" json:"source,omitempty"`
+	Attributes  map[string][]string `bun:"attributes,type:jsonb" json:"attributes,omitempty"`
+}
+
+// ProteinRevision is a write to a protein, recorded by the proteins_record_revision
+// trigger; the table has no foreign key so that history outlives a purge
+type ProteinRevision struct {
+	bun.BaseModel `bun:"table:protein_revisions"`
+
+	ID            int64           `bun:"id,pk,autoincrement" json:"id"`
+	ProteinID     string          `bun:"protein_id,notnull" json:"protein_id"`
+	Revision      int             `bun:"revision,notnull" json:"revision"` // unique per protein
+	Action        string          `bun:"action,notnull" json:"action"`
+	ChangedFields []string        `bun:"changed_fields,array" json:"changed_fields"`
+	Snapshot      json.RawMessage `bun:"snapshot,type:jsonb" json:"snapshot,omitempty"` // the row as to_jsonb wrote it
+	Actor         *string         `bun:"actor" json:"actor,omitempty"`
+	Note          *string         `bun:"note" json:"note,omitempty"`
+	CreatedAt     time.Time       `bun:"created_at,default:current_timestamp" json:"created_at"`
+}
+
+// ImportCheckpoint represents the progress of a resumable file import
+type ImportCheckpoint struct {
+	bun.BaseModel `bun:"table:import_checkpoints"`
+
+	Source   string    `bun:"source,pk" json:"source"`
+	Format   string    `bun:"format" json:"format"`
+	FileSize int64     `bun:"file_size" json:"file_size"`
+	Offset   int64     `bun:"byte_offset" json:"offset"`
+	Records  int       `bun:"records" json:"records"`
+	Updated  time.Time `bun:"updated,default:current_timestamp" json:"updated"`
+}
+
+// ProteinStructure represents an uploaded PDB or mmCIF file kept in the structure store
+type ProteinStructure struct {
+	bun.BaseModel `bun:"table:protein_structures"`
+
+	ID         int64    `bun:"id,pk,autoincrement" json:"id"`
+	PDBID      string   `bun:"pdb_id" json:"pdb_id,omitempty"`
+	Title      string   `bun:"title" json:"title,omitempty"`
+	Method     string   `bun:"method" json:"method,omitempty"`
+	Resolution *float64 `bun:"resolution" json:"resolution,omitempty"`
+	Format     string   `bun:"format,notnull" json:"format"`
+	FileName   string   `bun:"file_name" json:"file_name,omitempty"`
+	Path       string   `bun:"path,notnull" json:"path"`
+	Checksum   string   `bun:"checksum,notnull,unique" json:"checksum"`
+	FileSize   int64    `bun:"file_size" json:"file_size"`
+
+	Created time.Time `bun:"created,default:current_timestamp" json:"created"`
+}
+
+// StructureChain represents a chain of an uploaded structure and its link to a protein
+type StructureChain struct {
+	bun.BaseModel `bun:"table:structure_chains"`
+
+	ID               int64     `bun:"id,pk,autoincrement" json:"id"`
+	StructureID      int64     `bun:"structure_id,notnull" json:"structure_id"` // references protein_structures(id) on delete cascade
+	ChainID          string    `bun:"chain_id,notnull" json:"chain_id"`
+	ProteinID        *string   `bun:"protein_id" json:"protein_id,omitempty"` // references proteins(id) on delete set null
+	SeqResSequence   string    `bun:"seqres_sequence" json:"seqres_sequence,omitempty"`
+	AtomSequence     string    `bun:"atom_sequence" json:"atom_sequence"`
+	ResidueNumbers   []string  `bun:"residue_numbers,array" json:"residue_numbers,omitempty"`
+	CACoords         []float64 `bun:"ca_coords,array" json:"ca_coords,omitempty"`
+	ProteinPositions []int     `bun:"protein_positions,array" json:"protein_positions,omitempty"`
+	Identity         float64   `bun:"identity" json:"identity"`
+	Coverage         float64   `bun:"coverage" json:"coverage"`
+}
+
+// GOTerm represents a Gene Ontology term loaded from an OBO file
+type GOTerm struct {
+	bun.BaseModel `bun:"table:go_terms"`
+
+	ID         string   `bun:"id,pk" json:"id"`
+	Name       string   `bun:"name,notnull" json:"name"`
+	Namespace  string   `bun:"namespace" json:"namespace"`
+	Definition string   `bun:"definition" json:"definition,omitempty"`
+	Synonyms   []string `bun:"synonyms,array" json:"synonyms,omitempty"`
+	AltIDs     []string `bun:"alt_ids,array" json:"alt_ids,omitempty"` // GIN-indexed for alternative ID lookups
+	Obsolete   bool     `bun:"obsolete,notnull,default:false" json:"obsolete"`
+	ReplacedBy string   `bun:"replaced_by" json:"replaced_by,omitempty"`
+
+	Updated time.Time `bun:"updated,default:current_timestamp" json:"updated"`
+}
+
+// GORelationship represents an edge of the ontology graph, indexed by parent for closure queries
+type GORelationship struct {
+	bun.BaseModel `bun:"table:go_relationships"`
+
+	TermID   string `bun:"term_id,pk" json:"term_id"` // references go_terms(id) on delete cascade
+	Relation string `bun:"relation,pk" json:"relation"`
+	ParentID string `bun:"parent_id,pk" json:"parent_id"` // references go_terms(id) on delete cascade
+}
+
+// ProteinGOAnnotation represents the annotation of a protein with a GO term
+type ProteinGOAnnotation struct {
+	bun.BaseModel `bun:"table:protein_go_annotations"`
+
+	ID           int64  `bun:"id,pk,autoincrement" json:"id"`
+	ProteinID    string `bun:"protein_id,notnull" json:"protein_id"` // references proteins(id) on delete cascade
+	TermID       string `bun:"term_id,notnull" json:"term_id"`       // references go_terms(id)
+	EvidenceCode string `bun:"evidence_code,notnull" json:"evidence_code"`
+	Qualifier    string `bun:"qualifier" json:"qualifier,omitempty"`
+	Negated      bool   `bun:"negated,notnull,default:false" json:"negated"`
+	Reference    string `bun:"reference" json:"reference,omitempty"`
+	WithFrom     string `bun:"with_from" json:"with_from,omitempty"`
+	AssignedBy   string `bun:"assigned_by" json:"assigned_by,omitempty"`
+	Source       string `bun:"source" json:"source,omitempty"`
+
+	Created time.Time `bun:"created,default:current_timestamp" json:"created"`
+}
+
+// Taxon represents a node of the NCBI taxonomy loaded from nodes.dmp
+type Taxon struct {
+	bun.BaseModel `bun:"table:taxa"`
+
+	ID             int    `bun:"id,pk" json:"id"`
+	ParentID       int    `bun:"parent_id,notnull" json:"parent_id"` // indexed for subtree queries
+	Rank           string `bun:"rank" json:"rank"`
+	ScientificName string `bun:"scientific_name" json:"scientific_name"`
+	DivisionID     int    `bun:"division_id" json:"division_id"`
+	GeneticCode    int    `bun:"genetic_code" json:"genetic_code"`
+
+	Updated time.Time `bun:"updated,default:current_timestamp" json:"updated"`
+}
+
+// TaxonName represents a name of a taxon loaded from names.dmp
+type TaxonName struct {
+	bun.BaseModel `bun:"table:taxon_names"`
+
+	ID         int64  `bun:"id,pk,autoincrement" json:"id"`
+	TaxonID    int    `bun:"taxon_id,notnull" json:"taxon_id"` // indexed
+	Name       string `bun:"name,notnull" json:"name"`         // indexed on lower(name)
+	UniqueName string `bun:"unique_name" json:"unique_name,omitempty"`
+	NameClass  string `bun:"name_class,notnull" json:"name_class"`
+}
+
+// Disease represents a disease or phenotype
+type Disease struct {
+	bun.BaseModel `bun:"table:diseases"`
+
+	ID          string `bun:"id,pk" json:"id"`
+	Name        string `bun:"name,notnull" json:"name"`
+	Description string `bun:"description" json:"description,omitempty"`
+	Category    string `bun:"category" json:"category,omitempty"`
+	Source      string `bun:"source" json:"source,omitempty"`
+
+	Created time.Time `bun:"created,default:current_timestamp" json:"created"`
+	Updated time.Time `bun:"updated,default:current_timestamp" json:"updated"`
+}
+
+// DiseaseSynonym represents an alternative name of a disease
+type DiseaseSynonym struct {
+	bun.BaseModel `bun:"table:disease_synonyms"`
+
+	DiseaseID string `bun:"disease_id,pk" json:"disease_id"` // references diseases(id) on delete cascade
+	Synonym   string `bun:"synonym,pk" json:"synonym"`
+}
+
+// ProteinDiseaseAssociation represents the association of a protein with a disease,
+// unique per protein, disease and source
+type ProteinDiseaseAssociation struct {
+	bun.BaseModel `bun:"table:protein_disease_associations"`
+
+	ID           int64    `bun:"id,pk,autoincrement" json:"id"`
+	ProteinID    string   `bun:"protein_id,notnull" json:"protein_id"` // references proteins(id) on delete cascade
+	DiseaseID    string   `bun:"disease_id,notnull" json:"disease_id"` // references diseases(id) on delete cascade
+	EvidenceType string   `bun:"evidence_type,notnull" json:"evidence_type"`
+	Source       string   `bun:"source,notnull,default:''" json:"source"`
+	Score        *float64 `bun:"score" json:"score,omitempty"`
+	References   []string `bun:"reference_ids,array" json:"references,omitempty"`
+
+	Created time.Time `bun:"created,default:current_timestamp" json:"created"`
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"go-crawler/web/BE/internal/infrastructure/database/migrations"
+
+	"github.com/uptrace/bun/migrate"
+)
+
+// migrationLockID keys the PostgreSQL advisory lock held while migrating, so replicas
+// started together apply the schema one at a time.
+const migrationLockID int64 = 0x70726f7465696e // "protein"
+
+// Migrate applies every pending migration as one group.
+func (d *Database) Migrate(ctx context.Context) (*migrate.MigrationGroup, error) {
+	var group *migrate.MigrationGroup
+	err := d.withMigrator(ctx, func(migrator *migrate.Migrator) error {
+		var err error
+		group, err = migrator.Migrate(ctx)
+		return err
+	})
+	if err != nil {
+		return group, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return group, nil
+}
+
+// Rollback reverts the last applied migration group.
+func (d *Database) Rollback(ctx context.Context) (*migrate.MigrationGroup, error) {
+	var group *migrate.MigrationGroup
+	err := d.withMigrator(ctx, func(migrator *migrate.Migrator) error {
+		var err error
+		group, err = migrator.Rollback(ctx)
+		return err
+	})
+	if err != nil {
+		return group, fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+	return group, nil
+}
+
+// MigrationStatus lists every known migration; applied ones carry their group and time.
+func (d *Database) MigrationStatus(ctx context.Context) (migrate.MigrationSlice, error) {
+	var status migrate.MigrationSlice
+	err := d.withMigrator(ctx, func(migrator *migrate.Migrator) error {
+		var err error
+		status, err = migrator.MigrationsWithStatus(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	return status, nil
+}
+
+// withMigrator runs fn under the migration advisory lock. The lock belongs to the
+// session that took it, so it is taken and released on one dedicated connection; a
+// crashed replica's lock goes away with its connection. Migrations are only marked
+// applied once they succeed, so a failed one is retried on the next run.
+func (d *Database) withMigrator(ctx context.Context, fn func(*migrate.Migrator) error) error {
+	conn, err := d.Conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock(?)", migrationLockID)
+
+	migrator := migrate.NewMigrator(d.Conn, migrations.Migrations, migrate.WithMarkAppliedOnSuccess(true))
+	if err := migrator.Init(ctx); err != nil {
+		return err
+	}
+	return fn(migrator)
+}
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS protein_families;
+
+--bun:split
+
+DROP TABLE IF EXISTS genes;
+
+--bun:split
+
+DROP TABLE IF EXISTS proteins;
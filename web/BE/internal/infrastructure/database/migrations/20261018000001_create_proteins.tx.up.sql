@@ -0,0 +1,43 @@
+-- Databases set up by hand before migrations existed already have these tables;
+-- IF NOT EXISTS lets them adopt the migration history.
+CREATE TABLE IF NOT EXISTS proteins (
+    id                   text PRIMARY KEY,
+    name                 text NOT NULL,
+    gene                 text,
+    taxo                 text,
+    cc                   text,
+    length               integer,
+    domain               text,
+    family               text,
+    bio_process          text,
+    function             text,
+    mw                   numeric(12,4),
+    seq                  text[] NOT NULL DEFAULT '{}',
+    n_interactors        integer,
+    pi                   numeric(4,2),
+    nc_7_4               numeric(8,4),
+    hydrophobicity_gravy numeric(8,4),
+    d_rank               integer,
+    l_rank               varchar(100),
+    f_rank               varchar(100),
+    created              timestamptz NOT NULL DEFAULT current_timestamp,
+    updated              timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS proteins_gene_idx ON proteins (lower(gene));
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS genes (
+    id   serial PRIMARY KEY,
+    name text NOT NULL UNIQUE
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS protein_families (
+    id   serial PRIMARY KEY,
+    name text NOT NULL UNIQUE
+);
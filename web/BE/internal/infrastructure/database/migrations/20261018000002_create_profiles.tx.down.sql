@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS protein_domain_hits;
+
+--bun:split
+
+DROP TABLE IF EXISTS pssm_profiles;
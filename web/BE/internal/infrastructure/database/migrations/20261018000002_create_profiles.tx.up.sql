@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS pssm_profiles (
+    id                  bigserial PRIMARY KEY,
+    name                text NOT NULL UNIQUE,
+    description         text,
+    family              text,
+    alphabet            text NOT NULL,
+    length              integer NOT NULL,
+    consensus           text NOT NULL,
+    scores              jsonb NOT NULL,
+    frequencies         jsonb,
+    information         jsonb,
+    num_sequences       integer NOT NULL DEFAULT 0,
+    effective_sequences double precision NOT NULL DEFAULT 0,
+    pseudocount_weight  double precision NOT NULL DEFAULT 0,
+    source_ids          text[],
+    created             timestamptz NOT NULL DEFAULT current_timestamp,
+    updated             timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS protein_domain_hits (
+    id              bigserial PRIMARY KEY,
+    protein_id      text NOT NULL REFERENCES proteins (id) ON DELETE CASCADE,
+    model_name      text NOT NULL,
+    model_accession text,
+    description     text,
+    seq_start       integer NOT NULL,
+    seq_end         integer NOT NULL,
+    hmm_start       integer NOT NULL,
+    hmm_end         integer NOT NULL,
+    hmm_length      integer NOT NULL,
+    score           double precision NOT NULL,
+    evalue          double precision NOT NULL,
+    sequence_score  double precision NOT NULL,
+    sequence_evalue double precision NOT NULL,
+    created         timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS protein_domain_hits_protein_idx ON protein_domain_hits (protein_id, seq_start);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS import_checkpoints;
+
+--bun:split
+
+DROP TABLE IF EXISTS protein_features;
+
+--bun:split
+
+DROP TABLE IF EXISTS protein_cross_references;
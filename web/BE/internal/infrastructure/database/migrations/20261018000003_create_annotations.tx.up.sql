@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS protein_cross_references (
+    id         bigserial PRIMARY KEY,
+    protein_id text NOT NULL REFERENCES proteins (id) ON DELETE CASCADE,
+    database   text NOT NULL,
+    accession  text NOT NULL,
+    properties text[],
+    source     text NOT NULL DEFAULT ''
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS protein_cross_references_protein_idx ON protein_cross_references (protein_id);
+
+--bun:split
+
+-- Annotation imports resolve accessions such as UniProtKB entries to proteins.
+CREATE INDEX IF NOT EXISTS protein_cross_references_accession_idx ON protein_cross_references (database, accession);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS protein_features (
+    id          bigserial PRIMARY KEY,
+    protein_id  text NOT NULL REFERENCES proteins (id) ON DELETE CASCADE,
+    type        text NOT NULL,
+    seq_start   integer NOT NULL DEFAULT 0,
+    seq_end     integer NOT NULL DEFAULT 0,
+    score       double precision,
+    description text,
+    evidence    text,
+    source      text NOT NULL DEFAULT '',
+    attributes  jsonb
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS protein_features_range_idx ON protein_features (protein_id, seq_start, seq_end);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS import_checkpoints (
+    source      text PRIMARY KEY,
+    format      text NOT NULL,
+    file_size   bigint NOT NULL,
+    byte_offset bigint NOT NULL,
+    records     integer NOT NULL DEFAULT 0,
+    updated     timestamptz NOT NULL DEFAULT current_timestamp
+);
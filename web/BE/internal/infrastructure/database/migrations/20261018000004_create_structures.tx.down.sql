@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS structure_chains;
+
+--bun:split
+
+DROP TABLE IF EXISTS protein_structures;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS protein_structures (
+    id         bigserial PRIMARY KEY,
+    pdb_id     text,
+    title      text,
+    method     text,
+    resolution double precision,
+    format     text NOT NULL,
+    file_name  text,
+    path       text NOT NULL,
+    checksum   text NOT NULL UNIQUE,
+    file_size  bigint NOT NULL DEFAULT 0,
+    created    timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS structure_chains (
+    id                bigserial PRIMARY KEY,
+    structure_id      bigint NOT NULL REFERENCES protein_structures (id) ON DELETE CASCADE,
+    chain_id          text NOT NULL,
+    protein_id        text REFERENCES proteins (id) ON DELETE SET NULL,
+    seqres_sequence   text,
+    atom_sequence     text NOT NULL DEFAULT '',
+    residue_numbers   text[],
+    ca_coords         double precision[],
+    protein_positions integer[],
+    identity          double precision NOT NULL DEFAULT 0,
+    coverage          double precision NOT NULL DEFAULT 0,
+    UNIQUE (structure_id, chain_id)
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS structure_chains_protein_idx ON structure_chains (protein_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS protein_go_annotations;
+
+--bun:split
+
+DROP TABLE IF EXISTS go_relationships;
+
+--bun:split
+
+DROP TABLE IF EXISTS go_terms;
@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS go_terms (
+    id          text PRIMARY KEY,
+    name        text NOT NULL,
+    namespace   text NOT NULL,
+    definition  text,
+    synonyms    text[],
+    alt_ids     text[],
+    obsolete    boolean NOT NULL DEFAULT false,
+    replaced_by text,
+    updated     timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS go_terms_alt_ids_idx ON go_terms USING gin (alt_ids);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS go_terms_name_idx ON go_terms (lower(name));
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS go_relationships (
+    term_id   text NOT NULL REFERENCES go_terms (id) ON DELETE CASCADE,
+    relation  text NOT NULL,
+    parent_id text NOT NULL REFERENCES go_terms (id) ON DELETE CASCADE,
+    PRIMARY KEY (term_id, relation, parent_id)
+);
+
+--bun:split
+
+-- Descendant closures walk the graph from parent to child.
+CREATE INDEX IF NOT EXISTS go_relationships_parent_idx ON go_relationships (parent_id);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS protein_go_annotations (
+    id            bigserial PRIMARY KEY,
+    protein_id    text NOT NULL REFERENCES proteins (id) ON DELETE CASCADE,
+    term_id       text NOT NULL REFERENCES go_terms (id),
+    evidence_code text NOT NULL,
+    qualifier     text,
+    negated       boolean NOT NULL DEFAULT false,
+    reference     text,
+    with_from     text,
+    assigned_by   text,
+    source        text NOT NULL DEFAULT '',
+    created       timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS protein_go_annotations_protein_idx ON protein_go_annotations (protein_id, source);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS protein_go_annotations_term_idx ON protein_go_annotations (term_id);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS proteins_taxon_idx;
+
+--bun:split
+
+ALTER TABLE proteins DROP COLUMN IF EXISTS taxon_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS taxon_names;
+
+--bun:split
+
+DROP TABLE IF EXISTS taxa;
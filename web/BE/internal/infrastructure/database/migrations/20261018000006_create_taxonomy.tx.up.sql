@@ -0,0 +1,42 @@
+-- taxa and proteins.taxon_id carry no foreign keys: taxdump nodes are loaded in
+-- batches in file order, and proteins record their NCBI taxon before the taxonomy is
+-- imported.
+CREATE TABLE IF NOT EXISTS taxa (
+    id              integer PRIMARY KEY,
+    parent_id       integer NOT NULL,
+    rank            text NOT NULL DEFAULT '',
+    scientific_name text NOT NULL DEFAULT '',
+    division_id     integer NOT NULL DEFAULT 0,
+    genetic_code    integer NOT NULL DEFAULT 0,
+    updated         timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS taxa_parent_idx ON taxa (parent_id);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS taxon_names (
+    id          bigserial PRIMARY KEY,
+    taxon_id    integer NOT NULL REFERENCES taxa (id) ON DELETE CASCADE,
+    name        text NOT NULL,
+    unique_name text,
+    name_class  text NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS taxon_names_taxon_idx ON taxon_names (taxon_id);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS taxon_names_name_idx ON taxon_names (lower(name));
+
+--bun:split
+
+ALTER TABLE proteins ADD COLUMN IF NOT EXISTS taxon_id integer;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS proteins_taxon_idx ON proteins (taxon_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS protein_disease_associations;
+
+--bun:split
+
+DROP TABLE IF EXISTS disease_synonyms;
+
+--bun:split
+
+DROP TABLE IF EXISTS diseases;
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS diseases (
+    id          text PRIMARY KEY,
+    name        text NOT NULL,
+    description text,
+    category    text,
+    source      text,
+    created     timestamptz NOT NULL DEFAULT current_timestamp,
+    updated     timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS diseases_name_idx ON diseases (lower(name));
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS disease_synonyms (
+    disease_id text NOT NULL REFERENCES diseases (id) ON DELETE CASCADE,
+    synonym    text NOT NULL,
+    PRIMARY KEY (disease_id, synonym)
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS protein_disease_associations (
+    id            bigserial PRIMARY KEY,
+    protein_id    text NOT NULL REFERENCES proteins (id) ON DELETE CASCADE,
+    disease_id    text NOT NULL REFERENCES diseases (id) ON DELETE CASCADE,
+    evidence_type text NOT NULL,
+    source        text NOT NULL DEFAULT '',
+    score         double precision,
+    reference_ids text[],
+    created       timestamptz NOT NULL DEFAULT current_timestamp,
+    UNIQUE (protein_id, disease_id, source)
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS protein_disease_associations_disease_idx ON protein_disease_associations (disease_id);
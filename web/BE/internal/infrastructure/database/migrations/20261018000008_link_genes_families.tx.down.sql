@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS proteins_link_gene_family ON proteins;
+
+--bun:split
+
+DROP FUNCTION IF EXISTS proteins_link_gene_family();
+
+--bun:split
+
+ALTER TABLE proteins DROP COLUMN IF EXISTS family_id;
+
+--bun:split
+
+ALTER TABLE proteins DROP COLUMN IF EXISTS gene_id;
@@ -0,0 +1,59 @@
+ALTER TABLE proteins ADD COLUMN IF NOT EXISTS gene_id integer REFERENCES genes (id) ON DELETE SET NULL;
+
+--bun:split
+
+ALTER TABLE proteins ADD COLUMN IF NOT EXISTS family_id integer REFERENCES protein_families (id) ON DELETE SET NULL;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS proteins_gene_id_idx ON proteins (gene_id);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS proteins_family_id_idx ON proteins (family_id);
+
+--bun:split
+
+-- proteins.gene and proteins.family stay the values clients read and write; the
+-- trigger trims them and links each protein to its genes / protein_families row,
+-- creating the row for a new name.
+CREATE OR REPLACE FUNCTION proteins_link_gene_family() RETURNS trigger AS $$
+BEGIN
+    NEW.gene := NULLIF(btrim(NEW.gene), '');
+    NEW.gene_id := NULL;
+    IF NEW.gene IS NOT NULL THEN
+        SELECT id INTO NEW.gene_id FROM genes WHERE name = NEW.gene;
+        IF NOT FOUND THEN
+            INSERT INTO genes (name) VALUES (NEW.gene) ON CONFLICT (name) DO NOTHING;
+            SELECT id INTO NEW.gene_id FROM genes WHERE name = NEW.gene;
+        END IF;
+    END IF;
+
+    NEW.family := NULLIF(btrim(NEW.family), '');
+    NEW.family_id := NULL;
+    IF NEW.family IS NOT NULL THEN
+        SELECT id INTO NEW.family_id FROM protein_families WHERE name = NEW.family;
+        IF NOT FOUND THEN
+            INSERT INTO protein_families (name) VALUES (NEW.family) ON CONFLICT (name) DO NOTHING;
+            SELECT id INTO NEW.family_id FROM protein_families WHERE name = NEW.family;
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+--bun:split
+
+DROP TRIGGER IF EXISTS proteins_link_gene_family ON proteins;
+
+--bun:split
+
+CREATE TRIGGER proteins_link_gene_family
+    BEFORE INSERT OR UPDATE OF gene, gene_id, family, family_id ON proteins
+    FOR EACH ROW EXECUTE FUNCTION proteins_link_gene_family();
+
+--bun:split
+
+-- Normalize the existing free-text values through the trigger.
+UPDATE proteins SET gene = gene, family = family
+WHERE gene IS NOT NULL OR family IS NOT NULL;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS proteins_search_vector ON proteins;
+
+--bun:split
+
+DROP FUNCTION IF EXISTS proteins_search_vector();
+
+--bun:split
+
+ALTER TABLE proteins DROP COLUMN IF EXISTS search_vector;
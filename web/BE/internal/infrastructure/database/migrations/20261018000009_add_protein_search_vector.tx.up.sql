@@ -0,0 +1,40 @@
+ALTER TABLE proteins ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+--bun:split
+
+-- The full-text document of a protein, weighted by where a word occurs: names and gene
+-- symbols (A), family and domains (B), function and biological process (C) and the
+-- remaining comments (D).
+CREATE OR REPLACE FUNCTION proteins_search_vector() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.gene, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.family, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.domain, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.function, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(NEW.bio_process, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(NEW.cc, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+--bun:split
+
+DROP TRIGGER IF EXISTS proteins_search_vector ON proteins;
+
+--bun:split
+
+-- Named to fire after proteins_link_gene_family, which trims gene and family.
+CREATE TRIGGER proteins_search_vector
+    BEFORE INSERT OR UPDATE OF name, gene, family, domain, function, bio_process, cc, search_vector ON proteins
+    FOR EACH ROW EXECUTE FUNCTION proteins_search_vector();
+
+--bun:split
+
+-- Fill in existing proteins; the trigger computes the vector whatever is set.
+UPDATE proteins SET search_vector = NULL;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS proteins_search_vector_idx ON proteins USING gin (search_vector);
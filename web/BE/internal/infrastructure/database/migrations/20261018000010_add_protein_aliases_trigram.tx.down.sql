@@ -0,0 +1,40 @@
+CREATE OR REPLACE FUNCTION proteins_search_vector() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.gene, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.family, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.domain, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.function, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(NEW.bio_process, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(NEW.cc, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+--bun:split
+
+DROP TRIGGER IF EXISTS proteins_search_vector ON proteins;
+
+--bun:split
+
+CREATE TRIGGER proteins_search_vector
+    BEFORE INSERT OR UPDATE OF name, gene, family, domain, function, bio_process, cc, search_vector ON proteins
+    FOR EACH ROW EXECUTE FUNCTION proteins_search_vector();
+
+--bun:split
+
+DROP INDEX IF EXISTS proteins_gene_trgm_idx;
+
+--bun:split
+
+DROP INDEX IF EXISTS proteins_name_trgm_idx;
+
+--bun:split
+
+ALTER TABLE proteins DROP COLUMN IF EXISTS aliases;
+
+--bun:split
+
+-- Restore the vectors of proteins that had aliases.
+UPDATE proteins SET search_vector = NULL;
@@ -0,0 +1,44 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+--bun:split
+
+-- Alternative protein names and gene synonyms, searched alongside name and gene.
+ALTER TABLE proteins ADD COLUMN IF NOT EXISTS aliases text[];
+
+--bun:split
+
+-- Trigram indexes serve the ILIKE patterns of autocompletion and of the name and gene
+-- filters.
+CREATE INDEX IF NOT EXISTS proteins_name_trgm_idx ON proteins USING gin (name gin_trgm_ops);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS proteins_gene_trgm_idx ON proteins USING gin (gene gin_trgm_ops);
+
+--bun:split
+
+-- Aliases join names and gene symbols at weight A of the full-text document.
+CREATE OR REPLACE FUNCTION proteins_search_vector() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.gene, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(array_to_string(NEW.aliases, ' '), '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.family, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.domain, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.function, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(NEW.bio_process, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(NEW.cc, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+--bun:split
+
+DROP TRIGGER IF EXISTS proteins_search_vector ON proteins;
+
+--bun:split
+
+CREATE TRIGGER proteins_search_vector
+    BEFORE INSERT OR UPDATE OF name, gene, aliases, family, domain, function, bio_process, cc, search_vector ON proteins
+    FOR EACH ROW EXECUTE FUNCTION proteins_search_vector();
@@ -0,0 +1,10 @@
+-- Proteins still in the trash are deleted for good.
+DELETE FROM proteins WHERE deleted_at IS NOT NULL;
+
+--bun:split
+
+DROP INDEX IF EXISTS proteins_deleted_at_idx;
+
+--bun:split
+
+ALTER TABLE proteins DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,7 @@
+-- Deleted proteins stay in the trash, with their annotations, until restored or purged.
+ALTER TABLE proteins ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+--bun:split
+
+-- The trash listing and the purge only look at deleted rows.
+CREATE INDEX IF NOT EXISTS proteins_deleted_at_idx ON proteins (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS proteins_record_revision ON proteins;
+
+--bun:split
+
+DROP FUNCTION IF EXISTS proteins_record_revision();
+
+--bun:split
+
+DROP TABLE IF EXISTS protein_revisions;
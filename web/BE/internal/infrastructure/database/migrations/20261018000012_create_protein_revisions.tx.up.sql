@@ -0,0 +1,80 @@
+-- Every write to a protein leaves an immutable revision: the row as it was written, the
+-- columns that changed and who changed them. Revisions outlive a purge of the protein.
+CREATE TABLE IF NOT EXISTS protein_revisions (
+    id             bigserial PRIMARY KEY,
+    protein_id     text NOT NULL,
+    revision       integer NOT NULL,
+    action         text NOT NULL,
+    changed_fields text[] NOT NULL DEFAULT '{}',
+    snapshot       jsonb NOT NULL,
+    actor          text,
+    note           text,
+    created_at     timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (protein_id, revision)
+);
+
+--bun:split
+
+-- Records the revision of a write. The writer's transaction names the actor and an
+-- optional note in the app.actor and app.change_note settings. Updates that change
+-- nothing but the updated timestamp leave no revision.
+CREATE OR REPLACE FUNCTION proteins_record_revision() RETURNS trigger AS $$
+DECLARE
+    old_row jsonb;
+    new_row jsonb;
+    changed text[];
+    change_action text;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD) - 'search_vector';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW) - 'search_vector';
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        change_action := 'create';
+        SELECT array_agg(key ORDER BY key) INTO changed FROM jsonb_each(new_row)
+        WHERE value <> 'null'::jsonb AND key NOT IN ('id', 'created', 'updated', 'deleted_at');
+    ELSIF TG_OP = 'DELETE' THEN
+        change_action := 'purge';
+    ELSE
+        SELECT array_agg(key ORDER BY key) INTO changed FROM jsonb_each(new_row)
+        WHERE value IS DISTINCT FROM old_row -> key AND key NOT IN ('updated', 'deleted_at');
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            change_action := 'delete';
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            change_action := 'restore';
+        ELSIF changed IS NULL THEN
+            RETURN NULL;
+        ELSE
+            change_action := 'update';
+        END IF;
+    END IF;
+
+    INSERT INTO protein_revisions (protein_id, revision, action, changed_fields, snapshot, actor, note)
+    SELECT coalesce(new_row, old_row) ->> 'id', coalesce(max(revision), 0) + 1, change_action,
+           coalesce(changed, '{}'), coalesce(new_row, old_row),
+           nullif(current_setting('app.actor', true), ''), nullif(current_setting('app.change_note', true), '')
+    FROM protein_revisions WHERE protein_id = coalesce(new_row, old_row) ->> 'id';
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+--bun:split
+
+DROP TRIGGER IF EXISTS proteins_record_revision ON proteins;
+
+--bun:split
+
+CREATE TRIGGER proteins_record_revision
+    AFTER INSERT OR UPDATE OR DELETE ON proteins
+    FOR EACH ROW EXECUTE FUNCTION proteins_record_revision();
+
+--bun:split
+
+-- Existing proteins start their history from their current state.
+INSERT INTO protein_revisions (protein_id, revision, action, snapshot, note)
+SELECT id, 1, 'create', to_jsonb(proteins) - 'search_vector', 'recorded when revision history was enabled'
+FROM proteins
+ON CONFLICT (protein_id, revision) DO NOTHING;
@@ -0,0 +1,54 @@
+DROP TRIGGER IF EXISTS proteins_bump_version ON proteins;
+
+--bun:split
+
+DROP FUNCTION IF EXISTS proteins_bump_version();
+
+--bun:split
+
+CREATE OR REPLACE FUNCTION proteins_record_revision() RETURNS trigger AS $$
+DECLARE
+    old_row jsonb;
+    new_row jsonb;
+    changed text[];
+    change_action text;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD) - 'search_vector';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW) - 'search_vector';
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        change_action := 'create';
+        SELECT array_agg(key ORDER BY key) INTO changed FROM jsonb_each(new_row)
+        WHERE value <> 'null'::jsonb AND key NOT IN ('id', 'created', 'updated', 'deleted_at');
+    ELSIF TG_OP = 'DELETE' THEN
+        change_action := 'purge';
+    ELSE
+        SELECT array_agg(key ORDER BY key) INTO changed FROM jsonb_each(new_row)
+        WHERE value IS DISTINCT FROM old_row -> key AND key NOT IN ('updated', 'deleted_at');
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            change_action := 'delete';
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            change_action := 'restore';
+        ELSIF changed IS NULL THEN
+            RETURN NULL;
+        ELSE
+            change_action := 'update';
+        END IF;
+    END IF;
+
+    INSERT INTO protein_revisions (protein_id, revision, action, changed_fields, snapshot, actor, note)
+    SELECT coalesce(new_row, old_row) ->> 'id', coalesce(max(revision), 0) + 1, change_action,
+           coalesce(changed, '{}'), coalesce(new_row, old_row),
+           nullif(current_setting('app.actor', true), ''), nullif(current_setting('app.change_note', true), '')
+    FROM protein_revisions WHERE protein_id = coalesce(new_row, old_row) ->> 'id';
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+--bun:split
+
+ALTER TABLE proteins DROP COLUMN IF EXISTS version;
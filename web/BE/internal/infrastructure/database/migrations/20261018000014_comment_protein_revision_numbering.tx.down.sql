@@ -0,0 +1 @@
+COMMENT ON FUNCTION proteins_record_revision() IS NULL;
@@ -0,0 +1,5 @@
+-- Revisions are numbered max + 1 per protein. This relies on the row lock a write holds
+-- until it commits: a concurrent write of the same protein waits for it, and under READ
+-- COMMITTED the trigger then takes a fresh snapshot that sees the revision written
+-- before. A concurrent insert of the same ID waits on the primary key the same way.
+COMMENT ON FUNCTION proteins_record_revision() IS 'Records a protein revision numbered max + 1 per protein; relies on the row lock of the write (or the primary key for inserts) to serialize concurrent writes of the same protein under READ COMMITTED.';
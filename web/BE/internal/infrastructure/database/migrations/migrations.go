@@ -0,0 +1,22 @@
+// Package migrations holds the versioned database schema as embedded SQL files. Each
+// version is a <timestamp>_<name>.tx.up.sql / .tx.down.sql pair run in a transaction;
+// statements are separated by --bun:split lines.
+package migrations
+
+import (
+	"embed"
+
+	"github.com/uptrace/bun/migrate"
+)
+
+//go:embed *.sql
+var sqlMigrations embed.FS
+
+// Migrations is the ordered set of schema versions.
+var Migrations = migrate.NewMigrations()
+
+func init() {
+	if err := Migrations.Discover(sqlMigrations); err != nil {
+		panic(err)
+	}
+}
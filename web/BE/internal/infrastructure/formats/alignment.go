@@ -0,0 +1,159 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"io"
+	"strings"
+)
+
+var (
+	ErrInvalidAlignment           = errors.New("invalid alignment")
+	ErrUnsupportedAlignmentFormat = errors.New("unsupported alignment format")
+)
+
+const (
+	AlignmentFASTA     = "fasta"
+	AlignmentClustal   = "clustal"
+	AlignmentStockholm = "stockholm"
+)
+
+// ReadAlignment parses a multiple sequence alignment. An empty format is detected from
+// the first line. Rows are upper-cased and '.' gaps are normalised to '-'.
+func ReadAlignment(r io.Reader, format string) ([]entities.AlignedSequence, error) {
+	br := bufio.NewReader(r)
+	if format == "" {
+		var err error
+		if format, err = detectAlignmentFormat(br); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []entities.AlignedSequence
+	var err error
+	switch strings.ToLower(format) {
+	case AlignmentFASTA:
+		rows, err = readFASTAAlignment(br)
+	case AlignmentClustal:
+		rows, err = readBlockAlignment(br, "CLUSTAL", "")
+	case AlignmentStockholm:
+		rows, err = readBlockAlignment(br, "# STOCKHOLM", "//")
+	default:
+		return nil, ErrUnsupportedAlignmentFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].Sequence = strings.Map(func(r rune) rune {
+			if r == '.' || r == '~' {
+				return '-'
+			}
+			return r
+		}, strings.ToUpper(rows[i].Sequence))
+	}
+	return rows, nil
+}
+
+func detectAlignmentFormat(br *bufio.Reader) (string, error) {
+	for {
+		peek, err := br.Peek(1)
+		if err != nil {
+			return "", fmt.Errorf("%w: empty input", ErrInvalidAlignment)
+		}
+		if peek[0] != '\n' && peek[0] != '\r' && peek[0] != ' ' && peek[0] != '\t' {
+			break
+		}
+		br.ReadByte()
+	}
+
+	head, _ := br.Peek(16)
+	switch {
+	case head[0] == '>':
+		return AlignmentFASTA, nil
+	case strings.HasPrefix(string(head), "CLUSTAL"), strings.HasPrefix(string(head), "MUSCLE"):
+		return AlignmentClustal, nil
+	case strings.HasPrefix(string(head), "# STOCKHOLM"):
+		return AlignmentStockholm, nil
+	}
+	return "", fmt.Errorf("%w: cannot detect format", ErrInvalidAlignment)
+}
+
+func readFASTAAlignment(r io.Reader) ([]entities.AlignedSequence, error) {
+	reader := NewFASTAReader(r)
+	var rows []entities.AlignedSequence
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAlignment, err)
+		}
+		rows = append(rows, entities.AlignedSequence{ID: record.ID(), Sequence: record.Sequence})
+	}
+}
+
+// readBlockAlignment reads interleaved "name sequence" blocks, as used by Clustal and
+// Stockholm. Rows keep their first-seen order; Clustal residue counts and conservation
+// lines and Stockholm markup lines are ignored.
+func readBlockAlignment(r io.Reader, header, terminator string) ([]entities.AlignedSequence, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var order []string
+	sequences := make(map[string]*strings.Builder)
+	lineNo := 0
+	seenHeader := false
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if !seenHeader {
+			// MUSCLE writes Clustal files with its own header line.
+			if !strings.HasPrefix(trimmed, header) && !(header == "CLUSTAL" && strings.HasPrefix(trimmed, "MUSCLE")) {
+				return nil, fmt.Errorf("%w: line %d: expected %s header", ErrInvalidAlignment, lineNo, header)
+			}
+			seenHeader = true
+			continue
+		}
+		if terminator != "" && trimmed == terminator {
+			break
+		}
+		// Conservation lines start with whitespace; Stockholm markup starts with '#'.
+		if line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		fields := strings.Fields(trimmed)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%w: line %d: expected name and sequence", ErrInvalidAlignment, lineNo)
+		}
+		name := fields[0]
+		seq, ok := sequences[name]
+		if !ok {
+			seq = &strings.Builder{}
+			sequences[name] = seq
+			order = append(order, name)
+		}
+		seq.WriteString(fields[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !seenHeader {
+		return nil, fmt.Errorf("%w: missing %s header", ErrInvalidAlignment, header)
+	}
+
+	rows := make([]entities.AlignedSequence, len(order))
+	for i, name := range order {
+		rows[i] = entities.AlignedSequence{ID: name, Sequence: sequences[name].String()}
+	}
+	return rows, nil
+}
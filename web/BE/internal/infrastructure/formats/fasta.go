@@ -0,0 +1,186 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidFASTA = errors.New("invalid FASTA")
+
+// FASTARecord is one entry of a FASTA file. Header is the definition line without '>'
+// and Line is the line number of the header.
+type FASTARecord struct {
+	Header   string
+	Sequence string
+	Line     int
+}
+
+// ID is the first word of the header.
+func (r *FASTARecord) ID() string {
+	if i := strings.IndexAny(r.Header, " \t"); i >= 0 {
+		return r.Header[:i]
+	}
+	return r.Header
+}
+
+// FASTAReader streams records from a (multi-)FASTA file without loading it whole.
+type FASTAReader struct {
+	scanner     *bufio.Scanner
+	lineNo      int
+	pending     string
+	pendingLine int
+	done        bool
+}
+
+func NewFASTAReader(r io.Reader) *FASTAReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return &FASTAReader{scanner: scanner}
+}
+
+// Next returns the next record, or io.EOF once the input is exhausted.
+func (r *FASTAReader) Next() (*FASTARecord, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	if r.pendingLine == 0 {
+		// First record: skip blank and comment lines up to the first header.
+		for r.scanner.Scan() {
+			r.lineNo++
+			line := strings.TrimSpace(r.scanner.Text())
+			if line == "" || strings.HasPrefix(line, ";") {
+				continue
+			}
+			if !strings.HasPrefix(line, ">") {
+				return nil, fmt.Errorf("%w: line %d: expected '>' header", ErrInvalidFASTA, r.lineNo)
+			}
+			r.pending, r.pendingLine = line[1:], r.lineNo
+			break
+		}
+		if err := r.scanner.Err(); err != nil {
+			return nil, err
+		}
+		if r.pendingLine == 0 {
+			r.done = true
+			return nil, io.EOF
+		}
+	}
+
+	record := &FASTARecord{Header: strings.TrimSpace(r.pending), Line: r.pendingLine}
+	var seq strings.Builder
+	r.done = true
+	for r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, ">") {
+			r.pending, r.pendingLine = line[1:], r.lineNo
+			r.done = false
+			break
+		}
+		for _, field := range strings.Fields(line) {
+			seq.WriteString(strings.TrimRight(field, "*"))
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	record.Sequence = seq.String()
+	return record, nil
+}
+
+// UniProtHeader holds the fields of a UniProtKB FASTA definition line:
+// >db|Accession|EntryName ProteinName OS=Organism OX=TaxID GN=Gene PE=n SV=n
+type UniProtHeader struct {
+	Database    string
+	Accession   string
+	EntryName   string
+	ProteinName string
+	Organism    string
+	TaxonID     string
+	Gene        string
+}
+
+var uniProtTags = []string{"OS", "OX", "GN", "PE", "SV"}
+
+// ParseUniProtHeader splits a UniProt-style header. Headers that do not follow the
+// db|accession|entry layout yield the first word as both accession and entry name and
+// the remainder as the protein name.
+func ParseUniProtHeader(header string) UniProtHeader {
+	var h UniProtHeader
+	id, rest := header, ""
+	if i := strings.IndexAny(header, " \t"); i >= 0 {
+		id, rest = header[:i], strings.TrimSpace(header[i+1:])
+	}
+
+	parts := strings.Split(id, "|")
+	if len(parts) >= 3 {
+		h.Database, h.Accession, h.EntryName = parts[0], parts[1], parts[2]
+	} else {
+		h.Accession, h.EntryName = id, id
+	}
+
+	// Tags appear as " XX=" in a fixed order; values may contain spaces.
+	type tagPos struct {
+		tag   string
+		start int
+	}
+	var positions []tagPos
+	for _, tag := range uniProtTags {
+		if i := strings.Index(" "+rest, " "+tag+"="); i >= 0 {
+			positions = append(positions, tagPos{tag, i})
+		}
+	}
+	nameEnd := len(rest)
+	for _, pos := range positions {
+		if pos.start < nameEnd {
+			nameEnd = pos.start
+		}
+	}
+	h.ProteinName = strings.TrimSpace(rest[:nameEnd])
+
+	for _, pos := range positions {
+		valueStart := pos.start + len(pos.tag) + 1
+		valueEnd := len(rest)
+		for _, other := range positions {
+			if other.start > pos.start && other.start < valueEnd {
+				valueEnd = other.start
+			}
+		}
+		value := strings.TrimSpace(rest[valueStart:valueEnd])
+		switch pos.tag {
+		case "OS":
+			h.Organism = value
+		case "OX":
+			h.TaxonID = value
+		case "GN":
+			h.Gene = value
+		}
+	}
+	return h
+}
+
+// WriteFASTARecord writes one record, wrapping the sequence at lineWidth residues
+// (no wrapping when lineWidth <= 0).
+func WriteFASTARecord(w io.Writer, header, sequence string, lineWidth int) error {
+	if _, err := fmt.Fprintf(w, ">%s\n", header); err != nil {
+		return err
+	}
+	if lineWidth <= 0 {
+		lineWidth = len(sequence)
+	}
+	for start := 0; start < len(sequence); start += lineWidth {
+		end := min(start+lineWidth, len(sequence))
+		if _, err := fmt.Fprintf(w, "%s\n", sequence[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFASTAReader(t *testing.T) {
+	reader := NewFASTAReader(strings.NewReader(readFixture(t, "three_records.fasta")))
+	want := []FASTARecord{
+		{Header: "sp|P04637|P53_HUMAN Cellular tumor antigen p53 OS=Homo sapiens OX=9606 GN=TP53 PE=1 SV=4", Sequence: "MEEPQSDPSVEPPLSQETFSDLWKLLPENN", Line: 3},
+		{Header: "sp|P38398|BRCA1_HUMAN Breast cancer type 1 susceptibility protein OS=Homo sapiens OX=9606 GN=BRCA1 PE=1 SV=2", Sequence: "MDLSALRVEEVQNVINAMQK", Line: 7},
+		{Header: "local_1", Sequence: "", Line: 11},
+	}
+	for i, w := range want {
+		record, err := reader.Next()
+		if err != nil {
+			t.Fatalf("record %d: %v", i+1, err)
+		}
+		if *record != w {
+			t.Errorf("record %d: %+v, want %+v", i+1, *record, w)
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Fatalf("after the last record: %v, want io.EOF", err)
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Fatalf("reading on after io.EOF: %v, want io.EOF", err)
+	}
+}
+
+func TestFASTAReaderMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "empty", input: "", wantErr: io.EOF},
+		{name: "only comments", input: "; nothing here\n\n", wantErr: io.EOF},
+		{name: "sequence before any header", input: "; comment\nMKVL\n>P1\nMKVL\n", wantErr: ErrInvalidFASTA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFASTAReader(strings.NewReader(tt.input)).Next()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == ErrInvalidFASTA && !strings.Contains(err.Error(), "line 2") {
+				t.Errorf("error %v does not name line 2", err)
+			}
+		})
+	}
+}
+
+func TestParseUniProtHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		want   UniProtHeader
+	}{
+		{
+			header: "sp|P04637|P53_HUMAN Cellular tumor antigen p53 OS=Homo sapiens OX=9606 GN=TP53 PE=1 SV=4",
+			want:   UniProtHeader{Database: "sp", Accession: "P04637", EntryName: "P53_HUMAN", ProteinName: "Cellular tumor antigen p53", Organism: "Homo sapiens", TaxonID: "9606", Gene: "TP53"},
+		},
+		{
+			header: "tr|A0A024R161|A0A024R161_HUMAN Guanine nucleotide-binding protein OS=Homo sapiens OX=9606 PE=3 SV=1",
+			want:   UniProtHeader{Database: "tr", Accession: "A0A024R161", EntryName: "A0A024R161_HUMAN", ProteinName: "Guanine nucleotide-binding protein", Organism: "Homo sapiens", TaxonID: "9606"},
+		},
+		{
+			header: "local_1 hypothetical protein",
+			want:   UniProtHeader{Accession: "local_1", EntryName: "local_1", ProteinName: "hypothetical protein"},
+		},
+		{
+			header: "local_2",
+			want:   UniProtHeader{Accession: "local_2", EntryName: "local_2"},
+		},
+	}
+	for _, tt := range tests {
+		if got := ParseUniProtHeader(tt.header); got != tt.want {
+			t.Errorf("ParseUniProtHeader(%q) = %+v, want %+v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFASTARecord(t *testing.T) {
+	tests := []struct {
+		lineWidth int
+		want      string
+	}{
+		{lineWidth: 4, want: ">P1 kinase\nMKVL\nLEAG\nK\n"},
+		{lineWidth: 0, want: ">P1 kinase\nMKVLLEAGK\n"},
+	}
+	for _, tt := range tests {
+		var sb strings.Builder
+		if err := WriteFASTARecord(&sb, "P1 kinase", "MKVLLEAGK", tt.lineWidth); err != nil {
+			t.Fatal(err)
+		}
+		if sb.String() != tt.want {
+			t.Errorf("width %d: wrote %q, want %q", tt.lineWidth, sb.String(), tt.want)
+		}
+
+		record, err := NewFASTAReader(strings.NewReader(sb.String())).Next()
+		if err != nil || record.Sequence != "MKVLLEAGK" {
+			t.Errorf("width %d: read back %+v, %v", tt.lineWidth, record, err)
+		}
+	}
+}
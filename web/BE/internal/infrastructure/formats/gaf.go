@@ -0,0 +1,110 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidGAF = errors.New("invalid GAF line")
+
+// gafMinColumns is the column count of GAF 1.0; GAF 2.x adds annotation extensions and
+// the gene product form.
+const gafMinColumns = 15
+
+// GAFAnnotation is one line of a GO Annotation File (GAF 2.x). Pipe-separated columns
+// are split into their values.
+type GAFAnnotation struct {
+	DB           string
+	ObjectID     string
+	Symbol       string
+	Qualifiers   []string
+	GOID         string
+	References   []string
+	EvidenceCode string
+	WithFrom     []string
+	Aspect       string
+	ObjectName   string
+	Synonyms     []string
+	ObjectType   string
+	Taxa         []string
+	Date         string
+	AssignedBy   string
+	Line         int
+}
+
+// Negated reports the NOT qualifier.
+func (a *GAFAnnotation) Negated() bool {
+	for _, qualifier := range a.Qualifiers {
+		if qualifier == "NOT" {
+			return true
+		}
+	}
+	return false
+}
+
+// GAFReader streams the annotation lines of a GAF file; "!" comment lines, including
+// the !gaf-version header, are skipped.
+type GAFReader struct {
+	scanner *bufio.Scanner
+	lineNo  int
+}
+
+func NewGAFReader(r io.Reader) *GAFReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &GAFReader{scanner: scanner}
+}
+
+// Next returns the next annotation, or io.EOF at the end of the file. A malformed line
+// returns an error wrapping ErrInvalidGAF; the following call continues with the next
+// line.
+func (r *GAFReader) Next() (*GAFAnnotation, error) {
+	for r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimRight(r.scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < gafMinColumns {
+			return nil, fmt.Errorf("%w: line %d: expected at least %d tab-separated columns, got %d", ErrInvalidGAF, r.lineNo, gafMinColumns, len(fields))
+		}
+		annotation := &GAFAnnotation{
+			DB:           fields[0],
+			ObjectID:     fields[1],
+			Symbol:       fields[2],
+			Qualifiers:   splitGAFList(fields[3]),
+			GOID:         fields[4],
+			References:   splitGAFList(fields[5]),
+			EvidenceCode: fields[6],
+			WithFrom:     splitGAFList(fields[7]),
+			Aspect:       fields[8],
+			ObjectName:   fields[9],
+			Synonyms:     splitGAFList(fields[10]),
+			ObjectType:   fields[11],
+			Taxa:         splitGAFList(fields[12]),
+			Date:         fields[13],
+			AssignedBy:   fields[14],
+			Line:         r.lineNo,
+		}
+		if annotation.ObjectID == "" || !strings.HasPrefix(annotation.GOID, "GO:") || annotation.EvidenceCode == "" {
+			return nil, fmt.Errorf("%w: line %d: missing object ID, GO ID or evidence code", ErrInvalidGAF, r.lineNo)
+		}
+		return annotation, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func splitGAFList(field string) []string {
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, "|")
+}
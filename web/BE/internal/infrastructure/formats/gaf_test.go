@@ -0,0 +1,67 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGAFReader(t *testing.T) {
+	reader := NewGAFReader(strings.NewReader(readFixture(t, "two_annotations.gaf")))
+	var annotations []*GAFAnnotation
+	for {
+		annotation, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		annotations = append(annotations, annotation)
+	}
+	if len(annotations) != 2 {
+		t.Fatalf("read %d annotations, want 2", len(annotations))
+	}
+
+	enables, located := annotations[0], annotations[1]
+	if enables.ObjectID != "P12345" || enables.GOID != "GO:0004672" || enables.EvidenceCode != "IDA" || enables.Aspect != "F" || enables.Line != 3 {
+		t.Errorf("first annotation %+v", enables)
+	}
+	if !reflect.DeepEqual(enables.References, []string{"PMID:1", "GO_REF:0000002"}) || enables.WithFrom != nil {
+		t.Errorf("references %q and with/from %q", enables.References, enables.WithFrom)
+	}
+	if enables.Negated() {
+		t.Error("an enables annotation reads as negated")
+	}
+	if !located.Negated() || located.Line != 5 || !reflect.DeepEqual(located.Taxa, []string{"taxon:9606", "taxon:10090"}) {
+		t.Errorf("second annotation %+v, want a negated one over two taxa", located)
+	}
+}
+
+func TestGAFReaderMalformed(t *testing.T) {
+	valid := "UniProtKB\tP12345\tKIN1\tenables\tGO:0004672\tPMID:1\tIDA\t\tF\tProtein kinase 1\t\tprotein\ttaxon:9606\t20260101\tUniProt"
+	tests := []struct {
+		name    string
+		line    string
+		wantMsg string
+	}{
+		{name: "GAF 1.0 short", line: valid[:strings.LastIndex(valid, "\t")], wantMsg: "expected at least 15 tab-separated columns, got 14"},
+		{name: "no GO ID", line: strings.Replace(valid, "GO:0004672", "0004672", 1), wantMsg: "missing object ID, GO ID or evidence code"},
+		{name: "no evidence", line: strings.Replace(valid, "\tIDA\t", "\t\t", 1), wantMsg: "missing object ID, GO ID or evidence code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The reader reports the bad line and carries on with the next one.
+			reader := NewGAFReader(strings.NewReader("!gaf-version: 2.2\n" + tt.line + "\n" + valid + "\n"))
+			_, err := reader.Next()
+			if !errors.Is(err, ErrInvalidGAF) || !strings.Contains(err.Error(), "line 2: "+tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, ErrInvalidGAF, "line 2: "+tt.wantMsg)
+			}
+			if annotation, err := reader.Next(); err != nil || annotation.Line != 3 {
+				t.Errorf("next annotation %+v, %v; want line 3", annotation, err)
+			}
+		})
+	}
+}
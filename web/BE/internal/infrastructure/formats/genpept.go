@@ -0,0 +1,242 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidGenPept = errors.New("invalid GenPept record")
+
+// GenPeptRecord is one record of an NCBI GenPept (protein GenBank) flat file.
+type GenPeptRecord struct {
+	Locus      string
+	Accession  string
+	Version    string
+	Definition string
+	DBSource   string
+	Organism   string
+	Features   []GenPeptFeature
+	Sequence   string
+	Line       int
+}
+
+// GenPeptFeature is an entry of the FEATURES table. Start and End are the outermost
+// 1-based positions of the location; Qualifiers keeps every value of repeated keys
+// such as /db_xref.
+type GenPeptFeature struct {
+	Key        string
+	Start      int
+	End        int
+	Qualifiers map[string][]string
+}
+
+// Qualifier returns the first value of a qualifier, or "".
+func (f *GenPeptFeature) Qualifier(key string) string {
+	if values := f.Qualifiers[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Feature returns the first feature with the given key, or nil.
+func (r *GenPeptRecord) Feature(key string) *GenPeptFeature {
+	for i := range r.Features {
+		if r.Features[i].Key == key {
+			return &r.Features[i]
+		}
+	}
+	return nil
+}
+
+// TaxonID is read from the /db_xref="taxon:NNN" qualifier of the source feature.
+func (r *GenPeptRecord) TaxonID() string {
+	if source := r.Feature("source"); source != nil {
+		for _, ref := range source.Qualifiers["db_xref"] {
+			if id, ok := strings.CutPrefix(ref, "taxon:"); ok {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// GenPeptReader streams records from a GenPept file; records end with "//".
+type GenPeptReader struct {
+	scanner *bufio.Scanner
+	lineNo  int
+}
+
+func NewGenPeptReader(r io.Reader) *GenPeptReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &GenPeptReader{scanner: scanner}
+}
+
+// Next returns the next record, or io.EOF at the end of the file.
+func (r *GenPeptReader) Next() (*GenPeptRecord, error) {
+	record := &GenPeptRecord{}
+	started := false
+	section := ""
+
+	var definition, sequence strings.Builder
+	var feature *GenPeptFeature
+	var qualifier strings.Builder
+
+	flushQualifier := func() {
+		if feature != nil && qualifier.Len() > 0 {
+			key, value, _ := strings.Cut(strings.TrimPrefix(qualifier.String(), "/"), "=")
+			feature.Qualifiers[key] = append(feature.Qualifiers[key], strings.Trim(value, "\""))
+		}
+		qualifier.Reset()
+	}
+	flushFeature := func() {
+		flushQualifier()
+		if feature != nil {
+			record.Features = append(record.Features, *feature)
+		}
+		feature = nil
+	}
+
+	for r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimRight(r.scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if !started {
+			if !strings.HasPrefix(line, "LOCUS") {
+				return nil, fmt.Errorf("%w: line %d: expected LOCUS line", ErrInvalidGenPept, r.lineNo)
+			}
+			started = true
+			record.Line = r.lineNo
+		}
+
+		if line == "//" {
+			flushFeature()
+			record.Definition = strings.TrimSuffix(strings.TrimSpace(definition.String()), ".")
+			record.Sequence = strings.ToUpper(sequence.String())
+			if record.Accession == "" {
+				record.Accession = record.Locus
+			}
+			if record.Accession == "" {
+				return nil, fmt.Errorf("%w: record at line %d has no LOCUS name or ACCESSION", ErrInvalidGenPept, record.Line)
+			}
+			return record, nil
+		}
+
+		// Keywords start in column 1; continuation lines are indented.
+		if line[0] != ' ' {
+			keyword, value, _ := strings.Cut(line, " ")
+			value = strings.TrimSpace(value)
+			section = keyword
+			switch keyword {
+			case "LOCUS":
+				if fields := strings.Fields(value); len(fields) > 0 {
+					record.Locus = fields[0]
+				}
+			case "DEFINITION":
+				definition.WriteString(value)
+			case "ACCESSION":
+				if fields := strings.Fields(value); len(fields) > 0 {
+					record.Accession = fields[0]
+				}
+			case "VERSION":
+				if fields := strings.Fields(value); len(fields) > 0 {
+					record.Version = fields[0]
+				}
+			case "DBSOURCE":
+				record.DBSource = value
+			}
+			continue
+		}
+
+		switch section {
+		case "DEFINITION":
+			definition.WriteByte(' ')
+			definition.WriteString(strings.TrimSpace(line))
+		case "SOURCE":
+			// The first ORGANISM line is the scientific name; the lineage follows.
+			if text := strings.TrimSpace(line); strings.HasPrefix(text, "ORGANISM") {
+				record.Organism = strings.TrimSpace(strings.TrimPrefix(text, "ORGANISM"))
+			}
+		case "FEATURES":
+			parseGenPeptFeatureLine(line, &feature, &qualifier, flushFeature, flushQualifier)
+		case "ORIGIN":
+			for _, field := range strings.Fields(line) {
+				if _, err := strconv.Atoi(field); err != nil {
+					sequence.WriteString(field)
+				}
+			}
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if started {
+		return nil, fmt.Errorf("%w: record %s at line %d is not terminated by //", ErrInvalidGenPept, record.Locus, record.Line)
+	}
+	return nil, io.EOF
+}
+
+// parseGenPeptFeatureLine handles feature key lines (key in column 6, location in column
+// 22) and the qualifier lines below them, which may wrap.
+func parseGenPeptFeatureLine(line string, feature **GenPeptFeature, qualifier *strings.Builder, flushFeature, flushQualifier func()) {
+	if len(line) > 5 && line[5] != ' ' {
+		flushFeature()
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return
+		}
+		f := &GenPeptFeature{Key: fields[0], Qualifiers: make(map[string][]string)}
+		f.Start, f.End = parseGenPeptLocation(strings.Join(fields[1:], ""))
+		*feature = f
+		return
+	}
+
+	text := strings.TrimSpace(line)
+	if strings.HasPrefix(text, "/") {
+		flushQualifier()
+		qualifier.WriteString(text)
+		return
+	}
+	if *feature == nil {
+		return
+	}
+	if qualifier.Len() == 0 {
+		// Wrapped location of a join(...) or order(...)
+		start, end := parseGenPeptLocation(text)
+		if start > 0 && (*feature).Start == 0 {
+			(*feature).Start = start
+		}
+		if end > (*feature).End {
+			(*feature).End = end
+		}
+		return
+	}
+	qualifier.WriteByte(' ')
+	qualifier.WriteString(text)
+}
+
+var locationNumber = regexp.MustCompile(`\d+`)
+
+// parseGenPeptLocation returns the first and last position of locations such as
+// "100..288", "<1..>393", "5", "join(1..10,20..30)" or "complement(4..9)". Locations on
+// other sequences ("NM_000546.6:143..1324") yield zeros.
+func parseGenPeptLocation(location string) (int, int) {
+	if strings.Contains(location, ":") {
+		return 0, 0
+	}
+	numbers := locationNumber.FindAllString(location, -1)
+	if len(numbers) == 0 {
+		return 0, 0
+	}
+	start, _ := strconv.Atoi(numbers[0])
+	end, _ := strconv.Atoi(numbers[len(numbers)-1])
+	return start, end
+}
@@ -0,0 +1,108 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenPeptReader(t *testing.T) {
+	reader := NewGenPeptReader(strings.NewReader(readFixture(t, "two_records.gp")))
+	var records []*GenPeptRecord
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("read %d records, want 2", len(records))
+	}
+
+	p53 := records[0]
+	if p53.Locus != "NP_000537" || p53.Accession != "NP_000537" || p53.Version != "NP_000537.3" || p53.Line != 1 {
+		t.Errorf("record %s, accession %s, version %s at line %d", p53.Locus, p53.Accession, p53.Version, p53.Line)
+	}
+	if p53.Definition != "cellular tumor antigen p53 isoform a [Homo sapiens]" {
+		t.Errorf("definition %q", p53.Definition)
+	}
+	if p53.Organism != "Homo sapiens" || p53.TaxonID() != "9606" || p53.DBSource != "REFSEQ: accession NM_000546.6" {
+		t.Errorf("organism %q with taxon %q from %q", p53.Organism, p53.TaxonID(), p53.DBSource)
+	}
+	if p53.Sequence != "MEEPQSDPSVEPPLSQETFSDLWKLLPENN" {
+		t.Errorf("sequence %q", p53.Sequence)
+	}
+
+	if got := len(p53.Features); got != 4 {
+		t.Fatalf("%d features, want 4", got)
+	}
+	if source := p53.Feature("source"); !reflect.DeepEqual(source.Qualifiers["db_xref"], []string{"HGNC:11998", "taxon:9606"}) {
+		t.Errorf("source db_xrefs %q", source.Qualifiers["db_xref"])
+	}
+	if note := p53.Feature("Protein").Qualifier("note"); note != "antigen NY-CO-13; tumor suppressor p53; a long note that wraps" {
+		t.Errorf("wrapped note %q", note)
+	}
+	// A join wrapped over two lines spans from its first to its last position.
+	if region := p53.Feature("Region"); region.Start != 102 || region.End != 292 || region.Qualifier("region_name") != "P53" {
+		t.Errorf("region %d..%d named %q", region.Start, region.End, region.Qualifier("region_name"))
+	}
+	if cds := p53.Feature("CDS"); cds.Qualifier("gene") != "TP53" || cds.Qualifier("coded_by") != "NM_000546.6:143..1324" {
+		t.Errorf("CDS qualifiers %q", cds.Qualifiers)
+	}
+
+	// Without an ACCESSION line the locus name stands in.
+	hypothetical := records[1]
+	if hypothetical.Accession != "XP_1" || hypothetical.Definition != "hypothetical protein" || hypothetical.TaxonID() != "" || hypothetical.Sequence != "MKVL" {
+		t.Errorf("second record %+v", hypothetical)
+	}
+}
+
+func TestParseGenPeptLocation(t *testing.T) {
+	tests := []struct {
+		location   string
+		start, end int
+	}{
+		{"100..288", 100, 288},
+		{"<1..>393", 1, 393},
+		{"5", 5, 5},
+		{"join(1..10,20..30)", 1, 30},
+		{"complement(4..9)", 4, 9},
+		{"NM_000546.6:143..1324", 0, 0},
+	}
+	for _, tt := range tests {
+		if start, end := parseGenPeptLocation(tt.location); start != tt.start || end != tt.end {
+			t.Errorf("parseGenPeptLocation(%q) = %d, %d, want %d, %d", tt.location, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestGenPeptReaderMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantMsg string
+	}{
+		{name: "no LOCUS line", input: "DEFINITION  kinase.\n//\n", wantMsg: "line 1: expected LOCUS line"},
+		{name: "no name", input: "LOCUS\nORIGIN\n        1 mkvl\n//\n", wantMsg: "record at line 1 has no LOCUS name or ACCESSION"},
+		{name: "unterminated", input: "LOCUS       XP_1   4 aa\nORIGIN\n        1 mkvl\n", wantMsg: "record XP_1 at line 1 is not terminated"},
+		{name: "second record unterminated", input: "LOCUS       XP_1   4 aa\n//\n\nLOCUS       XP_2   4 aa\n", wantMsg: "record XP_2 at line 4 is not terminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewGenPeptReader(strings.NewReader(tt.input))
+			var err error
+			for err == nil {
+				_, err = reader.Next()
+			}
+			if !errors.Is(err, ErrInvalidGenPept) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, ErrInvalidGenPept, tt.wantMsg)
+			}
+		})
+	}
+}
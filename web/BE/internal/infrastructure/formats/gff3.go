@@ -0,0 +1,221 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidGFF3 = errors.New("invalid GFF3 line")
+
+// GFF3Feature is one feature line of a GFF3 file. Start and End are 1-based and
+// inclusive; Score is nil for ".". Attribute values are unescaped, and multi-valued
+// attributes (Dbxref=a,b) keep every value.
+type GFF3Feature struct {
+	SeqID      string
+	Source     string
+	Type       string
+	Start      int
+	End        int
+	Score      *float64
+	Strand     string
+	Phase      string
+	Attributes map[string][]string
+	Line       int
+}
+
+// Attribute returns the values of an attribute joined by commas, or "".
+func (f *GFF3Feature) Attribute(key string) string {
+	return strings.Join(f.Attributes[key], ",")
+}
+
+// GFF3Reader streams feature lines from a GFF3 file. Directives and comments are
+// skipped; reading stops at a ##FASTA section.
+type GFF3Reader struct {
+	scanner *bufio.Scanner
+	lineNo  int
+	done    bool
+}
+
+func NewGFF3Reader(r io.Reader) *GFF3Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &GFF3Reader{scanner: scanner}
+}
+
+// Next returns the next feature, or io.EOF at the end of the features. A malformed
+// line returns an error wrapping ErrInvalidGFF3; the following call continues with
+// the next line.
+func (r *GFF3Reader) Next() (*GFF3Feature, error) {
+	for !r.done && r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimRight(r.scanner.Text(), "\r")
+		if line == "##FASTA" || strings.HasPrefix(line, ">") {
+			r.done = true
+			break
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return parseGFF3Line(line, r.lineNo)
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func parseGFF3Line(line string, lineNo int) (*GFF3Feature, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 9 {
+		return nil, fmt.Errorf("%w: line %d: expected 9 tab-separated columns, got %d", ErrInvalidGFF3, lineNo, len(fields))
+	}
+
+	feature := &GFF3Feature{
+		SeqID:      gff3Unescape(fields[0]),
+		Source:     gff3Unescape(gff3Value(fields[1])),
+		Type:       gff3Unescape(fields[2]),
+		Strand:     gff3Value(fields[6]),
+		Phase:      gff3Value(fields[7]),
+		Attributes: make(map[string][]string),
+		Line:       lineNo,
+	}
+	if feature.SeqID == "" || feature.Type == "" || feature.Type == "." {
+		return nil, fmt.Errorf("%w: line %d: missing seqid or type", ErrInvalidGFF3, lineNo)
+	}
+
+	var err error
+	if feature.Start, err = strconv.Atoi(fields[3]); err != nil || feature.Start < 1 {
+		return nil, fmt.Errorf("%w: line %d: invalid start %q", ErrInvalidGFF3, lineNo, fields[3])
+	}
+	if feature.End, err = strconv.Atoi(fields[4]); err != nil || feature.End < feature.Start {
+		return nil, fmt.Errorf("%w: line %d: invalid end %q", ErrInvalidGFF3, lineNo, fields[4])
+	}
+	if score := gff3Value(fields[5]); score != "" {
+		value, err := strconv.ParseFloat(score, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid score %q", ErrInvalidGFF3, lineNo, fields[5])
+		}
+		feature.Score = &value
+	}
+
+	for _, attribute := range strings.Split(gff3Value(fields[8]), ";") {
+		if attribute = strings.TrimSpace(attribute); attribute == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(attribute, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: line %d: malformed attribute %q", ErrInvalidGFF3, lineNo, attribute)
+		}
+		key = gff3Unescape(key)
+		for _, v := range strings.Split(value, ",") {
+			feature.Attributes[key] = append(feature.Attributes[key], gff3Unescape(v))
+		}
+	}
+	return feature, nil
+}
+
+// gff3Value maps the "." placeholder of an empty column to "".
+func gff3Value(field string) string {
+	if field == "." {
+		return ""
+	}
+	return field
+}
+
+// gff3Unescape decodes %XX escapes; a malformed escape is kept literally.
+func gff3Unescape(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	if decoded, err := url.PathUnescape(s); err == nil {
+		return decoded
+	}
+	return s
+}
+
+// gff3Escape percent-encodes the characters GFF3 reserves in a column; attribute
+// values additionally escape the separators ;=&,.
+func gff3Escape(s string, attribute bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c < 0x20 || c == 0x7f || c == '%' || c == '\t':
+			fmt.Fprintf(&b, "%%%02X", c)
+		case attribute && (c == ';' || c == '=' || c == '&' || c == ','):
+			fmt.Fprintf(&b, "%%%02X", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// WriteGFF3Header writes the version directive that starts a GFF3 file.
+func WriteGFF3Header(w io.Writer) error {
+	_, err := io.WriteString(w, "##gff-version 3\n")
+	return err
+}
+
+// WriteGFF3SequenceRegion writes a ##sequence-region directive.
+func WriteGFF3SequenceRegion(w io.Writer, seqID string, start, end int) error {
+	_, err := fmt.Fprintf(w, "##sequence-region %s %d %d\n", gff3Escape(seqID, false), start, end)
+	return err
+}
+
+// WriteGFF3Feature writes one feature line. Attributes are written with ID, Name,
+// Parent and Note first, as GFF3 tools conventionally expect, then alphabetically.
+func WriteGFF3Feature(w io.Writer, f *GFF3Feature) error {
+	column := func(s string) string {
+		if s == "" {
+			return "."
+		}
+		return gff3Escape(s, false)
+	}
+	score := "."
+	if f.Score != nil {
+		score = strconv.FormatFloat(*f.Score, 'g', -1, 64)
+	}
+
+	keys := make([]string, 0, len(f.Attributes))
+	for key := range f.Attributes {
+		keys = append(keys, key)
+	}
+	rank := map[string]int{"ID": 1, "Name": 2, "Parent": 3, "Note": 4}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := rank[keys[i]], rank[keys[j]]
+		if ri == 0 {
+			ri = len(rank) + 1
+		}
+		if rj == 0 {
+			rj = len(rank) + 1
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	attributes := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := make([]string, len(f.Attributes[key]))
+		for i, value := range f.Attributes[key] {
+			values[i] = gff3Escape(value, true)
+		}
+		attributes = append(attributes, gff3Escape(key, true)+"="+strings.Join(values, ","))
+	}
+	attributeColumn := "."
+	if len(attributes) > 0 {
+		attributeColumn = strings.Join(attributes, ";")
+	}
+
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+		column(f.SeqID), column(f.Source), column(f.Type), f.Start, f.End, score,
+		column(f.Strand), column(f.Phase), attributeColumn)
+	return err
+}
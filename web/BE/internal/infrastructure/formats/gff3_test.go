@@ -0,0 +1,105 @@
+package formats
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readGFF3(t *testing.T, input string) []*GFF3Feature {
+	t.Helper()
+	reader := NewGFF3Reader(strings.NewReader(input))
+	var features []*GFF3Feature
+	for {
+		feature, err := reader.Next()
+		if err == io.EOF {
+			return features
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		features = append(features, feature)
+	}
+}
+
+func TestGFF3Reader(t *testing.T) {
+	features := readGFF3(t, readFixture(t, "three_features.gff3"))
+	if len(features) != 3 {
+		t.Fatalf("read %d features, want 3 before ##FASTA", len(features))
+	}
+
+	domain, site, region := features[0], features[1], features[2]
+	if domain.SeqID != "P12345" || domain.Source != "UniProtKB" || domain.Type != "Domain" || domain.Start != 10 || domain.End != 80 || domain.Line != 4 {
+		t.Errorf("first feature %+v", domain)
+	}
+	if domain.Score != nil || domain.Strand != "" || domain.Phase != "" {
+		t.Errorf("placeholders read as score %v, strand %q, phase %q", domain.Score, domain.Strand, domain.Phase)
+	}
+	if got := domain.Attribute("Note"); got != "Protein kinase; catalytic" {
+		t.Errorf("Note %q, want the unescaped value", got)
+	}
+	if got := domain.Attributes["Dbxref"]; !reflect.DeepEqual(got, []string{"PROSITE:PS50011", "Pfam:PF00069"}) {
+		t.Errorf("Dbxref %q, want both values", got)
+	}
+	if site.Type != "Binding site" || site.Score == nil || *site.Score != 0.5 || site.Strand != "+" || site.Phase != "0" || site.Attribute("Parent") != "dom1" || site.Line != 6 {
+		t.Errorf("second feature %+v", site)
+	}
+	if len(region.Attributes) != 0 {
+		t.Errorf("attributes %v of a . column, want none", region.Attributes)
+	}
+}
+
+func TestGFF3ReaderMalformed(t *testing.T) {
+	valid := "P12345\tUniProtKB\tDomain\t10\t80\t.\t.\t.\tID=dom1"
+	tests := []struct {
+		name    string
+		line    string
+		wantMsg string
+	}{
+		{name: "spaces for tabs", line: strings.ReplaceAll(valid, "\t", " "), wantMsg: "expected 9 tab-separated columns, got 1"},
+		{name: "no type", line: strings.Replace(valid, "Domain", ".", 1), wantMsg: "missing seqid or type"},
+		{name: "zero start", line: strings.Replace(valid, "\t10\t", "\t0\t", 1), wantMsg: `invalid start "0"`},
+		{name: "end before start", line: strings.Replace(valid, "\t80\t", "\t9\t", 1), wantMsg: `invalid end "9"`},
+		{name: "bad score", line: strings.Replace(valid, "80\t.", "80\thigh", 1), wantMsg: `invalid score "high"`},
+		{name: "attribute without value", line: strings.Replace(valid, "ID=dom1", "ID=dom1;kinase", 1), wantMsg: `malformed attribute "kinase"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The reader reports the bad line and carries on with the next one.
+			reader := NewGFF3Reader(strings.NewReader("##gff-version 3\n" + tt.line + "\n" + valid + "\n"))
+			_, err := reader.Next()
+			if !errors.Is(err, ErrInvalidGFF3) || !strings.Contains(err.Error(), "line 2: "+tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, ErrInvalidGFF3, "line 2: "+tt.wantMsg)
+			}
+			if feature, err := reader.Next(); err != nil || feature.Line != 3 {
+				t.Errorf("next feature %+v, %v; want line 3", feature, err)
+			}
+		})
+	}
+}
+
+func TestWriteGFF3Feature(t *testing.T) {
+	score := 0.5
+	feature := &GFF3Feature{
+		SeqID: "P12345", Source: "UniProtKB", Type: "Domain", Start: 10, End: 80, Score: &score,
+		Attributes: map[string][]string{
+			"Dbxref": {"Pfam:PF00069"}, "Note": {"a;b=c"}, "ID": {"dom1"}, "Alias": {"x", "y"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteGFF3Feature(&buf, feature); err != nil {
+		t.Fatal(err)
+	}
+	want := "P12345\tUniProtKB\tDomain\t10\t80\t0.5\t.\t.\tID=dom1;Note=a%3Bb%3Dc;Alias=x,y;Dbxref=Pfam:PF00069\n"
+	if buf.String() != want {
+		t.Errorf("wrote %q, want %q", buf.String(), want)
+	}
+
+	read := readGFF3(t, buf.String())
+	if len(read) != 1 || !reflect.DeepEqual(read[0].Attributes, feature.Attributes) {
+		t.Errorf("read back %+v, want the written attributes", read)
+	}
+}
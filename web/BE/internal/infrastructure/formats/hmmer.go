@@ -0,0 +1,251 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidHMMFile = errors.New("invalid HMMER3 model file")
+
+type hmmLineReader struct {
+	scanner *bufio.Scanner
+	lineNo  int
+}
+
+func (r *hmmLineReader) next() (string, bool) {
+	for r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimRight(r.scanner.Text(), "\r")
+		if strings.TrimSpace(line) != "" {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+func (r *hmmLineReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidHMMFile, r.lineNo, fmt.Sprintf(format, args...))
+}
+
+// ReadHMMER3 parses every model in a HMMER3 ASCII save file (hmmbuild / Pfam-A.hmm).
+// Only protein models are supported.
+func ReadHMMER3(r io.Reader) ([]*entities.ProfileHMM, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	reader := &hmmLineReader{scanner: scanner}
+
+	var models []*entities.ProfileHMM
+	for {
+		line, ok := reader.next()
+		if !ok {
+			break
+		}
+		if !strings.HasPrefix(line, "HMMER3") {
+			return nil, reader.errorf("expected HMMER3 format header, got %q", line)
+		}
+		model, err := readHMMModel(reader)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, model)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+func readHMMModel(reader *hmmLineReader) (*entities.ProfileHMM, error) {
+	model := &entities.ProfileHMM{}
+	hasViterbi, hasForward := false, false
+
+	// Header section, up to the HMM line
+	for {
+		line, ok := reader.next()
+		if !ok {
+			return nil, reader.errorf("unexpected end of file in model header")
+		}
+		fields := strings.Fields(line)
+		tag := fields[0]
+		rest := strings.TrimSpace(strings.TrimPrefix(line, tag))
+
+		switch tag {
+		case "NAME":
+			model.Name = rest
+		case "ACC":
+			model.Accession = rest
+		case "DESC":
+			model.Description = rest
+		case "LENG":
+			n, err := strconv.Atoi(rest)
+			if err != nil || n <= 0 {
+				return nil, reader.errorf("invalid LENG %q", rest)
+			}
+			model.Length = n
+		case "ALPH":
+			if !strings.EqualFold(rest, "amino") {
+				return nil, reader.errorf("unsupported alphabet %q, only amino models are supported", rest)
+			}
+		case "STATS":
+			if len(fields) != 5 || fields[1] != "LOCAL" {
+				continue
+			}
+			a, errA := strconv.ParseFloat(fields[3], 64)
+			b, errB := strconv.ParseFloat(fields[4], 64)
+			if errA != nil || errB != nil {
+				return nil, reader.errorf("invalid STATS line %q", line)
+			}
+			switch fields[2] {
+			case "VITERBI":
+				model.ViterbiMu, model.ViterbiLambda, hasViterbi = a, b, true
+			case "FORWARD":
+				model.ForwardTau, model.ForwardLambda, hasForward = a, b, true
+			}
+		case "HMM":
+			model.Alphabet = strings.Join(fields[1:], "")
+		}
+		if tag == "HMM" {
+			break
+		}
+	}
+
+	if model.Name == "" || model.Length == 0 {
+		return nil, reader.errorf("model is missing NAME or LENG")
+	}
+	if !hasViterbi || !hasForward {
+		return nil, reader.errorf("model %s is missing STATS LOCAL VITERBI/FORWARD calibration", model.Name)
+	}
+	size := len(model.Alphabet)
+	if size == 0 {
+		return nil, reader.errorf("model %s has no alphabet", model.Name)
+	}
+
+	// Transition header (m->m m->i ...)
+	if _, ok := reader.next(); !ok {
+		return nil, reader.errorf("unexpected end of file after HMM line")
+	}
+
+	// Optional COMPO line, then node 0 insert emissions and transitions
+	line, ok := reader.next()
+	if !ok {
+		return nil, reader.errorf("unexpected end of file in model %s", model.Name)
+	}
+	if strings.Fields(line)[0] == "COMPO" {
+		if line, ok = reader.next(); !ok {
+			return nil, reader.errorf("unexpected end of file in model %s", model.Name)
+		}
+	}
+	if _, err := parseHMMValues(strings.Fields(line), size); err != nil {
+		return nil, reader.errorf("node 0 insert emissions: %v", err)
+	}
+	t0, err := readHMMTransitions(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	model.MatchEmissions = make([][]float64, model.Length)
+	model.Transitions = make([][]float64, model.Length+1)
+	model.Transitions[0] = t0
+
+	for k := 1; k <= model.Length; k++ {
+		line, ok := reader.next()
+		if !ok {
+			return nil, reader.errorf("unexpected end of file at node %d of %s", k, model.Name)
+		}
+		fields := strings.Fields(line)
+		if node, err := strconv.Atoi(fields[0]); err != nil || node != k {
+			return nil, reader.errorf("expected node %d, got %q", k, fields[0])
+		}
+		if len(fields) < 1+size {
+			return nil, reader.errorf("node %d has %d match emissions, expected %d", k, len(fields)-1, size)
+		}
+		match, err := parseHMMValues(fields[1:1+size], size)
+		if err != nil {
+			return nil, reader.errorf("node %d match emissions: %v", k, err)
+		}
+		model.MatchEmissions[k-1] = match
+
+		if line, ok = reader.next(); !ok {
+			return nil, reader.errorf("unexpected end of file at node %d of %s", k, model.Name)
+		}
+		if _, err := parseHMMValues(strings.Fields(line), size); err != nil {
+			return nil, reader.errorf("node %d insert emissions: %v", k, err)
+		}
+
+		if model.Transitions[k], err = readHMMTransitions(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	if line, ok := reader.next(); !ok || strings.TrimSpace(line) != "//" {
+		return nil, reader.errorf("expected // at end of model %s", model.Name)
+	}
+	return model, nil
+}
+
+func readHMMTransitions(reader *hmmLineReader) ([]float64, error) {
+	line, ok := reader.next()
+	if !ok {
+		return nil, reader.errorf("unexpected end of file, expected transitions")
+	}
+	values, err := parseHMMValues(strings.Fields(line), 7)
+	if err != nil {
+		return nil, reader.errorf("transitions: %v", err)
+	}
+	return values, nil
+}
+
+// parseHMMValues converts HMMER's negative natural log probabilities ("*" for zero) to probabilities.
+func parseHMMValues(fields []string, n int) ([]float64, error) {
+	if len(fields) != n {
+		return nil, fmt.Errorf("expected %d values, got %d", n, len(fields))
+	}
+	values := make([]float64, n)
+	for i, f := range fields {
+		if f == "*" {
+			continue
+		}
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", f)
+		}
+		values[i] = math.Exp(-v)
+	}
+	return values, nil
+}
+
+// LoadHMMDirectory reads every *.hmm file in dir, in name order.
+func LoadHMMDirectory(dir string) ([]*entities.ProfileHMM, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.hmm"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var models []*entities.ProfileHMM
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		fileModels, err := ReadHMMER3(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		for _, model := range fileModels {
+			model.SourceFile = filepath.Base(path)
+		}
+		models = append(models, fileModels...)
+	}
+	return models, nil
+}
@@ -0,0 +1,92 @@
+package formats
+
+import (
+	"errors"
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestReadHMMER3(t *testing.T) {
+	models, err := ReadHMMER3(strings.NewReader(readFixture(t, "two_models.hmm")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("read %d models, want 2", len(models))
+	}
+
+	kinase, zf := models[0], models[1]
+	if kinase.Name != "Kinase_N" || kinase.Accession != "PF00001.1" || kinase.Description != "Kinase N-terminal domain" || kinase.Length != 2 {
+		t.Errorf("first model %s %s %q of length %d", kinase.Name, kinase.Accession, kinase.Description, kinase.Length)
+	}
+	if zf.Name != "Zf" || zf.Length != 1 {
+		t.Errorf("second model %s of length %d, want Zf of length 1", zf.Name, zf.Length)
+	}
+	if kinase.Alphabet != "ACDEFGHIKLMNPQRSTVWY" {
+		t.Errorf("alphabet %q", kinase.Alphabet)
+	}
+	if kinase.ViterbiMu != -10.1 || kinase.ForwardTau != -4.2 || kinase.ForwardLambda != 0.7 {
+		t.Errorf("calibration mu %v, tau %v, lambda %v", kinase.ViterbiMu, kinase.ForwardTau, kinase.ForwardLambda)
+	}
+	if len(kinase.MatchEmissions) != 2 || len(kinase.Transitions) != 3 {
+		t.Fatalf("%d match emissions and %d transitions, want 2 and 3", len(kinase.MatchEmissions), len(kinase.Transitions))
+	}
+	// Values are negative natural logs, and * is a probability of zero.
+	if p := kinase.MatchEmissions[0][0]; math.Abs(p-0.5) > 1e-4 {
+		t.Errorf("P(A) at node 1 is %v, want 0.5", p)
+	}
+	if p := kinase.Transitions[0][0]; math.Abs(p-math.Exp(-0.01)) > 1e-9 {
+		t.Errorf("node 0 m->m is %v, want %v", p, math.Exp(-0.01))
+	}
+	if p := kinase.Transitions[0][2]; p != 0 {
+		t.Errorf("node 0 m->d is %v, want 0 for *", p)
+	}
+}
+
+func TestLoadHMMDirectory(t *testing.T) {
+	models, err := LoadHMMDirectory("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 2 || models[0].SourceFile != "two_models.hmm" || models[1].SourceFile != "two_models.hmm" {
+		t.Fatalf("loaded %d models, want the 2 of two_models.hmm with their source file", len(models))
+	}
+}
+
+func TestReadHMMER3Malformed(t *testing.T) {
+	fixture := readFixture(t, "two_models.hmm")
+	tests := []struct {
+		name    string
+		input   string
+		wantMsg string
+	}{
+		{name: "no header", input: strings.TrimPrefix(fixture, "HMMER3/f [3.3.2 | Nov 2020]\n"), wantMsg: "line 1: expected HMMER3 format header"},
+		{name: "nucleotide model", input: strings.Replace(fixture, "ALPH  amino", "ALPH  DNA", 1), wantMsg: "line 6: unsupported alphabet"},
+		{name: "bad length", input: strings.Replace(fixture, "LENG  2", "LENG  two", 1), wantMsg: `line 5: invalid LENG "two"`},
+		{name: "uncalibrated", input: strings.Replace(fixture, "STATS LOCAL VITERBI", "STATS LOCAL OTHER", 1), wantMsg: "missing STATS LOCAL VITERBI/FORWARD"},
+		{name: "node out of order", input: strings.Replace(fixture, "      2   0.69315", "      3   0.69315", 1), wantMsg: `line 19: expected node 2, got "3"`},
+		{name: "bad emission", input: strings.Replace(fixture, "      1   0.69315", "      1   0.6x315", 1), wantMsg: `line 16: node 1 match emissions: invalid value "0.6x315"`},
+		{name: "short transitions", input: strings.Replace(fixture, "0.01000  4.60517  *", "0.01000  *", 1), wantMsg: "line 15: transitions: expected 7 values, got 6"},
+		{name: "no end of model", input: strings.Replace(fixture, "//\n", "", 1), wantMsg: "line 22: expected // at end of model Kinase_N"},
+		{name: "truncated", input: fixture[:strings.Index(fixture, "      2   0.69315")], wantMsg: "unexpected end of file at node 2 of Kinase_N"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadHMMER3(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrInvalidHMMFile) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, ErrInvalidHMMFile, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidOBO = errors.New("invalid OBO file")
+
+// OBOStanza is a [Term], [Typedef] or [Instance] stanza of an OBO 1.2/1.4 file. Tag
+// values have their trailing modifiers ({...}) and comments (! ...) removed; repeated
+// tags such as is_a keep every value in order.
+type OBOStanza struct {
+	Type string
+	Tags map[string][]string
+	Line int
+}
+
+// Value returns the first value of a tag, or "".
+func (s *OBOStanza) Value(tag string) string {
+	if values := s.Tags[tag]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// OBOReader streams the stanzas of an OBO file. Header holds the header tags
+// (format-version, data-version, default-namespace, ...) once Next has been called.
+type OBOReader struct {
+	Header  map[string][]string
+	scanner *bufio.Scanner
+	lineNo  int
+	pending *OBOStanza
+}
+
+func NewOBOReader(r io.Reader) *OBOReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &OBOReader{Header: make(map[string][]string), scanner: scanner}
+}
+
+// Next returns the next stanza, or io.EOF at the end of the file.
+func (r *OBOReader) Next() (*OBOStanza, error) {
+	current := r.pending
+	r.pending = nil
+	for r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			stanza := &OBOStanza{Type: line[1 : len(line)-1], Tags: make(map[string][]string), Line: r.lineNo}
+			if current != nil {
+				r.pending = stanza
+				return current, nil
+			}
+			current = stanza
+			continue
+		}
+
+		tag, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: expected tag: value", ErrInvalidOBO, r.lineNo)
+		}
+		tag, value = strings.TrimSpace(tag), cleanOBOValue(value)
+		if current == nil {
+			r.Header[tag] = append(r.Header[tag], value)
+		} else {
+			current.Tags[tag] = append(current.Tags[tag], value)
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return current, nil
+	}
+	return nil, io.EOF
+}
+
+// cleanOBOValue removes the trailing comment and modifiers outside quoted text.
+func cleanOBOValue(value string) string {
+	inQuotes, escaped := false, false
+	end, modifier := len(value), -1
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == '{' && modifier < 0:
+			modifier = i
+		case c == '!':
+			end = i
+			i = len(value)
+		}
+	}
+	value = value[:end]
+	if trimmed := strings.TrimSpace(value); modifier >= 0 && modifier < end && strings.HasSuffix(trimmed, "}") {
+		value = value[:modifier]
+	}
+	return strings.TrimSpace(value)
+}
+
+// OBOQuoted splits a value that starts with a quoted string, as in def and synonym
+// tags, into the unescaped text and the rest of the value.
+func OBOQuoted(value string) (string, string) {
+	if !strings.HasPrefix(value, "\"") {
+		return value, ""
+	}
+	var text strings.Builder
+	for i := 1; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\' && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				text.WriteByte(' ')
+			default:
+				text.WriteByte(value[i])
+			}
+		case c == '"':
+			return text.String(), strings.TrimSpace(value[i+1:])
+		default:
+			text.WriteByte(c)
+		}
+	}
+	return text.String(), ""
+}
@@ -0,0 +1,63 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOBOReader(t *testing.T) {
+	reader := NewOBOReader(strings.NewReader(readFixture(t, "three_stanzas.obo")))
+	var stanzas []*OBOStanza
+	for {
+		stanza, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		stanzas = append(stanzas, stanza)
+	}
+	if len(stanzas) != 3 {
+		t.Fatalf("read %d stanzas, want 3", len(stanzas))
+	}
+	if got := reader.Header["data-version"]; !reflect.DeepEqual(got, []string{"releases/2026-01-01"}) {
+		t.Errorf("header data-version %q", got)
+	}
+
+	kinase := stanzas[0]
+	if kinase.Type != "Term" || kinase.Value("id") != "GO:0004672" || kinase.Line != 6 {
+		t.Errorf("first stanza %s %s at line %d", kinase.Type, kinase.Value("id"), kinase.Line)
+	}
+	// Comments and trailing modifiers are dropped, in that order.
+	if got := kinase.Tags["is_a"]; !reflect.DeepEqual(got, []string{"GO:0016301", "GO:0140096"}) {
+		t.Errorf("is_a %q, want both parents without comments or modifiers", got)
+	}
+	text, rest := OBOQuoted(kinase.Value("def"))
+	if text != `Catalysis of the "phosphorylation" of a protein.` || rest != "[GOC:mah]" {
+		t.Errorf("def %q with %q", text, rest)
+	}
+	if text, rest := OBOQuoted(kinase.Value("synonym")); text != "protein phosphorylating enzyme" || rest != "RELATED []" {
+		t.Errorf("synonym %q with %q", text, rest)
+	}
+
+	if got := stanzas[1].Value("name"); got != "kinase activity" {
+		t.Errorf("name %q, want the text before the comment", got)
+	}
+	if typedef := stanzas[2]; typedef.Type != "Typedef" || typedef.Value("id") != "part_of" {
+		t.Errorf("last stanza %s %s, want the part_of typedef", typedef.Type, typedef.Value("id"))
+	}
+	if got := stanzas[2].Value("is_a"); got != "" {
+		t.Errorf("missing tag reads as %q, want empty", got)
+	}
+}
+
+func TestOBOReaderMalformed(t *testing.T) {
+	_, err := NewOBOReader(strings.NewReader("format-version: 1.2\n\n[Term]\nis_obsolete true\n")).Next()
+	if !errors.Is(err, ErrInvalidOBO) || !strings.Contains(err.Error(), "line 4: expected tag: value") {
+		t.Errorf("error %v, want %v at line 4", err, ErrInvalidOBO)
+	}
+}
@@ -0,0 +1,168 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidASCIIPSSM = errors.New("invalid PSI-BLAST ASCII PSSM")
+
+const psiBlastHeader = "Last position-specific scoring matrix computed, weighted observed percentages rounded down, information per position, and relative weight of gapless real matches to pseudocounts"
+
+// WritePSIBlastPSSM writes the profile in the layout produced by psiblast -out_ascii_pssm.
+// Scores are written in half-bit units, matching the BLOSUM62 scale.
+func WritePSIBlastPSSM(w io.Writer, pssm *entities.PSSM) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "\n%s\n", psiBlastHeader)
+	fmt.Fprint(bw, "         ")
+	for i := 0; i < len(pssm.Alphabet); i++ {
+		fmt.Fprintf(bw, "%3c", pssm.Alphabet[i])
+	}
+	for i := 0; i < len(pssm.Alphabet); i++ {
+		fmt.Fprintf(bw, "%4c", pssm.Alphabet[i])
+	}
+	fmt.Fprintln(bw)
+
+	for pos := 0; pos < pssm.Length; pos++ {
+		residue := byte('X')
+		if pos < len(pssm.Consensus) {
+			residue = pssm.Consensus[pos]
+		}
+		fmt.Fprintf(bw, "%5d %c   ", pos+1, residue)
+		for _, score := range pssm.Scores[pos] {
+			fmt.Fprintf(bw, "%3d", int(math.Round(score*2)))
+		}
+		for a := range pssm.Alphabet {
+			percent := 0
+			if pos < len(pssm.Frequencies) && a < len(pssm.Frequencies[pos]) {
+				percent = int(math.Floor(pssm.Frequencies[pos][a] * 100))
+			}
+			fmt.Fprintf(bw, "%4d", percent)
+		}
+		info := 0.0
+		if pos < len(pssm.Information) {
+			info = pssm.Information[pos]
+		}
+		fmt.Fprintf(bw, "  %.2f %.2f\n", info, pseudocountRatio(pssm))
+	}
+	fmt.Fprintln(bw)
+
+	return bw.Flush()
+}
+
+// ReadPSIBlastPSSM parses a psiblast -out_ascii_pssm file. The query residues of the
+// file become the profile consensus.
+func ReadPSIBlastPSSM(r io.Reader) (*entities.PSSM, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	pssm := &entities.PSSM{}
+	var consensus strings.Builder
+	lineNo := 0
+	inMatrix := false
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		fields := strings.Fields(line)
+
+		if !inMatrix {
+			if isAlphabetHeader(fields) {
+				pssm.Alphabet = strings.Join(fields[:len(fields)/2], "")
+				inMatrix = true
+			}
+			continue
+		}
+
+		if len(fields) == 0 {
+			if pssm.Length > 0 {
+				break
+			}
+			continue
+		}
+
+		size := len(pssm.Alphabet)
+		if len(fields) < 2+size {
+			return nil, fmt.Errorf("%w: line %d has %d columns, expected at least %d", ErrInvalidASCIIPSSM, lineNo, len(fields), 2+size)
+		}
+		if pos, err := strconv.Atoi(fields[0]); err != nil || pos != pssm.Length+1 {
+			return nil, fmt.Errorf("%w: line %d: expected position %d, got %q", ErrInvalidASCIIPSSM, lineNo, pssm.Length+1, fields[0])
+		}
+		consensus.WriteString(strings.ToUpper(fields[1][:1]))
+
+		scores := make([]float64, size)
+		for a := 0; a < size; a++ {
+			v, err := strconv.Atoi(fields[2+a])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid score %q", ErrInvalidASCIIPSSM, lineNo, fields[2+a])
+			}
+			scores[a] = float64(v) / 2
+		}
+		pssm.Scores = append(pssm.Scores, scores)
+
+		if len(fields) >= 2+2*size {
+			freqs := make([]float64, size)
+			for a := 0; a < size; a++ {
+				v, err := strconv.Atoi(fields[2+size+a])
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d: invalid percentage %q", ErrInvalidASCIIPSSM, lineNo, fields[2+size+a])
+				}
+				freqs[a] = float64(v) / 100
+			}
+			pssm.Frequencies = append(pssm.Frequencies, freqs)
+		}
+		if len(fields) >= 3+2*size {
+			if info, err := strconv.ParseFloat(fields[2+2*size], 64); err == nil {
+				pssm.Information = append(pssm.Information, info)
+			}
+		}
+		pssm.Length++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if pssm.Length == 0 {
+		return nil, fmt.Errorf("%w: no matrix rows found", ErrInvalidASCIIPSSM)
+	}
+	if len(pssm.Frequencies) != pssm.Length {
+		pssm.Frequencies = nil
+	}
+	if len(pssm.Information) != pssm.Length {
+		pssm.Information = nil
+	}
+	pssm.Consensus = consensus.String()
+	return pssm, nil
+}
+
+func isAlphabetHeader(fields []string) bool {
+	if len(fields) < 2 || len(fields)%2 != 0 {
+		return false
+	}
+	for _, f := range fields {
+		if len(f) != 1 || f[0] < 'A' || f[0] > 'Z' {
+			return false
+		}
+	}
+	half := len(fields) / 2
+	for i := 0; i < half; i++ {
+		if fields[i] != fields[half+i] {
+			return false
+		}
+	}
+	return true
+}
+
+func pseudocountRatio(pssm *entities.PSSM) float64 {
+	alpha := pssm.EffectiveSequences - 1
+	if alpha <= 0 || pssm.PseudocountWeight <= 0 {
+		return 0
+	}
+	return alpha / pssm.PseudocountWeight
+}
@@ -0,0 +1,462 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidStructure           = errors.New("invalid structure file")
+	ErrUnsupportedStructureFormat = errors.New("unsupported structure format")
+)
+
+const (
+	StructurePDB   = "pdb"
+	StructureMMCIF = "mmcif"
+)
+
+// StructureFormatFromName guesses the format from a file name (.pdb, .ent, .cif,
+// .mmcif); it returns "" when the extension is unknown.
+func StructureFormatFromName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".gz")
+	switch {
+	case strings.HasSuffix(name, ".pdb"), strings.HasSuffix(name, ".ent"):
+		return StructurePDB
+	case strings.HasSuffix(name, ".cif"), strings.HasSuffix(name, ".mmcif"):
+		return StructureMMCIF
+	}
+	return ""
+}
+
+// ReadStructure parses the first model of a PDB or mmCIF file. An empty format is
+// detected from the content: mmCIF files start with a data_ block.
+func ReadStructure(r io.Reader, format string) (*entities.Structure, error) {
+	br := bufio.NewReader(r)
+	if format == "" {
+		head, _ := br.Peek(512)
+		format = StructurePDB
+		for _, line := range strings.Split(string(head), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				if strings.HasPrefix(line, "data_") {
+					format = StructureMMCIF
+				}
+				break
+			}
+		}
+	}
+
+	var structure *entities.Structure
+	var err error
+	switch strings.ToLower(format) {
+	case StructurePDB:
+		structure, err = readPDB(br)
+	case StructureMMCIF, "cif":
+		structure, err = readMMCIF(br)
+		format = StructureMMCIF
+	default:
+		return nil, ErrUnsupportedStructureFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	structure.Format = strings.ToLower(format)
+
+	for _, chain := range structure.Chains {
+		if len(chain.AminoAcids()) > 0 {
+			return structure, nil
+		}
+	}
+	return nil, entities.ErrNoStructureChains
+}
+
+// chainBuilder collects residues of the first model in file order, per chain.
+type chainBuilder struct {
+	chains map[string]*entities.Chain
+	order  []string
+	seqres map[string][]string
+}
+
+func newChainBuilder() *chainBuilder {
+	return &chainBuilder{chains: make(map[string]*entities.Chain), seqres: make(map[string][]string)}
+}
+
+// addAtom appends an atom to its residue; altLoc other than "", "A" or "1" is dropped.
+func (b *chainBuilder) addAtom(chainID, resName string, resNum int, iCode, altLoc string, hetero bool, atom entities.Atom) {
+	if altLoc != "" && altLoc != "A" && altLoc != "1" {
+		return
+	}
+	chain, ok := b.chains[chainID]
+	if !ok {
+		chain = &entities.Chain{ID: chainID}
+		b.chains[chainID] = chain
+		b.order = append(b.order, chainID)
+	}
+	n := len(chain.Residues)
+	if n == 0 || chain.Residues[n-1].Number != resNum || chain.Residues[n-1].InsertionCode != iCode || chain.Residues[n-1].Name != resName {
+		chain.Residues = append(chain.Residues, entities.Residue{Name: resName, Number: resNum, InsertionCode: iCode, Hetero: hetero})
+		n++
+	}
+	chain.Residues[n-1].Atoms = append(chain.Residues[n-1].Atoms, atom)
+}
+
+func (b *chainBuilder) build(structure *entities.Structure) {
+	for _, id := range b.order {
+		chain := b.chains[id]
+		chain.SeqRes = b.seqres[id]
+		structure.Chains = append(structure.Chains, *chain)
+	}
+}
+
+// readPDB reads the fixed-column PDB format: HEADER, TITLE, EXPDTA, REMARK 2, SEQRES
+// and the ATOM/HETATM records up to the end of the first MODEL.
+func readPDB(r io.Reader) (*entities.Structure, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	structure := &entities.Structure{}
+	builder := newChainBuilder()
+	var title strings.Builder
+	lineNo := 0
+
+	field := func(line string, from, to int) string {
+		if from > len(line) {
+			return ""
+		}
+		return strings.TrimSpace(line[from-1 : min(to, len(line))])
+	}
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		record := field(line, 1, 6)
+		switch record {
+		case "HEADER":
+			structure.PDBID = field(line, 63, 66)
+		case "TITLE":
+			if title.Len() > 0 {
+				title.WriteByte(' ')
+			}
+			title.WriteString(field(line, 11, 80))
+		case "EXPDTA":
+			structure.Method = field(line, 11, 79)
+		case "REMARK":
+			if field(line, 8, 10) == "2" && strings.Contains(line, "RESOLUTION.") {
+				fields := strings.Fields(line[strings.Index(line, "RESOLUTION.")+len("RESOLUTION."):])
+				if len(fields) > 0 {
+					if resolution, err := strconv.ParseFloat(fields[0], 64); err == nil {
+						structure.Resolution = &resolution
+					}
+				}
+			}
+		case "SEQRES":
+			chainID := field(line, 12, 12)
+			builder.seqres[chainID] = append(builder.seqres[chainID], strings.Fields(field(line, 20, 80))...)
+		case "ATOM", "HETATM":
+			if len(line) < 54 {
+				return nil, fmt.Errorf("%w: line %d: truncated %s record", ErrInvalidStructure, lineNo, record)
+			}
+			resNum, err := strconv.Atoi(field(line, 23, 26))
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: bad residue number", ErrInvalidStructure, lineNo)
+			}
+			var coords [3]float64
+			for i, from := range []int{31, 39, 47} {
+				if coords[i], err = strconv.ParseFloat(field(line, from, from+7), 64); err != nil {
+					return nil, fmt.Errorf("%w: line %d: bad coordinate", ErrInvalidStructure, lineNo)
+				}
+			}
+			serial, _ := strconv.Atoi(field(line, 7, 11))
+			occupancy, _ := strconv.ParseFloat(field(line, 55, 60), 64)
+			bFactor, _ := strconv.ParseFloat(field(line, 61, 66), 64)
+			atom := entities.Atom{
+				Serial: serial, Name: field(line, 13, 16), Element: field(line, 77, 78),
+				X: coords[0], Y: coords[1], Z: coords[2], Occupancy: occupancy, BFactor: bFactor,
+			}
+			builder.addAtom(field(line, 22, 22), field(line, 18, 20), resNum, field(line, 27, 27), field(line, 17, 17), record == "HETATM", atom)
+		case "ENDMDL":
+			structure.Title = title.String()
+			builder.build(structure)
+			return structure, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	structure.Title = title.String()
+	builder.build(structure)
+	return structure, nil
+}
+
+// readMMCIF reads the categories of a PDBx/mmCIF file that map onto a Structure:
+// _entry, _struct, _exptl, _refine, _pdbx_poly_seq_scheme and _atom_site. Chains use
+// the author chain IDs (auth_asym_id) so they match the PDB format.
+func readMMCIF(r io.Reader) (*entities.Structure, error) {
+	categories, err := readCIFCategories(r, map[string]bool{
+		"_entry": true, "_struct": true, "_exptl": true, "_refine": true,
+		"_pdbx_poly_seq_scheme": true, "_atom_site": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	structure := &entities.Structure{
+		PDBID:  categories["_entry"].value(0, "id"),
+		Title:  categories["_struct"].value(0, "title"),
+		Method: categories["_exptl"].value(0, "method"),
+	}
+	if resolution, err := strconv.ParseFloat(categories["_refine"].value(0, "ls_d_res_high"), 64); err == nil {
+		structure.Resolution = &resolution
+	}
+
+	builder := newChainBuilder()
+	if scheme := categories["_pdbx_poly_seq_scheme"]; scheme != nil {
+		for row := range scheme.rows {
+			chainID := scheme.value(row, "pdb_strand_id")
+			builder.seqres[chainID] = append(builder.seqres[chainID], scheme.value(row, "mon_id"))
+		}
+	}
+
+	sites := categories["_atom_site"]
+	if sites == nil {
+		return nil, fmt.Errorf("%w: no _atom_site category", ErrInvalidStructure)
+	}
+	firstModel := ""
+	for row := range sites.rows {
+		if model := sites.value(row, "pdbx_PDB_model_num"); model != "" {
+			if firstModel == "" {
+				firstModel = model
+			} else if model != firstModel {
+				break
+			}
+		}
+
+		chainID := sites.value(row, "auth_asym_id")
+		if chainID == "" {
+			chainID = sites.value(row, "label_asym_id")
+		}
+		resName := sites.value(row, "auth_comp_id")
+		if resName == "" {
+			resName = sites.value(row, "label_comp_id")
+		}
+		atomName := sites.value(row, "auth_atom_id")
+		if atomName == "" {
+			atomName = sites.value(row, "label_atom_id")
+		}
+		seqID := sites.value(row, "auth_seq_id")
+		if seqID == "" {
+			seqID = sites.value(row, "label_seq_id")
+		}
+		resNum, err := strconv.Atoi(seqID)
+		if err != nil {
+			continue
+		}
+
+		var coords [3]float64
+		for i, column := range []string{"Cartn_x", "Cartn_y", "Cartn_z"} {
+			if coords[i], err = strconv.ParseFloat(sites.value(row, column), 64); err != nil {
+				return nil, fmt.Errorf("%w: atom_site row %d: bad coordinate", ErrInvalidStructure, row+1)
+			}
+		}
+		serial, _ := strconv.Atoi(sites.value(row, "id"))
+		occupancy, _ := strconv.ParseFloat(sites.value(row, "occupancy"), 64)
+		bFactor, _ := strconv.ParseFloat(sites.value(row, "B_iso_or_equiv"), 64)
+		atom := entities.Atom{
+			Serial: serial, Name: atomName, Element: sites.value(row, "type_symbol"),
+			X: coords[0], Y: coords[1], Z: coords[2], Occupancy: occupancy, BFactor: bFactor,
+		}
+		builder.addAtom(chainID, resName, resNum, sites.value(row, "pdbx_PDB_ins_code"), sites.value(row, "label_alt_id"),
+			sites.value(row, "group_PDB") == "HETATM", atom)
+	}
+
+	builder.build(structure)
+	return structure, nil
+}
+
+// cifCategory is a category of one data block, as a table: single key-value items
+// form one row.
+type cifCategory struct {
+	columns map[string]int
+	rows    [][]string
+}
+
+// value returns the item of a row, with the CIF null markers "." and "?" as "".
+func (c *cifCategory) value(row int, item string) string {
+	if c == nil || row >= len(c.rows) {
+		return ""
+	}
+	i, ok := c.columns[item]
+	if !ok || i >= len(c.rows[row]) {
+		return ""
+	}
+	if v := c.rows[row][i]; v != "." && v != "?" {
+		return v
+	}
+	return ""
+}
+
+// readCIFCategories tokenizes the first data block of a CIF file and keeps the
+// requested categories.
+func readCIFCategories(r io.Reader, wanted map[string]bool) (map[string]*cifCategory, error) {
+	tokens, err := tokenizeCIF(r)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string]*cifCategory)
+	category := func(name string) *cifCategory {
+		c, ok := categories[name]
+		if !ok {
+			c = &cifCategory{columns: make(map[string]int)}
+			categories[name] = c
+		}
+		return c
+	}
+	splitTag := func(tag string) (string, string) {
+		name, item, _ := strings.Cut(tag, ".")
+		return name, item
+	}
+
+	seenData := false
+	for i := 0; i < len(tokens); {
+		token := tokens[i]
+		switch {
+		case !token.quoted && strings.HasPrefix(token.text, "data_"):
+			if seenData {
+				return categories, nil
+			}
+			seenData = true
+			i++
+		case !token.quoted && token.text == "loop_":
+			i++
+			var names []string
+			for i < len(tokens) && !tokens[i].quoted && strings.HasPrefix(tokens[i].text, "_") {
+				names = append(names, tokens[i].text)
+				i++
+			}
+			if len(names) == 0 {
+				return nil, fmt.Errorf("%w: loop_ without items", ErrInvalidStructure)
+			}
+			name, _ := splitTag(names[0])
+			var c *cifCategory
+			if wanted[name] {
+				c = category(name)
+				for col, tag := range names {
+					_, item := splitTag(tag)
+					c.columns[item] = col
+				}
+			}
+			for i < len(tokens) && (tokens[i].quoted || !isCIFReserved(tokens[i].text)) {
+				row := make([]string, len(names))
+				for col := range names {
+					if i >= len(tokens) || (!tokens[i].quoted && isCIFReserved(tokens[i].text)) {
+						return nil, fmt.Errorf("%w: loop %s has an incomplete row", ErrInvalidStructure, name)
+					}
+					row[col] = tokens[i].text
+					i++
+				}
+				if c != nil {
+					c.rows = append(c.rows, row)
+				}
+			}
+		case !token.quoted && strings.HasPrefix(token.text, "_"):
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("%w: item %s has no value", ErrInvalidStructure, token.text)
+			}
+			name, item := splitTag(token.text)
+			if wanted[name] {
+				c := category(name)
+				if len(c.rows) == 0 {
+					c.rows = append(c.rows, nil)
+				}
+				c.columns[item] = len(c.rows[0])
+				c.rows[0] = append(c.rows[0], tokens[i+1].text)
+			}
+			i += 2
+		default:
+			i++
+		}
+	}
+	return categories, nil
+}
+
+func isCIFReserved(text string) bool {
+	return strings.HasPrefix(text, "_") || text == "loop_" || strings.HasPrefix(text, "data_") ||
+		strings.HasPrefix(text, "save_") || text == "stop_" || text == "global_"
+}
+
+type cifToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeCIF splits CIF into whitespace-separated values, quoted strings and
+// semicolon-delimited text fields, dropping comments.
+func tokenizeCIF(r io.Reader) ([]cifToken, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var tokens []cifToken
+	var text strings.Builder
+	inText := false
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if inText {
+			if strings.HasPrefix(line, ";") {
+				tokens = append(tokens, cifToken{text: strings.TrimSpace(text.String()), quoted: true})
+				text.Reset()
+				inText = false
+				line = line[1:]
+			} else {
+				text.WriteString(line)
+				text.WriteByte('\n')
+				continue
+			}
+		} else if strings.HasPrefix(line, ";") {
+			inText = true
+			text.WriteString(line[1:])
+			text.WriteByte('\n')
+			continue
+		}
+
+		for pos := 0; pos < len(line); {
+			c := line[pos]
+			switch {
+			case c == ' ' || c == '\t':
+				pos++
+			case c == '#':
+				pos = len(line)
+			case c == '\'' || c == '"':
+				// A quote only closes when followed by whitespace or the end of the line.
+				end := pos + 1
+				for end < len(line) && !(line[end] == c && (end+1 == len(line) || line[end+1] == ' ' || line[end+1] == '\t')) {
+					end++
+				}
+				if end >= len(line) {
+					return nil, fmt.Errorf("%w: line %d: unterminated quoted string", ErrInvalidStructure, lineNo)
+				}
+				tokens = append(tokens, cifToken{text: line[pos+1 : end], quoted: true})
+				pos = end + 1
+			default:
+				end := pos
+				for end < len(line) && line[end] != ' ' && line[end] != '\t' {
+					end++
+				}
+				tokens = append(tokens, cifToken{text: line[pos:end]})
+				pos = end
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inText {
+		return nil, fmt.Errorf("%w: unterminated text field", ErrInvalidStructure)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,131 @@
+package formats
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"strings"
+	"testing"
+)
+
+func TestStructureFormatFromName(t *testing.T) {
+	tests := map[string]string{
+		"1abc.pdb": StructurePDB, "pdb1abc.ent.gz": StructurePDB, "1ABC.CIF": StructureMMCIF,
+		"1abc.mmcif": StructureMMCIF, "1abc.txt": "",
+	}
+	for name, want := range tests {
+		if got := StructureFormatFromName(name); got != want {
+			t.Errorf("StructureFormatFromName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestReadStructurePDB(t *testing.T) {
+	structure, err := ReadStructure(strings.NewReader(readFixture(t, "two_models.pdb")), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if structure.Format != StructurePDB || structure.PDBID != "1ABC" || structure.Method != "X-RAY DIFFRACTION" {
+		t.Errorf("read %s %s by %q", structure.Format, structure.PDBID, structure.Method)
+	}
+	if structure.Title != "KINASE DOMAIN IN COMPLEX WITH WATER" {
+		t.Errorf("title %q, want the TITLE lines joined", structure.Title)
+	}
+	if structure.Resolution == nil || *structure.Resolution != 2.1 {
+		t.Errorf("resolution %v, want 2.1", structure.Resolution)
+	}
+
+	// Chain C is only in the second model.
+	if len(structure.Chains) != 2 || structure.Chains[0].ID != "A" || structure.Chains[1].ID != "B" {
+		t.Fatalf("read %d chains, want A and B of the first model", len(structure.Chains))
+	}
+	chain := structure.Chains[0]
+	if got := chain.SeqResSequence(); got != "MKVA" {
+		t.Errorf("SEQRES sequence %q, want MKVA", got)
+	}
+	if got := chain.AtomSequence(); got != "MKV" {
+		t.Errorf("atom sequence %q, want MKV", got)
+	}
+	if len(chain.Residues) != 4 {
+		t.Fatalf("chain A has %d residues, want 4 with the water", len(chain.Residues))
+	}
+	if lys := chain.Residues[1]; len(lys.Atoms) != 1 || lys.Atoms[0].X != 5.5 {
+		t.Errorf("LYS 2 atoms %+v, want only altLoc A", lys.Atoms)
+	}
+	if val := chain.Residues[2]; val.Label() != "2A" {
+		t.Errorf("VAL label %q, want the insertion code 2A", val.Label())
+	}
+	if water := chain.Residues[3]; !water.Hetero || water.Number != 101 || water.IsAminoAcid() {
+		t.Errorf("water %+v, want a hetero non-amino acid 101", water)
+	}
+	if ca := chain.Residues[0].CA(); ca == nil || ca.Serial != 2 || ca.Element != "C" || ca.BFactor != 20 {
+		t.Errorf("MET 1 CA %+v", ca)
+	}
+}
+
+func TestReadStructureMMCIF(t *testing.T) {
+	structure, err := ReadStructure(strings.NewReader(readFixture(t, "two_blocks.cif")), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if structure.Format != StructureMMCIF || structure.PDBID != "2XYZ" || structure.Method != "X-RAY DIFFRACTION" {
+		t.Errorf("read %s %s by %q", structure.Format, structure.PDBID, structure.Method)
+	}
+	if structure.Title != "Kinase domain\n with a water" {
+		t.Errorf("title %q, want the text field", structure.Title)
+	}
+	if structure.Resolution == nil || *structure.Resolution != 1.8 {
+		t.Errorf("resolution %v, want 1.8", structure.Resolution)
+	}
+
+	// Chains use the author IDs, and the second model is skipped.
+	if len(structure.Chains) != 1 || structure.Chains[0].ID != "X" {
+		t.Fatalf("read %d chains, want X", len(structure.Chains))
+	}
+	chain := structure.Chains[0]
+	if got := chain.SeqResSequence(); got != "MKV" {
+		t.Errorf("sequence scheme %q, want MKV", got)
+	}
+	if got := chain.AtomSequence(); got != "MK" {
+		t.Errorf("atom sequence %q, want MK", got)
+	}
+	if len(chain.Residues) != 3 {
+		t.Fatalf("chain X has %d residues, want 3 with the water", len(chain.Residues))
+	}
+	if lys := chain.Residues[1]; len(lys.Atoms) != 1 || lys.Atoms[0].Occupancy != 0.6 {
+		t.Errorf("LYS 2 atoms %+v, want only altLoc A", lys.Atoms)
+	}
+	if water := chain.Residues[2]; !water.Hetero || water.Number != 201 {
+		t.Errorf("water %+v, want hetero 201 by its author number", water)
+	}
+}
+
+func TestReadStructureMalformed(t *testing.T) {
+	pdb, cif := readFixture(t, "two_models.pdb"), readFixture(t, "two_blocks.cif")
+	water := "HETATM    6  O   HOH A 101       0.000   0.000   0.000  1.00 20.00           O\n"
+	tests := []struct {
+		name    string
+		input   string
+		format  string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "unsupported format", input: pdb, format: "xyz", wantErr: ErrUnsupportedStructureFormat},
+		{name: "no amino acids", input: water, wantErr: entities.ErrNoStructureChains},
+		{name: "truncated atom", input: strings.Replace(pdb, "   3.000  1.00 20.00           N", "", 1), wantErr: ErrInvalidStructure, wantMsg: "line 10: truncated ATOM record"},
+		{name: "bad residue number", input: strings.Replace(pdb, "MET A   1", "MET A   x", 1), wantErr: ErrInvalidStructure, wantMsg: "line 10: bad residue number"},
+		{name: "bad PDB coordinate", input: strings.Replace(pdb, "  -2.000", "  -2.0x0", 1), wantErr: ErrInvalidStructure, wantMsg: "line 16: bad coordinate"},
+		{name: "no atom sites", input: cif[:strings.Index(cif, "loop_\n_atom_site")], format: StructureMMCIF, wantErr: ErrInvalidStructure, wantMsg: "no _atom_site category"},
+		{name: "bad CIF coordinate", input: strings.Replace(cif, "5.5 2.0", "5.5 two", 1), wantErr: ErrInvalidStructure, wantMsg: "atom_site row 3: bad coordinate"},
+		{name: "incomplete loop row", input: strings.Replace(cif, "A 3 VAL X\n", "A 3 VAL\n", 1), wantErr: ErrInvalidStructure, wantMsg: "loop _pdbx_poly_seq_scheme has an incomplete row"},
+		{name: "unterminated quote", input: strings.Replace(cif, "'X-RAY DIFFRACTION'", "'X-RAY DIFFRACTION", 1), wantErr: ErrInvalidStructure, wantMsg: "line 8: unterminated quoted string"},
+		{name: "unterminated text field", input: cif[:strings.Index(cif, ";\n_exptl")], wantErr: ErrInvalidStructure, wantMsg: "unterminated text field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadStructure(strings.NewReader(tt.input), tt.format)
+			if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, tt.wantErr, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidTaxdump = errors.New("invalid taxdump line")
+
+// TaxNode is one line of the NCBI taxdump nodes.dmp.
+type TaxNode struct {
+	TaxID       int
+	ParentID    int
+	Rank        string
+	DivisionID  int
+	GeneticCode int
+	Line        int
+}
+
+// TaxName is one line of the NCBI taxdump names.dmp.
+type TaxName struct {
+	TaxID      int
+	Name       string
+	UniqueName string
+	NameClass  string
+	Line       int
+}
+
+// taxdumpReader splits the "\t|\t"-separated, "\t|"-terminated lines of a .dmp file.
+type taxdumpReader struct {
+	scanner *bufio.Scanner
+	lineNo  int
+}
+
+func newTaxdumpReader(r io.Reader) taxdumpReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return taxdumpReader{scanner: scanner}
+}
+
+func (r *taxdumpReader) next(minFields int) ([]string, error) {
+	for r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimRight(r.scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "|"), "\t")
+		fields := strings.Split(line, "\t|\t")
+		if len(fields) < minFields {
+			return nil, fmt.Errorf("%w: line %d: expected at least %d fields, got %d", ErrInvalidTaxdump, r.lineNo, minFields, len(fields))
+		}
+		return fields, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// TaxNodeReader streams nodes.dmp.
+type TaxNodeReader struct {
+	taxdumpReader
+}
+
+func NewTaxNodeReader(r io.Reader) *TaxNodeReader {
+	return &TaxNodeReader{newTaxdumpReader(r)}
+}
+
+// Next returns the next node, or io.EOF at the end of the file. A malformed line
+// returns an error wrapping ErrInvalidTaxdump.
+func (r *TaxNodeReader) Next() (*TaxNode, error) {
+	fields, err := r.next(7)
+	if err != nil {
+		return nil, err
+	}
+	node := &TaxNode{Rank: strings.TrimSpace(fields[2]), Line: r.lineNo}
+	for _, field := range []struct {
+		value  string
+		target *int
+	}{
+		{fields[0], &node.TaxID},
+		{fields[1], &node.ParentID},
+		{fields[4], &node.DivisionID},
+		{fields[6], &node.GeneticCode},
+	} {
+		if *field.target, err = strconv.Atoi(strings.TrimSpace(field.value)); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidTaxdump, r.lineNo, err)
+		}
+	}
+	return node, nil
+}
+
+// TaxNameReader streams names.dmp.
+type TaxNameReader struct {
+	taxdumpReader
+}
+
+func NewTaxNameReader(r io.Reader) *TaxNameReader {
+	return &TaxNameReader{newTaxdumpReader(r)}
+}
+
+// Next returns the next name, or io.EOF at the end of the file. A malformed line
+// returns an error wrapping ErrInvalidTaxdump.
+func (r *TaxNameReader) Next() (*TaxName, error) {
+	fields, err := r.next(4)
+	if err != nil {
+		return nil, err
+	}
+	taxID, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidTaxdump, r.lineNo, err)
+	}
+	return &TaxName{
+		TaxID:      taxID,
+		Name:       strings.TrimSpace(fields[1]),
+		UniqueName: strings.TrimSpace(fields[2]),
+		NameClass:  strings.TrimSpace(fields[3]),
+		Line:       r.lineNo,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTaxNodeReader(t *testing.T) {
+	reader := NewTaxNodeReader(strings.NewReader(readFixture(t, "nodes.dmp")))
+	var nodes []*TaxNode
+	for {
+		node, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("read %d nodes, want 3", len(nodes))
+	}
+	if root := nodes[0]; root.TaxID != 1 || root.ParentID != 1 || root.Rank != "no rank" || root.DivisionID != 8 {
+		t.Errorf("root node %+v", root)
+	}
+	if human := nodes[1]; human.TaxID != 9606 || human.ParentID != 9605 || human.Rank != "species" || human.GeneticCode != 1 {
+		t.Errorf("human node %+v", human)
+	}
+	if mouse := nodes[2]; mouse.TaxID != 10090 || mouse.Line != 4 {
+		t.Errorf("mouse node %+v, want taxon 10090 at line 4 after the blank line", mouse)
+	}
+}
+
+func TestTaxNameReader(t *testing.T) {
+	reader := NewTaxNameReader(strings.NewReader(readFixture(t, "names.dmp")))
+	var names []*TaxName
+	for {
+		name, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	if len(names) != 3 {
+		t.Fatalf("read %d names, want 3", len(names))
+	}
+	// The fixture has CRLF line ends, which must not stick to the name class.
+	if got := names[0]; got.TaxID != 9606 || got.Name != "Homo sapiens" || got.UniqueName != "" || got.NameClass != "scientific name" {
+		t.Errorf("first name %+v", got)
+	}
+	if got := names[1]; got.Name != "human" || got.UniqueName != "human <Homo sapiens>" || got.NameClass != "genbank common name" {
+		t.Errorf("second name %+v", got)
+	}
+	if got := names[2]; got.TaxID != 10090 || got.Line != 3 {
+		t.Errorf("third name %+v", got)
+	}
+}
+
+func TestTaxdumpMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		next    func(io.Reader) error
+		input   string
+		wantMsg string
+	}{
+		{
+			name:    "node with too few fields",
+			next:    func(r io.Reader) error { _, err := NewTaxNodeReader(r).Next(); return err },
+			input:   "9606\t|\t9605\t|\tspecies\t|",
+			wantMsg: "line 1: expected at least 7 fields, got 3",
+		},
+		{
+			name:    "node with a bad parent",
+			next:    func(r io.Reader) error { _, err := NewTaxNodeReader(r).Next(); return err },
+			input:   "9606\t|\thominid\t|\tspecies\t|\tHS\t|\t5\t|\t1\t|\t1\t|",
+			wantMsg: `line 1: strconv.Atoi: parsing "hominid"`,
+		},
+		{
+			name:    "name with too few fields",
+			next:    func(r io.Reader) error { _, err := NewTaxNameReader(r).Next(); return err },
+			input:   "9606 | Homo sapiens | | scientific name |",
+			wantMsg: "line 1: expected at least 4 fields, got 1",
+		},
+		{
+			name:    "name with a bad taxon",
+			next:    func(r io.Reader) error { _, err := NewTaxNameReader(r).Next(); return err },
+			input:   "\n\nHS\t|\tHomo sapiens\t|\t\t|\tscientific name\t|",
+			wantMsg: `line 3: strconv.Atoi: parsing "HS"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.next(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrInvalidTaxdump) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, ErrInvalidTaxdump, tt.wantMsg)
+			}
+		})
+	}
+}
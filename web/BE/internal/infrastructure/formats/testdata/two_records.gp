@@ -0,0 +1,36 @@
+LOCUS       NP_000537                393 aa            linear   PRI 27-JAN-2024
+DEFINITION  cellular tumor antigen p53 isoform a [Homo
+            sapiens].
+ACCESSION   NP_000537 XP_001172077
+VERSION     NP_000537.3
+DBSOURCE    REFSEQ: accession NM_000546.6
+SOURCE      Homo sapiens (human)
+  ORGANISM  Homo sapiens
+            Eukaryota; Metazoa; Chordata; Craniata; Vertebrata; Euteleostomi;
+            Mammalia; Eutheria; Euarchontoglires; Primates; Haplorrhini.
+FEATURES             Location/Qualifiers
+     source          1..393
+                     /organism="Homo sapiens"
+                     /db_xref="HGNC:11998"
+                     /db_xref="taxon:9606"
+     Protein         1..393
+                     /product="cellular tumor antigen p53 isoform a"
+                     /note="antigen NY-CO-13; tumor suppressor p53; a long note
+                     that wraps"
+     Region          join(102..200,
+                     210..292)
+                     /region_name="P53"
+     CDS             1..393
+                     /gene="TP53"
+                     /coded_by="NM_000546.6:143..1324"
+ORIGIN      
+        1 meepqsdpsv epplsqetfs
+       21 dlwkllpenn
+//
+LOCUS       XP_1                       4 aa            linear   ROD 01-JAN-2024
+DEFINITION  hypothetical protein.
+SOURCE      Mus musculus (house mouse)
+  ORGANISM  Mus musculus
+ORIGIN
+        1 mkvl
+//
@@ -0,0 +1,99 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidTSV = errors.New("invalid TSV file")
+
+// TSVRecord is a data line of a tab-separated file with a header, keyed by the
+// normalised column names (see NormalizeTSVColumn).
+type TSVRecord struct {
+	Fields map[string]string
+	Line   int
+}
+
+// Get returns the first non-empty value among the given columns, so one reader serves
+// files that name the same column differently.
+func (r *TSVRecord) Get(columns ...string) string {
+	for _, column := range columns {
+		if value := strings.TrimSpace(r.Fields[NormalizeTSVColumn(column)]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// NormalizeTSVColumn lowercases a column name and drops spaces, underscores and
+// hyphens: "Disease Name", "disease_name" and "diseaseName" are the same column.
+func NormalizeTSVColumn(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-', '#':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// TSVReader streams a tab-separated file. The header is the first line, or, in files
+// such as OMIM's that comment out their header, the last "#" line with tabs before
+// the data; other "#" lines are skipped.
+type TSVReader struct {
+	Header  []string
+	scanner *bufio.Scanner
+	lineNo  int
+}
+
+func NewTSVReader(r io.Reader) *TSVReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &TSVReader{scanner: scanner}
+}
+
+// Next returns the next data line, or io.EOF at the end of the file. A file without a
+// header returns an error wrapping ErrInvalidTSV.
+func (r *TSVReader) Next() (*TSVRecord, error) {
+	var commented []string
+	for r.scanner.Scan() {
+		r.lineNo++
+		line := strings.TrimRight(r.scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if r.Header == nil && strings.Contains(line, "\t") {
+				commented = strings.Split(line, "\t")
+			}
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if r.Header == nil {
+			if commented == nil {
+				r.Header = fields
+				continue
+			}
+			r.Header = commented
+		}
+
+		record := &TSVRecord{Fields: make(map[string]string, len(r.Header)), Line: r.lineNo}
+		for i, column := range r.Header {
+			if i < len(fields) {
+				record.Fields[NormalizeTSVColumn(column)] = fields[i]
+			}
+		}
+		return record, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if r.Header == nil {
+		return nil, fmt.Errorf("%w: no header line", ErrInvalidTSV)
+	}
+	return nil, io.EOF
+}
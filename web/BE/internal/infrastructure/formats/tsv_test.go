@@ -0,0 +1,82 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func readTSV(t *testing.T, input string) (*TSVReader, []*TSVRecord) {
+	t.Helper()
+	reader := NewTSVReader(strings.NewReader(input))
+	var records []*TSVRecord
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			return reader, records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+}
+
+func TestNormalizeTSVColumn(t *testing.T) {
+	for _, name := range []string{"Disease Name", "disease_name", "diseaseName", " DISEASE-NAME ", "# disease name"} {
+		if got := NormalizeTSVColumn(name); got != "diseasename" {
+			t.Errorf("NormalizeTSVColumn(%q) = %q, want diseasename", name, got)
+		}
+	}
+}
+
+func TestTSVReader(t *testing.T) {
+	reader, records := readTSV(t, readFixture(t, "three_diseases.tsv"))
+	if len(reader.Header) != 4 || reader.Header[0] != "Disease Name" {
+		t.Errorf("header %q, want the first line", reader.Header)
+	}
+	if len(records) != 3 {
+		t.Fatalf("read %d records, want 3", len(records))
+	}
+
+	if got := records[0]; got.Get("disease name") != "Li-Fraumeni syndrome" || got.Get("DiseaseId") != "MONDO:0018875" || got.Get("gene_symbol") != "TP53" || got.Line != 2 {
+		t.Errorf("first record %v at line %d", got.Fields, got.Line)
+	}
+	// A short line leaves the missing columns empty, so Get falls back to the next name.
+	if got := records[1]; got.Get("UniProt", "Gene Symbol") != "BRCA1" || got.Line != 4 {
+		t.Errorf("second record %v at line %d", got.Fields, got.Line)
+	}
+	if got := records[2]; got.Get("uniprot") != "P60484" || len(got.Fields) != 4 {
+		t.Errorf("third record %v, want the extra column dropped", got.Fields)
+	}
+	if got := records[2].Get("mim number"); got != "" {
+		t.Errorf("missing column reads as %q, want empty", got)
+	}
+}
+
+func TestTSVReaderCommentedHeader(t *testing.T) {
+	reader, records := readTSV(t, readFixture(t, "commented_header.tsv"))
+	if len(records) != 2 {
+		t.Fatalf("read %d records, want 2", len(records))
+	}
+	if len(reader.Header) != 5 {
+		t.Errorf("header %q, want the last commented line with tabs", reader.Header)
+	}
+	if got := records[0]; got.Get("Approved Gene Symbol") != "TP53" || got.Get("mim number") != "191170" || got.Get("chromosome") != "chr17" {
+		t.Errorf("first record %v", got.Fields)
+	}
+	// The CRLF line end must not stick to the last column.
+	if got := records[1].Get("phenotypes"); got != "Fanconi anemia, 605724 (3)" || records[1].Line != 5 {
+		t.Errorf("second record phenotypes %q at line %d", got, records[1].Line)
+	}
+}
+
+func TestTSVReaderWithoutHeader(t *testing.T) {
+	for _, input := range []string{"", "\n\n", "# only comments\n# here\n"} {
+		_, err := NewTSVReader(strings.NewReader(input)).Next()
+		if !errors.Is(err, ErrInvalidTSV) || !strings.Contains(err.Error(), "no header line") {
+			t.Errorf("reading %q: error %v, want %v", input, err, ErrInvalidTSV)
+		}
+	}
+}
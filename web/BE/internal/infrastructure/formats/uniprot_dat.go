@@ -0,0 +1,366 @@
+package formats
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidUniProtEntry = errors.New("invalid UniProt flat-file entry")
+
+// UniProtEntry is one entry of a UniProtKB flat file, reduced to the lines the importer
+// maps. Offset and EndOffset are byte offsets of the entry in the file, so an import can
+// resume right after the last committed entry.
+type UniProtEntry struct {
+	EntryName   string
+	Accessions  []string
+	ProteinName string
+	Gene        string
+	Aliases     []string // alternative and short protein names, gene synonyms
+	Organism    string
+	TaxonID     string
+	Comments    map[string][]string
+	CrossRefs   []UniProtCrossRef
+	Features    []UniProtFeature
+	Sequence    string
+	Offset      int64
+	EndOffset   int64
+	Line        int
+}
+
+type UniProtCrossRef struct {
+	Database   string
+	ID         string
+	Properties []string
+}
+
+// UniProtFeature is an FT line with its qualifiers. Start and End are 1-based; 0 means
+// the position is unknown ("?").
+type UniProtFeature struct {
+	Type        string
+	Start       int
+	End         int
+	Description string
+	Evidence    string
+}
+
+var evidenceTag = regexp.MustCompile(`\s*\{ECO:[^}]*\}`)
+
+// StripEvidence removes UniProt evidence attributions ({ECO:...}) from a text value.
+func StripEvidence(s string) string {
+	return strings.TrimSpace(evidenceTag.ReplaceAllString(s, ""))
+}
+
+// UniProtDatReader streams entries from a UniProtKB flat file (.dat) in bounded memory.
+type UniProtDatReader struct {
+	reader *bufio.Reader
+	offset int64
+	lineNo int
+}
+
+// NewUniProtDatReader reads from r, whose first byte is at offset in the underlying file.
+func NewUniProtDatReader(r io.Reader, offset int64) *UniProtDatReader {
+	return &UniProtDatReader{reader: bufio.NewReaderSize(r, 256*1024), offset: offset}
+}
+
+func (r *UniProtDatReader) readLine() (string, error) {
+	var buf []byte
+	for {
+		chunk, err := r.reader.ReadSlice('\n')
+		buf = append(buf, chunk...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		r.offset += int64(len(buf))
+		if len(buf) > 0 {
+			r.lineNo++
+			return string(bytes.TrimRight(buf, "\r\n")), nil
+		}
+		return "", err
+	}
+}
+
+// Next returns the next entry, or io.EOF at the end of the file.
+func (r *UniProtDatReader) Next() (*UniProtEntry, error) {
+	entry := &UniProtEntry{Comments: make(map[string][]string)}
+	started := false
+
+	var organism, sequence strings.Builder
+	var commentTopic string
+	var comment strings.Builder
+	var feature *UniProtFeature
+	var qualifier strings.Builder
+	inSequence := false
+	// Names of included domains or contained chains, and genes after the first, are
+	// not names of the protein itself.
+	inSubNames, otherGene := false, false
+
+	flushComment := func() {
+		if commentTopic != "" {
+			entry.Comments[commentTopic] = append(entry.Comments[commentTopic], StripEvidence(comment.String()))
+		}
+		commentTopic = ""
+		comment.Reset()
+	}
+	flushQualifier := func() {
+		if feature != nil && qualifier.Len() > 0 {
+			applyFeatureQualifier(feature, qualifier.String())
+		}
+		qualifier.Reset()
+	}
+	flushFeature := func() {
+		flushQualifier()
+		if feature != nil {
+			entry.Features = append(entry.Features, *feature)
+		}
+		feature = nil
+	}
+
+	for {
+		start := r.offset
+		line, err := r.readLine()
+		if err == io.EOF {
+			if started {
+				return nil, fmt.Errorf("%w: entry %s at line %d is not terminated by //", ErrInvalidUniProtEntry, entry.EntryName, entry.Line)
+			}
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		code := line
+		if len(line) >= 2 {
+			code = line[:2]
+		}
+		value := ""
+		if len(line) > 5 {
+			value = line[5:]
+		}
+
+		if !started {
+			if code != "ID" {
+				return nil, fmt.Errorf("%w: line %d: expected ID line", ErrInvalidUniProtEntry, r.lineNo)
+			}
+			started = true
+			entry.Offset = start
+			entry.Line = r.lineNo
+		}
+
+		switch {
+		case code == "//":
+			flushComment()
+			flushFeature()
+			entry.Organism = strings.TrimSuffix(strings.TrimSpace(organism.String()), ".")
+			entry.Sequence = sequence.String()
+			entry.EndOffset = r.offset
+			if entry.EntryName == "" || len(entry.Accessions) == 0 {
+				return nil, fmt.Errorf("%w: entry at line %d has no ID or AC line", ErrInvalidUniProtEntry, entry.Line)
+			}
+			return entry, nil
+		case inSequence:
+			for _, field := range strings.Fields(line) {
+				sequence.WriteString(field)
+			}
+		case code == "ID":
+			if fields := strings.Fields(value); len(fields) > 0 {
+				entry.EntryName = fields[0]
+			}
+		case code == "AC":
+			for _, acc := range strings.Split(value, ";") {
+				if acc = strings.TrimSpace(acc); acc != "" {
+					entry.Accessions = append(entry.Accessions, acc)
+				}
+			}
+		case code == "DE":
+			text := strings.TrimSpace(value)
+			if strings.HasPrefix(text, "Includes:") || strings.HasPrefix(text, "Contains:") {
+				inSubNames = true
+			}
+			if !inSubNames {
+				parseDescriptionLine(entry, text)
+			}
+		case code == "GN":
+			if strings.TrimSpace(value) == "and" {
+				otherGene = true
+			}
+			if entry.Gene == "" {
+				entry.Gene = parseGeneLine(value)
+			}
+			if !otherGene {
+				entry.Aliases = append(entry.Aliases, parseGeneSynonyms(value)...)
+			}
+		case code == "OS":
+			if organism.Len() > 0 {
+				organism.WriteByte(' ')
+			}
+			organism.WriteString(strings.TrimSpace(value))
+		case code == "OX":
+			if i := strings.Index(value, "NCBI_TaxID="); i >= 0 && entry.TaxonID == "" {
+				id := value[i+len("NCBI_TaxID="):]
+				entry.TaxonID = strings.TrimSpace(strings.TrimRight(StripEvidence(id), ";"))
+			}
+		case code == "CC":
+			text := strings.TrimSpace(value)
+			switch {
+			case strings.HasPrefix(text, "-!- "):
+				flushComment()
+				topic, body, _ := strings.Cut(text[4:], ":")
+				commentTopic = strings.TrimSpace(topic)
+				comment.WriteString(strings.TrimSpace(body))
+			case strings.HasPrefix(text, "---"):
+				// Copyright block that closes the comments
+				flushComment()
+			case commentTopic != "":
+				comment.WriteByte(' ')
+				comment.WriteString(text)
+			}
+		case code == "DR":
+			fields := strings.Split(strings.TrimSuffix(strings.TrimSpace(value), "."), ";")
+			if len(fields) >= 2 {
+				ref := UniProtCrossRef{Database: strings.TrimSpace(fields[0]), ID: strings.TrimSpace(fields[1])}
+				for _, prop := range fields[2:] {
+					ref.Properties = append(ref.Properties, strings.TrimSpace(prop))
+				}
+				entry.CrossRefs = append(entry.CrossRefs, ref)
+			}
+		case code == "FT":
+			parseFeatureLine(line, &feature, &qualifier, flushFeature, flushQualifier)
+		case code == "SQ":
+			flushComment()
+			flushFeature()
+			inSequence = true
+		}
+	}
+}
+
+// parseDescriptionLine keeps the first recommended (or submitted) full name as the
+// protein name, and other full names and all short names as aliases.
+func parseDescriptionLine(entry *UniProtEntry, value string) {
+	key, name, ok := strings.Cut(value, "=")
+	if !ok {
+		return
+	}
+	// "RecName: Full" opens a name; a continuation line has the bare key ("Short")
+	category := ""
+	if i := strings.LastIndexByte(key, ' '); i >= 0 {
+		category, key = strings.TrimSpace(key[:i]), key[i+1:]
+	}
+	name = StripEvidence(strings.TrimSuffix(name, ";"))
+	switch {
+	case key == "Full" && (category == "RecName:" || category == "SubName:") && entry.ProteinName == "":
+		entry.ProteinName = name
+	case key == "Full" || key == "Short":
+		entry.Aliases = append(entry.Aliases, name)
+	}
+}
+
+// parseGeneLine returns the first gene name, falling back to ordered locus and ORF names.
+func parseGeneLine(value string) string {
+	fallback := ""
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		val = StripEvidence(val)
+		if first, _, found := strings.Cut(val, ","); found {
+			val = strings.TrimSpace(first)
+		}
+		switch key {
+		case "Name":
+			return val
+		case "OrderedLocusNames", "ORFNames":
+			if fallback == "" {
+				fallback = val
+			}
+		}
+	}
+	return fallback
+}
+
+// parseGeneSynonyms returns the Synonyms= names of a GN line.
+func parseGeneSynonyms(value string) []string {
+	var synonyms []string
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || key != "Synonyms" {
+			continue
+		}
+		for _, synonym := range strings.Split(StripEvidence(val), ",") {
+			if synonym = strings.TrimSpace(synonym); synonym != "" {
+				synonyms = append(synonyms, synonym)
+			}
+		}
+	}
+	return synonyms
+}
+
+// parseFeatureLine handles both the feature key line ("FT   DOMAIN   41..290") and the
+// qualifier lines ("FT                   /note=\"...\"") that may wrap.
+func parseFeatureLine(line string, feature **UniProtFeature, qualifier *strings.Builder, flushFeature, flushQualifier func()) {
+	if len(line) > 5 && line[5] != ' ' {
+		flushFeature()
+		fields := strings.Fields(line[5:])
+		if len(fields) < 2 {
+			return
+		}
+		f := &UniProtFeature{Type: fields[0]}
+		f.Start, f.End = parseFeatureLocation(fields[1])
+		*feature = f
+		return
+	}
+
+	text := strings.TrimSpace(line[min(len(line), 5):])
+	if strings.HasPrefix(text, "/") {
+		flushQualifier()
+		qualifier.WriteString(text)
+		return
+	}
+	if qualifier.Len() > 0 {
+		qualifier.WriteByte(' ')
+		qualifier.WriteString(text)
+	}
+}
+
+func applyFeatureQualifier(feature *UniProtFeature, qualifier string) {
+	key, value, ok := strings.Cut(strings.TrimPrefix(qualifier, "/"), "=")
+	if !ok {
+		return
+	}
+	value = strings.Trim(value, "\"")
+	switch key {
+	case "note":
+		feature.Description = value
+	case "evidence":
+		feature.Evidence = value
+	}
+}
+
+// parseFeatureLocation parses "41..290", "<1..>100", "17" or "?..50"; a location on
+// another entry ("P12345:1..10") yields zeros.
+func parseFeatureLocation(location string) (int, int) {
+	if strings.Contains(location, ":") {
+		return 0, 0
+	}
+	from, to, found := strings.Cut(location, "..")
+	if !found {
+		to = from
+	}
+	position := func(s string) int {
+		n, err := strconv.Atoi(strings.TrimLeft(s, "<>"))
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return position(from), position(to)
+}
@@ -0,0 +1,129 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readUniProtEntries(t *testing.T, input string, offset int64) []*UniProtEntry {
+	t.Helper()
+	reader := NewUniProtDatReader(strings.NewReader(input), offset)
+	var entries []*UniProtEntry
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func TestUniProtDatReader(t *testing.T) {
+	fixture := readFixture(t, "two_entries.dat")
+	entries := readUniProtEntries(t, fixture, 0)
+	if len(entries) != 2 {
+		t.Fatalf("read %d entries, want 2", len(entries))
+	}
+
+	p53 := entries[0]
+	if p53.EntryName != "P53_HUMAN" || !reflect.DeepEqual(p53.Accessions, []string{"P04637", "Q15086", "Q15087"}) {
+		t.Errorf("entry %s with accessions %v", p53.EntryName, p53.Accessions)
+	}
+	if p53.ProteinName != "Cellular tumor antigen p53" || p53.Gene != "TP53" {
+		t.Errorf("protein %q of gene %q", p53.ProteinName, p53.Gene)
+	}
+	if want := []string{"Tumor suppressor p53", "Antigen NY-CO-13", "P53", "LFS1"}; !reflect.DeepEqual(p53.Aliases, want) {
+		t.Errorf("aliases %q, want %q", p53.Aliases, want)
+	}
+	if p53.Organism != "Homo sapiens (Human)" || p53.TaxonID != "9606" {
+		t.Errorf("organism %q with taxon %q", p53.Organism, p53.TaxonID)
+	}
+	wantComments := map[string][]string{
+		"FUNCTION": {"Multifunctional transcription factor that induces cell cycle arrest."},
+		"SUBUNIT":  {"Forms homodimers and homotetramers."},
+	}
+	if !reflect.DeepEqual(p53.Comments, wantComments) {
+		t.Errorf("comments %q, want %q", p53.Comments, wantComments)
+	}
+	wantRefs := []UniProtCrossRef{
+		{Database: "PDB", ID: "1A1U", Properties: []string{"NMR", "-", "A=324-358"}},
+		{Database: "Pfam", ID: "PF00870", Properties: []string{"P53", "1"}},
+	}
+	if !reflect.DeepEqual(p53.CrossRefs, wantRefs) {
+		t.Errorf("cross-references %+v, want %+v", p53.CrossRefs, wantRefs)
+	}
+	wantFeatures := []UniProtFeature{
+		{Type: "DNA_BIND", Start: 102, End: 292, Evidence: "ECO:0000250"},
+		{Type: "REGION", Start: 1, End: 83, Description: "Interaction with HRMT1L2 and with the long note continued"},
+		{Type: "MUTAGEN", Start: 175, End: 175, Description: "R->H: Loss of DNA binding."},
+	}
+	if !reflect.DeepEqual(p53.Features, wantFeatures) {
+		t.Errorf("features %+v, want %+v", p53.Features, wantFeatures)
+	}
+	if p53.Sequence != "MEEPQSDPSVEPPLSQETFS" {
+		t.Errorf("sequence %q", p53.Sequence)
+	}
+
+	// Names in a Contains: block and genes after "and" are not the protein's.
+	mdm2 := entries[1]
+	if mdm2.ProteinName != "E3 ubiquitin-protein ligase Mdm2" || mdm2.Gene != "Mdm2a" || len(mdm2.Aliases) != 0 {
+		t.Errorf("protein %q of gene %q with aliases %q", mdm2.ProteinName, mdm2.Gene, mdm2.Aliases)
+	}
+	if mdm2.Organism != "Mus musculus (Mouse)" || mdm2.Sequence != "MCNTNMSVPT" {
+		t.Errorf("organism %q with sequence %q", mdm2.Organism, mdm2.Sequence)
+	}
+}
+
+func TestUniProtDatReaderOffsets(t *testing.T) {
+	for _, newline := range []string{"\n", "\r\n"} {
+		fixture := strings.ReplaceAll(readFixture(t, "two_entries.dat"), "\n", newline)
+		entries := readUniProtEntries(t, fixture, 0)
+		if len(entries) != 2 {
+			t.Fatalf("%q: read %d entries, want 2", newline, len(entries))
+		}
+		first, second := entries[0], entries[1]
+		if first.Offset != 0 || first.EndOffset != second.Offset || second.EndOffset != int64(len(fixture)) {
+			t.Errorf("%q: entries span %d-%d and %d-%d of %d bytes", newline, first.Offset, first.EndOffset, second.Offset, second.EndOffset, len(fixture))
+		}
+		if text := fixture[second.Offset:second.EndOffset]; !strings.HasPrefix(text, "ID   MDM2_MOUSE") || !strings.HasSuffix(text, "//"+newline) {
+			t.Errorf("%q: second entry spans %q", newline, text)
+		}
+
+		// An import resumes from the end offset of the last committed entry.
+		resumed := readUniProtEntries(t, fixture[first.EndOffset:], first.EndOffset)
+		if len(resumed) != 1 || resumed[0].EntryName != "MDM2_MOUSE" || resumed[0].Offset != second.Offset || resumed[0].EndOffset != second.EndOffset {
+			t.Errorf("%q: resuming at %d read %+v", newline, first.EndOffset, resumed)
+		}
+	}
+}
+
+func TestUniProtDatReaderMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantMsg string
+	}{
+		{name: "no ID line", input: "AC   P04637;\n//\n", wantMsg: "line 1: expected ID line"},
+		{name: "no AC line", input: "ID   P53_HUMAN Reviewed;\nSQ   SEQUENCE\n     MEEP\n//\n", wantMsg: "entry at line 1 has no ID or AC line"},
+		{name: "unterminated", input: "ID   P53_HUMAN Reviewed;\nAC   P04637;\n", wantMsg: "entry P53_HUMAN at line 1 is not terminated"},
+		{name: "second entry unterminated", input: "ID   A_HUMAN Reviewed;\nAC   P1;\n//\nID   B_HUMAN Reviewed;\nAC   P2;\n", wantMsg: "entry B_HUMAN at line 4 is not terminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewUniProtDatReader(strings.NewReader(tt.input), 0)
+			var err error
+			for err == nil {
+				_, err = reader.Next()
+			}
+			if !errors.Is(err, ErrInvalidUniProtEntry) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, ErrInvalidUniProtEntry, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package formats
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidUniProtXML = errors.New("invalid UniProt XML")
+
+// uniProtXMLEntry mirrors the parts of an <entry> element of the UniProtKB XML schema
+// that the importer maps.
+type uniProtXMLEntry struct {
+	Accessions []string `xml:"accession"`
+	Names      []string `xml:"name"`
+	Protein    struct {
+		RecommendedName struct {
+			FullName   string   `xml:"fullName"`
+			ShortNames []string `xml:"shortName"`
+		} `xml:"recommendedName"`
+		AlternativeNames []struct {
+			FullName   string   `xml:"fullName"`
+			ShortNames []string `xml:"shortName"`
+		} `xml:"alternativeName"`
+		SubmittedNames []struct {
+			FullName string `xml:"fullName"`
+		} `xml:"submittedName"`
+	} `xml:"protein"`
+	Genes []struct {
+		Names []uniProtXMLTypedName `xml:"name"`
+	} `xml:"gene"`
+	Organism struct {
+		Names        []uniProtXMLTypedName   `xml:"name"`
+		DBReferences []uniProtXMLDBReference `xml:"dbReference"`
+	} `xml:"organism"`
+	Comments []struct {
+		Type                 string   `xml:"type,attr"`
+		Texts                []string `xml:"text"`
+		SubcellularLocations []struct {
+			Locations []string `xml:"location"`
+		} `xml:"subcellularLocation"`
+	} `xml:"comment"`
+	DBReferences []uniProtXMLDBReference `xml:"dbReference"`
+	Features     []struct {
+		Type        string `xml:"type,attr"`
+		Description string `xml:"description,attr"`
+		Evidence    string `xml:"evidence,attr"`
+		Location    struct {
+			Begin    uniProtXMLPosition `xml:"begin"`
+			End      uniProtXMLPosition `xml:"end"`
+			Position uniProtXMLPosition `xml:"position"`
+		} `xml:"location"`
+	} `xml:"feature"`
+	Sequence string `xml:"sequence"`
+}
+
+type uniProtXMLTypedName struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type uniProtXMLDBReference struct {
+	Type       string `xml:"type,attr"`
+	ID         string `xml:"id,attr"`
+	Properties []struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:"value,attr"`
+	} `xml:"property"`
+}
+
+type uniProtXMLPosition struct {
+	Position string `xml:"position,attr"`
+}
+
+// UniProtXMLReader streams <entry> elements from a UniProtKB XML document, decoding one
+// entry at a time so memory stays bounded regardless of the file size.
+type UniProtXMLReader struct {
+	decoder *xml.Decoder
+}
+
+func NewUniProtXMLReader(r io.Reader) *UniProtXMLReader {
+	return &UniProtXMLReader{decoder: xml.NewDecoder(r)}
+}
+
+// Next returns the next entry mapped onto a UniProtEntry, or io.EOF at the end of the
+// document. Comment topics and feature types are upper-cased to match the flat-file
+// names ("function" becomes "FUNCTION", "binding site" becomes "BINDING SITE").
+// Offset and EndOffset are left zero; Line is the line of the <entry> tag. Only XML
+// syntax errors are returned; an entry missing fields is left to the importer.
+func (r *UniProtXMLReader) Next() (*UniProtEntry, error) {
+	for {
+		line, _ := r.decoder.InputPos()
+		token, err := r.decoder.Token()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidUniProtXML, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "entry" {
+			continue
+		}
+
+		var raw uniProtXMLEntry
+		if err := r.decoder.DecodeElement(&raw, &start); err != nil {
+			return nil, fmt.Errorf("%w: entry at line %d: %v", ErrInvalidUniProtXML, line, err)
+		}
+		entry := raw.toEntry()
+		entry.Line = line
+		return entry, nil
+	}
+}
+
+func (raw *uniProtXMLEntry) toEntry() *UniProtEntry {
+	entry := &UniProtEntry{
+		Accessions: raw.Accessions,
+		Comments:   make(map[string][]string),
+		Sequence:   strings.Join(strings.Fields(raw.Sequence), ""),
+	}
+	if len(raw.Names) > 0 {
+		entry.EntryName = strings.TrimSpace(raw.Names[0])
+	}
+
+	entry.ProteinName = strings.TrimSpace(raw.Protein.RecommendedName.FullName)
+	if entry.ProteinName == "" && len(raw.Protein.SubmittedNames) > 0 {
+		entry.ProteinName = strings.TrimSpace(raw.Protein.SubmittedNames[0].FullName)
+	}
+
+	entry.Aliases = append(entry.Aliases, raw.Protein.RecommendedName.ShortNames...)
+	for _, name := range raw.Protein.AlternativeNames {
+		if name.FullName != "" {
+			entry.Aliases = append(entry.Aliases, name.FullName)
+		}
+		entry.Aliases = append(entry.Aliases, name.ShortNames...)
+	}
+
+	// Same preference as the GN line: primary name, then ordered locus and ORF names;
+	// the synonyms of the first gene are aliases.
+	fallback := ""
+	for i, gene := range raw.Genes {
+		for _, name := range gene.Names {
+			switch name.Type {
+			case "primary":
+				if entry.Gene == "" {
+					entry.Gene = strings.TrimSpace(name.Value)
+				}
+			case "synonym":
+				if i == 0 {
+					entry.Aliases = append(entry.Aliases, name.Value)
+				}
+			case "ordered locus", "ORF":
+				if fallback == "" {
+					fallback = strings.TrimSpace(name.Value)
+				}
+			}
+		}
+	}
+	if entry.Gene == "" {
+		entry.Gene = fallback
+	}
+
+	for _, name := range raw.Organism.Names {
+		if name.Type == "scientific" {
+			entry.Organism = strings.TrimSpace(name.Value)
+			break
+		}
+	}
+	for _, ref := range raw.Organism.DBReferences {
+		if ref.Type == "NCBI Taxonomy" {
+			entry.TaxonID = ref.ID
+			break
+		}
+	}
+
+	for _, comment := range raw.Comments {
+		topic := strings.ToUpper(comment.Type)
+		if topic == "SUBCELLULAR LOCATION" {
+			var locations []string
+			for _, sub := range comment.SubcellularLocations {
+				locations = append(locations, strings.Join(sub.Locations, ", "))
+			}
+			if len(locations) > 0 {
+				entry.Comments[topic] = append(entry.Comments[topic], strings.Join(locations, "; ")+".")
+			}
+			continue
+		}
+		for _, text := range comment.Texts {
+			if text = strings.Join(strings.Fields(text), " "); text != "" {
+				entry.Comments[topic] = append(entry.Comments[topic], text)
+			}
+		}
+	}
+
+	for _, ref := range raw.DBReferences {
+		crossRef := UniProtCrossRef{Database: ref.Type, ID: ref.ID}
+		for _, prop := range ref.Properties {
+			crossRef.Properties = append(crossRef.Properties, prop.Value)
+		}
+		entry.CrossRefs = append(entry.CrossRefs, crossRef)
+	}
+
+	for _, f := range raw.Features {
+		feature := UniProtFeature{
+			Type:        strings.ToUpper(f.Type),
+			Description: f.Description,
+			Evidence:    f.Evidence,
+		}
+		if f.Location.Position.Position != "" {
+			feature.Start = xmlPosition(f.Location.Position)
+			feature.End = feature.Start
+		} else {
+			feature.Start = xmlPosition(f.Location.Begin)
+			feature.End = xmlPosition(f.Location.End)
+		}
+		entry.Features = append(entry.Features, feature)
+	}
+	return entry
+}
+
+// xmlPosition returns 0 for unknown positions, which carry no position attribute.
+func xmlPosition(p uniProtXMLPosition) int {
+	n, err := strconv.Atoi(p.Position)
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,90 @@
+package formats
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUniProtXMLReader(t *testing.T) {
+	reader := NewUniProtXMLReader(strings.NewReader(readFixture(t, "two_entries.xml")))
+	var entries []*UniProtEntry
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("read %d entries, want 2", len(entries))
+	}
+
+	p53 := entries[0]
+	if p53.EntryName != "P53_HUMAN" || !reflect.DeepEqual(p53.Accessions, []string{"P04637", "Q15086"}) || p53.Line != 3 {
+		t.Errorf("entry %s with accessions %v at line %d", p53.EntryName, p53.Accessions, p53.Line)
+	}
+	if p53.ProteinName != "Cellular tumor antigen p53" || p53.Gene != "TP53" {
+		t.Errorf("protein %q of gene %q", p53.ProteinName, p53.Gene)
+	}
+	if want := []string{"Tumor suppressor p53", "Antigen NY-CO-13", "P53"}; !reflect.DeepEqual(p53.Aliases, want) {
+		t.Errorf("aliases %q, want %q", p53.Aliases, want)
+	}
+	if p53.Organism != "Homo sapiens" || p53.TaxonID != "9606" {
+		t.Errorf("organism %q with taxon %q", p53.Organism, p53.TaxonID)
+	}
+	wantComments := map[string][]string{
+		"FUNCTION":             {"Multifunctional transcription factor that induces cell cycle arrest."},
+		"SUBCELLULAR LOCATION": {"Cytoplasm; Nucleus, PML body."},
+	}
+	if !reflect.DeepEqual(p53.Comments, wantComments) {
+		t.Errorf("comments %q, want %q", p53.Comments, wantComments)
+	}
+	if want := []UniProtCrossRef{{Database: "PDB", ID: "1A1U", Properties: []string{"NMR", "A=324-358"}}}; !reflect.DeepEqual(p53.CrossRefs, want) {
+		t.Errorf("cross-references %+v, want %+v", p53.CrossRefs, want)
+	}
+	wantFeatures := []UniProtFeature{
+		{Type: "DNA-BINDING REGION", Start: 102, End: 292, Evidence: "2"},
+		{Type: "MUTAGENESIS SITE", Start: 175, End: 175, Description: "Loss of DNA binding."},
+		{Type: "REGION OF INTEREST", Start: 0, End: 83, Description: "Disordered"},
+	}
+	if !reflect.DeepEqual(p53.Features, wantFeatures) {
+		t.Errorf("features %+v, want %+v", p53.Features, wantFeatures)
+	}
+	if p53.Sequence != "MEEPQSDPSVEPPLSQETFS" {
+		t.Errorf("sequence %q", p53.Sequence)
+	}
+
+	unreviewed := entries[1]
+	if unreviewed.ProteinName != "Uncharacterized protein" || unreviewed.Gene != "Gm123" || unreviewed.TaxonID != "10090" || unreviewed.Sequence != "MKVL" {
+		t.Errorf("second entry %+v", unreviewed)
+	}
+}
+
+func TestUniProtXMLReaderMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantMsg string
+	}{
+		{name: "unclosed entry", input: "<uniprot>\n<entry><accession>P1</accession>\n</uniprot>", wantMsg: "entry at line 2"},
+		{name: "broken tag", input: "<uniprot><entry><accession>P1</accession></entry><</uniprot>", wantMsg: "invalid UniProt XML"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewUniProtXMLReader(strings.NewReader(tt.input))
+			var err error
+			for err == nil {
+				_, err = reader.Next()
+			}
+			if !errors.Is(err, ErrInvalidUniProtXML) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %v, want %v with %q", err, ErrInvalidUniProtXML, tt.wantMsg)
+			}
+		})
+	}
+}
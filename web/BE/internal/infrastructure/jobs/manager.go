@@ -0,0 +1,122 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"log"
+	"sync"
+	"time"
+)
+
+// Func is the body of a background job. It reports progress through the supplied callback.
+type Func func(ctx context.Context, progress func(completed, total int)) (interface{}, error)
+
+// Manager runs jobs in background goroutines and keeps their state in memory.
+type Manager struct {
+	mu        sync.RWMutex
+	jobs      map[string]*entities.Job
+	retention time.Duration
+}
+
+func NewManager(retention time.Duration) *Manager {
+	return &Manager{
+		jobs:      make(map[string]*entities.Job),
+		retention: retention,
+	}
+}
+
+// Submit registers a new job and starts it immediately.
+func (m *Manager) Submit(kind string, fn Func) *entities.Job {
+	job := &entities.Job{
+		ID:          newJobID(),
+		Kind:        kind,
+		Status:      entities.JobPending,
+		SubmittedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	m.purgeExpiredLocked()
+	m.jobs[job.ID] = job
+	snapshot := *job
+	m.mu.Unlock()
+
+	go m.run(job, fn)
+
+	return &snapshot
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (m *Manager) Get(id string) (*entities.Job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
+}
+
+func (m *Manager) run(job *entities.Job, fn Func) {
+	m.mu.Lock()
+	now := time.Now()
+	job.Status = entities.JobRunning
+	job.StartedAt = &now
+	m.mu.Unlock()
+
+	progress := func(completed, total int) {
+		m.mu.Lock()
+		job.Completed = completed
+		job.Total = total
+		if total > 0 {
+			job.Progress = float64(completed) / float64(total)
+		}
+		m.mu.Unlock()
+	}
+
+	result, err := m.execute(fn, progress)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	finished := time.Now()
+	job.CompletedAt = &finished
+	if err != nil {
+		job.Status = entities.JobFailed
+		job.Error = err.Error()
+		log.Printf("Job %s (%s) failed: %v", job.ID, job.Kind, err)
+		return
+	}
+	job.Status = entities.JobCompleted
+	job.Progress = 1
+	job.Result = result
+}
+
+func (m *Manager) execute(fn Func, progress func(completed, total int)) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return fn(context.Background(), progress)
+}
+
+func (m *Manager) purgeExpiredLocked() {
+	cutoff := time.Now().Add(-m.retention)
+	for id, job := range m.jobs {
+		if job.IsFinished() && job.CompletedAt != nil && job.CompletedAt.Before(cutoff) {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func newJobID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+
+	"github.com/uptrace/bun"
+)
+
+type AnnotationRepositories struct {
+	db *bun.DB
+}
+
+func NewAnnotationRepository(db *bun.DB) *AnnotationRepositories {
+	return &AnnotationRepositories{db: db}
+}
+
+func (r *AnnotationRepositories) GetCrossReferences(ctx context.Context, proteinID string) ([]entities.CrossReference, error) {
+	var dbRefs []database.ProteinCrossReference
+	err := r.db.NewSelect().Model(&dbRefs).
+		Where("protein_id = ?", proteinID).
+		OrderExpr("database ASC, accession ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cross-references: %w", err)
+	}
+
+	refs := make([]entities.CrossReference, len(dbRefs))
+	for i, ref := range dbRefs {
+		refs[i] = entities.CrossReference{
+			ID:         ref.ID,
+			ProteinID:  ref.ProteinID,
+			Database:   ref.Database,
+			Accession:  ref.Accession,
+			Properties: ref.Properties,
+			Source:     ref.Source,
+		}
+	}
+	return refs, nil
+}
+
+func (r *AnnotationRepositories) GetFeatures(ctx context.Context, proteinID string) ([]entities.ProteinFeature, error) {
+	return r.SearchFeatures(ctx, &entities.FeatureFilter{ProteinID: proteinID})
+}
+
+// SearchFeatures returns the features matching the filter ordered by protein and
+// position. A range matches the features overlapping it, so features of unknown
+// position (0) never match one.
+func (r *AnnotationRepositories) SearchFeatures(ctx context.Context, filter *entities.FeatureFilter) ([]entities.ProteinFeature, error) {
+	var dbFeatures []database.ProteinFeature
+	query := r.db.NewSelect().Model(&dbFeatures)
+	applyFeatureFilter(query, filter)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if err := query.OrderExpr("protein_id ASC, seq_start ASC, seq_end ASC, id ASC").Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get features: %w", err)
+	}
+
+	features := make([]entities.ProteinFeature, len(dbFeatures))
+	for i := range dbFeatures {
+		features[i] = toFeatureEntity(&dbFeatures[i])
+	}
+	return features, nil
+}
+
+func applyFeatureFilter(query *bun.SelectQuery, filter *entities.FeatureFilter) {
+	if filter.ProteinID != "" {
+		query.Where("protein_id = ?", filter.ProteinID)
+	}
+	if filter.Type != "" {
+		query.Where("LOWER(type) = LOWER(?)", filter.Type)
+	}
+	if filter.Source != "" {
+		query.Where("source = ?", filter.Source)
+	}
+	if filter.Start != nil || filter.End != nil {
+		query.Where("seq_start > 0")
+	}
+	if filter.Start != nil {
+		query.Where("seq_end >= ?", *filter.Start)
+	}
+	if filter.End != nil {
+		query.Where("seq_start <= ?", *filter.End)
+	}
+}
+
+// CreateFeature stores a feature and sets its ID.
+func (r *AnnotationRepositories) CreateFeature(ctx context.Context, feature *entities.ProteinFeature) error {
+	dbFeature := toFeatureModel(feature)
+	if _, err := r.db.NewInsert().Model(dbFeature).Returning("id").Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create feature: %w", err)
+	}
+	feature.ID = dbFeature.ID
+	return nil
+}
+
+// DeleteFeature removes a feature of a protein; sql.ErrNoRows reports that there was none.
+func (r *AnnotationRepositories) DeleteFeature(ctx context.Context, proteinID string, id int64) error {
+	result, err := r.db.NewDelete().Model((*database.ProteinFeature)(nil)).
+		Where("id = ?", id).
+		Where("protein_id = ?", proteinID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete feature: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SaveFeatures inserts features in one transaction, first deleting the existing
+// features of every protein and source listed in replace.
+func (r *AnnotationRepositories) SaveFeatures(ctx context.Context, features []entities.ProteinFeature, replace []entities.FeatureSource) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		for _, key := range replace {
+			_, err := tx.NewDelete().Model((*database.ProteinFeature)(nil)).
+				Where("protein_id = ?", key.ProteinID).
+				Where("source = ?", key.Source).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to delete features: %w", err)
+			}
+		}
+		if len(features) == 0 {
+			return nil
+		}
+
+		dbFeatures := make([]*database.ProteinFeature, len(features))
+		for i := range features {
+			dbFeatures[i] = toFeatureModel(&features[i])
+		}
+		if _, err := tx.NewInsert().Model(&dbFeatures).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to insert features: %w", err)
+		}
+		return nil
+	})
+}
+
+// ProteinLengths returns the sequence length of each listed protein that exists.
+func (r *AnnotationRepositories) ProteinLengths(ctx context.Context, ids []string) (map[string]int, error) {
+	lengths := make(map[string]int, len(ids))
+	if len(ids) == 0 {
+		return lengths, nil
+	}
+	var rows []struct {
+		ID     string `bun:"id"`
+		Length *int   `bun:"length"`
+	}
+	err := r.db.NewSelect().Model((*database.Protein)(nil)).
+		Column("id", "length").
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get protein lengths: %w", err)
+	}
+	for _, row := range rows {
+		lengths[row.ID] = 0
+		if row.Length != nil {
+			lengths[row.ID] = *row.Length
+		}
+	}
+	return lengths, nil
+}
+
+func toFeatureModel(feature *entities.ProteinFeature) *database.ProteinFeature {
+	return &database.ProteinFeature{
+		ID:          feature.ID,
+		ProteinID:   feature.ProteinID,
+		Type:        feature.Type,
+		SeqStart:    feature.Start,
+		SeqEnd:      feature.End,
+		Score:       feature.Score,
+		Description: feature.Description,
+		Evidence:    feature.Evidence,
+		Source:      feature.Source,
+		Attributes:  feature.Attributes,
+	}
+}
+
+func toFeatureEntity(dbFeature *database.ProteinFeature) entities.ProteinFeature {
+	return entities.ProteinFeature{
+		ID:          dbFeature.ID,
+		ProteinID:   dbFeature.ProteinID,
+		Type:        dbFeature.Type,
+		Start:       dbFeature.SeqStart,
+		End:         dbFeature.SeqEnd,
+		Score:       dbFeature.Score,
+		Description: dbFeature.Description,
+		Evidence:    dbFeature.Evidence,
+		Source:      dbFeature.Source,
+		Attributes:  dbFeature.Attributes,
+	}
+}
@@ -0,0 +1,312 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+
+	"github.com/uptrace/bun"
+)
+
+type DiseaseRepositories struct {
+	db *bun.DB
+}
+
+func NewDiseaseRepository(db *bun.DB) *DiseaseRepositories {
+	return &DiseaseRepositories{db: db}
+}
+
+// CreateDisease stores a disease with its synonyms.
+func (r *DiseaseRepositories) CreateDisease(ctx context.Context, disease *entities.Disease) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(toDiseaseModel(disease)).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create disease: %w", err)
+		}
+		return insertDiseaseSynonyms(ctx, tx, disease.ID, disease.Synonyms)
+	})
+}
+
+// UpdateDisease overwrites a disease and replaces its synonyms; sql.ErrNoRows reports
+// that there was no such disease.
+func (r *DiseaseRepositories) UpdateDisease(ctx context.Context, disease *entities.Disease) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().Model(toDiseaseModel(disease)).
+			ExcludeColumn("created").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update disease: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return sql.ErrNoRows
+		}
+		_, err = tx.NewDelete().Model((*database.DiseaseSynonym)(nil)).Where("disease_id = ?", disease.ID).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete disease synonyms: %w", err)
+		}
+		return insertDiseaseSynonyms(ctx, tx, disease.ID, disease.Synonyms)
+	})
+}
+
+// DeleteDisease removes a disease; its synonyms and associations go with it.
+// sql.ErrNoRows reports that there was no such disease.
+func (r *DiseaseRepositories) DeleteDisease(ctx context.Context, id string) error {
+	result, err := r.db.NewDelete().Model((*database.Disease)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete disease: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *DiseaseRepositories) GetDisease(ctx context.Context, id string) (*entities.Disease, error) {
+	var dbDisease database.Disease
+	if err := r.db.NewSelect().Model(&dbDisease).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get disease: %w", err)
+	}
+	diseases := []*entities.Disease{toDiseaseEntity(&dbDisease)}
+	if err := r.loadSynonyms(ctx, diseases); err != nil {
+		return nil, err
+	}
+	return diseases[0], nil
+}
+
+// SearchDiseases finds diseases by ID, name or synonym; exact name matches come first.
+func (r *DiseaseRepositories) SearchDiseases(ctx context.Context, filter *entities.DiseaseFilter) ([]*entities.Disease, error) {
+	var dbDiseases []database.Disease
+	query := r.db.NewSelect().Model(&dbDiseases)
+	if filter.Query != "" {
+		pattern := "%" + filter.Query + "%"
+		query = query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("id = ?", filter.Query).
+				WhereOr("name ILIKE ?", pattern).
+				WhereOr("id IN (SELECT disease_id FROM disease_synonyms WHERE synonym ILIKE ?)", pattern)
+		})
+	}
+	if filter.Category != "" {
+		query = query.Where("category ILIKE ?", "%"+filter.Category+"%")
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	err := query.
+		OrderExpr("LOWER(name) = LOWER(?) DESC, LENGTH(name) ASC, id ASC", filter.Query).
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search diseases: %w", err)
+	}
+
+	diseases := make([]*entities.Disease, len(dbDiseases))
+	for i := range dbDiseases {
+		diseases[i] = toDiseaseEntity(&dbDiseases[i])
+	}
+	if err := r.loadSynonyms(ctx, diseases); err != nil {
+		return nil, err
+	}
+	return diseases, nil
+}
+
+// SaveImport upserts diseases and associations in one transaction. A disease keeps its
+// stored description, category and source where the import has none; synonyms are
+// added. An association already recorded for the same protein, disease and source is
+// updated.
+func (r *DiseaseRepositories) SaveImport(ctx context.Context, diseases []*entities.Disease, associations []entities.DiseaseAssociation) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if len(diseases) > 0 {
+			dbDiseases := make([]*database.Disease, len(diseases))
+			for i, disease := range diseases {
+				dbDiseases[i] = toDiseaseModel(disease)
+			}
+			upsert := tx.NewInsert().Model(&dbDiseases).
+				On("CONFLICT (id) DO UPDATE").
+				Set("name = EXCLUDED.name")
+			for _, column := range []string{"description", "category", "source"} {
+				upsert = upsert.Set("? = COALESCE(NULLIF(EXCLUDED.?, ''), ?TableAlias.?)", bun.Ident(column), bun.Ident(column), bun.Ident(column))
+			}
+			if _, err := upsert.Set("updated = EXCLUDED.updated").Exec(ctx); err != nil {
+				return fmt.Errorf("failed to upsert diseases: %w", err)
+			}
+			for _, disease := range diseases {
+				if err := insertDiseaseSynonyms(ctx, tx, disease.ID, disease.Synonyms); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(associations) > 0 {
+			dbAssociations := make([]*database.ProteinDiseaseAssociation, len(associations))
+			for i := range associations {
+				dbAssociations[i] = toDiseaseAssociationModel(&associations[i])
+			}
+			_, err := tx.NewInsert().Model(&dbAssociations).
+				On("CONFLICT (protein_id, disease_id, source) DO UPDATE").
+				Set("evidence_type = EXCLUDED.evidence_type").
+				Set("score = EXCLUDED.score").
+				Set("reference_ids = EXCLUDED.reference_ids").
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to upsert disease associations: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// CreateAssociation stores one association and sets its ID.
+func (r *DiseaseRepositories) CreateAssociation(ctx context.Context, association *entities.DiseaseAssociation) error {
+	dbAssociation := toDiseaseAssociationModel(association)
+	if _, err := r.db.NewInsert().Model(dbAssociation).Returning("id, created").Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create disease association: %w", err)
+	}
+	association.ID, association.Created = dbAssociation.ID, dbAssociation.Created
+	return nil
+}
+
+// DeleteAssociation removes an association of a protein; sql.ErrNoRows reports that
+// there was none.
+func (r *DiseaseRepositories) DeleteAssociation(ctx context.Context, proteinID string, id int64) error {
+	result, err := r.db.NewDelete().Model((*database.ProteinDiseaseAssociation)(nil)).
+		Where("id = ?", id).
+		Where("protein_id = ?", proteinID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete disease association: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetProteinAssociations lists the diseases of a protein, best scored first.
+func (r *DiseaseRepositories) GetProteinAssociations(ctx context.Context, proteinID string) ([]entities.DiseaseAssociation, error) {
+	return r.associations(ctx, "a.protein_id = ?", proteinID, 0, 0)
+}
+
+// GetDiseaseAssociations lists the proteins associated with a disease, best scored
+// first.
+func (r *DiseaseRepositories) GetDiseaseAssociations(ctx context.Context, diseaseID string, limit, offset int) ([]entities.DiseaseAssociation, error) {
+	return r.associations(ctx, "a.disease_id = ?", diseaseID, limit, offset)
+}
+
+func (r *DiseaseRepositories) associations(ctx context.Context, where string, arg any, limit, offset int) ([]entities.DiseaseAssociation, error) {
+	var rows []struct {
+		database.ProteinDiseaseAssociation `bun:",extend"`
+		DiseaseName                        string `bun:"disease_name"`
+	}
+	query := r.db.NewSelect().
+		TableExpr("protein_disease_associations AS a").
+		ColumnExpr("a.*").
+		ColumnExpr("d.name AS disease_name").
+		Join("JOIN diseases AS d ON d.id = a.disease_id").
+		Where(where, arg).
+		OrderExpr("a.score DESC NULLS LAST, d.name ASC, a.protein_id ASC, a.id ASC")
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+	if err := query.Scan(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to get disease associations: %w", err)
+	}
+
+	associations := make([]entities.DiseaseAssociation, len(rows))
+	for i := range rows {
+		associations[i] = toDiseaseAssociationEntity(&rows[i].ProteinDiseaseAssociation)
+		associations[i].DiseaseName = rows[i].DiseaseName
+	}
+	return associations, nil
+}
+
+func (r *DiseaseRepositories) loadSynonyms(ctx context.Context, diseases []*entities.Disease) error {
+	if len(diseases) == 0 {
+		return nil
+	}
+	byID := make(map[string]*entities.Disease, len(diseases))
+	ids := make([]string, len(diseases))
+	for i, disease := range diseases {
+		byID[disease.ID], ids[i] = disease, disease.ID
+	}
+
+	var synonyms []database.DiseaseSynonym
+	err := r.db.NewSelect().Model(&synonyms).
+		Where("disease_id IN (?)", bun.In(ids)).
+		OrderExpr("disease_id ASC, synonym ASC").
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get disease synonyms: %w", err)
+	}
+	for _, synonym := range synonyms {
+		disease := byID[synonym.DiseaseID]
+		disease.Synonyms = append(disease.Synonyms, synonym.Synonym)
+	}
+	return nil
+}
+
+func insertDiseaseSynonyms(ctx context.Context, db bun.IDB, diseaseID string, synonyms []string) error {
+	if len(synonyms) == 0 {
+		return nil
+	}
+	dbSynonyms := make([]*database.DiseaseSynonym, len(synonyms))
+	for i, synonym := range synonyms {
+		dbSynonyms[i] = &database.DiseaseSynonym{DiseaseID: diseaseID, Synonym: synonym}
+	}
+	if _, err := db.NewInsert().Model(&dbSynonyms).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+		return fmt.Errorf("failed to insert disease synonyms: %w", err)
+	}
+	return nil
+}
+
+func toDiseaseModel(disease *entities.Disease) *database.Disease {
+	return &database.Disease{
+		ID:          disease.ID,
+		Name:        disease.Name,
+		Description: disease.Description,
+		Category:    disease.Category,
+		Source:      disease.Source,
+		Created:     disease.Created,
+		Updated:     disease.Updated,
+	}
+}
+
+func toDiseaseEntity(dbDisease *database.Disease) *entities.Disease {
+	return &entities.Disease{
+		ID:          dbDisease.ID,
+		Name:        dbDisease.Name,
+		Description: dbDisease.Description,
+		Category:    dbDisease.Category,
+		Source:      dbDisease.Source,
+		Created:     dbDisease.Created,
+		Updated:     dbDisease.Updated,
+	}
+}
+
+func toDiseaseAssociationModel(association *entities.DiseaseAssociation) *database.ProteinDiseaseAssociation {
+	return &database.ProteinDiseaseAssociation{
+		ID:           association.ID,
+		ProteinID:    association.ProteinID,
+		DiseaseID:    association.DiseaseID,
+		EvidenceType: association.EvidenceType,
+		Source:       association.Source,
+		Score:        association.Score,
+		References:   association.References,
+		Created:      association.Created,
+	}
+}
+
+func toDiseaseAssociationEntity(dbAssociation *database.ProteinDiseaseAssociation) entities.DiseaseAssociation {
+	return entities.DiseaseAssociation{
+		ID:           dbAssociation.ID,
+		ProteinID:    dbAssociation.ProteinID,
+		DiseaseID:    dbAssociation.DiseaseID,
+		EvidenceType: dbAssociation.EvidenceType,
+		Source:       dbAssociation.Source,
+		Score:        dbAssociation.Score,
+		References:   dbAssociation.References,
+		Created:      dbAssociation.Created,
+	}
+}
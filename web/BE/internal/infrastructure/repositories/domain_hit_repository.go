@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type DomainHitRepositories struct {
+	db *bun.DB
+}
+
+func NewDomainHitRepository(db *bun.DB) *DomainHitRepositories {
+	return &DomainHitRepositories{db: db}
+}
+
+// ReplaceForProtein deletes the protein's previous hits and stores the new ones in a
+// single transaction, so re-annotation never leaves a protein half annotated.
+func (r *DomainHitRepositories) ReplaceForProtein(ctx context.Context, proteinID string, hits []entities.DomainHit) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().Model((*database.ProteinDomainHit)(nil)).Where("protein_id = ?", proteinID).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete domain hits: %w", err)
+		}
+		if len(hits) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		dbHits := make([]database.ProteinDomainHit, len(hits))
+		for i, hit := range hits {
+			dbHits[i] = database.ProteinDomainHit{
+				ProteinID:      proteinID,
+				ModelName:      hit.ModelName,
+				ModelAccession: hit.ModelAccession,
+				Description:    hit.Description,
+				SeqStart:       hit.Start,
+				SeqEnd:         hit.End,
+				HMMStart:       hit.HMMStart,
+				HMMEnd:         hit.HMMEnd,
+				HMMLength:      hit.HMMLength,
+				Score:          hit.Score,
+				EValue:         hit.EValue,
+				SequenceScore:  hit.SequenceScore,
+				SequenceEValue: hit.SequenceEValue,
+				Created:        now,
+			}
+		}
+		if _, err := tx.NewInsert().Model(&dbHits).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create domain hits: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *DomainHitRepositories) GetByProtein(ctx context.Context, proteinID string) ([]entities.DomainHit, error) {
+	var dbHits []database.ProteinDomainHit
+	err := r.db.NewSelect().Model(&dbHits).
+		Where("protein_id = ?", proteinID).
+		OrderExpr("seq_start ASC, evalue ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain hits: %w", err)
+	}
+
+	hits := make([]entities.DomainHit, len(dbHits))
+	for i := range dbHits {
+		hits[i] = toDomainHitEntity(&dbHits[i])
+	}
+	return hits, nil
+}
+
+func toDomainHitEntity(dbHit *database.ProteinDomainHit) entities.DomainHit {
+	return entities.DomainHit{
+		ID:             dbHit.ID,
+		ProteinID:      dbHit.ProteinID,
+		ModelName:      dbHit.ModelName,
+		ModelAccession: dbHit.ModelAccession,
+		Description:    dbHit.Description,
+		Start:          dbHit.SeqStart,
+		End:            dbHit.SeqEnd,
+		HMMStart:       dbHit.HMMStart,
+		HMMEnd:         dbHit.HMMEnd,
+		HMMLength:      dbHit.HMMLength,
+		Score:          dbHit.Score,
+		EValue:         dbHit.EValue,
+		SequenceScore:  dbHit.SequenceScore,
+		SequenceEValue: dbHit.SequenceEValue,
+		Created:        dbHit.Created,
+	}
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"go-crawler/web/BE/internal/domain/querylang"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// likeEscaper escapes the LIKE metacharacters of a literal text.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likePattern turns a matched text into a LIKE pattern: a wildcard pattern matches the
+// whole text with * for any run of characters and ? for one; other texts are contained.
+func likePattern(text string, wildcards bool) string {
+	text = likeEscaper.Replace(text)
+	if !wildcards {
+		return "%" + text + "%"
+	}
+	return strings.NewReplacer("*", "%", "?", "_").Replace(text)
+}
+
+// proteinExprColumn is the SQL for a filter expression field. Field names are the
+// protein column names, except the sequence, which is stored in chunks.
+func proteinExprColumn(field querylang.Field) schema.QueryAppender {
+	if field.Name == "seq" {
+		return bun.SafeQuery("array_to_string(seq, '')")
+	}
+	return bun.Ident(field.Name)
+}
+
+// compileFilterExpr turns a parsed filter expression into a WHERE condition. Columns
+// come from the schema and are quoted as identifiers, and values are always bound
+// arguments, so no input reaches the SQL text.
+func compileFilterExpr(node querylang.Node) schema.QueryAppender {
+	switch n := node.(type) {
+	case *querylang.And:
+		return bun.SafeQuery("(? AND ?)", compileFilterExpr(n.Left), compileFilterExpr(n.Right))
+	case *querylang.Or:
+		return bun.SafeQuery("(? OR ?)", compileFilterExpr(n.Left), compileFilterExpr(n.Right))
+	case *querylang.Not:
+		// A NULL column fails the term, so NOT keeps it rather than dropping it too.
+		return bun.SafeQuery("(? IS NOT TRUE)", compileFilterExpr(n.Expr))
+	case *querylang.Exists:
+		if n.Field.Kind == querylang.TextList {
+			return bun.SafeQuery("cardinality(?) > 0", proteinExprColumn(n.Field))
+		}
+		return bun.SafeQuery("? IS NOT NULL", proteinExprColumn(n.Field))
+	case *querylang.Range:
+		column := proteinExprColumn(n.Field)
+		var bounds []schema.QueryAppender
+		if n.From != nil {
+			op := ">"
+			if n.IncludeFrom {
+				op = ">="
+			}
+			bounds = append(bounds, bun.SafeQuery("? "+op+" ?", column, n.From))
+		}
+		if n.To != nil {
+			op := "<"
+			if n.IncludeTo {
+				op = "<="
+			}
+			bounds = append(bounds, bun.SafeQuery("? "+op+" ?", column, n.To))
+		}
+		if len(bounds) == 1 {
+			return bounds[0]
+		}
+		return bun.SafeQuery("(? AND ?)", bounds[0], bounds[1])
+	case *querylang.Comparison:
+		column := proteinExprColumn(n.Field)
+		if n.Field.Kind == querylang.TextList {
+			if n.Op == querylang.OpEq {
+				return bun.SafeQuery("? = ANY(?)", n.Value, column)
+			}
+			return bun.SafeQuery("EXISTS (SELECT 1 FROM unnest(?) AS alias WHERE alias ILIKE ?)", column, likePattern(n.Value.(string), n.Pattern))
+		}
+		if n.Op == querylang.OpMatch {
+			return bun.SafeQuery("? ILIKE ?", column, likePattern(n.Value.(string), n.Pattern))
+		}
+		return bun.SafeQuery("? "+string(n.Op)+" ?", column, n.Value)
+	default:
+		return bun.SafeQuery("TRUE")
+	}
+}
@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// importedProteinColumns are the protein columns a file import owns; curated columns
+// such as d_rank or n_interactors are left untouched when a protein is updated.
+var importedProteinColumns = []string{
+	"name", "length", "mw", "seq", "pi", "hydrophobicity_gravy",
+}
+
+// importedAnnotationColumns are only overwritten when the imported record has a value,
+// so a format without e.g. function comments does not erase those of another source.
+var importedAnnotationColumns = []string{
+	"gene", "aliases", "taxo", "taxon_id", "cc", "domain", "family", "function",
+}
+
+type ImportRepositories struct {
+	db *bun.DB
+}
+
+func NewImportRepository(db *bun.DB) *ImportRepositories {
+	return &ImportRepositories{db: db}
+}
+
+// SaveRecords creates the proteins of a batch and replaces the cross-references and
+// features that came from the same source on those it writes. With upsert, stored
+// proteins are updated too, merging the annotation columns, except those not at the
+// Version of their record's protein when it has one. Proteins in the trash are never
+// written. When checkpoint is not nil it is stored in the same transaction, so a
+// resumed import never skips or half-applies a batch. It returns what the batch did to each record by ID.
+func (r *ImportRepositories) SaveRecords(ctx context.Context, records []*entities.ProteinRecord, source string, upsert bool, checkpoint *entities.ImportCheckpoint) (map[string]entities.SaveOutcome, error) {
+	outcomes := make(map[string]entities.SaveOutcome)
+	if len(records) == 0 && checkpoint == nil {
+		return outcomes, nil
+	}
+
+	err := runInChange(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		if err := saveRecords(ctx, tx, records, source, upsert, outcomes); err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			return saveCheckpoint(ctx, tx, checkpoint)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+// SaveBatches saves several batches of records like SaveRecords, one statement per
+// batch, but all in one transaction: either every record is written or none is. When
+// a record is left alone nothing is saved, and the outcomes are returned with
+// entities.ErrImportRolledBack.
+func (r *ImportRepositories) SaveBatches(ctx context.Context, batches [][]*entities.ProteinRecord, source string, upsert bool) (map[string]entities.SaveOutcome, error) {
+	outcomes := make(map[string]entities.SaveOutcome)
+	err := runInChange(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		for _, records := range batches {
+			if err := saveRecords(ctx, tx, records, source, upsert, outcomes); err != nil {
+				return err
+			}
+		}
+		for _, outcome := range outcomes {
+			if outcome != entities.SaveCreated && outcome != entities.SaveUpdated {
+				return entities.ErrImportRolledBack
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, entities.ErrImportRolledBack) {
+		return outcomes, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+// writtenProtein is a protein a save wrote; inserted tells a created protein from an
+// updated one.
+type writtenProtein struct {
+	ID       string `bun:"id"`
+	Inserted bool   `bun:"inserted"`
+}
+
+// saveRecords writes records in tx and adds what it did to each to outcomes.
+func saveRecords(ctx context.Context, tx bun.Tx, records []*entities.ProteinRecord, source string, upsert bool, outcomes map[string]entities.SaveOutcome) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(records))
+	dbProteins := make([]*database.Protein, len(records))
+	versions := make(map[string]int)
+	for i, record := range records {
+		ids[i] = record.Protein.ID
+		dbProteins[i] = toProteinModel(record.Protein)
+		if record.Protein.Version > 0 {
+			versions[record.Protein.ID] = record.Protein.Version
+		}
+	}
+
+	insert := tx.NewInsert().Model(&dbProteins)
+	if upsert {
+		insert = insert.On("CONFLICT (id) DO UPDATE")
+		for _, column := range importedProteinColumns {
+			insert = insert.Set("? = EXCLUDED.?", bun.Ident(column), bun.Ident(column))
+		}
+		for _, column := range importedAnnotationColumns {
+			insert = insert.Set("? = COALESCE(EXCLUDED.?, ?TableAlias.?)", bun.Ident(column), bun.Ident(column), bun.Ident(column))
+		}
+		insert = insert.Set("updated = EXCLUDED.updated").Where("?TableAlias.deleted_at IS NULL")
+		if len(versions) > 0 {
+			expected, err := json.Marshal(versions)
+			if err != nil {
+				return err
+			}
+			insert = insert.Where("(?::jsonb ->> ?TableAlias.id) IS NULL OR (?::jsonb ->> ?TableAlias.id)::integer = ?TableAlias.version", string(expected), string(expected))
+		}
+	} else {
+		insert = insert.On("CONFLICT (id) DO NOTHING")
+	}
+	var written []writtenProtein
+	err := insert.Returning("id, (xmax = 0) AS inserted").Scan(ctx, &written)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to save proteins: %w", err)
+	}
+
+	writtenIDs := make([]string, len(written))
+	isWritten := make(map[string]bool, len(written))
+	for i, protein := range written {
+		writtenIDs[i], isWritten[protein.ID] = protein.ID, true
+		if protein.Inserted {
+			outcomes[protein.ID] = entities.SaveCreated
+		} else {
+			outcomes[protein.ID] = entities.SaveUpdated
+		}
+	}
+	if len(written) < len(ids) {
+		// The proteins left alone are stored already, in the trash or not.
+		var stored []database.Protein
+		err := tx.NewSelect().Model(&stored).
+			Column("id", "deleted_at").
+			WhereAllWithDeleted().
+			Where("id IN (?)", bun.In(ids)).
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check existing proteins: %w", err)
+		}
+		for _, protein := range stored {
+			switch {
+			case isWritten[protein.ID]:
+			case !protein.DeletedAt.IsZero():
+				outcomes[protein.ID] = entities.SaveTrashed
+			case upsert:
+				outcomes[protein.ID] = entities.SaveVersionConflict
+			default:
+				outcomes[protein.ID] = entities.SaveExists
+			}
+		}
+	}
+	if len(written) == 0 {
+		return nil
+	}
+
+	var dbRefs []database.ProteinCrossReference
+	var dbFeatures []database.ProteinFeature
+	for _, record := range records {
+		if !isWritten[record.Protein.ID] {
+			continue
+		}
+		for _, ref := range record.CrossReferences {
+			dbRefs = append(dbRefs, database.ProteinCrossReference{
+				ProteinID:  record.Protein.ID,
+				Database:   ref.Database,
+				Accession:  ref.Accession,
+				Properties: ref.Properties,
+				Source:     source,
+			})
+		}
+		for _, feature := range record.Features {
+			feature.ProteinID, feature.Source = record.Protein.ID, source
+			dbFeatures = append(dbFeatures, *toFeatureModel(&feature))
+		}
+	}
+
+	_, err = tx.NewDelete().Model((*database.ProteinCrossReference)(nil)).
+		Where("protein_id IN (?)", bun.In(writtenIDs)).
+		Where("source = ?", source).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete cross-references: %w", err)
+	}
+	_, err = tx.NewDelete().Model((*database.ProteinFeature)(nil)).
+		Where("protein_id IN (?)", bun.In(writtenIDs)).
+		Where("source = ?", source).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete features: %w", err)
+	}
+	if len(dbRefs) > 0 {
+		if _, err := tx.NewInsert().Model(&dbRefs).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create cross-references: %w", err)
+		}
+	}
+	if len(dbFeatures) > 0 {
+		if _, err := tx.NewInsert().Model(&dbFeatures).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create features: %w", err)
+		}
+	}
+	return nil
+}
+
+func saveCheckpoint(ctx context.Context, db bun.IDB, checkpoint *entities.ImportCheckpoint) error {
+	dbCheckpoint := &database.ImportCheckpoint{
+		Source:   checkpoint.Source,
+		Format:   checkpoint.Format,
+		FileSize: checkpoint.FileSize,
+		Offset:   checkpoint.Offset,
+		Records:  checkpoint.Records,
+		Updated:  time.Now(),
+	}
+	_, err := db.NewInsert().Model(dbCheckpoint).
+		On("CONFLICT (source) DO UPDATE").
+		Set("format = EXCLUDED.format").
+		Set("file_size = EXCLUDED.file_size").
+		Set("byte_offset = EXCLUDED.byte_offset").
+		Set("records = EXCLUDED.records").
+		Set("updated = EXCLUDED.updated").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save import checkpoint: %w", err)
+	}
+	return nil
+}
+
+// GetCheckpoint returns the stored checkpoint of a source, or nil when there is none.
+func (r *ImportRepositories) GetCheckpoint(ctx context.Context, source string) (*entities.ImportCheckpoint, error) {
+	var dbCheckpoint database.ImportCheckpoint
+	err := r.db.NewSelect().Model(&dbCheckpoint).Where("source = ?", source).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get import checkpoint: %w", err)
+	}
+
+	return &entities.ImportCheckpoint{
+		Source:   dbCheckpoint.Source,
+		Format:   dbCheckpoint.Format,
+		FileSize: dbCheckpoint.FileSize,
+		Offset:   dbCheckpoint.Offset,
+		Records:  dbCheckpoint.Records,
+		Updated:  dbCheckpoint.Updated,
+	}, nil
+}
+
+func (r *ImportRepositories) DeleteCheckpoint(ctx context.Context, source string) error {
+	_, err := r.db.NewDelete().Model((*database.ImportCheckpoint)(nil)).Where("source = ?", source).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete import checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// goTermSeedSQL selects the term named by ?0: its ID, an alternative ID or its exact
+// name, case-insensitively.
+const goTermSeedSQL = `SELECT id FROM go_terms WHERE id = ?0 OR ?0 = ANY(alt_ids) OR LOWER(name) = LOWER(?0)`
+
+// goDescendantsSQL selects the term named by ?0 and every term below it along is_a and
+// part_of.
+const goDescendantsSQL = `WITH RECURSIVE closure(id) AS (
+	` + goTermSeedSQL + `
+	UNION
+	SELECT r.term_id FROM go_relationships r JOIN closure c ON r.parent_id = c.id
+	WHERE r.relation IN ('` + entities.GORelationIsA + `', '` + entities.GORelationPartOf + `')
+) SELECT id FROM closure`
+
+// goAncestorsSQL selects the term named by ?0 and every term above it along is_a and
+// part_of.
+const goAncestorsSQL = `WITH RECURSIVE closure(id) AS (
+	` + goTermSeedSQL + `
+	UNION
+	SELECT r.parent_id FROM go_relationships r JOIN closure c ON r.term_id = c.id
+	WHERE r.relation IN ('` + entities.GORelationIsA + `', '` + entities.GORelationPartOf + `')
+) SELECT id FROM closure`
+
+// goAnnotatedProteinsSQL selects the proteins positively annotated to the term named
+// by ?0 or any of its descendants.
+const goAnnotatedProteinsSQL = `SELECT protein_id FROM protein_go_annotations
+	WHERE NOT negated AND term_id IN (` + goDescendantsSQL + `)`
+
+const ontologyBatchSize = 1000
+
+type OntologyRepositories struct {
+	db *bun.DB
+}
+
+func NewOntologyRepository(db *bun.DB) *OntologyRepositories {
+	return &OntologyRepositories{db: db}
+}
+
+// SaveOntology upserts the terms and replaces the whole relationship graph in one
+// transaction. Terms missing from a new release are kept, since annotations may
+// still refer to them.
+func (r *OntologyRepositories) SaveOntology(ctx context.Context, terms []*entities.GOTerm, relationships []entities.GORelationship) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		now := time.Now()
+		for start := 0; start < len(terms); start += ontologyBatchSize {
+			batch := terms[start:min(start+ontologyBatchSize, len(terms))]
+			dbTerms := make([]*database.GOTerm, len(batch))
+			for i, term := range batch {
+				dbTerms[i] = &database.GOTerm{
+					ID:         term.ID,
+					Name:       term.Name,
+					Namespace:  term.Namespace,
+					Definition: term.Definition,
+					Synonyms:   term.Synonyms,
+					AltIDs:     term.AltIDs,
+					Obsolete:   term.Obsolete,
+					ReplacedBy: term.ReplacedBy,
+					Updated:    now,
+				}
+			}
+			_, err := tx.NewInsert().Model(&dbTerms).
+				On("CONFLICT (id) DO UPDATE").
+				Set("name = EXCLUDED.name").
+				Set("namespace = EXCLUDED.namespace").
+				Set("definition = EXCLUDED.definition").
+				Set("synonyms = EXCLUDED.synonyms").
+				Set("alt_ids = EXCLUDED.alt_ids").
+				Set("obsolete = EXCLUDED.obsolete").
+				Set("replaced_by = EXCLUDED.replaced_by").
+				Set("updated = EXCLUDED.updated").
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to upsert GO terms: %w", err)
+			}
+		}
+
+		if _, err := tx.NewDelete().Model((*database.GORelationship)(nil)).Where("TRUE").Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete GO relationships: %w", err)
+		}
+		for start := 0; start < len(relationships); start += ontologyBatchSize {
+			batch := relationships[start:min(start+ontologyBatchSize, len(relationships))]
+			dbRelationships := make([]*database.GORelationship, len(batch))
+			for i, rel := range batch {
+				dbRelationships[i] = &database.GORelationship{TermID: rel.TermID, Relation: rel.Relation, ParentID: rel.ParentID}
+			}
+			if _, err := tx.NewInsert().Model(&dbRelationships).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+				return fmt.Errorf("failed to insert GO relationships: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetTerm returns the term named by id, matched as goTermSeedSQL does. A current term
+// wins over an obsolete one of the same name.
+func (r *OntologyRepositories) GetTerm(ctx context.Context, id string) (*entities.GOTerm, error) {
+	var dbTerm database.GOTerm
+	err := r.db.NewSelect().Model(&dbTerm).
+		Where("id = ?0 OR ?0 = ANY(alt_ids) OR LOWER(name) = LOWER(?0)", id).
+		OrderExpr("id = ? DESC, obsolete ASC", id).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GO term: %w", err)
+	}
+	return toGOTermEntity(&dbTerm), nil
+}
+
+// SearchTerms finds terms whose ID matches exactly or whose name or a synonym contains
+// the query, exact name matches first. Obsolete terms are left out.
+func (r *OntologyRepositories) SearchTerms(ctx context.Context, q, namespace string, limit, offset int) ([]*entities.GOTerm, error) {
+	var dbTerms []database.GOTerm
+	query := r.db.NewSelect().Model(&dbTerms).Where("NOT obsolete")
+	if q != "" {
+		pattern := "%" + q + "%"
+		query = query.WhereGroup(" AND ", func(q2 *bun.SelectQuery) *bun.SelectQuery {
+			return q2.Where("id = ?", q).
+				WhereOr("name ILIKE ?", pattern).
+				WhereOr("EXISTS (SELECT 1 FROM unnest(synonyms) s WHERE s ILIKE ?)", pattern)
+		})
+	}
+	if namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+	err := query.
+		OrderExpr("LOWER(name) = LOWER(?) DESC, LENGTH(name) ASC, id ASC", q).
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search GO terms: %w", err)
+	}
+
+	terms := make([]*entities.GOTerm, len(dbTerms))
+	for i := range dbTerms {
+		terms[i] = toGOTermEntity(&dbTerms[i])
+	}
+	return terms, nil
+}
+
+// GetRelatedTerms returns the direct parents and children of a term over every
+// relationship type.
+func (r *OntologyRepositories) GetRelatedTerms(ctx context.Context, id string) ([]entities.GORelatedTerm, []entities.GORelatedTerm, error) {
+	var parents, children []entities.GORelatedTerm
+	err := r.db.NewSelect().
+		TableExpr("go_relationships AS r").
+		Join("JOIN go_terms AS t ON t.id = r.parent_id").
+		ColumnExpr("t.id, t.name, t.namespace, r.relation").
+		Where("r.term_id = ?", id).
+		OrderExpr("r.relation ASC, t.id ASC").
+		Scan(ctx, &parents)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get GO term parents: %w", err)
+	}
+	err = r.db.NewSelect().
+		TableExpr("go_relationships AS r").
+		Join("JOIN go_terms AS t ON t.id = r.term_id").
+		ColumnExpr("t.id, t.name, t.namespace, r.relation").
+		Where("r.parent_id = ?", id).
+		OrderExpr("r.relation ASC, t.id ASC").
+		Scan(ctx, &children)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get GO term children: %w", err)
+	}
+	return parents, children, nil
+}
+
+// GetAncestors returns the terms above a term along is_a and part_of, excluding it.
+func (r *OntologyRepositories) GetAncestors(ctx context.Context, id string) ([]*entities.GOTerm, error) {
+	return r.closure(ctx, goAncestorsSQL, id)
+}
+
+// GetDescendants returns the terms below a term along is_a and part_of, excluding it.
+func (r *OntologyRepositories) GetDescendants(ctx context.Context, id string) ([]*entities.GOTerm, error) {
+	return r.closure(ctx, goDescendantsSQL, id)
+}
+
+func (r *OntologyRepositories) closure(ctx context.Context, closureSQL, id string) ([]*entities.GOTerm, error) {
+	var dbTerms []database.GOTerm
+	err := r.db.NewSelect().Model(&dbTerms).
+		Where("id IN ("+closureSQL+")", id).
+		Where("id != ?", id).
+		OrderExpr("namespace ASC, name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GO term closure: %w", err)
+	}
+
+	terms := make([]*entities.GOTerm, len(dbTerms))
+	for i := range dbTerms {
+		terms[i] = toGOTermEntity(&dbTerms[i])
+	}
+	return terms, nil
+}
+
+// ResolveTermIDs maps term IDs, primary or alternative, to the primary ID of a stored
+// term; unknown IDs are absent.
+func (r *OntologyRepositories) ResolveTermIDs(ctx context.Context, ids []string) (map[string]string, error) {
+	resolved := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return resolved, nil
+	}
+	var dbTerms []database.GOTerm
+	err := r.db.NewSelect().Model(&dbTerms).
+		Column("id", "alt_ids").
+		Where("id IN (?)", bun.In(ids)).
+		WhereOr("alt_ids && ?", pgdialect.Array(ids)).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve GO term IDs: %w", err)
+	}
+	for _, term := range dbTerms {
+		for _, alt := range term.AltIDs {
+			if _, ok := resolved[alt]; !ok {
+				resolved[alt] = term.ID
+			}
+		}
+	}
+	for _, term := range dbTerms {
+		resolved[term.ID] = term.ID
+	}
+	return resolved, nil
+}
+
+// SaveAnnotations inserts annotations in one transaction, first deleting the
+// annotations recorded by source for each protein in replace.
+func (r *OntologyRepositories) SaveAnnotations(ctx context.Context, annotations []entities.GOAnnotation, source string, replace []string) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if len(replace) > 0 {
+			_, err := tx.NewDelete().Model((*database.ProteinGOAnnotation)(nil)).
+				Where("protein_id IN (?)", bun.In(replace)).
+				Where("source = ?", source).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to delete GO annotations: %w", err)
+			}
+		}
+		if len(annotations) == 0 {
+			return nil
+		}
+
+		dbAnnotations := make([]*database.ProteinGOAnnotation, len(annotations))
+		for i := range annotations {
+			dbAnnotations[i] = toGOAnnotationModel(&annotations[i])
+		}
+		if _, err := tx.NewInsert().Model(&dbAnnotations).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to insert GO annotations: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateAnnotation stores one annotation and sets its ID.
+func (r *OntologyRepositories) CreateAnnotation(ctx context.Context, annotation *entities.GOAnnotation) error {
+	dbAnnotation := toGOAnnotationModel(annotation)
+	if _, err := r.db.NewInsert().Model(dbAnnotation).Returning("id, created").Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create GO annotation: %w", err)
+	}
+	annotation.ID, annotation.Created = dbAnnotation.ID, dbAnnotation.Created
+	return nil
+}
+
+// DeleteAnnotation removes an annotation of a protein; sql.ErrNoRows reports that
+// there was none.
+func (r *OntologyRepositories) DeleteAnnotation(ctx context.Context, proteinID string, id int64) error {
+	result, err := r.db.NewDelete().Model((*database.ProteinGOAnnotation)(nil)).
+		Where("id = ?", id).
+		Where("protein_id = ?", proteinID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete GO annotation: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetProteinAnnotations lists the annotations of a protein with their term names,
+// ordered by namespace and term.
+func (r *OntologyRepositories) GetProteinAnnotations(ctx context.Context, proteinID string) ([]entities.GOAnnotation, error) {
+	var rows []struct {
+		database.ProteinGOAnnotation `bun:",extend"`
+		TermName                     string `bun:"term_name"`
+		Namespace                    string `bun:"namespace"`
+	}
+	err := r.db.NewSelect().
+		TableExpr("protein_go_annotations AS a").
+		ColumnExpr("a.*").
+		ColumnExpr("t.name AS term_name, t.namespace").
+		Join("JOIN go_terms AS t ON t.id = a.term_id").
+		Where("a.protein_id = ?", proteinID).
+		OrderExpr("t.namespace ASC, t.name ASC, a.id ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GO annotations: %w", err)
+	}
+
+	annotations := make([]entities.GOAnnotation, len(rows))
+	for i := range rows {
+		annotations[i] = toGOAnnotationEntity(&rows[i].ProteinGOAnnotation)
+		annotations[i].TermName, annotations[i].Namespace = rows[i].TermName, rows[i].Namespace
+	}
+	return annotations, nil
+}
+
+func toGOTermEntity(dbTerm *database.GOTerm) *entities.GOTerm {
+	return &entities.GOTerm{
+		ID:         dbTerm.ID,
+		Name:       dbTerm.Name,
+		Namespace:  dbTerm.Namespace,
+		Definition: dbTerm.Definition,
+		Synonyms:   dbTerm.Synonyms,
+		AltIDs:     dbTerm.AltIDs,
+		Obsolete:   dbTerm.Obsolete,
+		ReplacedBy: dbTerm.ReplacedBy,
+	}
+}
+
+func toGOAnnotationModel(annotation *entities.GOAnnotation) *database.ProteinGOAnnotation {
+	return &database.ProteinGOAnnotation{
+		ID:           annotation.ID,
+		ProteinID:    annotation.ProteinID,
+		TermID:       annotation.TermID,
+		EvidenceCode: annotation.EvidenceCode,
+		Qualifier:    annotation.Qualifier,
+		Negated:      annotation.Negated,
+		Reference:    annotation.Reference,
+		WithFrom:     annotation.WithFrom,
+		AssignedBy:   annotation.AssignedBy,
+		Source:       annotation.Source,
+		Created:      annotation.Created,
+	}
+}
+
+func toGOAnnotationEntity(dbAnnotation *database.ProteinGOAnnotation) entities.GOAnnotation {
+	return entities.GOAnnotation{
+		ID:           dbAnnotation.ID,
+		ProteinID:    dbAnnotation.ProteinID,
+		TermID:       dbAnnotation.TermID,
+		EvidenceCode: dbAnnotation.EvidenceCode,
+		Qualifier:    dbAnnotation.Qualifier,
+		Negated:      dbAnnotation.Negated,
+		Reference:    dbAnnotation.Reference,
+		WithFrom:     dbAnnotation.WithFrom,
+		AssignedBy:   dbAnnotation.AssignedBy,
+		Source:       dbAnnotation.Source,
+		Created:      dbAnnotation.Created,
+	}
+}
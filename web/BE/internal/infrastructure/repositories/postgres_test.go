@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// openTestDB migrates the PostgreSQL database named by TEST_DATABASE_URL and returns
+// it, skipping the test when the variable is unset. The database should be a scratch
+// one: tests leave their rows behind, under IDs unique to the run.
+func openTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	sqlDB, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := bun.NewDB(sqlDB, pgdialect.New())
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := (&database.Database{Conn: db}).Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// testID returns an ID unique to the test run.
+func testID(prefix string) string {
+	return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano())
+}
+
+func createTestProtein(t *testing.T, repo *ProteinRepositories, id string, gene *string) *entities.Protein {
+	t.Helper()
+	length := 4
+	protein := &entities.Protein{ID: id, Name: "Kinase", Gene: gene, Seq: []string{"MKVL"}, Length: &length}
+	if err := repo.Create(context.Background(), protein); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := repo.GetByID(context.Background(), id)
+	if err != nil || stored == nil {
+		t.Fatalf("created protein %s reads as %v, %v", id, stored, err)
+	}
+	return stored
+}
+
+func TestProteinVersionCheck(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProteinRepository(openTestDB(t))
+	protein := createTestProtein(t, repo, testID("V"), nil)
+	if protein.Version != 1 {
+		t.Fatalf("created at version %d, want 1", protein.Version)
+	}
+
+	stale := *protein
+	protein.Name = "Renamed kinase"
+	if err := repo.Update(ctx, protein); err != nil {
+		t.Fatal(err)
+	}
+	if protein.Version != 2 {
+		t.Errorf("update returned version %d, want the bumped 2", protein.Version)
+	}
+
+	stale.Name = "Lost update"
+	if err := repo.Update(ctx, &stale); !errors.Is(err, entities.ErrVersionConflict) {
+		t.Errorf("update at a stale version: %v, want %v", err, entities.ErrVersionConflict)
+	}
+	if err := repo.Delete(ctx, protein.ID, 1); !errors.Is(err, entities.ErrVersionConflict) {
+		t.Errorf("delete at a stale version: %v, want %v", err, entities.ErrVersionConflict)
+	}
+
+	stored, err := repo.GetByID(ctx, protein.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != "Renamed kinase" || stored.Version != 2 {
+		t.Errorf("stored %q at version %d, want %q at 2", stored.Name, stored.Version, "Renamed kinase")
+	}
+
+	if err := repo.Delete(ctx, protein.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetByID(ctx, protein.ID); !errors.Is(err, entities.ErrProteinDeleted) {
+		t.Errorf("get of a trashed protein: %v, want %v", err, entities.ErrProteinDeleted)
+	}
+}
+
+func TestProteinRevisionNumbering(t *testing.T) {
+	db := openTestDB(t)
+	repo, revisions := NewProteinRepository(db), NewRevisionRepository(db)
+	protein := createTestProtein(t, repo, testID("R"), nil)
+
+	ctx := entities.WithChange(context.Background(), entities.Change{Actor: "curator", Note: "typo"})
+	protein.Name = "Renamed kinase"
+	if err := repo.Update(ctx, protein); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, protein.ID, protein.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := revisions.Latest(ctx, protein.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 3 {
+		t.Fatalf("latest revision %d, want 3", latest)
+	}
+	for i, want := range []string{"create", "update", "delete"} {
+		revision, err := revisions.Get(ctx, protein.ID, i+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revision.Action != want {
+			t.Errorf("revision %d: action %q, want %q", i+1, revision.Action, want)
+		}
+		if i > 0 && (revision.Note == nil || *revision.Note != "typo" || revision.Actor == nil || *revision.Actor != "curator") {
+			t.Errorf("revision %d: actor %v and note %v, want the change's", i+1, revision.Actor, revision.Note)
+		}
+	}
+	revision, err := revisions.Get(ctx, protein.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revision.Snapshot == nil || revision.Snapshot.Name != "Renamed kinase" || revision.Snapshot.Version != 2 {
+		t.Errorf("revision 2 snapshot %+v, want the renamed protein at version 2", revision.Snapshot)
+	}
+}
+
+func TestGeneLinkAndDelete(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo, genes := NewProteinRepository(db), NewGeneRepository(db)
+
+	name := testID("GENE")
+	protein := createTestProtein(t, repo, testID("G"), &name)
+	gene, err := genes.GetByName(ctx, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gene == nil || protein.GeneID == nil || *protein.GeneID != gene.ID {
+		t.Fatalf("protein of gene %q links to %v, want the created gene %+v", name, protein.GeneID, gene)
+	}
+
+	if err := genes.Delete(ctx, gene.ID); !errors.Is(err, entities.ErrGeneInUse) {
+		t.Errorf("delete of a linked gene: %v, want %v", err, entities.ErrGeneInUse)
+	}
+	if err := repo.Delete(ctx, protein.ID, protein.Version); err != nil {
+		t.Fatal(err)
+	}
+	if err := genes.Delete(ctx, gene.ID); !errors.Is(err, entities.ErrGeneInUse) {
+		t.Errorf("delete of a gene linked from the trash: %v, want %v", err, entities.ErrGeneInUse)
+	}
+
+	if err := repo.Restore(ctx, protein.ID); err != nil {
+		t.Fatal(err)
+	}
+	if protein, err = repo.GetByID(ctx, protein.ID); err != nil {
+		t.Fatal(err)
+	}
+	protein.Gene = nil
+	if err := repo.Update(ctx, protein); err != nil {
+		t.Fatal(err)
+	}
+	if err := genes.Delete(ctx, gene.ID); err != nil {
+		t.Errorf("delete of an unlinked gene: %v", err)
+	}
+}
+
+func TestSaveRecordsOutcomes(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo, imports := NewProteinRepository(db), NewImportRepository(db)
+
+	stored := createTestProtein(t, repo, testID("S"), nil)
+	trashed := createTestProtein(t, repo, testID("T"), nil)
+	if err := repo.Delete(ctx, trashed.ID, trashed.Version); err != nil {
+		t.Fatal(err)
+	}
+	fresh := testID("N")
+
+	record := func(id string, version int) *entities.ProteinRecord {
+		length := 4
+		return &entities.ProteinRecord{Protein: &entities.Protein{ID: id, Name: "Imported kinase", Seq: []string{"MKVL"}, Length: &length, Version: version}}
+	}
+	tests := []struct {
+		name    string
+		records []*entities.ProteinRecord
+		upsert  bool
+		want    map[string]entities.SaveOutcome
+	}{
+		{
+			name:    "create only",
+			records: []*entities.ProteinRecord{record(fresh, 0), record(stored.ID, 0), record(trashed.ID, 0)},
+			want:    map[string]entities.SaveOutcome{fresh: entities.SaveCreated, stored.ID: entities.SaveExists, trashed.ID: entities.SaveTrashed},
+		},
+		{
+			name:    "upsert at another version",
+			records: []*entities.ProteinRecord{record(stored.ID, 5)},
+			upsert:  true,
+			want:    map[string]entities.SaveOutcome{stored.ID: entities.SaveVersionConflict},
+		},
+		{
+			name:    "upsert",
+			records: []*entities.ProteinRecord{record(stored.ID, 1), record(fresh, 0), record(trashed.ID, 0)},
+			upsert:  true,
+			want:    map[string]entities.SaveOutcome{stored.ID: entities.SaveUpdated, fresh: entities.SaveUpdated, trashed.ID: entities.SaveTrashed},
+		},
+	}
+	for _, tt := range tests {
+		outcomes, err := imports.SaveRecords(ctx, tt.records, "test", tt.upsert, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for id, want := range tt.want {
+			if outcomes[id] != want {
+				t.Errorf("%s: %s saved with outcome %d, want %d", tt.name, id, outcomes[id], want)
+			}
+		}
+	}
+
+	protein, err := repo.GetByID(ctx, stored.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if protein.Name != "Imported kinase" || protein.Version != 2 {
+		t.Errorf("upserted protein %q at version %d, want %q at 2", protein.Name, protein.Version, "Imported kinase")
+	}
+	if _, err := repo.GetByID(ctx, trashed.ID); !errors.Is(err, entities.ErrProteinDeleted) {
+		t.Errorf("trashed protein after an upsert: %v, want it still in the trash", err)
+	}
+}
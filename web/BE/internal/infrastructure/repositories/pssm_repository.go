@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+
+	"github.com/uptrace/bun"
+)
+
+type PSSMRepositories struct {
+	db *bun.DB
+}
+
+func NewPSSMRepository(db *bun.DB) *PSSMRepositories {
+	return &PSSMRepositories{db: db}
+}
+
+func (r *PSSMRepositories) Create(ctx context.Context, pssm *entities.PSSM) error {
+	if pssm == nil {
+		return errors.New("pssm is nil")
+	}
+
+	dbProfile := &database.PSSMProfile{
+		Name:               pssm.Name,
+		Description:        pssm.Description,
+		Family:             pssm.Family,
+		Alphabet:           pssm.Alphabet,
+		Length:             pssm.Length,
+		Consensus:          pssm.Consensus,
+		Scores:             pssm.Scores,
+		Frequencies:        pssm.Frequencies,
+		Information:        pssm.Information,
+		NumSequences:       pssm.NumSequences,
+		EffectiveSequences: pssm.EffectiveSequences,
+		PseudocountWeight:  pssm.PseudocountWeight,
+		SourceIDs:          pssm.SourceIDs,
+		Created:            pssm.Created,
+		Updated:            pssm.Updated,
+	}
+
+	_, err := r.db.NewInsert().Model(dbProfile).Returning("id").Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create pssm: %w", err)
+	}
+	pssm.ID = dbProfile.ID
+	return nil
+}
+
+func (r *PSSMRepositories) GetByID(ctx context.Context, id int64) (*entities.PSSM, error) {
+	var dbProfile database.PSSMProfile
+	err := r.db.NewSelect().Model(&dbProfile).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pssm by ID: %w", err)
+	}
+
+	return toPSSMEntity(&dbProfile), nil
+}
+
+func (r *PSSMRepositories) GetAll(ctx context.Context) ([]*entities.PSSM, error) {
+	var dbProfiles []database.PSSMProfile
+	err := r.db.NewSelect().Model(&dbProfiles).
+		ExcludeColumn("scores", "frequencies", "information").
+		OrderExpr("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all pssms: %w", err)
+	}
+
+	profiles := make([]*entities.PSSM, len(dbProfiles))
+	for i := range dbProfiles {
+		profiles[i] = toPSSMEntity(&dbProfiles[i])
+	}
+	return profiles, nil
+}
+
+func (r *PSSMRepositories) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.NewDelete().Model((*database.PSSMProfile)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete pssm: %w", err)
+	}
+	return nil
+}
+
+func toPSSMEntity(dbProfile *database.PSSMProfile) *entities.PSSM {
+	return &entities.PSSM{
+		ID:                 dbProfile.ID,
+		Name:               dbProfile.Name,
+		Description:        dbProfile.Description,
+		Family:             dbProfile.Family,
+		Alphabet:           dbProfile.Alphabet,
+		Length:             dbProfile.Length,
+		Consensus:          dbProfile.Consensus,
+		Scores:             dbProfile.Scores,
+		Frequencies:        dbProfile.Frequencies,
+		Information:        dbProfile.Information,
+		NumSequences:       dbProfile.NumSequences,
+		EffectiveSequences: dbProfile.EffectiveSequences,
+		PseudocountWeight:  dbProfile.PseudocountWeight,
+		SourceIDs:          dbProfile.SourceIDs,
+		Created:            dbProfile.Created,
+		Updated:            dbProfile.Updated,
+	}
+}
@@ -2,10 +2,15 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go-crawler/web/BE/internal/domain/entities"
 	"go-crawler/web/BE/internal/infrastructure/database"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/uptrace/bun"
 )
@@ -23,69 +28,36 @@ func (p *ProteinRepositories) Create(ctx context.Context, protein *entities.Prot
 		return errors.New("protein is nil")
 	}
 
-	dbProtein := &database.Protein{
-		ID:                  protein.ID,
-		Name:                protein.Name,
-		Gene:                protein.Gene,
-		Taxo:                protein.Taxo,
-		CC:                  protein.CC,
-		Length:              protein.Length,
-		Domain:              protein.Domain,
-		Family:              protein.Family,
-		BioProcess:          protein.BioProcess,
-		Function:            protein.Function,
-		MW:                  protein.MW,
-		Seq:                 protein.Seq,
-		NInteractors:        protein.NInteractors,
-		PI:                  protein.PI,
-		NC74:                protein.NC74,
-		HydrophobicityGravy: protein.HydrophobicityGravy,
-		DRank:               protein.DRank,
-		LRank:               protein.LRank,
-		FRank:               protein.FRank,
-		Created:             protein.Created,
-		Updated:             protein.Updated,
-	}
+	dbProtein := toProteinModel(protein)
 
-	_, err := p.db.NewInsert().Model(dbProtein).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create protein: %w", err)
-	}
-	return nil
+	return runInChange(ctx, p.db, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(dbProtein).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create protein: %w", err)
+		}
+		return nil
+	})
 }
 
+// GetByID returns nil when there is no such protein, and entities.ErrProteinDeleted
+// when it is in the trash.
 func (p *ProteinRepositories) GetByID(ctx context.Context, id string) (*entities.Protein, error) {
 	var dbProtein database.Protein
 	err := p.db.NewSelect().Model(&dbProtein).Where("id = ?", id).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		deleted, err := p.db.NewSelect().Model((*database.Protein)(nil)).WhereDeleted().Where("id = ?", id).Exists(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get protein by ID: %w", err)
+		}
+		if deleted {
+			return nil, entities.ErrProteinDeleted
+		}
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get protein by ID: %w", err)
 	}
 
-	protein := &entities.Protein{
-		ID:                  dbProtein.ID,
-		Name:                dbProtein.Name,
-		Gene:                dbProtein.Gene,
-		Taxo:                dbProtein.Taxo,
-		CC:                  dbProtein.CC,
-		Length:              dbProtein.Length,
-		Domain:              dbProtein.Domain,
-		Family:              dbProtein.Family,
-		BioProcess:          dbProtein.BioProcess,
-		Function:            dbProtein.Function,
-		MW:                  dbProtein.MW,
-		Seq:                 dbProtein.Seq,
-		NInteractors:        dbProtein.NInteractors,
-		PI:                  dbProtein.PI,
-		NC74:                dbProtein.NC74,
-		HydrophobicityGravy: dbProtein.HydrophobicityGravy,
-		DRank:               dbProtein.DRank,
-		LRank:               dbProtein.LRank,
-		FRank:               dbProtein.FRank,
-		Created:             dbProtein.Created,
-		Updated:             dbProtein.Updated,
-	}
-
-	return protein, nil
+	return toProteinEntity(&dbProtein), nil
 }
 
 func (p *ProteinRepositories) GetByName(ctx context.Context, name string) ([]*entities.Protein, error) {
@@ -96,38 +68,634 @@ func (p *ProteinRepositories) GetByName(ctx context.Context, name string) ([]*en
 	}
 
 	proteins := make([]*entities.Protein, len(dbProteins))
-	for i, dbProtein := range dbProteins {
-		proteins[i] = &entities.Protein{
-			ID:                  dbProtein.ID,
-			Name:                dbProtein.Name,
-			Gene:                dbProtein.Gene,
-			Taxo:                dbProtein.Taxo,
-			CC:                  dbProtein.CC,
-			Length:              dbProtein.Length,
-			Domain:              dbProtein.Domain,
-			Family:              dbProtein.Family,
-			BioProcess:          dbProtein.BioProcess,
-			Function:            dbProtein.Function,
-			MW:                  dbProtein.MW,
-			Seq:                 dbProtein.Seq,
-			NInteractors:        dbProtein.NInteractors,
-			PI:                  dbProtein.PI,
-			NC74:                dbProtein.NC74,
-			HydrophobicityGravy: dbProtein.HydrophobicityGravy,
-			DRank:               dbProtein.DRank,
-			LRank:               dbProtein.LRank,
-			FRank:               dbProtein.FRank,
-			Created:             dbProtein.Created,
-			Updated:             dbProtein.Updated,
-		}
+	for i := range dbProteins {
+		proteins[i] = toProteinEntity(&dbProteins[i])
 	}
 
 	return proteins, nil
 }
 
+// Search returns a page of the proteins matching the filter. Pages are addressed by
+// Offset or, to stay stable while rows are inserted and fast however deep, by a Cursor
+// from an earlier page; Count picks an exact, estimated or no total.
 func (p *ProteinRepositories) Search(ctx context.Context, filter *entities.ProteinFilter) (*entities.PaginatedProteins, error) {
-	query := p.db.NewSelect().Model((*database.Protein)(nil))
+	query := applyProteinFilter(p.db.NewSelect().Model((*database.Protein)(nil)), filter)
+	order := proteinSearchOrder(filter)
+
+	var cursor *searchCursor
+	if filter.Cursor != "" {
+		var err error
+		if cursor, err = decodeSearchCursor(filter.Cursor, order); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &entities.PaginatedProteins{Limit: filter.Limit, Offset: filter.Offset}
+	switch filter.Count {
+	case entities.CountNone:
+	case entities.CountEstimated:
+		total, err := p.estimateCount(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		result.Total, result.TotalEstimated = &total, true
+	default:
+		total, err := query.Count(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count proteins: %w", err)
+		}
+		result.Total = &total
+	}
+
+	query = query.ColumnExpr("?TableColumns").
+		ColumnExpr("("+order.expr+")::text AS sort_key", order.arg)
+	if filter.Query != nil {
+		query = query.ColumnExpr(proteinRankSQL+" AS rank", *filter.Query).
+			ColumnExpr(proteinHighlightsSQL, *filter.Query, proteinHeadlineOptions)
+	}
+	if filter.Fuzzy != nil {
+		query = query.ColumnExpr(proteinSimilaritySQL+" AS similarity", *filter.Fuzzy)
+	}
+
+	backward := cursor != nil && cursor.Backward
+	if cursor != nil {
+		query = cursor.after(query, order)
+		result.Offset = 0
+	} else {
+		query = query.Offset(filter.Offset)
+	}
+
+	// One row past the page tells whether there is another page
+	var rows []proteinSearchRow
+	err := order.apply(query, backward).
+		Limit(filter.Limit+1).
+		Scan(ctx, &rows)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search proteins: %w", err)
+	}
+
+	more := len(rows) > filter.Limit
+	if more {
+		rows = rows[:filter.Limit]
+	}
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	if len(rows) > 0 {
+		first, last := &rows[0], &rows[len(rows)-1]
+		if backward {
+			// The page the cursor came from follows this one
+			result.HasMore = true
+			result.NextCursor = newSearchCursor(order, last, false).encode()
+			if more {
+				result.PrevCursor = newSearchCursor(order, first, true).encode()
+			}
+		} else {
+			result.HasMore = more
+			if more {
+				result.NextCursor = newSearchCursor(order, last, false).encode()
+			}
+			if cursor != nil || filter.Offset > 0 {
+				result.PrevCursor = newSearchCursor(order, first, true).encode()
+			}
+		}
+	}
+
+	result.Proteins = make([]entities.Protein, len(rows))
+	for i, row := range rows {
+		protein := toProteinEntity(&row.Protein)
+		protein.Rank, protein.Highlights, protein.Similarity = row.Rank, row.Highlights, row.Similarity
+		result.Proteins[i] = *protein
+	}
+
+	return result, nil
+}
+
+// estimateCount returns the planner's estimate of the rows the query matches, read from
+// EXPLAIN without running it; it is as accurate as the table statistics.
+func (p *ProteinRepositories) estimateCount(ctx context.Context, query *bun.SelectQuery) (int, error) {
+	var plan []byte
+	if err := p.db.NewRaw("EXPLAIN (FORMAT JSON) ?", query).Scan(ctx, &plan); err != nil {
+		return 0, fmt.Errorf("failed to estimate protein count: %w", err)
+	}
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil || len(explained) == 0 {
+		return 0, fmt.Errorf("failed to read the query plan: %v", err)
+	}
+	return int(explained[0].Plan.Rows), nil
+}
+
+// Facets counts the proteins matching the filter by each facet in opts, with the same
+// WHERE clause as Search. Term facets leave out proteins without a family, gene or taxon.
+func (p *ProteinRepositories) Facets(ctx context.Context, filter *entities.ProteinFilter, opts *entities.ProteinFacetOptions) (*entities.ProteinFacets, error) {
+	matched := applyProteinFilter(p.db.NewSelect().Model((*database.Protein)(nil)), filter).
+		Column("family_id", "family", "gene_id", "gene", "taxon_id", "length", "pi")
+
+	total, err := matched.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count proteins: %w", err)
+	}
+	facets := &entities.ProteinFacets{Total: total, Facets: make(map[string][]entities.FacetBucket, len(opts.Fields))}
+
+	for _, field := range opts.Fields {
+		query := p.db.NewSelect().TableExpr("(?) AS matched", matched)
+		switch field {
+		case entities.FacetFamily:
+			query = termFacet(query, "family_id", "family", opts.Size)
+		case entities.FacetGene:
+			query = termFacet(query, "gene_id", "gene", opts.Size)
+		case entities.FacetTaxon:
+			query = termFacet(query.Join("LEFT JOIN taxa AS t ON t.id = matched.taxon_id"),
+				"matched.taxon_id", "coalesce(t.scientific_name, matched.taxon_id::text)", opts.Size)
+		case entities.FacetLength:
+			query = histogramFacet(query, "length", float64(opts.LengthWidth))
+		case entities.FacetPI:
+			query = histogramFacet(query, "pi", opts.PIWidth)
+		default:
+			return nil, fmt.Errorf("unknown facet %q", field)
+		}
+
+		buckets := []entities.FacetBucket{}
+		if err := query.Scan(ctx, &buckets); err != nil {
+			return nil, fmt.Errorf("failed to count %s facet: %w", field, err)
+		}
+		facets.Facets[field] = buckets
+	}
+	return facets, nil
+}
 
+// termFacet counts the matched proteins by an ID column and its name, keeping the size
+// largest buckets.
+func termFacet(query *bun.SelectQuery, idColumn, valueExpr string, size int) *bun.SelectQuery {
+	return query.
+		ColumnExpr(idColumn + " AS id").
+		ColumnExpr(valueExpr + " AS value").
+		ColumnExpr("count(*) AS count").
+		Where(idColumn + " IS NOT NULL").
+		GroupExpr(idColumn + ", " + valueExpr).
+		OrderExpr("count DESC, value ASC").
+		Limit(size)
+}
+
+// histogramFacet counts the matched proteins by ranges of width over a numeric column.
+func histogramFacet(query *bun.SelectQuery, column string, width float64) *bun.SelectQuery {
+	return query.
+		ColumnExpr("floor(? / ?) * ? AS ?", bun.Ident(column), width, width, bun.Ident("from")).
+		ColumnExpr("floor(? / ?) * ? + ? AS ?", bun.Ident(column), width, width, width, bun.Ident("to")).
+		ColumnExpr("count(*) AS count").
+		Where("? IS NOT NULL", bun.Ident(column)).
+		GroupExpr("1, 2").
+		OrderExpr("1 ASC")
+}
+
+// proteinSearchRow is a protein with the relevance and highlights of a full-text search
+// and the similarity of a fuzzy one.
+type proteinSearchRow struct {
+	database.Protein `bun:",extend"`
+
+	Rank       *float64          `bun:"rank,scanonly"`
+	Highlights map[string]string `bun:"highlights,type:jsonb,scanonly"`
+	Similarity *float64          `bun:"similarity,scanonly"`
+	SortKey    *string           `bun:"sort_key,scanonly"`
+}
+
+// defaultMinSimilarity is the trigram similarity a fuzzy match needs when the filter
+// names none; it is also pg_trgm's default threshold.
+const defaultMinSimilarity = 0.3
+
+// proteinSimilaritySQL scores a protein against a possibly misspelled term (?0): the
+// best of its similarity to the gene and its word similarity to the name and each
+// alias, so that the term may match one word of a longer name.
+const proteinSimilaritySQL = `greatest(word_similarity(?0, name), similarity(?0, coalesce(gene, '')), ` +
+	`coalesce((SELECT max(word_similarity(?0, alias)) FROM unnest(aliases) AS alias), 0))`
+
+// proteinTermsSQL lists every name, gene and alias of a protein as (text, field,
+// similarity) rows, scored against ?0 as by proteinSimilaritySQL.
+const proteinTermsSQL = `SELECT name AS text, 'name' AS field, word_similarity(?0, name) AS similarity FROM proteins
+	WHERE deleted_at IS NULL
+	UNION ALL
+	SELECT gene, 'gene', similarity(?0, gene) FROM proteins WHERE gene IS NOT NULL AND deleted_at IS NULL
+	UNION ALL
+	SELECT alias, 'alias', word_similarity(?0, alias) FROM proteins, unnest(aliases) AS alias
+	WHERE deleted_at IS NULL`
+
+// proteinSuggestionsSQL finds the names, genes and aliases most similar to ?0, of at
+// least similarity ?1.
+const proteinSuggestionsSQL = `SELECT text, field, count(*) AS proteins, max(similarity) AS similarity
+FROM (` + proteinTermsSQL + `) AS terms
+WHERE similarity >= ?1
+GROUP BY text, field
+ORDER BY similarity DESC, proteins DESC, text
+LIMIT ?2`
+
+// proteinCompletionsSQL finds the names, genes and aliases that start with ?0, or have a
+// word that does; whole-term prefixes come first, then the closest and most common.
+const proteinCompletionsSQL = `SELECT text, field, count(*) AS proteins, max(similarity(?0, text)) AS similarity
+FROM (
+	SELECT name AS text, 'name' AS field FROM proteins WHERE (name ILIKE ?1 OR name ILIKE ?2) AND deleted_at IS NULL
+	UNION ALL
+	SELECT gene, 'gene' FROM proteins WHERE gene ILIKE ?1 AND deleted_at IS NULL
+	UNION ALL
+	SELECT alias, 'alias' FROM proteins, unnest(aliases) AS alias WHERE (alias ILIKE ?1 OR alias ILIKE ?2) AND deleted_at IS NULL
+) AS terms
+GROUP BY text, field
+ORDER BY text ILIKE ?1 DESC, similarity DESC, proteins DESC, text
+LIMIT ?3`
+
+// proteinTSQuerySQL parses a ProteinFilter.Query as a web search box would: quoted
+// phrases, "or" and "-" for exclusion.
+const proteinTSQuerySQL = "websearch_to_tsquery('english', ?)"
+
+// proteinRankSQL is the relevance of a protein to the full-text query ?0.
+const proteinRankSQL = "ts_rank_cd(search_vector, websearch_to_tsquery('english', ?0), 32)"
+
+const proteinHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MinWords=5, MaxWords=25, MaxFragments=2"
+
+// proteinTextFields are the text columns indexed by the proteins_search_vector trigger.
+var proteinTextFields = []string{"name", "gene", "family", "domain", "function", "bio_process", "cc"}
+
+// proteinHighlightsSQL builds a JSON object of ts_headline snippets for the fields that
+// match the query (?0), with headline options ?1.
+var proteinHighlightsSQL = func() string {
+	fields := make([]string, len(proteinTextFields))
+	for i, field := range proteinTextFields {
+		fields[i] = fmt.Sprintf(`'%[1]s', CASE WHEN to_tsvector('english', coalesce("%[1]s", '')) @@ websearch_to_tsquery('english', ?0) `+
+			`THEN ts_headline('english', "%[1]s", websearch_to_tsquery('english', ?0), ?1) END`, field)
+	}
+	return "jsonb_strip_nulls(jsonb_build_object(" + strings.Join(fields, ", ") + ")) AS highlights"
+}()
+
+// Suggest returns up to limit stored names, genes and aliases resembling term with at
+// least minSimilarity, most similar first.
+func (p *ProteinRepositories) Suggest(ctx context.Context, term string, minSimilarity float64, limit int) ([]entities.SearchSuggestion, error) {
+	var suggestions []entities.SearchSuggestion
+	if err := p.db.NewRaw(proteinSuggestionsSQL, term, minSimilarity, limit).Scan(ctx, &suggestions); err != nil {
+		return nil, fmt.Errorf("failed to suggest protein terms: %w", err)
+	}
+	return suggestions, nil
+}
+
+// Complete returns up to limit stored names, genes and aliases that complete prefix.
+func (p *ProteinRepositories) Complete(ctx context.Context, prefix string, limit int) ([]entities.SearchSuggestion, error) {
+	completions := []entities.SearchSuggestion{}
+	err := p.db.NewRaw(proteinCompletionsSQL, prefix, prefix+"%", "% "+prefix+"%", limit).Scan(ctx, &completions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete protein terms: %w", err)
+	}
+	return completions, nil
+}
+
+func (p *ProteinRepositories) Update(ctx context.Context, protein *entities.Protein) error {
+	if protein == nil {
+		return errors.New("protein is nil")
+	}
+
+	dbProtein := toProteinModel(protein)
+
+	// The write only applies to the version the protein was read at, in the same
+	// statement, so a write made in between is never overwritten.
+	return runInChange(ctx, p.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().Model(dbProtein).
+			ExcludeColumn("version").
+			Where("id = ?", protein.ID).
+			Where("version = ?", protein.Version).
+			Returning("version").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update protein: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return entities.ErrVersionConflict
+		}
+		protein.Version = dbProtein.Version
+		return nil
+	})
+}
+
+// Delete moves a protein to the trash if it is still at the given version; its
+// annotations stay until it is purged.
+func (p *ProteinRepositories) Delete(ctx context.Context, id string, version int) error {
+	return runInChange(ctx, p.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewDelete().Model((*database.Protein)(nil)).
+			Where("id = ?", id).
+			Where("version = ?", version).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete protein: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return entities.ErrVersionConflict
+		}
+		return nil
+	})
+}
+
+// ListDeleted returns a page of the trash, most recently deleted first, and the number
+// of proteins in it.
+func (p *ProteinRepositories) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Protein, int, error) {
+	var dbProteins []database.Protein
+	total, err := p.db.NewSelect().Model(&dbProteins).
+		WhereDeleted().
+		OrderExpr("deleted_at DESC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list deleted proteins: %w", err)
+	}
+
+	proteins := make([]*entities.Protein, len(dbProteins))
+	for i := range dbProteins {
+		proteins[i] = toProteinEntity(&dbProteins[i])
+	}
+	return proteins, total, nil
+}
+
+// Restore takes a protein out of the trash; sql.ErrNoRows reports that it was not there.
+func (p *ProteinRepositories) Restore(ctx context.Context, id string) error {
+	return runInChange(ctx, p.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().Model((*database.Protein)(nil)).
+			WhereDeleted().
+			Set("deleted_at = NULL").
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to restore protein: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+}
+
+// Purge deletes for good the proteins that went to the trash before the given time,
+// and their annotations with them. It returns how many were purged.
+func (p *ProteinRepositories) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int64
+	err := runInChange(ctx, p.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewDelete().Model((*database.Protein)(nil)).
+			WhereDeleted().
+			Where("deleted_at < ?", deletedBefore).
+			ForceDelete().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to purge deleted proteins: %w", err)
+		}
+		purged, _ = result.RowsAffected()
+		return nil
+	})
+	return int(purged), err
+}
+
+func (p *ProteinRepositories) GetStats(ctx context.Context) (*entities.ProteinStats, error) {
+	var stats entities.ProteinStats
+
+	// Use BunDB's built-in functions for better safety and readability
+	err := p.db.NewSelect().Model((*database.Protein)(nil)).
+		ColumnExpr("COUNT(?) as total_proteins", bun.Ident("*")).
+		ColumnExpr("AVG(?) as avg_length", bun.Ident("length")).
+		ColumnExpr("AVG(?) as avg_mw", bun.Ident("mw")).
+		ColumnExpr("AVG(?) as avg_pi", bun.Ident("pi")).
+		ColumnExpr("AVG(?) as avg_n_interactors", bun.Ident("n_interactors")).
+		ColumnExpr("AVG(?) as avg_hydrophobicity", bun.Ident("hydrophobicity_gravy")).
+		ColumnExpr("COUNT(DISTINCT ?) as total_genes", bun.Ident("gene")).
+		ColumnExpr("COUNT(DISTINCT ?) as total_families", bun.Ident("family")).
+		Scan(ctx, &stats)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get protein stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetRankCounts counts proteins by their ancestor taxon of the given rank (species,
+// genus, family, ...). Proteins without a taxon of that rank in their lineage are not
+// counted.
+func (p *ProteinRepositories) GetRankCounts(ctx context.Context, rank string) ([]entities.RankCount, error) {
+	var counts []entities.RankCount
+	if err := p.db.NewRaw(taxonRankCountsSQL, rank).Scan(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("failed to count proteins by rank: %w", err)
+	}
+	return counts, nil
+}
+
+func (p *ProteinRepositories) BulkCreate(ctx context.Context, proteins []*entities.Protein) error {
+	if len(proteins) == 0 {
+		return errors.New("no proteins to create")
+	}
+
+	dbProteins := make([]*database.Protein, len(proteins))
+	for i, protein := range proteins {
+		dbProteins[i] = toProteinModel(protein)
+	}
+
+	return runInChange(ctx, p.db, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(&dbProteins).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to bulk create proteins: %w", err)
+		}
+		return nil
+	})
+}
+
+func (p *ProteinRepositories) GetByIDs(ctx context.Context, ids []string) ([]*entities.Protein, error) {
+	if len(ids) == 0 {
+		return []*entities.Protein{}, nil
+	}
+
+	var dbProteins []database.Protein
+	err := p.db.NewSelect().Model(&dbProteins).Where("id IN (?)", bun.In(ids)).Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proteins by IDs: %w", err)
+	}
+
+	byID := make(map[string]*entities.Protein, len(dbProteins))
+	for i := range dbProteins {
+		byID[dbProteins[i].ID] = toProteinEntity(&dbProteins[i])
+	}
+
+	// Preserve the caller's order
+	proteins := make([]*entities.Protein, 0, len(dbProteins))
+	for _, id := range ids {
+		if protein, ok := byID[id]; ok {
+			proteins = append(proteins, protein)
+		}
+	}
+	return proteins, nil
+}
+
+func (p *ProteinRepositories) GetByFamily(ctx context.Context, family string) ([]*entities.Protein, error) {
+	var dbProteins []database.Protein
+	err := p.db.NewSelect().Model(&dbProteins).Where("lower(family) = lower(?)", family).OrderExpr("id ASC").Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proteins by family: %w", err)
+	}
+
+	proteins := make([]*entities.Protein, len(dbProteins))
+	for i := range dbProteins {
+		proteins[i] = toProteinEntity(&dbProteins[i])
+	}
+	return proteins, nil
+}
+
+// ForEachBatch walks the whole proteins table in ID order, batchSize rows at a time.
+func (p *ProteinRepositories) ForEachBatch(ctx context.Context, batchSize int, fn func(proteins []*entities.Protein) error) error {
+	return p.ForEachMatch(ctx, &entities.ProteinFilter{}, batchSize, fn)
+}
+
+// ForEachMatch walks every protein matching the filter in ID order, batchSize rows at a
+// time. The filter's paging and ordering fields are ignored.
+func (p *ProteinRepositories) ForEachMatch(ctx context.Context, filter *entities.ProteinFilter, batchSize int, fn func(proteins []*entities.Protein) error) error {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	lastID := ""
+	for {
+		var dbProteins []database.Protein
+		err := applyProteinFilter(p.db.NewSelect().Model(&dbProteins), filter).
+			Where("id > ?", lastID).
+			OrderExpr("id ASC").
+			Limit(batchSize).
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to iterate proteins: %w", err)
+		}
+		if len(dbProteins) == 0 {
+			return nil
+		}
+
+		proteins := make([]*entities.Protein, len(dbProteins))
+		for i := range dbProteins {
+			proteins[i] = toProteinEntity(&dbProteins[i])
+		}
+		if err := fn(proteins); err != nil {
+			return err
+		}
+
+		if len(dbProteins) < batchSize {
+			return nil
+		}
+		lastID = dbProteins[len(dbProteins)-1].ID
+	}
+}
+
+// GetStoredVersions returns the stored proteins with the given IDs, in the trash or
+// not, by ID. Only their ID, version and deletion time are read.
+func (p *ProteinRepositories) GetStoredVersions(ctx context.Context, ids []string) (map[string]*entities.Protein, error) {
+	stored := make(map[string]*entities.Protein)
+	if len(ids) == 0 {
+		return stored, nil
+	}
+
+	var dbProteins []database.Protein
+	err := p.db.NewSelect().Model(&dbProteins).
+		Column("id", "version", "deleted_at").
+		WhereAllWithDeleted().
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing proteins: %w", err)
+	}
+	for i := range dbProteins {
+		stored[dbProteins[i].ID] = toProteinEntity(&dbProteins[i])
+	}
+	return stored, nil
+}
+
+// ResolveIDs maps identifiers from an annotation file to stored proteins: an ID that
+// is a protein ID maps to itself, otherwise a cross-reference of database db (e.g.
+// UniProtKB accessions) is followed. Unknown IDs are absent.
+func (p *ProteinRepositories) ResolveIDs(ctx context.Context, db string, ids []string) (map[string]string, error) {
+	resolved := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return resolved, nil
+	}
+
+	var refs []struct {
+		Accession string `bun:"accession"`
+		ProteinID string `bun:"protein_id"`
+	}
+	err := p.db.NewSelect().Model((*database.ProteinCrossReference)(nil)).
+		Column("accession", "protein_id").
+		Where("database = ?", db).
+		Where("accession IN (?)", bun.In(ids)).
+		OrderExpr("protein_id ASC").
+		Scan(ctx, &refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve protein accessions: %w", err)
+	}
+	for _, ref := range refs {
+		if _, ok := resolved[ref.Accession]; !ok {
+			resolved[ref.Accession] = ref.ProteinID
+		}
+	}
+
+	var found []string
+	err = p.db.NewSelect().Model((*database.Protein)(nil)).Column("id").Where("id IN (?)", bun.In(ids)).Scan(ctx, &found)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve protein IDs: %w", err)
+	}
+	for _, id := range found {
+		resolved[id] = id
+	}
+	return resolved, nil
+}
+
+// ResolveGenes maps gene symbols to the proteins of that gene, case-insensitively.
+// Unknown symbols are absent.
+func (p *ProteinRepositories) ResolveGenes(ctx context.Context, genes []string) (map[string][]string, error) {
+	resolved := make(map[string][]string, len(genes))
+	if len(genes) == 0 {
+		return resolved, nil
+	}
+	lower := make([]string, len(genes))
+	byLower := make(map[string][]string, len(genes))
+	for i, gene := range genes {
+		lower[i] = strings.ToLower(gene)
+		byLower[lower[i]] = append(byLower[lower[i]], gene)
+	}
+
+	var rows []struct {
+		ID   string `bun:"id"`
+		Gene string `bun:"gene"`
+	}
+	err := p.db.NewSelect().Model((*database.Protein)(nil)).
+		Column("id", "gene").
+		Where("LOWER(gene) IN (?)", bun.In(lower)).
+		OrderExpr("id ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve gene symbols: %w", err)
+	}
+	for _, row := range rows {
+		for _, gene := range byLower[strings.ToLower(row.Gene)] {
+			resolved[gene] = append(resolved[gene], row.ID)
+		}
+	}
+	return resolved, nil
+}
+
+// applyProteinFilter adds the WHERE clauses of a ProteinFilter; paging and ordering are
+// left to the caller.
+func applyProteinFilter(query *bun.SelectQuery, filter *entities.ProteinFilter) *bun.SelectQuery {
+	if filter.Query != nil {
+		query = query.Where("search_vector @@ "+proteinTSQuerySQL, *filter.Query)
+	}
+	if filter.Fuzzy != nil {
+		minSimilarity := defaultMinSimilarity
+		if filter.MinSimilarity != nil {
+			minSimilarity = *filter.MinSimilarity
+		}
+		query = query.Where(proteinSimilaritySQL+" >= ?1", *filter.Fuzzy, minSimilarity)
+	}
 	if filter.ID != nil {
 		query = query.Where("id = ?", *filter.ID)
 	}
@@ -140,6 +708,12 @@ func (p *ProteinRepositories) Search(ctx context.Context, filter *entities.Prote
 	if filter.Family != nil {
 		query = query.Where("family ILIKE ?", "%"+*filter.Family+"%")
 	}
+	if filter.GeneID != nil {
+		query = query.Where("gene_id = ?", *filter.GeneID)
+	}
+	if filter.FamilyID != nil {
+		query = query.Where("family_id = ?", *filter.FamilyID)
+	}
 	if filter.MinLength != nil {
 		query = query.Where("length >= ?", *filter.MinLength)
 	}
@@ -170,88 +744,65 @@ func (p *ProteinRepositories) Search(ctx context.Context, filter *entities.Prote
 	if filter.MaxDRank != nil {
 		query = query.Where("d_rank <= ?", *filter.MaxDRank)
 	}
-
-	total, err := query.Count(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count proteins: %w", err)
+	if filter.GOTerm != nil {
+		query = query.Where("id IN ("+goAnnotatedProteinsSQL+")", *filter.GOTerm)
 	}
-
-	orderBy := "created"
-	if filter.OrderBy != "" {
-		orderBy = filter.OrderBy
+	if filter.TaxonID != nil {
+		query = query.Where("taxon_id IN ("+taxonSubtreeSQL+")", *filter.TaxonID)
 	}
-	orderDirection := "DESC"
-	if filter.OrderDirection != "" {
-		orderDirection = filter.OrderDirection
+	if filter.Where != nil {
+		query = query.Where("?", compileFilterExpr(filter.Where))
 	}
+	return query
+}
 
-	var dbProteins []database.Protein
-
-	// Use BunDB's safe ordering instead of string interpolation
-	if orderDirection == "ASC" {
-		err = query.OrderExpr("? ASC", bun.Ident(orderBy)).
-			Limit(filter.Limit).
-			Offset(filter.Offset).
-			Scan(ctx, &dbProteins)
-	} else {
-		err = query.OrderExpr("? DESC", bun.Ident(orderBy)).
-			Limit(filter.Limit).
-			Offset(filter.Offset).
-			Scan(ctx, &dbProteins)
+func toProteinEntity(dbProtein *database.Protein) *entities.Protein {
+	protein := &entities.Protein{
+		ID:                  dbProtein.ID,
+		Name:                dbProtein.Name,
+		Gene:                dbProtein.Gene,
+		Aliases:             dbProtein.Aliases,
+		GeneID:              dbProtein.GeneID,
+		Taxo:                dbProtein.Taxo,
+		TaxonID:             dbProtein.TaxonID,
+		CC:                  dbProtein.CC,
+		Length:              dbProtein.Length,
+		Domain:              dbProtein.Domain,
+		Family:              dbProtein.Family,
+		FamilyID:            dbProtein.FamilyID,
+		BioProcess:          dbProtein.BioProcess,
+		Function:            dbProtein.Function,
+		MW:                  dbProtein.MW,
+		Seq:                 dbProtein.Seq,
+		NInteractors:        dbProtein.NInteractors,
+		PI:                  dbProtein.PI,
+		NC74:                dbProtein.NC74,
+		HydrophobicityGravy: dbProtein.HydrophobicityGravy,
+		DRank:               dbProtein.DRank,
+		LRank:               dbProtein.LRank,
+		FRank:               dbProtein.FRank,
+		Created:             dbProtein.Created,
+		Updated:             dbProtein.Updated,
+		Version:             dbProtein.Version,
 	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to search proteins: %w", err)
+	if !dbProtein.DeletedAt.IsZero() {
+		deletedAt := dbProtein.DeletedAt
+		protein.DeletedAt = &deletedAt
 	}
-
-	proteins := make([]entities.Protein, len(dbProteins))
-	for i, dbProtein := range dbProteins {
-		proteins[i] = entities.Protein{
-			ID:                  dbProtein.ID,
-			Name:                dbProtein.Name,
-			Gene:                dbProtein.Gene,
-			Taxo:                dbProtein.Taxo,
-			CC:                  dbProtein.CC,
-			Length:              dbProtein.Length,
-			Domain:              dbProtein.Domain,
-			Family:              dbProtein.Family,
-			BioProcess:          dbProtein.BioProcess,
-			Function:            dbProtein.Function,
-			MW:                  dbProtein.MW,
-			Seq:                 dbProtein.Seq,
-			NInteractors:        dbProtein.NInteractors,
-			PI:                  dbProtein.PI,
-			NC74:                dbProtein.NC74,
-			HydrophobicityGravy: dbProtein.HydrophobicityGravy,
-			DRank:               dbProtein.DRank,
-			LRank:               dbProtein.LRank,
-			FRank:               dbProtein.FRank,
-			Created:             dbProtein.Created,
-			Updated:             dbProtein.Updated,
-		}
-	}
-
-	hasMore := filter.Offset+filter.Limit < total
-
-	return &entities.PaginatedProteins{
-		Proteins: proteins,
-		Total:    total,
-		Limit:    filter.Limit,
-		Offset:   filter.Offset,
-		HasMore:  hasMore,
-	}, nil
+	return protein
 }
 
-func (p *ProteinRepositories) Update(ctx context.Context, protein *entities.Protein) error {
-	if protein == nil {
-		return errors.New("protein is nil")
-	}
-
-	dbProtein := &database.Protein{
+// toProteinModel maps a protein onto its row for every write path. The version is left
+// to the column default and the proteins_bump_version trigger, and the trigger that
+// links genes and families sets GeneID and FamilyID from Gene and Family.
+func toProteinModel(protein *entities.Protein) *database.Protein {
+	return &database.Protein{
 		ID:                  protein.ID,
 		Name:                protein.Name,
 		Gene:                protein.Gene,
+		Aliases:             protein.Aliases,
 		Taxo:                protein.Taxo,
+		TaxonID:             protein.TaxonID,
 		CC:                  protein.CC,
 		Length:              protein.Length,
 		Domain:              protein.Domain,
@@ -267,84 +818,11 @@ func (p *ProteinRepositories) Update(ctx context.Context, protein *entities.Prot
 		DRank:               protein.DRank,
 		LRank:               protein.LRank,
 		FRank:               protein.FRank,
+		GeneID:              protein.GeneID,
+		FamilyID:            protein.FamilyID,
 		Created:             protein.Created,
 		Updated:             protein.Updated,
 	}
-
-	_, err := p.db.NewUpdate().Model(dbProtein).Where("id = ?", protein.ID).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to update protein: %w", err)
-	}
-	return nil
-}
-
-func (p *ProteinRepositories) Delete(ctx context.Context, id string) error {
-	_, err := p.db.NewDelete().Model((*database.Protein)(nil)).Where("id = ?", id).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to delete protein: %w", err)
-	}
-	return nil
-}
-
-func (p *ProteinRepositories) GetStats(ctx context.Context) (*entities.ProteinStats, error) {
-	var stats entities.ProteinStats
-
-	// Use BunDB's built-in functions for better safety and readability
-	err := p.db.NewSelect().Model((*database.Protein)(nil)).
-		ColumnExpr("COUNT(?) as total_proteins", bun.Ident("*")).
-		ColumnExpr("AVG(?) as avg_length", bun.Ident("length")).
-		ColumnExpr("AVG(?) as avg_mw", bun.Ident("mw")).
-		ColumnExpr("AVG(?) as avg_pi", bun.Ident("pi")).
-		ColumnExpr("AVG(?) as avg_n_interactors", bun.Ident("n_interactors")).
-		ColumnExpr("AVG(?) as avg_hydrophobicity", bun.Ident("hydrophobicity_gravy")).
-		ColumnExpr("COUNT(DISTINCT ?) as total_genes", bun.Ident("gene")).
-		ColumnExpr("COUNT(DISTINCT ?) as total_families", bun.Ident("family")).
-		Scan(ctx, &stats)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to get protein stats: %w", err)
-	}
-
-	return &stats, nil
-}
-
-func (p *ProteinRepositories) BulkCreate(ctx context.Context, proteins []*entities.Protein) error {
-	if len(proteins) == 0 {
-		return errors.New("no proteins to create")
-	}
-
-	dbProteins := make([]*database.Protein, len(proteins))
-	for i, protein := range proteins {
-		dbProteins[i] = &database.Protein{
-			ID:                  protein.ID,
-			Name:                protein.Name,
-			Gene:                protein.Gene,
-			Taxo:                protein.Taxo,
-			CC:                  protein.CC,
-			Length:              protein.Length,
-			Domain:              protein.Domain,
-			Family:              protein.Family,
-			BioProcess:          protein.BioProcess,
-			Function:            protein.Function,
-			MW:                  protein.MW,
-			Seq:                 protein.Seq,
-			NInteractors:        protein.NInteractors,
-			PI:                  protein.PI,
-			NC74:                protein.NC74,
-			HydrophobicityGravy: protein.HydrophobicityGravy,
-			DRank:               protein.DRank,
-			LRank:               protein.LRank,
-			FRank:               protein.FRank,
-			Created:             protein.Created,
-			Updated:             protein.Updated,
-		}
-	}
-
-	_, err := p.db.NewInsert().Model(&dbProteins).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to bulk create proteins: %w", err)
-	}
-	return nil
 }
 
 type GeneRepositories struct {
@@ -369,6 +847,7 @@ func (g *GeneRepositories) Create(ctx context.Context, gene *entities.Gene) erro
 	if err != nil {
 		return fmt.Errorf("failed to create gene: %w", err)
 	}
+	gene.ID = dbGene.ID
 	return nil
 }
 
@@ -378,10 +857,15 @@ func (g *GeneRepositories) GetByID(ctx context.Context, id int) (*entities.Gene,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gene by ID: %w", err)
 	}
+	count, err := g.db.NewSelect().Model((*database.Protein)(nil)).Where("gene_id = ?", id).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count proteins of gene: %w", err)
+	}
 
 	gene := &entities.Gene{
-		ID:   dbGene.ID,
-		Name: dbGene.Name,
+		ID:           dbGene.ID,
+		Name:         dbGene.Name,
+		ProteinCount: count,
 	}
 
 	return gene, nil
@@ -430,19 +914,83 @@ func (g *GeneRepositories) Update(ctx context.Context, gene *entities.Gene) erro
 		Name: gene.Name,
 	}
 
-	_, err := g.db.NewUpdate().Model(dbGene).Where("id = ?", gene.ID).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to update gene: %w", err)
-	}
-	return nil
+	// Proteins keep the name as text, so a rename is carried over to them.
+	return runInChange(ctx, g.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().Model(dbGene).Where("id = ?", gene.ID).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update gene: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return sql.ErrNoRows
+		}
+		_, err = tx.NewUpdate().Model((*database.Protein)(nil)).WhereAllWithDeleted().
+			Set("gene = ?", gene.Name).
+			Where("gene_id = ?", gene.ID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to rename the gene of proteins: %w", err)
+		}
+		return nil
+	})
 }
 
+// Delete deletes a gene no protein links to, in the trash or not, and returns
+// entities.ErrGeneInUse otherwise: proteins keep the name as text, which their next write
+// would link to a recreated row.
 func (g *GeneRepositories) Delete(ctx context.Context, id int) error {
-	_, err := g.db.NewDelete().Model((*database.Gene)(nil)).Where("id = ?", id).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to delete gene: %w", err)
+	return g.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		// Lock the row so that no protein links to it before it is deleted.
+		err := tx.NewSelect().Model((*database.Gene)(nil)).Column("id").Where("id = ?", id).For("UPDATE").Scan(ctx, new(int))
+		if err != nil {
+			return err
+		}
+		linked, err := tx.NewSelect().Model((*database.Protein)(nil)).WhereAllWithDeleted().Where("gene_id = ?", id).Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check the proteins of the gene: %w", err)
+		}
+		if linked {
+			return entities.ErrGeneInUse
+		}
+		result, err := tx.NewDelete().Model((*database.Gene)(nil)).Where("id = ?", id).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete gene: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+}
+
+// List pages through genes by name, keeping those whose name contains query
+// when it is given, with the number of proteins linked to each.
+func (g *GeneRepositories) List(ctx context.Context, query string, limit, offset int) ([]*entities.Gene, error) {
+	var rows []struct {
+		database.Gene `bun:",extend"`
+		ProteinCount  int `bun:"protein_count"`
 	}
-	return nil
+	q := g.db.NewSelect().Model(&rows).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("(SELECT count(*) FROM proteins AS p WHERE p.gene_id = ?TableAlias.id AND p.deleted_at IS NULL) AS protein_count").
+		OrderExpr("name ASC").
+		Limit(limit).
+		Offset(offset)
+	if query != "" {
+		q = q.Where("name ILIKE ?", "%"+query+"%")
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list genes: %w", err)
+	}
+
+	genes := make([]*entities.Gene, len(rows))
+	for i, row := range rows {
+		genes[i] = &entities.Gene{
+			ID:           row.ID,
+			Name:         row.Name,
+			ProteinCount: row.ProteinCount,
+		}
+	}
+	return genes, nil
 }
 
 type ProteinFamilyRepositories struct {
@@ -467,6 +1015,7 @@ func (pf *ProteinFamilyRepositories) Create(ctx context.Context, family *entitie
 	if err != nil {
 		return fmt.Errorf("failed to create protein family: %w", err)
 	}
+	family.ID = dbFamily.ID
 	return nil
 }
 
@@ -476,10 +1025,15 @@ func (pf *ProteinFamilyRepositories) GetByID(ctx context.Context, id int) (*enti
 	if err != nil {
 		return nil, fmt.Errorf("failed to get protein family by ID: %w", err)
 	}
+	count, err := pf.db.NewSelect().Model((*database.Protein)(nil)).Where("family_id = ?", id).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count proteins of protein family: %w", err)
+	}
 
 	family := &entities.ProteinFamily{
-		ID:   dbFamily.ID,
-		Name: dbFamily.Name,
+		ID:           dbFamily.ID,
+		Name:         dbFamily.Name,
+		ProteinCount: count,
 	}
 
 	return family, nil
@@ -528,17 +1082,81 @@ func (pf *ProteinFamilyRepositories) Update(ctx context.Context, family *entitie
 		Name: family.Name,
 	}
 
-	_, err := pf.db.NewUpdate().Model(dbFamily).Where("id = ?", family.ID).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to update protein family: %w", err)
-	}
-	return nil
+	// Proteins keep the name as text, so a rename is carried over to them.
+	return runInChange(ctx, pf.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().Model(dbFamily).Where("id = ?", family.ID).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update protein family: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return sql.ErrNoRows
+		}
+		_, err = tx.NewUpdate().Model((*database.Protein)(nil)).WhereAllWithDeleted().
+			Set("family = ?", family.Name).
+			Where("family_id = ?", family.ID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to rename the protein family of proteins: %w", err)
+		}
+		return nil
+	})
 }
 
+// Delete deletes a protein family no protein links to, in the trash or not, and returns
+// entities.ErrFamilyInUse otherwise: proteins keep the name as text, which their next write
+// would link to a recreated row.
 func (pf *ProteinFamilyRepositories) Delete(ctx context.Context, id int) error {
-	_, err := pf.db.NewDelete().Model((*database.ProteinFamily)(nil)).Where("id = ?", id).Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to delete protein family: %w", err)
+	return pf.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		// Lock the row so that no protein links to it before it is deleted.
+		err := tx.NewSelect().Model((*database.ProteinFamily)(nil)).Column("id").Where("id = ?", id).For("UPDATE").Scan(ctx, new(int))
+		if err != nil {
+			return err
+		}
+		linked, err := tx.NewSelect().Model((*database.Protein)(nil)).WhereAllWithDeleted().Where("family_id = ?", id).Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check the proteins of the protein family: %w", err)
+		}
+		if linked {
+			return entities.ErrFamilyInUse
+		}
+		result, err := tx.NewDelete().Model((*database.ProteinFamily)(nil)).Where("id = ?", id).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete protein family: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+}
+
+// List pages through protein families by name, keeping those whose name contains query
+// when it is given, with the number of proteins linked to each.
+func (pf *ProteinFamilyRepositories) List(ctx context.Context, query string, limit, offset int) ([]*entities.ProteinFamily, error) {
+	var rows []struct {
+		database.ProteinFamily `bun:",extend"`
+		ProteinCount           int `bun:"protein_count"`
 	}
-	return nil
+	q := pf.db.NewSelect().Model(&rows).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("(SELECT count(*) FROM proteins AS p WHERE p.family_id = ?TableAlias.id AND p.deleted_at IS NULL) AS protein_count").
+		OrderExpr("name ASC").
+		Limit(limit).
+		Offset(offset)
+	if query != "" {
+		q = q.Where("name ILIKE ?", "%"+query+"%")
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list protein families: %w", err)
+	}
+
+	families := make([]*entities.ProteinFamily, len(rows))
+	for i, row := range rows {
+		families[i] = &entities.ProteinFamily{
+			ID:           row.ID,
+			Name:         row.Name,
+			ProteinCount: row.ProteinCount,
+		}
+	}
+	return families, nil
 }
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+
+	"github.com/uptrace/bun"
+)
+
+type RevisionRepositories struct {
+	db *bun.DB
+}
+
+func NewRevisionRepository(db *bun.DB) *RevisionRepositories {
+	return &RevisionRepositories{db: db}
+}
+
+// List returns a page of the revisions of a protein, newest first and without their
+// snapshots, and the number of revisions.
+func (r *RevisionRepositories) List(ctx context.Context, proteinID string, limit, offset int) ([]*entities.ProteinRevision, int, error) {
+	var dbRevisions []database.ProteinRevision
+	total, err := r.db.NewSelect().Model(&dbRevisions).
+		ExcludeColumn("snapshot").
+		Where("protein_id = ?", proteinID).
+		OrderExpr("revision DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list protein revisions: %w", err)
+	}
+
+	revisions := make([]*entities.ProteinRevision, len(dbRevisions))
+	for i := range dbRevisions {
+		if revisions[i], err = toRevisionEntity(&dbRevisions[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return revisions, total, nil
+}
+
+// Get returns a revision of a protein with its snapshot; sql.ErrNoRows reports that
+// there is no such revision.
+func (r *RevisionRepositories) Get(ctx context.Context, proteinID string, revision int) (*entities.ProteinRevision, error) {
+	var dbRevision database.ProteinRevision
+	err := r.db.NewSelect().Model(&dbRevision).
+		Where("protein_id = ?", proteinID).
+		Where("revision = ?", revision).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toRevisionEntity(&dbRevision)
+}
+
+// Latest returns the number of the newest revision of a protein, or 0 when it has none.
+func (r *RevisionRepositories) Latest(ctx context.Context, proteinID string) (int, error) {
+	var latest int
+	err := r.db.NewSelect().Model((*database.ProteinRevision)(nil)).
+		ColumnExpr("coalesce(max(revision), 0)").
+		Where("protein_id = ?", proteinID).
+		Scan(ctx, &latest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the latest protein revision: %w", err)
+	}
+	return latest, nil
+}
+
+func toRevisionEntity(dbRevision *database.ProteinRevision) (*entities.ProteinRevision, error) {
+	revision := &entities.ProteinRevision{
+		ID:            dbRevision.ID,
+		ProteinID:     dbRevision.ProteinID,
+		Revision:      dbRevision.Revision,
+		Action:        dbRevision.Action,
+		ChangedFields: dbRevision.ChangedFields,
+		Actor:         dbRevision.Actor,
+		Note:          dbRevision.Note,
+		CreatedAt:     dbRevision.CreatedAt,
+	}
+	if revision.ChangedFields == nil {
+		revision.ChangedFields = []string{}
+	}
+	if len(dbRevision.Snapshot) > 0 {
+		var snapshot database.Protein
+		if err := json.Unmarshal(dbRevision.Snapshot, &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to read snapshot of protein revision %d: %w", dbRevision.ID, err)
+		}
+		revision.Snapshot = toProteinEntity(&snapshot)
+	}
+	return revision, nil
+}
+
+// runInChange runs fn in a transaction whose writes to proteins record the actor and
+// note of the entities.Change in ctx on the revisions they leave.
+func runInChange(ctx context.Context, db *bun.DB, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if err := setChange(ctx, tx); err != nil {
+			return err
+		}
+		return fn(ctx, tx)
+	})
+}
+
+// setChange hands the change in ctx to the revision trigger for the rest of tx.
+func setChange(ctx context.Context, tx bun.Tx) error {
+	change := entities.ChangeFrom(ctx)
+	_, err := tx.NewRaw("SELECT set_config('app.actor', ?, true), set_config('app.change_note', ?, true)", change.Actor, change.Note).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record the author of the change: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"go-crawler/web/BE/internal/domain/entities"
+
+	"github.com/uptrace/bun"
+)
+
+// proteinOrder is the sort key of a protein search: an SQL expression with its argument
+// as ?0, and a direction. NULL keys sort last and ties go by ascending ID, so every
+// row has one place in the order and a cursor can resume after it.
+type proteinOrder struct {
+	name string
+	expr string
+	arg  any
+	desc bool
+}
+
+// proteinSearchOrder is relevance for a full-text search and similarity for a fuzzy one
+// unless the filter orders otherwise; by default the newest proteins come first.
+func proteinSearchOrder(filter *entities.ProteinFilter) proteinOrder {
+	switch {
+	case filter.Query != nil && (filter.OrderBy == "" || filter.OrderBy == "rank"):
+		return proteinOrder{name: "rank", expr: proteinRankSQL, arg: *filter.Query, desc: true}
+	case filter.Fuzzy != nil && (filter.OrderBy == "" || filter.OrderBy == "similarity"):
+		return proteinOrder{name: "similarity", expr: proteinSimilaritySQL, arg: *filter.Fuzzy, desc: true}
+	}
+
+	orderBy := "created"
+	if filter.OrderBy != "" {
+		orderBy = filter.OrderBy
+	}
+	// Use BunDB's safe ordering instead of string interpolation
+	return proteinOrder{name: orderBy, expr: "?0", arg: bun.Ident(orderBy), desc: filter.OrderDirection != "ASC"}
+}
+
+func (o proteinOrder) String() string {
+	if o.desc {
+		return o.name + " DESC"
+	}
+	return o.name + " ASC"
+}
+
+// apply orders the query, reversed when reading backward from a cursor.
+func (o proteinOrder) apply(query *bun.SelectQuery, backward bool) *bun.SelectQuery {
+	direction, nulls, idDirection := "ASC", "NULLS LAST", "ASC"
+	if o.desc != backward {
+		direction = "DESC"
+	}
+	if backward {
+		nulls, idDirection = "NULLS FIRST", "DESC"
+	}
+	return query.OrderExpr("("+o.expr+") "+direction+" "+nulls+", id "+idDirection, o.arg)
+}
+
+// searchCursor is the position of a row in a search order: its sort key as text (nil
+// for NULL) and its ID. A backward cursor reads the page before the row, a forward one
+// the page after it.
+type searchCursor struct {
+	Order    string  `json:"o"`
+	Key      *string `json:"k"`
+	ID       string  `json:"id"`
+	Backward bool    `json:"b,omitempty"`
+}
+
+func newSearchCursor(order proteinOrder, row *proteinSearchRow, backward bool) *searchCursor {
+	return &searchCursor{Order: order.String(), Key: row.SortKey, ID: row.ID, Backward: backward}
+}
+
+// encode makes the cursor opaque to clients.
+func (c *searchCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSearchCursor reads a cursor, which must come from a search in the same order.
+func decodeSearchCursor(s string, order proteinOrder) (*searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, entities.ErrInvalidCursor
+	}
+	var cursor searchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" || cursor.Order != order.String() {
+		return nil, entities.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// after keeps the rows past the cursor in the order: the key is compared as an untyped
+// literal, which PostgreSQL reads as the type of the sort expression.
+func (c *searchCursor) after(query *bun.SelectQuery, order proteinOrder) *bun.SelectQuery {
+	key := "(" + order.expr + ")"
+	greater, less := ">", "<"
+	if order.desc {
+		greater, less = less, greater
+	}
+
+	switch {
+	case c.Backward && c.Key == nil:
+		return query.Where(key+" IS NOT NULL OR id < ?1", order.arg, c.ID)
+	case c.Backward:
+		return query.Where(key+" "+less+" ?1 OR ("+key+" = ?1 AND id < ?2)", order.arg, *c.Key, c.ID)
+	case c.Key == nil:
+		return query.Where(key+" IS NULL AND id > ?1", order.arg, c.ID)
+	default:
+		return query.Where(key+" "+greater+" ?1 OR ("+key+" = ?1 AND id > ?2) OR "+key+" IS NULL", order.arg, *c.Key, c.ID)
+	}
+}
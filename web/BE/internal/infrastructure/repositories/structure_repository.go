@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+
+	"github.com/uptrace/bun"
+)
+
+type StructureRepositories struct {
+	db *bun.DB
+}
+
+func NewStructureRepository(db *bun.DB) *StructureRepositories {
+	return &StructureRepositories{db: db}
+}
+
+// Create stores the structure and its chains in one transaction and sets their IDs.
+func (r *StructureRepositories) Create(ctx context.Context, entry *entities.StructureEntry) error {
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		dbStructure := &database.ProteinStructure{
+			PDBID:      entry.PDBID,
+			Title:      entry.Title,
+			Method:     entry.Method,
+			Resolution: entry.Resolution,
+			Format:     entry.Format,
+			FileName:   entry.FileName,
+			Path:       entry.Path,
+			Checksum:   entry.Checksum,
+			FileSize:   entry.FileSize,
+			Created:    entry.Created,
+		}
+		if _, err := tx.NewInsert().Model(dbStructure).Returning("id").Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create structure: %w", err)
+		}
+		entry.ID = dbStructure.ID
+		if len(entry.Chains) == 0 {
+			return nil
+		}
+
+		dbChains := make([]*database.StructureChain, len(entry.Chains))
+		for i, chain := range entry.Chains {
+			chain.StructureID = entry.ID
+			dbChains[i] = toStructureChainModel(chain)
+		}
+		if _, err := tx.NewInsert().Model(&dbChains).Returning("id").Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create structure chains: %w", err)
+		}
+		for i, dbChain := range dbChains {
+			entry.Chains[i].ID = dbChain.ID
+		}
+		return nil
+	})
+}
+
+// GetByID returns the structure with its chains.
+func (r *StructureRepositories) GetByID(ctx context.Context, id int64) (*entities.StructureEntry, error) {
+	var dbStructure database.ProteinStructure
+	if err := r.db.NewSelect().Model(&dbStructure).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get structure by ID: %w", err)
+	}
+
+	var dbChains []database.StructureChain
+	err := r.db.NewSelect().Model(&dbChains).Where("structure_id = ?", id).OrderExpr("chain_id ASC").Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get structure chains: %w", err)
+	}
+
+	entry := toStructureEntity(&dbStructure)
+	entry.Chains = make([]*entities.StructureChain, len(dbChains))
+	for i := range dbChains {
+		entry.Chains[i] = toStructureChainEntity(&dbChains[i])
+	}
+	return entry, nil
+}
+
+// GetByChecksum returns the structure stored from the same file, without chains.
+func (r *StructureRepositories) GetByChecksum(ctx context.Context, checksum string) (*entities.StructureEntry, error) {
+	var dbStructure database.ProteinStructure
+	if err := r.db.NewSelect().Model(&dbStructure).Where("checksum = ?", checksum).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get structure by checksum: %w", err)
+	}
+	return toStructureEntity(&dbStructure), nil
+}
+
+// GetAll lists the structures, newest first, without chains.
+func (r *StructureRepositories) GetAll(ctx context.Context) ([]*entities.StructureEntry, error) {
+	var dbStructures []database.ProteinStructure
+	if err := r.db.NewSelect().Model(&dbStructures).OrderExpr("created DESC, id DESC").Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get all structures: %w", err)
+	}
+
+	entries := make([]*entities.StructureEntry, len(dbStructures))
+	for i := range dbStructures {
+		entries[i] = toStructureEntity(&dbStructures[i])
+	}
+	return entries, nil
+}
+
+// Delete removes the structure; its chains are removed by the foreign key cascade.
+func (r *StructureRepositories) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.NewDelete().Model((*database.ProteinStructure)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete structure: %w", err)
+	}
+	return nil
+}
+
+func (r *StructureRepositories) GetChain(ctx context.Context, structureID int64, chainID string) (*entities.StructureChain, error) {
+	var dbChain database.StructureChain
+	err := r.db.NewSelect().Model(&dbChain).
+		Where("structure_id = ?", structureID).
+		Where("chain_id = ?", chainID).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get structure chain: %w", err)
+	}
+	return toStructureChainEntity(&dbChain), nil
+}
+
+// UpdateChainLink stores the protein a chain is linked to and its residue mapping.
+func (r *StructureRepositories) UpdateChainLink(ctx context.Context, chain *entities.StructureChain) error {
+	_, err := r.db.NewUpdate().Model(toStructureChainModel(chain)).
+		Column("protein_id", "protein_positions", "identity", "coverage").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update structure chain: %w", err)
+	}
+	return nil
+}
+
+// GetChainsByProtein returns the chains linked to a protein, ordered by structure.
+func (r *StructureRepositories) GetChainsByProtein(ctx context.Context, proteinID string) ([]*entities.StructureChain, error) {
+	var dbChains []database.StructureChain
+	err := r.db.NewSelect().Model(&dbChains).
+		Where("protein_id = ?", proteinID).
+		OrderExpr("structure_id ASC, chain_id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get structure chains by protein: %w", err)
+	}
+
+	chains := make([]*entities.StructureChain, len(dbChains))
+	for i := range dbChains {
+		chains[i] = toStructureChainEntity(&dbChains[i])
+	}
+	return chains, nil
+}
+
+func toStructureEntity(dbStructure *database.ProteinStructure) *entities.StructureEntry {
+	return &entities.StructureEntry{
+		ID:         dbStructure.ID,
+		PDBID:      dbStructure.PDBID,
+		Title:      dbStructure.Title,
+		Method:     dbStructure.Method,
+		Resolution: dbStructure.Resolution,
+		Format:     dbStructure.Format,
+		FileName:   dbStructure.FileName,
+		Path:       dbStructure.Path,
+		Checksum:   dbStructure.Checksum,
+		FileSize:   dbStructure.FileSize,
+		Created:    dbStructure.Created,
+	}
+}
+
+func toStructureChainModel(chain *entities.StructureChain) *database.StructureChain {
+	return &database.StructureChain{
+		ID:               chain.ID,
+		StructureID:      chain.StructureID,
+		ChainID:          chain.ChainID,
+		ProteinID:        chain.ProteinID,
+		SeqResSequence:   chain.SeqResSequence,
+		AtomSequence:     chain.AtomSequence,
+		ResidueNumbers:   chain.ResidueNumbers,
+		CACoords:         chain.CACoords,
+		ProteinPositions: chain.ProteinPositions,
+		Identity:         chain.Identity,
+		Coverage:         chain.Coverage,
+	}
+}
+
+func toStructureChainEntity(dbChain *database.StructureChain) *entities.StructureChain {
+	return &entities.StructureChain{
+		ID:               dbChain.ID,
+		StructureID:      dbChain.StructureID,
+		ChainID:          dbChain.ChainID,
+		ProteinID:        dbChain.ProteinID,
+		SeqResSequence:   dbChain.SeqResSequence,
+		AtomSequence:     dbChain.AtomSequence,
+		ResidueNumbers:   dbChain.ResidueNumbers,
+		CACoords:         dbChain.CACoords,
+		ProteinPositions: dbChain.ProteinPositions,
+		Identity:         dbChain.Identity,
+		Coverage:         dbChain.Coverage,
+	}
+}
@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/database"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// taxonSubtreeSQL selects the taxon ?0 and every taxon below it. The seed does not
+// require the taxon to be loaded, so proteins linked to it still match before the
+// taxonomy is imported.
+const taxonSubtreeSQL = `WITH RECURSIVE subtree(id) AS (
+	SELECT CAST(?0 AS integer)
+	UNION
+	SELECT t.id FROM taxa t JOIN subtree s ON t.parent_id = s.id WHERE t.id <> t.parent_id
+) SELECT id FROM subtree`
+
+// taxonLineageSQL selects the taxon ?0 and its ancestors up to the root, which is its
+// own parent, with their distance from ?0.
+const taxonLineageSQL = `WITH RECURSIVE lineage(id, parent_id, depth) AS (
+	SELECT id, parent_id, 0 FROM taxa WHERE id = ?0
+	UNION ALL
+	SELECT t.id, t.parent_id, l.depth + 1 FROM taxa t JOIN lineage l ON t.id = l.parent_id
+	WHERE l.id <> l.parent_id
+) SELECT t.* FROM lineage l JOIN taxa t ON t.id = l.id ORDER BY l.depth DESC`
+
+// taxonRankCountsSQL counts proteins by their ancestor of rank ?0. Each lineage walk
+// stops at the first taxon of that rank.
+const taxonRankCountsSQL = `WITH RECURSIVE lineage(protein_taxon, id, parent_id, rank) AS (
+	SELECT t.id, t.id, t.parent_id, t.rank FROM taxa t
+	WHERE t.id IN (SELECT DISTINCT taxon_id FROM proteins WHERE taxon_id IS NOT NULL AND deleted_at IS NULL)
+	UNION ALL
+	SELECT l.protein_taxon, t.id, t.parent_id, t.rank FROM taxa t JOIN lineage l ON t.id = l.parent_id
+	WHERE l.id <> l.parent_id AND l.rank <> ?0
+)
+SELECT l.id AS taxon_id, t.scientific_name AS name, COUNT(p.id) AS count
+FROM lineage l
+JOIN taxa t ON t.id = l.id
+JOIN proteins p ON p.taxon_id = l.protein_taxon AND p.deleted_at IS NULL
+WHERE l.rank = ?0
+GROUP BY l.id, t.scientific_name
+ORDER BY count DESC, name ASC`
+
+// taxonLinkSQL links proteins without a taxon to the taxon that has their organism as a
+// name of one of the classes ?0; a trailing parenthesised common name ("Homo sapiens
+// (Human)") is ignored. Names shared by several taxa are not used.
+const taxonLinkSQL = `UPDATE proteins AS p SET taxon_id = n.taxon_id
+FROM (
+	SELECT LOWER(name) AS name, MIN(taxon_id) AS taxon_id FROM taxon_names
+	WHERE name_class IN (?0)
+	GROUP BY LOWER(name)
+	HAVING COUNT(DISTINCT taxon_id) = 1
+) AS n
+WHERE p.taxon_id IS NULL AND p.taxo IS NOT NULL
+	AND n.name = LOWER(TRIM(regexp_replace(p.taxo, '\s*\(.*\)\s*$', '')))`
+
+type TaxonomyRepositories struct {
+	db *bun.DB
+}
+
+func NewTaxonomyRepository(db *bun.DB) *TaxonomyRepositories {
+	return &TaxonomyRepositories{db: db}
+}
+
+// SaveTaxa upserts a batch of nodes. Scientific names are kept; they are set from the
+// names by RefreshScientificNames.
+func (r *TaxonomyRepositories) SaveTaxa(ctx context.Context, taxa []*entities.Taxon) error {
+	if len(taxa) == 0 {
+		return nil
+	}
+	now := time.Now()
+	dbTaxa := make([]*database.Taxon, len(taxa))
+	for i, taxon := range taxa {
+		dbTaxa[i] = &database.Taxon{
+			ID:          taxon.ID,
+			ParentID:    taxon.ParentID,
+			Rank:        taxon.Rank,
+			DivisionID:  taxon.DivisionID,
+			GeneticCode: taxon.GeneticCode,
+			Updated:     now,
+		}
+	}
+	_, err := r.db.NewInsert().Model(&dbTaxa).
+		On("CONFLICT (id) DO UPDATE").
+		Set("parent_id = EXCLUDED.parent_id").
+		Set("rank = EXCLUDED.rank").
+		Set("division_id = EXCLUDED.division_id").
+		Set("genetic_code = EXCLUDED.genetic_code").
+		Set("updated = EXCLUDED.updated").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to upsert taxa: %w", err)
+	}
+	return nil
+}
+
+// DeleteTaxonNames removes every stored name before a new names.dmp is loaded.
+func (r *TaxonomyRepositories) DeleteTaxonNames(ctx context.Context) error {
+	if _, err := r.db.NewDelete().Model((*database.TaxonName)(nil)).Where("TRUE").Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete taxon names: %w", err)
+	}
+	return nil
+}
+
+func (r *TaxonomyRepositories) SaveTaxonNames(ctx context.Context, names []entities.TaxonName) error {
+	if len(names) == 0 {
+		return nil
+	}
+	dbNames := make([]*database.TaxonName, len(names))
+	for i, name := range names {
+		dbNames[i] = &database.TaxonName{
+			TaxonID:    name.TaxonID,
+			Name:       name.Name,
+			UniqueName: name.UniqueName,
+			NameClass:  name.NameClass,
+		}
+	}
+	if _, err := r.db.NewInsert().Model(&dbNames).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to insert taxon names: %w", err)
+	}
+	return nil
+}
+
+// RefreshScientificNames copies each taxon's scientific name from taxon_names.
+func (r *TaxonomyRepositories) RefreshScientificNames(ctx context.Context) error {
+	_, err := r.db.NewRaw(`UPDATE taxa AS t SET scientific_name = n.name
+		FROM taxon_names AS n
+		WHERE n.taxon_id = t.id AND n.name_class = ? AND t.scientific_name IS DISTINCT FROM n.name`,
+		entities.TaxonNameScientific).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set scientific names: %w", err)
+	}
+	return nil
+}
+
+func (r *TaxonomyRepositories) GetTaxon(ctx context.Context, id int) (*entities.Taxon, error) {
+	var dbTaxon database.Taxon
+	if err := r.db.NewSelect().Model(&dbTaxon).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get taxon: %w", err)
+	}
+	return toTaxonEntity(&dbTaxon), nil
+}
+
+// GetTaxonNames lists the names of a taxon, scientific name first.
+func (r *TaxonomyRepositories) GetTaxonNames(ctx context.Context, id int) ([]entities.TaxonName, error) {
+	var dbNames []database.TaxonName
+	err := r.db.NewSelect().Model(&dbNames).
+		Where("taxon_id = ?", id).
+		OrderExpr("name_class = ? DESC, name_class ASC, name ASC", entities.TaxonNameScientific).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get taxon names: %w", err)
+	}
+	names := make([]entities.TaxonName, len(dbNames))
+	for i, dbName := range dbNames {
+		names[i] = entities.TaxonName{TaxonID: dbName.TaxonID, Name: dbName.Name, UniqueName: dbName.UniqueName, NameClass: dbName.NameClass}
+	}
+	return names, nil
+}
+
+// GetLineage returns the taxon and its ancestors, from the root down.
+func (r *TaxonomyRepositories) GetLineage(ctx context.Context, id int) ([]*entities.Taxon, error) {
+	var dbTaxa []database.Taxon
+	if err := r.db.NewRaw(taxonLineageSQL, id).Scan(ctx, &dbTaxa); err != nil {
+		return nil, fmt.Errorf("failed to get lineage: %w", err)
+	}
+	return toTaxonEntities(dbTaxa), nil
+}
+
+// GetChildren returns the direct children of a taxon by name.
+func (r *TaxonomyRepositories) GetChildren(ctx context.Context, id, limit, offset int) ([]*entities.Taxon, error) {
+	var dbTaxa []database.Taxon
+	err := r.db.NewSelect().Model(&dbTaxa).
+		Where("parent_id = ?", id).
+		Where("id <> parent_id").
+		OrderExpr("scientific_name ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child taxa: %w", err)
+	}
+	return toTaxonEntities(dbTaxa), nil
+}
+
+// SearchNames finds taxa by any of their names (scientific names, synonyms, common
+// names, ...) containing q. Exact matches come first, then scientific names.
+func (r *TaxonomyRepositories) SearchNames(ctx context.Context, q, rank string, limit, offset int) ([]entities.TaxonMatch, error) {
+	var matches []entities.TaxonMatch
+	query := r.db.NewSelect().
+		TableExpr("taxon_names AS n").
+		ColumnExpr("n.taxon_id, n.name, n.name_class, t.scientific_name, t.rank").
+		Join("JOIN taxa AS t ON t.id = n.taxon_id").
+		Where("n.name ILIKE ?", "%"+q+"%")
+	if rank != "" {
+		query = query.Where("t.rank = ?", rank)
+	}
+	err := query.
+		OrderExpr("LOWER(n.name) = LOWER(?) DESC, n.name_class = ? DESC, LENGTH(n.name) ASC, n.taxon_id ASC", q, entities.TaxonNameScientific).
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx, &matches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search taxa: %w", err)
+	}
+	return matches, nil
+}
+
+// LinkProteins sets the taxon of proteins that have an organism name but no taxon,
+// trying scientific names before synonyms and common names. It returns the number of
+// proteins linked.
+func (r *TaxonomyRepositories) LinkProteins(ctx context.Context) (int, error) {
+	linked := 0
+	for _, classes := range [][]string{
+		{entities.TaxonNameScientific},
+		{entities.TaxonNameSynonym, entities.TaxonNameGenBank, entities.TaxonNameCommon},
+	} {
+		result, err := r.db.NewRaw(taxonLinkSQL, bun.In(classes)).Exec(ctx)
+		if err != nil {
+			return linked, fmt.Errorf("failed to link proteins to taxa: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			linked += int(n)
+		}
+	}
+	return linked, nil
+}
+
+func toTaxonEntity(dbTaxon *database.Taxon) *entities.Taxon {
+	return &entities.Taxon{
+		ID:             dbTaxon.ID,
+		ParentID:       dbTaxon.ParentID,
+		Rank:           dbTaxon.Rank,
+		ScientificName: dbTaxon.ScientificName,
+		DivisionID:     dbTaxon.DivisionID,
+		GeneticCode:    dbTaxon.GeneticCode,
+	}
+}
+
+func toTaxonEntities(dbTaxa []database.Taxon) []*entities.Taxon {
+	taxa := make([]*entities.Taxon, len(dbTaxa))
+	for i := range dbTaxa {
+		taxa[i] = toTaxonEntity(&dbTaxa[i])
+	}
+	return taxa
+}
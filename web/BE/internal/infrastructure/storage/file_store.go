@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrFileTooLarge = errors.New("file exceeds the maximum upload size")
+
+// FileStore keeps uploaded files in a local directory, content-addressed by SHA-256 so
+// the same file uploaded twice is stored once.
+type FileStore struct {
+	dir     string
+	maxSize int64
+}
+
+// NewFileStore creates the directory if needed. maxSize <= 0 disables the size limit.
+func NewFileStore(dir string, maxSize int64) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create file store: %w", err)
+	}
+	return &FileStore{dir: dir, maxSize: maxSize}, nil
+}
+
+// Save streams r into the store and returns the relative path, the hex SHA-256
+// checksum and the size. ext (e.g. ".cif") is kept so stored files stay recognisable.
+// The file is written to a temporary name first, so a failed upload leaves nothing.
+func (s *FileStore) Save(r io.Reader, ext string) (string, string, int64, error) {
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if s.maxSize > 0 {
+		r = io.LimitReader(r, s.maxSize+1)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to write upload file: %w", err)
+	}
+	if s.maxSize > 0 && size > s.maxSize {
+		return "", "", 0, ErrFileTooLarge
+	}
+	if err := tmp.Close(); err != nil {
+		return "", "", 0, fmt.Errorf("failed to write upload file: %w", err)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	name := filepath.Join(checksum[:2], checksum+strings.ToLower(ext))
+	if err := os.MkdirAll(filepath.Join(s.dir, checksum[:2]), 0o755); err != nil {
+		return "", "", 0, fmt.Errorf("failed to create file store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return "", "", 0, fmt.Errorf("failed to store upload file: %w", err)
+	}
+	return name, checksum, size, nil
+}
+
+// Open opens a stored file by its relative path.
+func (s *FileStore) Open(name string) (*os.File, error) {
+	return os.Open(s.path(name))
+}
+
+// Delete removes a stored file; a missing file is not an error.
+func (s *FileStore) Delete(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete stored file: %w", err)
+	}
+	return nil
+}
+
+// path keeps names inside the store directory.
+func (s *FileStore) path(name string) string {
+	return filepath.Join(s.dir, filepath.Clean("/"+name))
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnnotationHandler struct {
+	annotationUseCases usecases.AnnotationUseCases
+}
+
+func NewAnnotationHandler(annotationUseCases usecases.AnnotationUseCases) *AnnotationHandler {
+	return &AnnotationHandler{
+		annotationUseCases: annotationUseCases,
+	}
+}
+
+// CreateFeatureRequest is a feature to add to a protein. Attributes holds further
+// GFF3-style attributes.
+type CreateFeatureRequest struct {
+	Type        string              `json:"type" validate:"required"`
+	Start       int                 `json:"start" validate:"required"`
+	End         int                 `json:"end" validate:"required"`
+	Score       *float64            `json:"score,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Evidence    string              `json:"evidence,omitempty"`
+	Source      string              `json:"source,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
+}
+
+// GetCrossReferences godoc
+// @Summary Get cross-references of a protein
+// @Description Get the external database links of a protein (UniProt DR lines and accessions)
+// @Tags annotations
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/xrefs [get]
+func (h *AnnotationHandler) GetCrossReferences(c *gin.Context) {
+	refs, err := h.annotationUseCases.GetCrossReferences(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, refs, "Cross-references retrieved successfully")
+}
+
+// GetFeatures godoc
+// @Summary Get sequence features of a protein
+// @Description Get the annotated sequence regions of a protein, ordered by position. With start and/or end, only features overlapping that range are returned (start=end=15 finds features covering residue 15).
+// @Tags annotations
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param type query string false "Feature type (case-insensitive)"
+// @Param source query string false "Feature source"
+// @Param start query int false "Start of the range (1-based)"
+// @Param end query int false "End of the range (1-based, inclusive)"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/features [get]
+func (h *AnnotationHandler) GetFeatures(c *gin.Context) {
+	filter, ok := parseFeatureFilter(c)
+	if !ok {
+		return
+	}
+	features, err := h.annotationUseCases.GetFeatures(c.Request.Context(), c.Param("id"), filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, features, "Features retrieved successfully")
+}
+
+// SearchFeatures godoc
+// @Summary Search sequence features
+// @Description Find features across proteins by type, source and position range; a range matches the features overlapping it
+// @Tags annotations
+// @Produce json
+// @Param protein_id query string false "Protein ID"
+// @Param type query string false "Feature type (case-insensitive)"
+// @Param source query string false "Feature source"
+// @Param start query int false "Start of the range (1-based)"
+// @Param end query int false "End of the range (1-based, inclusive)"
+// @Param limit query int false "Maximum number of features (at most 1000)" default(100)
+// @Param offset query int false "Number of features to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/features [get]
+func (h *AnnotationHandler) SearchFeatures(c *gin.Context) {
+	filter, ok := parseFeatureFilter(c)
+	if !ok {
+		return
+	}
+	filter.ProteinID = c.Query("protein_id")
+	if filter.Limit, filter.Offset, ok = queryPaging(c); !ok {
+		return
+	}
+
+	features, err := h.annotationUseCases.SearchFeatures(c.Request.Context(), filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, features, "Features retrieved successfully")
+}
+
+// CreateFeature godoc
+// @Summary Add a sequence feature
+// @Description Add an annotated region to a protein; the range must lie within its sequence
+// @Tags annotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param feature body CreateFeatureRequest true "Feature"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/features [post]
+func (h *AnnotationHandler) CreateFeature(c *gin.Context) {
+	var req CreateFeatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	feature := &entities.ProteinFeature{
+		Type:        req.Type,
+		Start:       req.Start,
+		End:         req.End,
+		Score:       req.Score,
+		Description: req.Description,
+		Evidence:    req.Evidence,
+		Source:      req.Source,
+		Attributes:  req.Attributes,
+	}
+	if err := h.annotationUseCases.CreateFeature(c.Request.Context(), c.Param("id"), feature); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    feature,
+		Message: "Feature created successfully",
+	})
+}
+
+// DeleteFeature godoc
+// @Summary Delete a sequence feature
+// @Description Delete a feature of a protein
+// @Tags annotations
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param feature_id path int true "Feature ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/features/{feature_id} [delete]
+func (h *AnnotationHandler) DeleteFeature(c *gin.Context) {
+	featureID, err := strconv.ParseInt(c.Param("feature_id"), 10, 64)
+	if err != nil || featureID <= 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	if err := h.annotationUseCases.DeleteFeature(c.Request.Context(), c.Param("id"), featureID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, nil, "Feature deleted successfully")
+}
+
+// ImportGFF3 godoc
+// @Summary Import features from GFF3
+// @Description Import positional features from a GFF3 file (raw body or multipart "file" field). The seqid column names the protein; lines for unknown proteins are skipped. Note and evidence attributes become the description and evidence, other attributes are kept. By default the features a source recorded earlier for each protein in the file are replaced, so re-importing a file does not duplicate them.
+// @Tags annotations
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param replace query bool false "Replace earlier features of the same protein and source" default(true)
+// @Param dry_run query bool false "Validate without writing" default(false)
+// @Param source query string false "Source for lines whose source column is empty" default(GFF3)
+// @Param file body string true "GFF3 content"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/import/gff3 [post]
+func (h *AnnotationHandler) ImportGFF3(c *gin.Context) {
+	replace, err := strconv.ParseBool(c.DefaultQuery("replace", "true"))
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	body, err := uploadReader(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	opts := usecases.GFF3ImportOptions{Replace: replace, DryRun: dryRun, Source: c.Query("source")}
+	report, err := h.annotationUseCases.ImportGFF3(c.Request.Context(), body, opts)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, report, "GFF3 import finished")
+}
+
+// ExportGFF3 godoc
+// @Summary Export features as GFF3
+// @Description Export the features matching the filters as GFF3, grouped by protein with a ##sequence-region directive each. Features of unknown position are left out.
+// @Tags annotations
+// @Produce plain
+// @Param protein_id query string false "Protein ID"
+// @Param type query string false "Feature type (case-insensitive)"
+// @Param source query string false "Feature source"
+// @Param start query int false "Start of the range (1-based)"
+// @Param end query int false "End of the range (1-based, inclusive)"
+// @Param limit query int false "Maximum number of features"
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/export/gff3 [get]
+func (h *AnnotationHandler) ExportGFF3(c *gin.Context) {
+	filter, ok := parseFeatureFilter(c)
+	if !ok {
+		return
+	}
+	filter.ProteinID = c.Query("protein_id")
+	limit, ok := queryInt(c, "limit")
+	if !ok {
+		return
+	}
+	if limit != nil && *limit > 0 {
+		filter.Limit = *limit
+	}
+
+	c.Header("Content-Type", "text/x-gff3; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="features.gff3"`)
+	c.Status(http.StatusOK)
+	if err := h.annotationUseCases.ExportGFF3(c.Request.Context(), filter, c.Writer); err != nil && !c.Writer.Written() {
+		h.handleError(c, err)
+	}
+}
+
+// parseFeatureFilter reads the type, source and range parameters shared by the
+// feature endpoints; a malformed position responds 400.
+func parseFeatureFilter(c *gin.Context) (*entities.FeatureFilter, bool) {
+	filter := &entities.FeatureFilter{Type: c.Query("type"), Source: c.Query("source")}
+	for key, target := range map[string]**int{"start": &filter.Start, "end": &filter.End} {
+		var ok bool
+		if *target, ok = queryInt(c, key); !ok {
+			return nil, false
+		}
+	}
+	return filter, true
+}
+
+func (h *AnnotationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInput):
+		respondError(c, err, http.StatusBadRequest)
+	case err == usecases.ErrProteinNotFound, err == usecases.ErrFeatureNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, entities.ErrProteinDeleted):
+		respondError(c, err, http.StatusGone)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
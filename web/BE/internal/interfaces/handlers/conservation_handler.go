@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConservationHandler struct {
+	conservationUseCases usecases.ConservationUseCases
+}
+
+func NewConservationHandler(conservationUseCases usecases.ConservationUseCases) *ConservationHandler {
+	return &ConservationHandler{
+		conservationUseCases: conservationUseCases,
+	}
+}
+
+func (h *ConservationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case err == usecases.ErrProteinNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, usecases.ErrInvalidInput),
+		err == usecases.ErrUnsupportedFormat,
+		err == entities.ErrEmptyAlignment,
+		err == entities.ErrRaggedAlignment:
+		respondError(c, err, http.StatusBadRequest)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
+
+// ScoreConservation godoc
+// @Summary Score per-column conservation
+// @Description Compute Shannon entropy, Jensen-Shannon divergence and property-group conservation for every column of an alignment (FASTA, Clustal or Stockholm text) or of stored proteins aligned to the first ID. Returns per-column scores and the consensus sequence.
+// @Tags conservation
+// @Accept json
+// @Produce json
+// @Param request body usecases.ConservationRequest true "Alignment text or protein IDs; sequences are Henikoff-weighted unless weighted is false"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/conservation [post]
+func (h *ConservationHandler) ScoreConservation(c *gin.Context) {
+	var req usecases.ConservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.conservationUseCases.ScoreConservation(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, profile, "Conservation scored successfully")
+}
+
+// UploadAlignment godoc
+// @Summary Score conservation of an alignment file
+// @Description Upload a FASTA, Clustal or Stockholm alignment as the request body and score per-column conservation. The format is detected when not given.
+// @Tags conservation
+// @Accept plain
+// @Produce json
+// @Param format query string false "Alignment format (fasta, clustal, stockholm)"
+// @Param weighted query bool false "Use Henikoff sequence weights" default(true)
+// @Param file body string true "Alignment file content"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/conservation/upload [post]
+func (h *ConservationHandler) UploadAlignment(c *gin.Context) {
+	weighted, err := strconv.ParseBool(c.DefaultQuery("weighted", "true"))
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.conservationUseCases.ScoreAlignmentFile(c.Request.Context(), c.Query("format"), weighted, c.Request.Body)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, profile, "Conservation scored successfully")
+}
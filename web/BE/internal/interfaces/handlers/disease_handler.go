@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DiseaseHandler struct {
+	diseaseUseCases usecases.DiseaseUseCases
+}
+
+func NewDiseaseHandler(diseaseUseCases usecases.DiseaseUseCases) *DiseaseHandler {
+	return &DiseaseHandler{
+		diseaseUseCases: diseaseUseCases,
+	}
+}
+
+// SearchDiseases godoc
+// @Summary Search diseases
+// @Description Find diseases by ID, or by text in the name or a synonym
+// @Tags diseases
+// @Produce json
+// @Param q query string false "Disease ID (e.g. OMIM:114480) or text in the name or a synonym"
+// @Param category query string false "Disease category"
+// @Param source query string false "Source the disease was imported from"
+// @Param limit query int false "Maximum number of diseases (at most 500)" default(50)
+// @Param offset query int false "Number of diseases to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/diseases [get]
+func (h *DiseaseHandler) SearchDiseases(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	filter := &entities.DiseaseFilter{
+		Query:    c.Query("q"),
+		Category: c.Query("category"),
+		Source:   c.Query("source"),
+		Limit:    limit,
+		Offset:   offset,
+	}
+	diseases, err := h.diseaseUseCases.SearchDiseases(c.Request.Context(), filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, diseases, "Diseases retrieved successfully")
+}
+
+// GetDisease godoc
+// @Summary Get a disease
+// @Description Get a disease with its synonyms
+// @Tags diseases
+// @Produce json
+// @Param id path string true "Disease ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/diseases/{id} [get]
+func (h *DiseaseHandler) GetDisease(c *gin.Context) {
+	disease, err := h.diseaseUseCases.GetDisease(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, disease, "Disease retrieved successfully")
+}
+
+// CreateDisease godoc
+// @Summary Create a disease
+// @Description Create a disease. Bare OMIM numbers and UMLS CUIs are stored with an OMIM: or UMLS: prefix.
+// @Tags diseases
+// @Accept json
+// @Produce json
+// @Param disease body usecases.DiseaseRequest true "Disease"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/diseases [post]
+func (h *DiseaseHandler) CreateDisease(c *gin.Context) {
+	var req usecases.DiseaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	disease, err := h.diseaseUseCases.CreateDisease(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    disease,
+		Message: "Disease created successfully",
+	})
+}
+
+// UpdateDisease godoc
+// @Summary Update a disease
+// @Description Replace the name, description, category, source and synonyms of a disease
+// @Tags diseases
+// @Accept json
+// @Produce json
+// @Param id path string true "Disease ID"
+// @Param disease body usecases.DiseaseRequest true "Disease"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/diseases/{id} [put]
+func (h *DiseaseHandler) UpdateDisease(c *gin.Context) {
+	var req usecases.DiseaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	disease, err := h.diseaseUseCases.UpdateDisease(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, disease, "Disease updated successfully")
+}
+
+// DeleteDisease godoc
+// @Summary Delete a disease
+// @Description Delete a disease with its synonyms and protein associations
+// @Tags diseases
+// @Produce json
+// @Param id path string true "Disease ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/diseases/{id} [delete]
+func (h *DiseaseHandler) DeleteDisease(c *gin.Context) {
+	if err := h.diseaseUseCases.DeleteDisease(c.Request.Context(), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, nil, "Disease deleted successfully")
+}
+
+// GetDiseaseProteins godoc
+// @Summary Get the proteins associated with a disease
+// @Description Get the protein associations of a disease, highest score first
+// @Tags diseases
+// @Produce json
+// @Param id path string true "Disease ID"
+// @Param limit query int false "Maximum number of associations (at most 500)" default(50)
+// @Param offset query int false "Number of associations to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/diseases/{id}/proteins [get]
+func (h *DiseaseHandler) GetDiseaseProteins(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	associations, err := h.diseaseUseCases.GetDiseaseProteins(c.Request.Context(), c.Param("id"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, associations, "Disease associations retrieved successfully")
+}
+
+// ImportDiseases godoc
+// @Summary Import protein–disease associations
+// @Description Import diseases and protein associations from a tab-separated file with a header line (raw body or multipart "file" field), such as a DisGeNET gene–disease file or an OMIM morbid map. Proteins are named by a protein_id or uniprot_id column, or by gene symbol; lines for unknown proteins are skipped. Diseases are upserted, and an association already recorded for the same protein, disease and source is updated.
+// @Tags diseases
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param source query string false "Source of lines without a source column" default(TSV)
+// @Param evidence_type query string false "Evidence type of lines without an evidence column" default(curated)
+// @Param dry_run query bool false "Validate without writing" default(false)
+// @Param file body string true "TSV content"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/diseases/import [post]
+func (h *DiseaseHandler) ImportDiseases(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	body, err := uploadReader(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	opts := usecases.DiseaseImportOptions{
+		Source:       c.Query("source"),
+		EvidenceType: c.Query("evidence_type"),
+		DryRun:       dryRun,
+	}
+	report, err := h.diseaseUseCases.ImportTSV(c.Request.Context(), body, opts)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, report, "Disease import finished")
+}
+
+// GetProteinDiseases godoc
+// @Summary Get the diseases associated with a protein
+// @Description Get the disease associations of a protein, highest score first
+// @Tags diseases
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/diseases [get]
+func (h *DiseaseHandler) GetProteinDiseases(c *gin.Context) {
+	associations, err := h.diseaseUseCases.GetProteinDiseases(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, associations, "Disease associations retrieved successfully")
+}
+
+// CreateAssociation godoc
+// @Summary Associate a protein with a disease
+// @Description Associate a protein with a stored disease. The evidence type is one of curated, genetic_association, literature, animal_model, inferred or predicted; the score, if given, is between 0 and 1.
+// @Tags diseases
+// @Accept json
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param association body usecases.DiseaseAssociationRequest true "Association"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/diseases [post]
+func (h *DiseaseHandler) CreateAssociation(c *gin.Context) {
+	var req usecases.DiseaseAssociationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	association, err := h.diseaseUseCases.CreateAssociation(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    association,
+		Message: "Disease association created successfully",
+	})
+}
+
+// DeleteAssociation godoc
+// @Summary Delete a disease association
+// @Description Delete a disease association of a protein
+// @Tags diseases
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param association_id path int true "Association ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/diseases/{association_id} [delete]
+func (h *DiseaseHandler) DeleteAssociation(c *gin.Context) {
+	associationID, err := strconv.ParseInt(c.Param("association_id"), 10, 64)
+	if err != nil || associationID <= 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	if err := h.diseaseUseCases.DeleteAssociation(c.Request.Context(), c.Param("id"), associationID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, nil, "Disease association deleted successfully")
+}
+
+func (h *DiseaseHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInput):
+		respondError(c, err, http.StatusBadRequest)
+	case err == usecases.ErrProteinNotFound, err == usecases.ErrDiseaseNotFound, err == usecases.ErrDiseaseAssociationNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, entities.ErrProteinDeleted):
+		respondError(c, err, http.StatusGone)
+	case err == usecases.ErrDiseaseExists, err == usecases.ErrDiseaseAssociationExists:
+		respondError(c, err, http.StatusConflict)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DomainHandler struct {
+	domainUseCases usecases.DomainAnnotationUseCases
+}
+
+func NewDomainHandler(domainUseCases usecases.DomainAnnotationUseCases) *DomainHandler {
+	return &DomainHandler{
+		domainUseCases: domainUseCases,
+	}
+}
+
+// ListModels godoc
+// @Summary List loaded profile HMMs
+// @Description List the HMMER3 models loaded from the local model directory at startup
+// @Tags domains
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Router /api/v1/hmm-models [get]
+func (h *DomainHandler) ListModels(c *gin.Context) {
+	models := h.domainUseCases.ListModels(c.Request.Context())
+	respondSuccess(c, models, "Profile HMMs retrieved successfully")
+}
+
+// GetProteinDomains godoc
+// @Summary Get domain hits of a protein
+// @Description Get the stored profile HMM domain hits of a protein, ordered by sequence position
+// @Tags domains
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/domains [get]
+func (h *DomainHandler) GetProteinDomains(c *gin.Context) {
+	hits, err := h.domainUseCases.GetProteinDomains(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, hits, "Domain hits retrieved successfully")
+}
+
+// AnnotateProtein godoc
+// @Summary Annotate a protein with domain hits
+// @Description Scan the protein against every loaded profile HMM and replace its stored domain hits
+// @Tags domains
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/domains/annotate [post]
+func (h *DomainHandler) AnnotateProtein(c *gin.Context) {
+	hits, err := h.domainUseCases.AnnotateProtein(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, hits, "Protein annotated successfully")
+}
+
+func (h *DomainHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case usecases.ErrInvalidInput:
+		respondError(c, err, http.StatusBadRequest)
+	case usecases.ErrProteinNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case entities.ErrProteinDeleted:
+		respondError(c, err, http.StatusGone)
+	case usecases.ErrNoHMMModels:
+		respondError(c, err, http.StatusServiceUnavailable)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
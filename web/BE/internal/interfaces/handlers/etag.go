@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errIfMatchRequired = errors.New("an If-Match header with the ETag of the protein is required")
+
+// setProteinETag sets the ETag of a protein response: its row version, which every
+// write to the protein bumps.
+func setProteinETag(c *gin.Context, version int) {
+	c.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// ifMatchVersions reads the If-Match header of a write as the protein versions it may
+// apply to. No header, or *, applies to any version and gives nil, unless the header is
+// required: then a write without one answers 428 and ok is false. If-Match compares
+// strongly, so weak and unknown tags match no version.
+func ifMatchVersions(c *gin.Context, required bool) (versions []int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		if required {
+			respondError(c, errIfMatchRequired, http.StatusPreconditionRequired)
+			return nil, false
+		}
+		return nil, true
+	}
+
+	versions = []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions, true
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FamilyHandler struct {
+	familyUseCases usecases.FamilyUseCases
+}
+
+func NewFamilyHandler(familyUseCases usecases.FamilyUseCases) *FamilyHandler {
+	return &FamilyHandler{
+		familyUseCases: familyUseCases,
+	}
+}
+
+// ListFamilies godoc
+// @Summary List protein families
+// @Description List protein families by name with the number of proteins linked to each
+// @Tags families
+// @Produce json
+// @Param q query string false "Text in the protein family name"
+// @Param limit query int false "Maximum number of protein families (at most 500)" default(50)
+// @Param offset query int false "Number of protein families to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/families [get]
+func (h *FamilyHandler) ListFamilies(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	families, err := h.familyUseCases.ListFamilies(c.Request.Context(), c.Query("q"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, families, "Protein families retrieved successfully")
+}
+
+// GetFamily godoc
+// @Summary Get a protein family
+// @Description Get a protein family with the number of proteins linked to it
+// @Tags families
+// @Produce json
+// @Param id path int true "Protein family ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/families/{id} [get]
+func (h *FamilyHandler) GetFamily(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	family, err := h.familyUseCases.GetFamily(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, family, "Protein family retrieved successfully")
+}
+
+// CreateFamily godoc
+// @Summary Create a protein family
+// @Description Create a protein family. Proteins are linked to protein families by name, so a protein whose family is set to the name links to it.
+// @Tags families
+// @Accept json
+// @Produce json
+// @Param family body usecases.GeneRequest true "Protein family"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/families [post]
+func (h *FamilyHandler) CreateFamily(c *gin.Context) {
+	var req usecases.GeneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	family, err := h.familyUseCases.CreateFamily(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    family,
+		Message: "Protein family created successfully",
+	})
+}
+
+// UpdateFamily godoc
+// @Summary Rename a protein family
+// @Description Rename a protein family; the family of every linked protein is renamed with it
+// @Tags families
+// @Accept json
+// @Produce json
+// @Param id path int true "Protein family ID"
+// @Param family body usecases.GeneRequest true "Protein family"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/families/{id} [put]
+func (h *FamilyHandler) UpdateFamily(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req usecases.GeneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	family, err := h.familyUseCases.UpdateFamily(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, family, "Protein family updated successfully")
+}
+
+// DeleteFamily godoc
+// @Summary Delete a protein family
+// @Description Delete a protein family no protein links to. While proteins, in the trash or not, still carry its name the family is kept and 409 is returned; change or clear their family first.
+// @Tags families
+// @Produce json
+// @Param id path int true "Protein family ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/families/{id} [delete]
+func (h *FamilyHandler) DeleteFamily(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	if err := h.familyUseCases.DeleteFamily(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, nil, "Protein family deleted successfully")
+}
+
+// GetFamilyProteins godoc
+// @Summary Get the proteins of a protein family
+// @Description Page through the proteins linked to a protein family, ordered by ID
+// @Tags families
+// @Produce json
+// @Param id path int true "Protein family ID"
+// @Param limit query int false "Page size (at most 100)" default(10)
+// @Param offset query int false "Number of proteins to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/families/{id}/proteins [get]
+func (h *FamilyHandler) GetFamilyProteins(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	proteins, err := h.familyUseCases.GetFamilyProteins(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, proteins, "Proteins retrieved successfully")
+}
+
+func (h *FamilyHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInput):
+		respondError(c, err, http.StatusBadRequest)
+	case err == usecases.ErrFamilyNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case err == usecases.ErrFamilyExists, errors.Is(err, entities.ErrFamilyInUse):
+		respondError(c, err, http.StatusConflict)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
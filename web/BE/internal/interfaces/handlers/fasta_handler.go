@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FASTAHandler struct {
+	fastaUseCases usecases.FASTAUseCases
+}
+
+func NewFASTAHandler(fastaUseCases usecases.FASTAUseCases) *FASTAHandler {
+	return &FASTAHandler{
+		fastaUseCases: fastaUseCases,
+	}
+}
+
+// ImportFASTA godoc
+// @Summary Import proteins from FASTA
+// @Description Stream a multi-FASTA file (raw body or multipart "file" field) and create one protein per record. UniProt headers (>sp|P04637|P53_HUMAN Cellular tumor antigen p53 OS=Homo sapiens OX=9606 GN=TP53) fill ID, Name, Taxo and Gene. Existing IDs are handled by the dedup policy; with dry_run nothing is written. Returns a per-record report.
+// @Tags proteins
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param id_field query string false "Header field used as protein ID (entry_name, accession)" default(entry_name)
+// @Param dedup query string false "Policy for existing IDs (skip, update, fail)" default(skip)
+// @Param dry_run query bool false "Validate without writing" default(false)
+// @Param file body string true "FASTA file content"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/import/fasta [post]
+func (h *FASTAHandler) ImportFASTA(c *gin.Context) {
+	importOpts, err := parseImportOptions(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	body, err := uploadReader(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	opts := usecases.FASTAImportOptions{ImportOptions: importOpts, IDField: c.DefaultQuery("id_field", usecases.FASTAIDEntryName)}
+	report, err := h.fastaUseCases.ImportFASTA(c.Request.Context(), body, opts)
+	if err != nil {
+		if err == usecases.ErrInvalidInput {
+			respondError(c, err, http.StatusBadRequest)
+			return
+		}
+		respondError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	respondSuccess(c, report, "FASTA import finished")
+}
+
+// ExportFASTA godoc
+// @Summary Export proteins as FASTA
+// @Description Export every protein matching the search filters as FASTA. Unlike search, no limit applies unless one is given. Header templates may use {id}, {name}, {gene}, {taxo}, {family}, {length}, {mw} and {pi}.
+// @Tags proteins
+// @Produce plain
+// @Param q query string false "Full-text query over names, gene, aliases, family, domains, function, biological process and comments, in web search syntax (\"heme binding\" or oxygen -plant)"
+// @Param fuzzy query string false "Typo-tolerant match on protein name, gene and aliases (e.g. hemoglobn, BRAC1)"
+// @Param min_similarity query number false "Trigram similarity a fuzzy match needs, between 0 and 1" default(0.3)
+// @Param id query string false "Protein ID"
+// @Param name query string false "Protein name"
+// @Param gene query string false "Gene name"
+// @Param family query string false "Protein family"
+// @Param min_length query int false "Minimum sequence length"
+// @Param max_length query int false "Maximum sequence length"
+// @Param go_term query string false "GO ID or term name; matches proteins annotated to it or any descendant term"
+// @Param taxon_id query int false "NCBI taxon ID; matches proteins of the taxon or any taxon below it (40674 for all mammals)"
+// @Param gene_id query int false "Gene ID (see /api/v1/genes)"
+// @Param family_id query int false "Protein family ID (see /api/v1/families)"
+// @Param filter query string false "Filter expression over every protein field, e.g. family:kinase AND mw:[30000 TO 80000] AND NOT taxo:\"Mus musculus\" OR pi>9. Terms are field:value (texts contain it, or match * and ? wildcards), field>value (also >=, <, <=, =), field:[from TO to] ({} excludes a bound, * leaves it open) and field:*; NOT binds tighter than AND, AND tighter than OR. Fields include l_rank (lrank), f_rank (frank), nc_7_4 (nc74) and hydrophobicity_gravy (gravy)"
+// @Param limit query int false "Maximum number of records"
+// @Param line_width query int false "Residues per line, 0 for unwrapped" default(60)
+// @Param header query string false "Header template" default({id} {name})
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/export/fasta [get]
+func (h *FASTAHandler) ExportFASTA(c *gin.Context) {
+	filter, ok := parseProteinFilter(c)
+	if !ok {
+		return
+	}
+	filter.Limit, filter.Offset = 0, 0
+	limit, _, _ := queryPaging(c)
+	if limit > 0 {
+		filter.Limit = limit
+	}
+
+	lineWidth, err := strconv.Atoi(c.DefaultQuery("line_width", strconv.Itoa(usecases.DefaultFASTALineWidth)))
+	if err != nil || lineWidth < 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	opts := usecases.FASTAExportOptions{
+		LineWidth:      lineWidth,
+		HeaderTemplate: c.DefaultQuery("header", usecases.DefaultFASTAHeaderTemplate),
+	}
+
+	c.Header("Content-Type", "text/x-fasta; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="proteins.fasta"`)
+	c.Status(http.StatusOK)
+	if err := h.fastaUseCases.ExportFASTA(c.Request.Context(), filter, opts, c.Writer); err != nil && !c.Writer.Written() {
+		status := http.StatusInternalServerError
+		if errors.Is(err, usecases.ErrInvalidInput) {
+			status = http.StatusBadRequest
+		}
+		respondError(c, err, status)
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GeneHandler struct {
+	geneUseCases usecases.GeneUseCases
+}
+
+func NewGeneHandler(geneUseCases usecases.GeneUseCases) *GeneHandler {
+	return &GeneHandler{
+		geneUseCases: geneUseCases,
+	}
+}
+
+// ListGenes godoc
+// @Summary List genes
+// @Description List genes by name with the number of proteins linked to each
+// @Tags genes
+// @Produce json
+// @Param q query string false "Text in the gene name"
+// @Param limit query int false "Maximum number of genes (at most 500)" default(50)
+// @Param offset query int false "Number of genes to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/genes [get]
+func (h *GeneHandler) ListGenes(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	genes, err := h.geneUseCases.ListGenes(c.Request.Context(), c.Query("q"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, genes, "Genes retrieved successfully")
+}
+
+// GetGene godoc
+// @Summary Get a gene
+// @Description Get a gene with the number of proteins linked to it
+// @Tags genes
+// @Produce json
+// @Param id path int true "Gene ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/genes/{id} [get]
+func (h *GeneHandler) GetGene(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	gene, err := h.geneUseCases.GetGene(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, gene, "Gene retrieved successfully")
+}
+
+// CreateGene godoc
+// @Summary Create a gene
+// @Description Create a gene. Proteins are linked to genes by name, so a protein whose gene is set to the name links to it.
+// @Tags genes
+// @Accept json
+// @Produce json
+// @Param gene body usecases.GeneRequest true "Gene"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/genes [post]
+func (h *GeneHandler) CreateGene(c *gin.Context) {
+	var req usecases.GeneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	gene, err := h.geneUseCases.CreateGene(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    gene,
+		Message: "Gene created successfully",
+	})
+}
+
+// UpdateGene godoc
+// @Summary Rename a gene
+// @Description Rename a gene; the gene of every linked protein is renamed with it
+// @Tags genes
+// @Accept json
+// @Produce json
+// @Param id path int true "Gene ID"
+// @Param gene body usecases.GeneRequest true "Gene"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/genes/{id} [put]
+func (h *GeneHandler) UpdateGene(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req usecases.GeneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	gene, err := h.geneUseCases.UpdateGene(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, gene, "Gene updated successfully")
+}
+
+// DeleteGene godoc
+// @Summary Delete a gene
+// @Description Delete a gene no protein links to. While proteins, in the trash or not, still carry its name the gene is kept and 409 is returned; change or clear their gene first.
+// @Tags genes
+// @Produce json
+// @Param id path int true "Gene ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/genes/{id} [delete]
+func (h *GeneHandler) DeleteGene(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	if err := h.geneUseCases.DeleteGene(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, nil, "Gene deleted successfully")
+}
+
+// GetGeneProteins godoc
+// @Summary Get the proteins of a gene
+// @Description Page through the proteins linked to a gene, ordered by ID
+// @Tags genes
+// @Produce json
+// @Param id path int true "Gene ID"
+// @Param limit query int false "Page size (at most 100)" default(10)
+// @Param offset query int false "Number of proteins to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/genes/{id}/proteins [get]
+func (h *GeneHandler) GetGeneProteins(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	proteins, err := h.geneUseCases.GetGeneProteins(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, proteins, "Proteins retrieved successfully")
+}
+
+func (h *GeneHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInput):
+		respondError(c, err, http.StatusBadRequest)
+	case err == usecases.ErrGeneNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case err == usecases.ErrGeneExists, errors.Is(err, entities.ErrGeneInUse):
+		respondError(c, err, http.StatusConflict)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
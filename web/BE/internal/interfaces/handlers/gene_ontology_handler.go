@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GeneOntologyHandler struct {
+	goUseCases usecases.GeneOntologyUseCases
+}
+
+func NewGeneOntologyHandler(goUseCases usecases.GeneOntologyUseCases) *GeneOntologyHandler {
+	return &GeneOntologyHandler{
+		goUseCases: goUseCases,
+	}
+}
+
+// CreateGOAnnotationRequest annotates a protein with a GO term.
+type CreateGOAnnotationRequest struct {
+	TermID       string `json:"term_id" validate:"required"`
+	EvidenceCode string `json:"evidence_code" validate:"required"`
+	Qualifier    string `json:"qualifier,omitempty"`
+	Negated      bool   `json:"negated,omitempty"`
+	Reference    string `json:"reference,omitempty"`
+	WithFrom     string `json:"with_from,omitempty"`
+	AssignedBy   string `json:"assigned_by,omitempty"`
+	Source       string `json:"source,omitempty"`
+}
+
+// ImportOntology godoc
+// @Summary Import the Gene Ontology
+// @Description Load GO terms and their relationships from an OBO file such as go-basic.obo (raw body or multipart "file" field). Terms are upserted and the relationship graph is replaced.
+// @Tags gene-ontology
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param file body string true "OBO content"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/go/import [post]
+func (h *GeneOntologyHandler) ImportOntology(c *gin.Context) {
+	body, err := uploadReader(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	summary, err := h.goUseCases.ImportOntology(c.Request.Context(), body)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, summary, "Gene Ontology imported successfully")
+}
+
+// SearchTerms godoc
+// @Summary Search GO terms
+// @Description Find current GO terms by ID, name or synonym; exact name matches come first
+// @Tags gene-ontology
+// @Produce json
+// @Param q query string false "GO ID or text in the name or a synonym"
+// @Param namespace query string false "biological_process, molecular_function or cellular_component"
+// @Param limit query int false "Maximum number of terms (at most 500)" default(50)
+// @Param offset query int false "Number of terms to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/go/terms [get]
+func (h *GeneOntologyHandler) SearchTerms(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	terms, err := h.goUseCases.SearchTerms(c.Request.Context(), c.Query("q"), c.Query("namespace"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, terms, "GO terms retrieved successfully")
+}
+
+// GetTerm godoc
+// @Summary Get a GO term
+// @Description Get a GO term by ID, alternative ID or exact name, with its direct parents and children
+// @Tags gene-ontology
+// @Produce json
+// @Param id path string true "GO ID (e.g. GO:0016301) or term name"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/go/terms/{id} [get]
+func (h *GeneOntologyHandler) GetTerm(c *gin.Context) {
+	term, err := h.goUseCases.GetTerm(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, term, "GO term retrieved successfully")
+}
+
+// GetAncestors godoc
+// @Summary Get the ancestors of a GO term
+// @Description Get every term above a GO term along is_a and part_of
+// @Tags gene-ontology
+// @Produce json
+// @Param id path string true "GO ID or term name"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/go/terms/{id}/ancestors [get]
+func (h *GeneOntologyHandler) GetAncestors(c *gin.Context) {
+	terms, err := h.goUseCases.GetAncestors(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, terms, "GO ancestors retrieved successfully")
+}
+
+// GetDescendants godoc
+// @Summary Get the descendants of a GO term
+// @Description Get every term below a GO term along is_a and part_of
+// @Tags gene-ontology
+// @Produce json
+// @Param id path string true "GO ID or term name"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/go/terms/{id}/descendants [get]
+func (h *GeneOntologyHandler) GetDescendants(c *gin.Context) {
+	terms, err := h.goUseCases.GetDescendants(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, terms, "GO descendants retrieved successfully")
+}
+
+// GetTermProteins godoc
+// @Summary Get the proteins annotated to a GO term
+// @Description Get the proteins annotated to a GO term or any of its descendants along is_a and part_of, so "kinase activity" also finds protein kinases. NOT annotations are left out.
+// @Tags gene-ontology
+// @Produce json
+// @Param id path string true "GO ID or term name"
+// @Param limit query int false "Page size" default(10)
+// @Param offset query int false "Number of proteins to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/go/terms/{id}/proteins [get]
+func (h *GeneOntologyHandler) GetTermProteins(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	proteins, err := h.goUseCases.GetTermProteins(c.Request.Context(), c.Param("id"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, proteins, "Proteins retrieved successfully")
+}
+
+// ImportGAF godoc
+// @Summary Import GO annotations from GAF
+// @Description Import protein GO annotations from a GAF 2.x file (raw body or multipart "file" field). Object IDs are matched to protein IDs or cross-references of the line's database, such as UniProtKB accessions; lines for unknown proteins are skipped, lines with an unknown GO term or evidence code fail. By default the GAF annotations stored earlier for each protein in the file are replaced.
+// @Tags gene-ontology
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param replace query bool false "Replace earlier GAF annotations of the same protein" default(true)
+// @Param dry_run query bool false "Validate without writing" default(false)
+// @Param file body string true "GAF content"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/go/annotations/import [post]
+func (h *GeneOntologyHandler) ImportGAF(c *gin.Context) {
+	replace, err := strconv.ParseBool(c.DefaultQuery("replace", "true"))
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	body, err := uploadReader(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.goUseCases.ImportGAF(c.Request.Context(), body, usecases.GAFImportOptions{Replace: replace, DryRun: dryRun})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, report, "GAF import finished")
+}
+
+// GetProteinAnnotations godoc
+// @Summary Get the GO annotations of a protein
+// @Description Get the GO terms a protein is annotated to, with evidence codes, ordered by namespace and term
+// @Tags gene-ontology
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/go [get]
+func (h *GeneOntologyHandler) GetProteinAnnotations(c *gin.Context) {
+	annotations, err := h.goUseCases.GetProteinAnnotations(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, annotations, "GO annotations retrieved successfully")
+}
+
+// CreateAnnotation godoc
+// @Summary Annotate a protein with a GO term
+// @Description Add a GO annotation to a protein. The term may be given by ID, alternative ID or name; the evidence code must be a GO evidence code (IDA, IEA, ISS, ...).
+// @Tags gene-ontology
+// @Accept json
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param annotation body CreateGOAnnotationRequest true "Annotation"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/go [post]
+func (h *GeneOntologyHandler) CreateAnnotation(c *gin.Context) {
+	var req CreateGOAnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	annotation := &entities.GOAnnotation{
+		TermID:       req.TermID,
+		EvidenceCode: req.EvidenceCode,
+		Qualifier:    req.Qualifier,
+		Negated:      req.Negated,
+		Reference:    req.Reference,
+		WithFrom:     req.WithFrom,
+		AssignedBy:   req.AssignedBy,
+		Source:       req.Source,
+	}
+	if err := h.goUseCases.CreateAnnotation(c.Request.Context(), c.Param("id"), annotation); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    annotation,
+		Message: "GO annotation created successfully",
+	})
+}
+
+// DeleteAnnotation godoc
+// @Summary Delete a GO annotation
+// @Description Delete a GO annotation of a protein
+// @Tags gene-ontology
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param annotation_id path int true "Annotation ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/go/{annotation_id} [delete]
+func (h *GeneOntologyHandler) DeleteAnnotation(c *gin.Context) {
+	annotationID, err := strconv.ParseInt(c.Param("annotation_id"), 10, 64)
+	if err != nil || annotationID <= 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	if err := h.goUseCases.DeleteAnnotation(c.Request.Context(), c.Param("id"), annotationID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, nil, "GO annotation deleted successfully")
+}
+
+func (h *GeneOntologyHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInput):
+		respondError(c, err, http.StatusBadRequest)
+	case err == usecases.ErrProteinNotFound, err == usecases.ErrGOTermNotFound, err == usecases.ErrGOAnnotationNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, entities.ErrProteinDeleted):
+		respondError(c, err, http.StatusGone)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
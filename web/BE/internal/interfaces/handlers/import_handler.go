@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	importUseCases usecases.ImportUseCases
+}
+
+func NewImportHandler(importUseCases usecases.ImportUseCases) *ImportHandler {
+	return &ImportHandler{
+		importUseCases: importUseCases,
+	}
+}
+
+// parseImportOptions reads the dedup and dry_run query parameters shared by the import
+// endpoints.
+func parseImportOptions(c *gin.Context) (usecases.ImportOptions, error) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		return usecases.ImportOptions{}, usecases.ErrInvalidInput
+	}
+	return usecases.ImportOptions{
+		Dedup:  usecases.DedupPolicy(c.DefaultQuery("dedup", string(usecases.DedupSkip))),
+		DryRun: dryRun,
+	}, nil
+}
+
+// ImportRecords godoc
+// @Summary Import proteins from UniProt XML or GenPept
+// @Description Stream a UniProt XML or NCBI GenPept file (raw body or multipart "file" field) and create or update one protein per entry, with its features and cross-references. Existing IDs are handled by the dedup policy: skip keeps the stored protein, update replaces the imported fields, fail reports the record as failed. With dry_run nothing is written. Returns a report listing every record with its status and reason.
+// @Tags proteins
+// @Accept xml
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param format query string true "File format (uniprot_xml, genpept)"
+// @Param dedup query string false "Policy for existing IDs (skip, update, fail)" default(skip)
+// @Param dry_run query bool false "Validate without writing" default(false)
+// @Param file body string true "File content"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/import [post]
+func (h *ImportHandler) ImportRecords(c *gin.Context) {
+	opts, err := parseImportOptions(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	body, err := uploadReader(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.importUseCases.ImportRecords(c.Request.Context(), c.Query("format"), body, opts)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidInput) || errors.Is(err, usecases.ErrUnsupportedFormat) {
+			respondError(c, err, http.StatusBadRequest)
+			return
+		}
+		respondError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	respondSuccess(c, report, "Import finished")
+}
+
+// BulkCreateProteins godoc
+// @Summary Bulk create proteins
+// @Description Create proteins from an array and report every item by its position, from 1, with its status and error. The mode decides how invalid items and stored IDs are handled: all_or_nothing writes every item or, when any fails, none; best_effort writes the valid new items; upsert also updates stored proteins with the given values, keeping the stored value of optional fields an item leaves out; skip_existing leaves stored proteins alone. In upsert mode an item may carry the version of the stored protein it updates, and fails with a version conflict when the protein is at another version. Items of proteins in the trash fail in every mode and the trashed protein is left alone; restore it from the trash first. Items are written in batches of batch_size, each batch in one transaction, and all of them in one transaction for all_or_nothing. An item repeating an earlier ID is skipped, or failed for all_or_nothing. When an all_or_nothing create fails nothing is written and the report comes with status 422. With dry_run nothing is written.
+// @Tags proteins
+// @Accept json
+// @Produce json
+// @Param mode query string false "How to handle invalid items and stored IDs (all_or_nothing, best_effort, upsert, skip_existing)" default(all_or_nothing)
+// @Param batch_size query int false "Items written per statement" default(500)
+// @Param dry_run query bool false "Validate without writing" default(false)
+// @Param proteins body []usecases.BulkProteinRequest true "Array of protein data"
+// @Param X-Actor header string false "Who makes the change, recorded on the revision"
+// @Param X-Change-Note header string false "Why the change is made, recorded on the revision"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} SuccessResponse "all_or_nothing failed, nothing was written; data is the report"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/bulk [post]
+func (h *ImportHandler) BulkCreateProteins(c *gin.Context) {
+	opts := usecases.BulkOptions{Mode: usecases.BulkMode(c.Query("mode"))}
+	var err error
+	if opts.DryRun, err = strconv.ParseBool(c.DefaultQuery("dry_run", "false")); err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	if opts.BatchSize, err = strconv.Atoi(c.DefaultQuery("batch_size", "0")); err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	var requests []*usecases.BulkProteinRequest
+	if err := c.ShouldBindJSON(&requests); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.importUseCases.BulkCreateProteins(c.Request.Context(), requests, opts)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidInput) {
+			respondError(c, err, http.StatusBadRequest)
+			return
+		}
+		respondError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if report.Failed > 0 && (opts.Mode == "" || opts.Mode == usecases.BulkAllOrNothing) {
+		c.JSON(http.StatusUnprocessableEntity, SuccessResponse{
+			Data:    report,
+			Message: "Bulk create failed, nothing was written",
+		})
+		return
+	}
+	respondSuccess(c, report, "Bulk create finished")
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bulkUseCases answers every bulk create with one created and one failed item.
+type bulkUseCases struct {
+	usecases.ImportUseCases
+}
+
+func (bulkUseCases) BulkCreateProteins(ctx context.Context, requests []*usecases.BulkProteinRequest, opts usecases.BulkOptions) (*entities.ImportReport, error) {
+	report := entities.NewImportReport()
+	report.Add(entities.ImportRecordResult{Line: 1, ID: "P1", Status: entities.ImportCreated})
+	report.Add(entities.ImportRecordResult{Line: 2, ID: "P2", Status: entities.ImportFailed, Error: usecases.ErrProteinExists.Error()})
+	return report, nil
+}
+
+func TestBulkCreateProteinsStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/proteins/bulk", NewImportHandler(bulkUseCases{}).BulkCreateProteins)
+
+	tests := []struct {
+		mode       string
+		wantStatus int
+	}{
+		{mode: "", wantStatus: http.StatusUnprocessableEntity},
+		{mode: "all_or_nothing", wantStatus: http.StatusUnprocessableEntity},
+		{mode: "best_effort", wantStatus: http.StatusOK},
+		{mode: "skip_existing", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/proteins/bulk?mode="+tt.mode, strings.NewReader(`[{"id":"P1"},{"id":"P2"}]`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("mode %q: status %d, want %d", tt.mode, w.Code, tt.wantStatus)
+			continue
+		}
+		var body struct {
+			Data entities.ImportReport `json:"data"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body.Data.Records) != 2 || body.Data.Failed != 1 {
+			t.Errorf("mode %q: report %+v, want both items with one failed", tt.mode, body.Data)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	jobUseCases usecases.JobUseCases
+}
+
+func NewJobHandler(jobUseCases usecases.JobUseCases) *JobHandler {
+	return &JobHandler{
+		jobUseCases: jobUseCases,
+	}
+}
+
+// GetJob godoc
+// @Summary Get background job status
+// @Description Get the status, progress and, once completed, the result of a background job
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobUseCases.GetJob(c.Request.Context(), id)
+	if err != nil {
+		if err == usecases.ErrJobNotFound {
+			respondError(c, err, http.StatusNotFound)
+			return
+		}
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	respondSuccess(c, job, "Job retrieved successfully")
+}
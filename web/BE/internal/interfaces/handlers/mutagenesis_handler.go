@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/usecases"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MutagenesisHandler struct {
+	mutagenesisUseCases usecases.MutagenesisUseCases
+}
+
+func NewMutagenesisHandler(mutagenesisUseCases usecases.MutagenesisUseCases) *MutagenesisHandler {
+	return &MutagenesisHandler{
+		mutagenesisUseCases: mutagenesisUseCases,
+	}
+}
+
+// StartSaturationScan godoc
+// @Summary Start an in-silico saturation mutagenesis scan
+// @Description Mutate every position of the stored sequence (or the given region) to all 19 alternative residues in a background job. Poll the returned job for progress and the resulting heatmap.
+// @Tags mutagenesis
+// @Accept json
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param scan body usecases.SaturationScanRequest false "Scan region (1-based, inclusive) and heatmap metric (severity, delta_mw, delta_pi, delta_gravy)"
+// @Success 202 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/saturation-scan [post]
+func (h *MutagenesisHandler) StartSaturationScan(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	var req usecases.SaturationScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.mutagenesisUseCases.StartSaturationScan(c.Request.Context(), id, &req)
+	if err != nil {
+		switch {
+		case err == usecases.ErrProteinNotFound:
+			respondError(c, err, http.StatusNotFound)
+		case errors.Is(err, entities.ErrProteinDeleted):
+			respondError(c, err, http.StatusGone)
+		case err == usecases.ErrInvalidInput,
+			errors.Is(err, services.ErrInvalidRegion),
+			errors.Is(err, services.ErrUnknownHeatmapMetric),
+			errors.Is(err, entities.ErrInvalidSequenceFormat),
+			errors.Is(err, entities.ErrSequenceTooShort):
+			respondError(c, err, http.StatusBadRequest)
+		default:
+			respondError(c, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, SuccessResponse{
+		Data:    job,
+		Message: "Saturation scan started",
+	})
+}
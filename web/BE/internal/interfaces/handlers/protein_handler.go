@@ -1,70 +1,246 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"go-crawler/web/BE/internal/domain/entities"
 	"go-crawler/web/BE/internal/usecases"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// maxPatchSize bounds the body of a protein patch, which may carry a whole sequence.
+const maxPatchSize = 8 << 20
+
 type ProteinHandler struct {
 	proteinUseCases usecases.ProteinUseCases
+	// requireIfMatch refuses writes to a protein that do not say which version they
+	// were made from.
+	requireIfMatch bool
 }
 
-func NewProteinHandler(proteinUseCases usecases.ProteinUseCases) *ProteinHandler {
+func NewProteinHandler(proteinUseCases usecases.ProteinUseCases, requireIfMatch bool) *ProteinHandler {
 	return &ProteinHandler{
 		proteinUseCases: proteinUseCases,
+		requireIfMatch:  requireIfMatch,
 	}
 }
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    int    `json:"code"`
-}
-
-type SuccessResponse struct {
-	Data    interface{} `json:"data,omitempty"`
-	Message string      `json:"message,omitempty"`
-}
-
 func (h *ProteinHandler) handleError(c *gin.Context, err error, statusCode int) {
-	c.JSON(statusCode, ErrorResponse{
-		Error:   err.Error(),
-		Code:    statusCode,
-		Message: "An error occurred while processing your request",
-	})
+	respondError(c, err, statusCode)
 }
 
 func (h *ProteinHandler) handleSuccess(c *gin.Context, data interface{}, message string) {
-	c.JSON(http.StatusOK, SuccessResponse{
-		Data:    data,
-		Message: message,
-	})
+	respondSuccess(c, data, message)
 }
 
 // SearchProteins godoc
 // @Summary Search proteins with filters
-// @Description Search for proteins based on various filters like name, gene, family, etc.
+// @Description Search for proteins based on various filters like name, gene, family, etc. With q, results are ordered by relevance unless order_by is given, and each carries its rank and the snippets of the fields that matched, with matched words in <mark> tags. With fuzzy, results are ordered by similarity unless order_by is given. When nothing matches a text search, suggestions lists similar stored names, genes and aliases. Pages can be walked with offset, or with the next_cursor and prev_cursor of each page, which stay stable while proteins are added and are as fast on deep pages as on the first.
 // @Tags proteins
 // @Accept json
 // @Produce json
+// @Param q query string false "Full-text query over names, gene, aliases, family, domains, function, biological process and comments, in web search syntax (\"heme binding\" or oxygen -plant)"
+// @Param fuzzy query string false "Typo-tolerant match on protein name, gene and aliases (e.g. hemoglobn, BRAC1)"
+// @Param min_similarity query number false "Trigram similarity a fuzzy match needs, between 0 and 1" default(0.3)
 // @Param id query string false "Protein ID"
 // @Param name query string false "Protein name"
 // @Param gene query string false "Gene name"
 // @Param family query string false "Protein family"
+// @Param min_length query int false "Minimum sequence length"
+// @Param max_length query int false "Maximum sequence length"
+// @Param min_mw query number false "Minimum molecular weight"
+// @Param max_mw query number false "Maximum molecular weight"
+// @Param min_pi query number false "Minimum isoelectric point"
+// @Param max_pi query number false "Maximum isoelectric point"
+// @Param min_n_interactors query int false "Minimum number of interactors"
+// @Param max_n_interactors query int false "Maximum number of interactors"
+// @Param min_d_rank query int false "Minimum disease rank"
+// @Param max_d_rank query int false "Maximum disease rank"
+// @Param go_term query string false "GO ID or term name; matches proteins annotated to it or any descendant term"
+// @Param taxon_id query int false "NCBI taxon ID; matches proteins of the taxon or any taxon below it (40674 for all mammals)"
+// @Param gene_id query int false "Gene ID (see /api/v1/genes)"
+// @Param family_id query int false "Protein family ID (see /api/v1/families)"
+// @Param filter query string false "Filter expression over every protein field, e.g. family:kinase AND mw:[30000 TO 80000] AND NOT taxo:\"Mus musculus\" OR pi>9. Terms are field:value (texts contain it, or match * and ? wildcards), field>value (also >=, <, <=, =), field:[from TO to] ({} excludes a bound, * leaves it open) and field:*; NOT binds tighter than AND, AND tighter than OR. Fields include l_rank (lrank), f_rank (frank), nc_7_4 (nc74) and hydrophobicity_gravy (gravy)"
 // @Param limit query int false "Limit results" default(10)
 // @Param offset query int false "Offset for pagination" default(0)
-// @Param order_by query string false "Order by field"
+// @Param order_by query string false "Order by field; rank (the default with q) orders by relevance and similarity (the default with fuzzy) by fuzzy match"
 // @Param order_direction query string false "Order direction (ASC/DESC)" default(ASC)
+// @Param cursor query string false "next_cursor or prev_cursor of an earlier page of the same search; replaces offset"
+// @Param count query string false "Total to return: exact, estimated (from planner statistics) or none" default(exact)
 // @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/proteins [get]
 func (h *ProteinHandler) SearchProteins(c *gin.Context) {
+	filter, ok := parseProteinFilter(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.proteinUseCases.SearchProteins(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidInput) {
+			h.handleError(c, err, http.StatusBadRequest)
+			return
+		}
+		h.handleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.handleSuccess(c, response, "Proteins retrieved successfully")
+}
+
+// GetProteinFacets godoc
+// @Summary Count search results by facet
+// @Description Count the proteins matching the search filters by family, gene and taxon (the largest buckets first) and in length and pI histograms. Proteins without a family, gene or taxon are left out of that facet.
+// @Tags proteins
+// @Produce json
+// @Param q query string false "Full-text query over names, gene, aliases, family, domains, function, biological process and comments, in web search syntax (\"heme binding\" or oxygen -plant)"
+// @Param fuzzy query string false "Typo-tolerant match on protein name, gene and aliases (e.g. hemoglobn, BRAC1)"
+// @Param min_similarity query number false "Trigram similarity a fuzzy match needs, between 0 and 1" default(0.3)
+// @Param id query string false "Protein ID"
+// @Param name query string false "Protein name"
+// @Param gene query string false "Gene name"
+// @Param family query string false "Protein family"
+// @Param min_length query int false "Minimum sequence length"
+// @Param max_length query int false "Maximum sequence length"
+// @Param min_mw query number false "Minimum molecular weight"
+// @Param max_mw query number false "Maximum molecular weight"
+// @Param min_pi query number false "Minimum isoelectric point"
+// @Param max_pi query number false "Maximum isoelectric point"
+// @Param min_n_interactors query int false "Minimum number of interactors"
+// @Param max_n_interactors query int false "Maximum number of interactors"
+// @Param min_d_rank query int false "Minimum disease rank"
+// @Param max_d_rank query int false "Maximum disease rank"
+// @Param go_term query string false "GO ID or term name; matches proteins annotated to it or any descendant term"
+// @Param taxon_id query int false "NCBI taxon ID; matches proteins of the taxon or any taxon below it (40674 for all mammals)"
+// @Param gene_id query int false "Gene ID (see /api/v1/genes)"
+// @Param family_id query int false "Protein family ID (see /api/v1/families)"
+// @Param filter query string false "Filter expression over every protein field, e.g. family:kinase AND mw:[30000 TO 80000] AND NOT taxo:\"Mus musculus\" OR pi>9. Terms are field:value (texts contain it, or match * and ? wildcards), field>value (also >=, <, <=, =), field:[from TO to] ({} excludes a bound, * leaves it open) and field:*; NOT binds tighter than AND, AND tighter than OR. Fields include l_rank (lrank), f_rank (frank), nc_7_4 (nc74) and hydrophobicity_gravy (gravy)"
+// @Param facets query string false "Facets to count, comma-separated (family, gene, taxon, length, pi); all by default"
+// @Param facet_size query int false "Buckets kept per family, gene and taxon facet (at most 100)" default(10)
+// @Param length_width query int false "Width of the length histogram buckets in residues (at least 10)" default(100)
+// @Param pi_width query number false "Width of the pI histogram buckets (at least 0.05)" default(1)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/facets [get]
+func (h *ProteinHandler) GetProteinFacets(c *gin.Context) {
+	filter, ok := parseProteinFilter(c)
+	if !ok {
+		return
+	}
+	opts := &entities.ProteinFacetOptions{}
+	for _, field := range strings.Split(c.Query("facets"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			opts.Fields = append(opts.Fields, field)
+		}
+	}
+	size, ok := queryInt(c, "facet_size")
+	if !ok {
+		return
+	}
+	if size != nil {
+		opts.Size = *size
+	}
+	lengthWidth, ok := queryInt(c, "length_width")
+	if !ok {
+		return
+	}
+	if lengthWidth != nil {
+		opts.LengthWidth = *lengthWidth
+	}
+	piWidth, ok := queryFloat(c, "pi_width")
+	if !ok {
+		return
+	}
+	if piWidth != nil {
+		opts.PIWidth = *piWidth
+	}
+
+	facets, err := h.proteinUseCases.GetProteinFacets(c.Request.Context(), filter, opts)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidInput) {
+			h.handleError(c, err, http.StatusBadRequest)
+			return
+		}
+		h.handleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.handleSuccess(c, facets, "Protein facets retrieved successfully")
+}
+
+// AutocompleteProteins godoc
+// @Summary Autocomplete protein search
+// @Description Complete the text typed in a search box to stored protein names, genes and aliases that start with it or have a word that does. Whole-name prefixes come first, then the closest and most common terms.
+// @Tags proteins
+// @Produce json
+// @Param q query string true "Typed text"
+// @Param limit query int false "Maximum number of completions (at most 50)" default(10)
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/autocomplete [get]
+func (h *ProteinHandler) AutocompleteProteins(c *gin.Context) {
+	limit, _, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	completions, err := h.proteinUseCases.AutocompleteProteins(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		h.handleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.handleSuccess(c, completions, "Completions retrieved successfully")
+}
+
+// parseProteinFilter reads the ProteinFilter query parameters shared by search and export;
+// a malformed number responds 400.
+func parseProteinFilter(c *gin.Context) (*entities.ProteinFilter, bool) {
 	filter := &entities.ProteinFilter{}
+	ints := map[string]**int{
+		"min_length":        &filter.MinLength,
+		"max_length":        &filter.MaxLength,
+		"min_n_interactors": &filter.MinNInteractors,
+		"max_n_interactors": &filter.MaxNInteractors,
+		"min_d_rank":        &filter.MinDRank,
+		"max_d_rank":        &filter.MaxDRank,
+		"taxon_id":          &filter.TaxonID,
+		"gene_id":           &filter.GeneID,
+		"family_id":         &filter.FamilyID,
+	}
+	for key, target := range ints {
+		var ok bool
+		if *target, ok = queryInt(c, key); !ok {
+			return nil, false
+		}
+	}
+	floats := map[string]**float64{
+		"min_similarity": &filter.MinSimilarity,
+		"min_mw":         &filter.MinMW,
+		"max_mw":         &filter.MaxMW,
+		"min_pi":         &filter.MinPI,
+		"max_pi":         &filter.MaxPI,
+	}
+	for key, target := range floats {
+		var ok bool
+		if *target, ok = queryFloat(c, key); !ok {
+			return nil, false
+		}
+	}
 
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		filter.Query = &q
+	}
+	if fuzzy := strings.TrimSpace(c.Query("fuzzy")); fuzzy != "" {
+		filter.Fuzzy = &fuzzy
+	}
 	if id := c.Query("id"); id != "" {
 		filter.ID = &id
 	}
@@ -77,19 +253,23 @@ func (h *ProteinHandler) SearchProteins(c *gin.Context) {
 	if family := c.Query("family"); family != "" {
 		filter.Family = &family
 	}
-
-	if limitStr := c.DefaultQuery("limit", "10"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
-			filter.Limit = limit
-		} else {
-			filter.Limit = 10
-		}
+	if goTerm := c.Query("go_term"); goTerm != "" {
+		filter.GOTerm = &goTerm
+	}
+	if expr := strings.TrimSpace(c.Query("filter")); expr != "" {
+		filter.Expr = &expr
 	}
 
-	if offsetStr := c.DefaultQuery("offset", "0"); offsetStr != "" {
-		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
-			filter.Offset = offset
-		}
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return nil, false
+	}
+	filter.Limit = 10
+	if limit > 0 {
+		filter.Limit = limit
+	}
+	if offset > 0 {
+		filter.Offset = offset
 	}
 
 	if orderBy := c.Query("order_by"); orderBy != "" {
@@ -98,26 +278,83 @@ func (h *ProteinHandler) SearchProteins(c *gin.Context) {
 	if orderDir := c.DefaultQuery("order_direction", "ASC"); orderDir != "" {
 		filter.OrderDirection = orderDir
 	}
+	filter.Cursor = c.Query("cursor")
+	filter.Count = c.Query("count")
 
-	response, err := h.proteinUseCases.SearchProteins(c.Request.Context(), filter)
+	return filter, true
+}
+
+// queryInt returns the parsed query parameter, or nil when it is absent. A malformed
+// value responds 400, so that a typo'd filter is not silently dropped.
+func queryInt(c *gin.Context, key string) (*int, bool) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, true
+	}
+	v, err := strconv.Atoi(raw)
 	if err != nil {
-		h.handleError(c, err, http.StatusInternalServerError)
-		return
+		respondError(c, fmt.Errorf("%w: %s must be an integer", usecases.ErrInvalidInput, key), http.StatusBadRequest)
+		return nil, false
 	}
+	return &v, true
+}
 
-	h.handleSuccess(c, response, "Proteins retrieved successfully")
+// queryPaging reads limit and offset; absent values are 0 and a malformed one responds 400.
+func queryPaging(c *gin.Context) (int, int, bool) {
+	limit, ok := queryInt(c, "limit")
+	if !ok {
+		return 0, 0, false
+	}
+	offset, ok := queryInt(c, "offset")
+	if !ok {
+		return 0, 0, false
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+	if offset == nil {
+		offset = new(int)
+	}
+	return *limit, *offset, true
+}
+
+// idParam reads a positive integer :id, answering 400 when it is malformed.
+func idParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// queryFloat is queryInt for numbers.
+func queryFloat(c *gin.Context, key string) (*float64, bool) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, true
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		respondError(c, fmt.Errorf("%w: %s must be a number", usecases.ErrInvalidInput, key), http.StatusBadRequest)
+		return nil, false
+	}
+	return &v, true
 }
 
 // GetProteinByID godoc
 // @Summary Get protein by ID
-// @Description Get a specific protein by its ID
+// @Description Get a specific protein by its ID. A protein in the trash answers 410 Gone rather than 404.
 // @Tags proteins
 // @Accept json
 // @Produce json
 // @Param id path string true "Protein ID"
+// @Param include query string false "Related data to embed, comma-separated (features, diseases)"
 // @Success 200 {object} SuccessResponse
+// @Header 200 {string} ETag "Version of the protein, for If-Match on writes"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/proteins/{id} [get]
 func (h *ProteinHandler) GetProteinByID(c *gin.Context) {
@@ -126,23 +363,38 @@ func (h *ProteinHandler) GetProteinByID(c *gin.Context) {
 		h.handleError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
 		return
 	}
+	var include []string
+	for _, name := range strings.Split(c.Query("include"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			include = append(include, name)
+		}
+	}
 
-	protein, err := h.proteinUseCases.GetProteinByID(c.Request.Context(), id)
+	protein, err := h.proteinUseCases.GetProteinByID(c.Request.Context(), id, include...)
 	if err != nil {
 		if err == usecases.ErrProteinNotFound {
 			h.handleError(c, err, http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, entities.ErrProteinDeleted) {
+			h.handleError(c, err, http.StatusGone)
+			return
+		}
+		if errors.Is(err, usecases.ErrInvalidInput) {
+			h.handleError(c, err, http.StatusBadRequest)
+			return
+		}
 		h.handleError(c, err, http.StatusInternalServerError)
 		return
 	}
 
+	setProteinETag(c, protein.Version)
 	h.handleSuccess(c, protein, "Protein retrieved successfully")
 }
 
 // CompareProteins godoc
 // @Summary Compare proteins
-// @Description Compare multiple proteins for analysis
+// @Description Compare two proteins by sequence similarity; with include_structure, also superpose the linked structure chain of each that models the most residues (RMSD, TM-score, GDT-TS)
 // @Tags proteins
 // @Accept json
 // @Produce json
@@ -150,6 +402,7 @@ func (h *ProteinHandler) GetProteinByID(c *gin.Context) {
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/proteins/compare [post]
 func (h *ProteinHandler) CompareProteins(c *gin.Context) {
@@ -165,6 +418,10 @@ func (h *ProteinHandler) CompareProteins(c *gin.Context) {
 			h.handleError(c, err, http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, entities.ErrProteinDeleted) {
+			h.handleError(c, err, http.StatusGone)
+			return
+		}
 		h.handleError(c, err, http.StatusInternalServerError)
 		return
 	}
@@ -201,11 +458,13 @@ func (h *ProteinHandler) AnalyzeSequence(c *gin.Context) {
 
 // CreateProtein godoc
 // @Summary Create a new protein
-// @Description Create a new protein entry
+// @Description Create a new protein entry. The ID of a protein in the trash is still taken: restore it instead.
 // @Tags proteins
 // @Accept json
 // @Produce json
 // @Param protein body usecases.ProteinCreateRequest true "Protein data"
+// @Param X-Actor header string false "Who makes the change, recorded on the revision"
+// @Param X-Change-Note header string false "Why the change is made, recorded on the revision"
 // @Success 201 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
@@ -219,7 +478,7 @@ func (h *ProteinHandler) CreateProtein(c *gin.Context) {
 	}
 
 	if err := h.proteinUseCases.CreateProtein(c.Request.Context(), &req); err != nil {
-		if err == usecases.ErrProteinExists {
+		if err == usecases.ErrProteinExists || errors.Is(err, entities.ErrProteinDeleted) {
 			h.handleError(c, err, http.StatusConflict)
 			return
 		}
@@ -234,15 +493,22 @@ func (h *ProteinHandler) CreateProtein(c *gin.Context) {
 
 // UpdateProtein godoc
 // @Summary Update a protein
-// @Description Update an existing protein by ID
+// @Description Update an existing protein by ID. With If-Match set to the ETag the protein was read with, the update is refused with 412 if the protein has changed since.
 // @Tags proteins
 // @Accept json
 // @Produce json
 // @Param id path string true "Protein ID"
 // @Param protein body usecases.ProteinUpdateRequest true "Updated protein data"
+// @Param If-Match header string false "ETag of the protein as read"
+// @Param X-Actor header string false "Who makes the change, recorded on the revision"
+// @Param X-Change-Note header string false "Why the change is made, recorded on the revision"
 // @Success 200 {object} SuccessResponse
+// @Header 200 {string} ETag "New version of the protein"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/proteins/{id} [put]
 func (h *ProteinHandler) UpdateProtein(c *gin.Context) {
@@ -252,34 +518,126 @@ func (h *ProteinHandler) UpdateProtein(c *gin.Context) {
 		return
 	}
 
+	ifMatch, ok := ifMatchVersions(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	var req usecases.ProteinUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.handleError(c, err, http.StatusBadRequest)
 		return
 	}
 
-	if err := h.proteinUseCases.UpdateProtein(c.Request.Context(), id, &req); err != nil {
+	protein, err := h.proteinUseCases.UpdateProtein(c.Request.Context(), id, &req, ifMatch)
+	if err != nil {
 		if err == usecases.ErrProteinNotFound {
 			h.handleError(c, err, http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, entities.ErrProteinDeleted) {
+			h.handleError(c, err, http.StatusGone)
+			return
+		}
+		if errors.Is(err, entities.ErrVersionConflict) {
+			h.handleError(c, err, http.StatusPreconditionFailed)
+			return
+		}
 		h.handleError(c, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.handleSuccess(c, nil, "Protein updated successfully")
+	setProteinETag(c, protein.Version)
+	h.handleSuccess(c, protein, "Protein updated successfully")
+}
+
+// PatchProtein godoc
+// @Summary Patch a protein
+// @Description Change some fields of a protein with a JSON Merge Patch (RFC 7396, application/merge-patch+json) or a JSON Patch (RFC 6902, application/json-patch+json) of its JSON. A null or removed field is cleared. name, gene, aliases, seq, taxo, taxon_id, cc, domain, family, bio_process, function, n_interactors, nc_7_4, d_rank, l_rank and f_rank can be patched; the values computed from the sequence (length, mw, pi, hydrophobicity_gravy) are computed again, and patching them or other server-set fields is refused. A failed JSON Patch test answers 409. With If-Match set to the ETag the protein was read with, the patch is refused with 412 if the protein has changed since.
+// @Tags proteins
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param patch body object true "Merge patch object or array of JSON Patch operations"
+// @Param If-Match header string false "ETag of the protein as read"
+// @Param X-Actor header string false "Who makes the change, recorded on the revision"
+// @Param X-Change-Note header string false "Why the change is made, recorded on the revision"
+// @Success 200 {object} SuccessResponse
+// @Header 200 {string} ETag "New version of the protein"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id} [patch]
+func (h *ProteinHandler) PatchProtein(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.handleError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	ifMatch, ok := ifMatchVersions(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.handleError(c, err, http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.handleError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	protein, err := h.proteinUseCases.PatchProtein(c.Request.Context(), id, c.ContentType(), patch, ifMatch)
+	if err != nil {
+		switch {
+		case err == usecases.ErrUnsupportedPatch:
+			h.handleError(c, err, http.StatusUnsupportedMediaType)
+		case errors.Is(err, usecases.ErrInvalidInput):
+			h.handleError(c, err, http.StatusBadRequest)
+		case err == usecases.ErrProteinNotFound:
+			h.handleError(c, err, http.StatusNotFound)
+		case errors.Is(err, usecases.ErrPatchConflict):
+			h.handleError(c, err, http.StatusConflict)
+		case errors.Is(err, entities.ErrProteinDeleted):
+			h.handleError(c, err, http.StatusGone)
+		case errors.Is(err, entities.ErrVersionConflict):
+			h.handleError(c, err, http.StatusPreconditionFailed)
+		default:
+			h.handleError(c, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	setProteinETag(c, protein.Version)
+	h.handleSuccess(c, protein, "Protein patched successfully")
 }
 
 // DeleteProtein godoc
 // @Summary Delete a protein
-// @Description Delete a protein by ID
+// @Description Move a protein to the trash, with its annotations. It can be restored until it is purged, once the trash retention period has passed. With If-Match set to the ETag the protein was read with, the delete is refused with 412 if the protein has changed since.
 // @Tags proteins
 // @Accept json
 // @Produce json
 // @Param id path string true "Protein ID"
+// @Param If-Match header string false "ETag of the protein as read"
+// @Param X-Actor header string false "Who makes the change, recorded on the revision"
+// @Param X-Change-Note header string false "Why the change is made, recorded on the revision"
 // @Success 204
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/proteins/{id} [delete]
 func (h *ProteinHandler) DeleteProtein(c *gin.Context) {
@@ -289,11 +647,24 @@ func (h *ProteinHandler) DeleteProtein(c *gin.Context) {
 		return
 	}
 
-	if err := h.proteinUseCases.DeleteProtein(c.Request.Context(), id); err != nil {
+	ifMatch, ok := ifMatchVersions(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	if err := h.proteinUseCases.DeleteProtein(c.Request.Context(), id, ifMatch); err != nil {
 		if err == usecases.ErrProteinNotFound {
 			h.handleError(c, err, http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, entities.ErrProteinDeleted) {
+			h.handleError(c, err, http.StatusGone)
+			return
+		}
+		if errors.Is(err, entities.ErrVersionConflict) {
+			h.handleError(c, err, http.StatusPreconditionFailed)
+			return
+		}
 		h.handleError(c, err, http.StatusInternalServerError)
 		return
 	}
@@ -301,47 +672,81 @@ func (h *ProteinHandler) DeleteProtein(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
-// GetProteinStats godoc
-// @Summary Get protein statistics
-// @Description Get statistical information about proteins in the database
+// ListDeletedProteins godoc
+// @Summary List the trash
+// @Description List the deleted proteins that have not been purged yet, most recently deleted first
 // @Tags proteins
-// @Accept json
 // @Produce json
+// @Param limit query int false "Page size (max 100)" default(10)
+// @Param offset query int false "Offset" default(0)
 // @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/v1/proteins/stats [get]
-func (h *ProteinHandler) GetProteinStats(c *gin.Context) {
-	stats, err := h.proteinUseCases.GetProteinStats(c.Request.Context())
+// @Router /api/v1/proteins/trash [get]
+func (h *ProteinHandler) ListDeletedProteins(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	result, err := h.proteinUseCases.ListDeletedProteins(c.Request.Context(), limit, offset)
 	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidInput) {
+			h.handleError(c, err, http.StatusBadRequest)
+			return
+		}
 		h.handleError(c, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.handleSuccess(c, stats, "Protein statistics retrieved successfully")
+	h.handleSuccess(c, result, "Deleted proteins retrieved successfully")
 }
 
-// BulkCreateProteins godoc
-// @Summary Bulk create proteins
-// @Description Create multiple proteins in a single request
+// RestoreProtein godoc
+// @Summary Restore a protein
+// @Description Take a protein out of the trash, with its annotations
 // @Tags proteins
-// @Accept json
 // @Produce json
-// @Param proteins body []usecases.ProteinCreateRequest true "Array of protein data"
+// @Param id path string true "Protein ID"
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/v1/proteins/bulk [post]
-func (h *ProteinHandler) BulkCreateProteins(c *gin.Context) {
-	var requests []*usecases.ProteinCreateRequest
-	if err := c.ShouldBindJSON(&requests); err != nil {
-		h.handleError(c, err, http.StatusBadRequest)
+// @Router /api/v1/proteins/trash/{id}/restore [post]
+func (h *ProteinHandler) RestoreProtein(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		h.handleError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.proteinUseCases.RestoreProtein(c.Request.Context(), id); err != nil {
+		if err == usecases.ErrProteinNotFound {
+			h.handleError(c, err, http.StatusNotFound)
+			return
+		}
+		h.handleError(c, err, http.StatusInternalServerError)
 		return
 	}
 
-	if err := h.proteinUseCases.BulkCreateProteins(c.Request.Context(), requests); err != nil {
+	h.handleSuccess(c, nil, "Protein restored successfully")
+}
+
+// GetProteinStats godoc
+// @Summary Get protein statistics
+// @Description Get statistical information about proteins in the database. With rank, proteins linked to a taxon are also counted by their ancestor of that rank.
+// @Tags proteins
+// @Accept json
+// @Produce json
+// @Param rank query string false "Taxonomic rank to break counts down by (species, genus, family, ...)"
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/stats [get]
+func (h *ProteinHandler) GetProteinStats(c *gin.Context) {
+	stats, err := h.proteinUseCases.GetProteinStats(c.Request.Context(), c.Query("rank"))
+	if err != nil {
 		h.handleError(c, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.handleSuccess(c, nil, "Proteins created successfully")
+	h.handleSuccess(c, stats, "Protein statistics retrieved successfully")
 }
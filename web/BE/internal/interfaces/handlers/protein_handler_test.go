@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionedUseCases stands in for the protein use cases with one protein, P12345,
+// whose version is bumped by every update made at one of the If-Match versions, if any.
+type versionedUseCases struct {
+	usecases.ProteinUseCases
+	version int
+}
+
+func (uc *versionedUseCases) GetProteinByID(ctx context.Context, id string, include ...string) (*entities.Protein, error) {
+	if id != "P12345" {
+		return nil, usecases.ErrProteinNotFound
+	}
+	return &entities.Protein{ID: id, Name: "Kinase", Seq: []string{"MKVL"}, Version: uc.version}, nil
+}
+
+func (uc *versionedUseCases) UpdateProtein(ctx context.Context, id string, req *usecases.ProteinUpdateRequest, ifMatch []int) (*entities.Protein, error) {
+	if ifMatch != nil && !slices.Contains(ifMatch, uc.version) {
+		return nil, entities.ErrVersionConflict
+	}
+	uc.version++
+	return uc.GetProteinByID(ctx, id)
+}
+
+func newProteinTestRouter(requireIfMatch bool) (*gin.Engine, *versionedUseCases) {
+	gin.SetMode(gin.TestMode)
+	uc := &versionedUseCases{version: 1}
+	h := NewProteinHandler(uc, requireIfMatch)
+
+	r := gin.New()
+	r.GET("/proteins/:id", h.GetProteinByID)
+	r.PUT("/proteins/:id", h.UpdateProtein)
+	return r, uc
+}
+
+func TestProteinETagRoundTrip(t *testing.T) {
+	r, _ := newProteinTestRouter(false)
+
+	steps := []struct {
+		name       string
+		method     string
+		ifMatch    string
+		wantStatus int
+		wantETag   string
+	}{
+		{name: "get", method: http.MethodGet, wantStatus: http.StatusOK, wantETag: `"1"`},
+		{name: "put at the read version", method: http.MethodPut, ifMatch: `"1"`, wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "get after the update", method: http.MethodGet, wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "put at a stale version", method: http.MethodPut, ifMatch: `"1"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "put at one of several versions", method: http.MethodPut, ifMatch: `"1", "2"`, wantStatus: http.StatusOK, wantETag: `"3"`},
+		{name: "put with a weak tag", method: http.MethodPut, ifMatch: `W/"3"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "put with any version", method: http.MethodPut, ifMatch: "*", wantStatus: http.StatusOK, wantETag: `"4"`},
+		{name: "put without If-Match", method: http.MethodPut, wantStatus: http.StatusOK, wantETag: `"5"`},
+	}
+	for _, step := range steps {
+		var body *strings.Reader
+		if step.method == http.MethodPut {
+			body = strings.NewReader(`{"name":"Renamed kinase"}`)
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(step.method, "/proteins/P12345", body)
+		req.Header.Set("Content-Type", "application/json")
+		if step.ifMatch != "" {
+			req.Header.Set("If-Match", step.ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status %d, want %d: %s", step.name, w.Code, step.wantStatus, w.Body)
+		}
+		if got := w.Header().Get("ETag"); step.wantETag != "" && got != step.wantETag {
+			t.Fatalf("%s: ETag %s, want %s", step.name, got, step.wantETag)
+		}
+	}
+}
+
+func TestProteinUpdateRequiresIfMatch(t *testing.T) {
+	r, uc := newProteinTestRouter(true)
+
+	req := httptest.NewRequest(http.MethodPut, "/proteins/P12345", strings.NewReader(`{"name":"Renamed kinase"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPreconditionRequired {
+		t.Fatalf("status %d, want %d", w.Code, http.StatusPreconditionRequired)
+	}
+	if uc.version != 1 {
+		t.Fatalf("version %d after a refused update, want 1", uc.version)
+	}
+}
+
+// searchUseCases records the filter of the last search.
+type searchUseCases struct {
+	usecases.ProteinUseCases
+	filter *entities.ProteinFilter
+}
+
+func (uc *searchUseCases) SearchProteins(ctx context.Context, filter *entities.ProteinFilter) (*entities.PaginatedProteins, error) {
+	uc.filter = filter
+	return &entities.PaginatedProteins{}, nil
+}
+
+func TestSearchProteinsRejectsMalformedNumbers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		query      string
+		wantStatus int
+	}{
+		{query: "", wantStatus: http.StatusOK},
+		{query: "min_length=10&max_pi=7.5&limit=5&offset=10", wantStatus: http.StatusOK},
+		{query: "min_length=abc", wantStatus: http.StatusBadRequest},
+		{query: "taxon_id=9606x", wantStatus: http.StatusBadRequest},
+		{query: "max_pi=neutral", wantStatus: http.StatusBadRequest},
+		{query: "limit=x", wantStatus: http.StatusBadRequest},
+		{query: "offset=1.5", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		uc := &searchUseCases{}
+		r := gin.New()
+		r.GET("/proteins", NewProteinHandler(uc, false).SearchProteins)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/proteins?"+tt.query, nil))
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%q: status %d, want %d: %s", tt.query, w.Code, tt.wantStatus, w.Body)
+			continue
+		}
+		if tt.wantStatus != http.StatusOK && uc.filter != nil {
+			t.Errorf("%q: searched despite the malformed parameter", tt.query)
+		}
+	}
+}
+
+// lookupStore answers protein lookups by ID: P1 and P2 are stored, TRASHED is in the
+// trash and BROKEN fails to read.
+type lookupStore struct {
+	repositories.IProteinRepository
+}
+
+func (lookupStore) GetByID(ctx context.Context, id string) (*entities.Protein, error) {
+	switch id {
+	case "P1", "P2":
+		return &entities.Protein{ID: id, Name: "Kinase", Seq: []string{"MKVL"}, Version: 1}, nil
+	case "TRASHED":
+		return nil, entities.ErrProteinDeleted
+	case "BROKEN":
+		return nil, errors.New("failed to get protein by ID: connection refused")
+	}
+	return nil, nil
+}
+
+func TestCompareProteinsStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := usecases.NewProteinUseCases(lookupStore{}, nil, nil, nil, services.NewProteinService(), nil)
+	r := gin.New()
+	r.POST("/proteins/compare", NewProteinHandler(uc, false).CompareProteins)
+
+	tests := []struct {
+		id1, id2   string
+		wantStatus int
+	}{
+		{id1: "P1", id2: "P2", wantStatus: http.StatusOK},
+		{id1: "P1", id2: "MISSING", wantStatus: http.StatusNotFound},
+		{id1: "TRASHED", id2: "P2", wantStatus: http.StatusGone},
+		{id1: "P1", id2: "TRASHED", wantStatus: http.StatusGone},
+		{id1: "BROKEN", id2: "P2", wantStatus: http.StatusInternalServerError},
+		{id1: "P1", id2: "BROKEN", wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		body := fmt.Sprintf(`{"protein_id_1":%q,"protein_id_2":%q}`, tt.id1, tt.id2)
+		req := httptest.NewRequest(http.MethodPost, "/proteins/compare", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s and %s: status %d, want %d: %s", tt.id1, tt.id2, w.Code, tt.wantStatus, w.Body)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PSSMHandler struct {
+	pssmUseCases usecases.PSSMUseCases
+}
+
+func NewPSSMHandler(pssmUseCases usecases.PSSMUseCases) *PSSMHandler {
+	return &PSSMHandler{
+		pssmUseCases: pssmUseCases,
+	}
+}
+
+func (h *PSSMHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case err == usecases.ErrPSSMNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, usecases.ErrInvalidInput),
+		err == usecases.ErrUnsupportedFormat,
+		err == usecases.ErrNoProfileProteins,
+		err == services.ErrNoProfileSequences,
+		err == entities.ErrInvalidPSSMName,
+		err == entities.ErrInvalidPSSMShape,
+		err == entities.ErrSequenceTooShortForProfile,
+		err == entities.ErrInvalidSequenceFormat,
+		err == entities.ErrSequenceTooShort:
+		respondError(c, err, http.StatusBadRequest)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
+
+// BuildPSSM godoc
+// @Summary Build a PSSM
+// @Description Build a position-specific scoring matrix from stored proteins (by ID or curated family) and/or raw sequences, using Henikoff sequence weights and BLOSUM62 pseudocounts. The first sequence defines the profile positions.
+// @Tags pssm
+// @Accept json
+// @Produce json
+// @Param pssm body usecases.PSSMBuildRequest true "Profile sources"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pssms [post]
+func (h *PSSMHandler) BuildPSSM(c *gin.Context) {
+	var req usecases.PSSMBuildRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	pssm, err := h.pssmUseCases.BuildPSSM(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    pssm,
+		Message: "PSSM built successfully",
+	})
+}
+
+// ImportPSSM godoc
+// @Summary Import a PSSM
+// @Description Import a profile from the JSON representation or a PSI-BLAST ASCII PSSM (psiblast -out_ascii_pssm)
+// @Tags pssm
+// @Accept plain
+// @Produce json
+// @Param name query string false "Profile name (required for ASCII files)"
+// @Param format query string false "Input format (json, ascii)" default(json)
+// @Param file body string true "Profile file content"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pssms/import [post]
+func (h *PSSMHandler) ImportPSSM(c *gin.Context) {
+	pssm, err := h.pssmUseCases.ImportPSSM(c.Request.Context(), c.Query("name"), c.DefaultQuery("format", usecases.FormatJSON), c.Request.Body)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    pssm,
+		Message: "PSSM imported successfully",
+	})
+}
+
+// ExportPSSM godoc
+// @Summary Export a PSSM
+// @Description Export a stored profile as JSON or as a PSI-BLAST ASCII PSSM
+// @Tags pssm
+// @Produce json
+// @Produce plain
+// @Param id path int true "PSSM ID"
+// @Param format query string false "Output format (json, ascii)" default(json)
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/pssms/{id}/export [get]
+func (h *PSSMHandler) ExportPSSM(c *gin.Context) {
+	id, ok := parsePSSMID(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", usecases.FormatJSON)
+	var buf bytes.Buffer
+	if err := h.pssmUseCases.ExportPSSM(c.Request.Context(), id, format, &buf); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	contentType, ext := "application/json", "json"
+	if format == usecases.FormatASCII {
+		contentType, ext = "text/plain; charset=utf-8", "pssm"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=pssm_%d.%s", id, ext))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// ListPSSMs godoc
+// @Summary List PSSMs
+// @Description List stored profiles without their matrices
+// @Tags pssm
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pssms [get]
+func (h *PSSMHandler) ListPSSMs(c *gin.Context) {
+	pssms, err := h.pssmUseCases.ListPSSMs(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondSuccess(c, pssms, "PSSMs retrieved successfully")
+}
+
+// GetPSSM godoc
+// @Summary Get a PSSM
+// @Description Get a stored profile including its score matrix
+// @Tags pssm
+// @Produce json
+// @Param id path int true "PSSM ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/pssms/{id} [get]
+func (h *PSSMHandler) GetPSSM(c *gin.Context) {
+	id, ok := parsePSSMID(c)
+	if !ok {
+		return
+	}
+
+	pssm, err := h.pssmUseCases.GetPSSM(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondSuccess(c, pssm, "PSSM retrieved successfully")
+}
+
+// DeletePSSM godoc
+// @Summary Delete a PSSM
+// @Description Delete a stored profile
+// @Tags pssm
+// @Produce json
+// @Param id path int true "PSSM ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/pssms/{id} [delete]
+func (h *PSSMHandler) DeletePSSM(c *gin.Context) {
+	id, ok := parsePSSMID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.pssmUseCases.DeletePSSM(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ScoreSequence godoc
+// @Summary Score a sequence against a PSSM
+// @Description Slide the profile along the sequence and return the best window with per-position and per-window scores (bits)
+// @Tags pssm
+// @Accept json
+// @Produce json
+// @Param id path int true "PSSM ID"
+// @Param sequence body usecases.PSSMScoreRequest true "Sequence to score"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/pssms/{id}/score [post]
+func (h *PSSMHandler) ScoreSequence(c *gin.Context) {
+	id, ok := parsePSSMID(c)
+	if !ok {
+		return
+	}
+
+	var req usecases.PSSMScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	score, err := h.pssmUseCases.ScoreSequence(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondSuccess(c, score, "Sequence scored successfully")
+}
+
+// SearchProteins godoc
+// @Summary Search proteins with a PSSM
+// @Description Scan the proteins table for high-scoring profile windows
+// @Tags pssm
+// @Produce json
+// @Param id path int true "PSSM ID"
+// @Param min_score query number false "Minimum window score in bits (default: half the maximum attainable score)"
+// @Param limit query int false "Maximum number of hits" default(50)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/pssms/{id}/search [get]
+func (h *PSSMHandler) SearchProteins(c *gin.Context) {
+	id, ok := parsePSSMID(c)
+	if !ok {
+		return
+	}
+	minScore, ok := parseMinScore(c)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	hits, err := h.pssmUseCases.SearchProteins(c.Request.Context(), id, minScore, limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondSuccess(c, hits, "Profile search completed successfully")
+}
+
+// CheckFamily godoc
+// @Summary Re-check family assignments with a PSSM
+// @Description Score the members of a curated family against the profile, flag members below the threshold and list non-members that score above it
+// @Tags pssm
+// @Produce json
+// @Param id path int true "PSSM ID"
+// @Param family query string false "Family name (defaults to the family the profile was built from)"
+// @Param min_score query number false "Minimum window score in bits (default: half the maximum attainable score)"
+// @Param limit query int false "Maximum number of candidates" default(50)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/pssms/{id}/family-check [get]
+func (h *PSSMHandler) CheckFamily(c *gin.Context) {
+	id, ok := parsePSSMID(c)
+	if !ok {
+		return
+	}
+	minScore, ok := parseMinScore(c)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	check, err := h.pssmUseCases.CheckFamily(c.Request.Context(), id, c.Query("family"), minScore, limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondSuccess(c, check, "Family check completed successfully")
+}
+
+func parsePSSMID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func parseMinScore(c *gin.Context) (*float64, bool) {
+	raw := c.Query("min_score")
+	if raw == "" {
+		return nil, true
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return nil, false
+	}
+	return &v, true
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code"`
+}
+
+type SuccessResponse struct {
+	Data    interface{} `json:"data,omitempty"`
+	Message string      `json:"message,omitempty"`
+}
+
+func respondError(c *gin.Context, err error, statusCode int) {
+	c.JSON(statusCode, ErrorResponse{
+		Error:   err.Error(),
+		Code:    statusCode,
+		Message: "An error occurred while processing your request",
+	})
+}
+
+func respondSuccess(c *gin.Context, data interface{}, message string) {
+	c.JSON(http.StatusOK, SuccessResponse{
+		Data:    data,
+		Message: message,
+	})
+}
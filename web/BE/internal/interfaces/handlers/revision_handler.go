@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RevisionHandler struct {
+	revisionUseCases usecases.RevisionUseCases
+	requireIfMatch   bool
+}
+
+func NewRevisionHandler(revisionUseCases usecases.RevisionUseCases, requireIfMatch bool) *RevisionHandler {
+	return &RevisionHandler{
+		revisionUseCases: revisionUseCases,
+		requireIfMatch:   requireIfMatch,
+	}
+}
+
+// ListRevisions godoc
+// @Summary List protein revisions
+// @Description List the history of a protein, newest first and without snapshots. Every create, update, delete, restore and purge leaves a revision with the fields it changed, its actor (the X-Actor header, or else the basic auth user) and its note (the X-Change-Note header). The history outlives a purged protein.
+// @Tags revisions
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param limit query int false "Maximum number of revisions (at most 100)" default(20)
+// @Param offset query int false "Number of revisions to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/revisions [get]
+func (h *RevisionHandler) ListRevisions(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	revisions, err := h.revisionUseCases.ListRevisions(c.Request.Context(), c.Param("id"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, revisions, "Protein revisions retrieved successfully")
+}
+
+// GetRevision godoc
+// @Summary Get a protein revision
+// @Description Get a revision of a protein with the snapshot of the protein as it was written
+// @Tags revisions
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/revisions/{revision} [get]
+func (h *RevisionHandler) GetRevision(c *gin.Context) {
+	revision, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+	found, err := h.revisionUseCases.GetRevision(c.Request.Context(), c.Param("id"), revision)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, found, "Protein revision retrieved successfully")
+}
+
+// DiffRevisions godoc
+// @Summary Diff two protein revisions
+// @Description Compare two revisions of a protein: the fields that differ, and the residue substitutions, insertions and deletions when the sequence differs. Either revision may be older.
+// @Tags revisions
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param from query int false "Revision to compare from (default the one before to)"
+// @Param to query int false "Revision to compare to (default the latest)"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/revisions/diff [get]
+func (h *RevisionHandler) DiffRevisions(c *gin.Context) {
+	from, to := 0, 0
+	if v := c.Query("from"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+			return
+		}
+		from = n
+	}
+	if v := c.Query("to"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+			return
+		}
+		to = n
+	}
+
+	diff, err := h.revisionUseCases.DiffRevisions(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, diff, "Protein revisions compared successfully")
+}
+
+// RevertProtein godoc
+// @Summary Revert a protein to a revision
+// @Description Write the snapshot of an earlier revision back over a protein. The revert is itself recorded as a new revision, noted with the revision it reverts to, so the history is never rewritten. With If-Match set to the ETag the protein was read with, the revert is refused with 412 if the protein has changed since.
+// @Tags revisions
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param revision path int true "Revision number to revert to"
+// @Param If-Match header string false "ETag of the protein as read"
+// @Param X-Actor header string false "Who makes the change"
+// @Param X-Change-Note header string false "Why the change is made"
+// @Success 200 {object} SuccessResponse
+// @Header 200 {string} ETag "New version of the protein"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/revisions/{revision}/revert [post]
+func (h *RevisionHandler) RevertProtein(c *gin.Context) {
+	revision, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+	ifMatch, ok := ifMatchVersions(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+	reverted, err := h.revisionUseCases.RevertProtein(c.Request.Context(), c.Param("id"), revision, ifMatch)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	setProteinETag(c, reverted.Snapshot.Version)
+	respondSuccess(c, reverted, "Protein reverted successfully")
+}
+
+// revisionParam reads a positive :revision, answering 400 when it is malformed.
+func revisionParam(c *gin.Context) (int, bool) {
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision <= 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return 0, false
+	}
+	return revision, true
+}
+
+func (h *RevisionHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInput):
+		respondError(c, err, http.StatusBadRequest)
+	case err == usecases.ErrProteinNotFound, err == usecases.ErrRevisionNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, entities.ErrProteinDeleted):
+		respondError(c, err, http.StatusGone)
+	case errors.Is(err, entities.ErrVersionConflict):
+		respondError(c, err, http.StatusPreconditionFailed)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StructureHandler struct {
+	structureUseCases usecases.StructureUseCases
+}
+
+func NewStructureHandler(structureUseCases usecases.StructureUseCases) *StructureHandler {
+	return &StructureHandler{
+		structureUseCases: structureUseCases,
+	}
+}
+
+type LinkChainRequest struct {
+	ProteinID string `json:"protein_id" validate:"required"`
+}
+
+// CompareChainsRequest names the mobile chain (1) superposed onto the target chain (2).
+type CompareChainsRequest struct {
+	StructureID1 int64  `json:"structure_id_1" validate:"required"`
+	ChainID1     string `json:"chain_1" validate:"required"`
+	StructureID2 int64  `json:"structure_id_2" validate:"required"`
+	ChainID2     string `json:"chain_2" validate:"required"`
+}
+
+func (h *StructureHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case err == usecases.ErrStructureNotFound,
+		err == usecases.ErrProteinNotFound,
+		errors.Is(err, usecases.ErrChainNotFound):
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, entities.ErrProteinDeleted):
+		respondError(c, err, http.StatusGone)
+	case errors.Is(err, usecases.ErrChainMismatch):
+		respondError(c, err, http.StatusUnprocessableEntity)
+	case err == usecases.ErrFileTooLarge:
+		respondError(c, err, http.StatusRequestEntityTooLarge)
+	case errors.Is(err, usecases.ErrInvalidInput),
+		err == usecases.ErrUnsupportedFormat:
+		respondError(c, err, http.StatusBadRequest)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
+
+// UploadStructure godoc
+// @Summary Upload a PDB or mmCIF structure
+// @Description Upload a structure file (raw body or multipart "file" field). The first model is parsed; every polypeptide chain is stored with its SEQRES and ATOM sequences and CA trace. Chains can be linked to stored proteins with chains=A:P53_HUMAN,B:MDM2_HUMAN or protein_id (all chains); residue numbering is mapped by local alignment. Uploading the same file again returns 409.
+// @Tags structures
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param format query string false "File format (pdb, mmcif); detected from the file name or content when empty"
+// @Param file_name query string false "File name, for raw uploads"
+// @Param chains query string false "Chain links as CHAIN:PROTEIN_ID pairs separated by commas"
+// @Param protein_id query string false "Protein linked to every chain not listed in chains"
+// @Param file body string true "Structure file content"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures [post]
+func (h *StructureHandler) UploadStructure(c *gin.Context) {
+	upload := usecases.StructureUpload{
+		FileName:      c.Query("file_name"),
+		Format:        c.Query("format"),
+		ChainProteins: make(map[string]string),
+	}
+	for _, pair := range strings.Split(c.Query("chains"), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		chainID, proteinID, ok := strings.Cut(pair, ":")
+		if !ok || chainID == "" || proteinID == "" {
+			respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+			return
+		}
+		upload.ChainProteins[chainID] = proteinID
+	}
+	if proteinID := c.Query("protein_id"); proteinID != "" {
+		upload.ChainProteins["*"] = proteinID
+	}
+
+	body, err := uploadReader(c)
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	if part, ok := body.(*multipart.Part); ok && upload.FileName == "" {
+		upload.FileName = part.FileName()
+	}
+
+	entry, err := h.structureUseCases.UploadStructure(c.Request.Context(), body, upload)
+	if err == usecases.ErrStructureExists {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   err.Error(),
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("The file is already stored as structure %d", entry.ID),
+		})
+		return
+	}
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Data:    entry,
+		Message: "Structure uploaded successfully",
+	})
+}
+
+// ListStructures godoc
+// @Summary List structures
+// @Description List uploaded structures, newest first, without chains
+// @Tags structures
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures [get]
+func (h *StructureHandler) ListStructures(c *gin.Context) {
+	entries, err := h.structureUseCases.ListStructures(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, entries, "Structures retrieved successfully")
+}
+
+// GetStructure godoc
+// @Summary Get a structure
+// @Description Get an uploaded structure with its chains, sequences and protein links
+// @Tags structures
+// @Produce json
+// @Param id path int true "Structure ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures/{id} [get]
+func (h *StructureHandler) GetStructure(c *gin.Context) {
+	id, ok := parseStructureID(c)
+	if !ok {
+		return
+	}
+	entry, err := h.structureUseCases.GetStructure(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, entry, "Structure retrieved successfully")
+}
+
+// DownloadStructure godoc
+// @Summary Download a structure file
+// @Description Download the uploaded PDB or mmCIF file as stored
+// @Tags structures
+// @Produce plain
+// @Param id path int true "Structure ID"
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures/{id}/file [get]
+func (h *StructureHandler) DownloadStructure(c *gin.Context) {
+	id, ok := parseStructureID(c)
+	if !ok {
+		return
+	}
+	file, entry, err := h.structureUseCases.OpenStructureFile(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	name := entry.FileName
+	if name == "" {
+		name = fmt.Sprintf("structure_%d.%s", entry.ID, entry.Format)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	c.Header("Content-Type", "chemical/x-"+entry.Format)
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, file)
+}
+
+// DeleteStructure godoc
+// @Summary Delete a structure
+// @Description Delete an uploaded structure, its chains and the stored file
+// @Tags structures
+// @Produce json
+// @Param id path int true "Structure ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures/{id} [delete]
+func (h *StructureHandler) DeleteStructure(c *gin.Context) {
+	id, ok := parseStructureID(c)
+	if !ok {
+		return
+	}
+	if err := h.structureUseCases.DeleteStructure(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, nil, "Structure deleted successfully")
+}
+
+// LinkChain godoc
+// @Summary Link a chain to a protein
+// @Description Link (or relink) a structure chain to a stored protein; its modelled residues are mapped to protein positions by local alignment
+// @Tags structures
+// @Accept json
+// @Produce json
+// @Param id path int true "Structure ID"
+// @Param chain path string true "Chain ID"
+// @Param link body LinkChainRequest true "Protein to link"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures/{id}/chains/{chain}/protein [put]
+func (h *StructureHandler) LinkChain(c *gin.Context) {
+	id, ok := parseStructureID(c)
+	if !ok {
+		return
+	}
+	var req LinkChainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	chain, err := h.structureUseCases.LinkChain(c.Request.Context(), id, c.Param("chain"), req.ProteinID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, chain, "Chain linked successfully")
+}
+
+// GetCATrace godoc
+// @Summary Get the CA trace of a chain
+// @Description Get the alpha-carbon coordinates of a chain's modelled residues, with author residue numbers and positions in the linked protein
+// @Tags structures
+// @Produce json
+// @Param id path int true "Structure ID"
+// @Param chain path string true "Chain ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures/{id}/chains/{chain}/trace [get]
+func (h *StructureHandler) GetCATrace(c *gin.Context) {
+	id, ok := parseStructureID(c)
+	if !ok {
+		return
+	}
+	trace, err := h.structureUseCases.GetCATrace(c.Request.Context(), id, c.Param("chain"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, trace, "CA trace retrieved successfully")
+}
+
+// GetProteinStructures godoc
+// @Summary Get structures of a protein
+// @Description Get the structure chains linked to a protein
+// @Tags structures
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/structures [get]
+func (h *StructureHandler) GetProteinStructures(c *gin.Context) {
+	chains, err := h.structureUseCases.GetProteinStructures(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, chains, "Structures retrieved successfully")
+}
+
+// CompareChains godoc
+// @Summary Superpose two structure chains
+// @Description Pair the CA atoms of two chains by aligning their modelled sequences and superpose chain 1 onto chain 2 with the Kabsch algorithm. Reports RMSD over all aligned pairs, TM-score and GDT-TS normalised by the length of chain 2, the rotation and translation, and both CA traces in the frame of chain 2 for display.
+// @Tags structures
+// @Accept json
+// @Produce json
+// @Param comparison body CompareChainsRequest true "Chains to compare"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures/compare [post]
+func (h *StructureHandler) CompareChains(c *gin.Context) {
+	var req CompareChainsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	if req.StructureID1 <= 0 || req.StructureID2 <= 0 || req.ChainID1 == "" || req.ChainID2 == "" {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	comparison, err := h.structureUseCases.CompareChains(c.Request.Context(), req.StructureID1, req.ChainID1, req.StructureID2, req.ChainID2)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, comparison, "Chains compared successfully")
+}
+
+// GetContactMap godoc
+// @Summary Get the contact map of a chain
+// @Description List the residue pairs of a chain whose CA (or CB; CA for glycine) atoms are within the distance threshold. Indexes refer to the chain's modelled residues, listed with their author numbers.
+// @Tags structures
+// @Produce json
+// @Param id path int true "Structure ID"
+// @Param chain path string true "Chain ID"
+// @Param atom query string false "Atom to measure between (ca, cb)" default(ca)
+// @Param threshold query number false "Distance threshold in Å (at most 30)" default(8)
+// @Param min_separation query int false "Minimum sequence separation of a pair" default(1)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/structures/{id}/chains/{chain}/contacts [get]
+func (h *StructureHandler) GetContactMap(c *gin.Context) {
+	id, ok := parseStructureID(c)
+	if !ok {
+		return
+	}
+	threshold, err := strconv.ParseFloat(c.DefaultQuery("threshold", "0"), 64)
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+	minSeparation, err := strconv.Atoi(c.DefaultQuery("min_separation", "0"))
+	if err != nil {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return
+	}
+
+	contacts, err := h.structureUseCases.GetContactMap(c.Request.Context(), id, c.Param("chain"), c.Query("atom"), threshold, minSeparation)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, contacts, "Contact map retrieved successfully")
+}
+
+func parseStructureID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondError(c, usecases.ErrInvalidInput, http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaxonomyHandler struct {
+	taxonomyUseCases usecases.TaxonomyUseCases
+}
+
+func NewTaxonomyHandler(taxonomyUseCases usecases.TaxonomyUseCases) *TaxonomyHandler {
+	return &TaxonomyHandler{
+		taxonomyUseCases: taxonomyUseCases,
+	}
+}
+
+// LinkTaxonRequest links a protein to an NCBI taxon.
+type LinkTaxonRequest struct {
+	TaxonID int `json:"taxon_id" validate:"required"`
+}
+
+// ImportTaxonomy godoc
+// @Summary Import the NCBI taxonomy
+// @Description Load nodes.dmp and names.dmp of an NCBI taxdump (multipart fields "nodes" and "names"). Taxa are upserted and all names replaced; proteins are not linked automatically, see POST /api/v1/taxonomy/link.
+// @Tags taxonomy
+// @Accept mpfd
+// @Produce json
+// @Param nodes formData file true "nodes.dmp"
+// @Param names formData file true "names.dmp"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/taxonomy/import [post]
+func (h *TaxonomyHandler) ImportTaxonomy(c *gin.Context) {
+	nodesHeader, err := c.FormFile("nodes")
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	namesHeader, err := c.FormFile("names")
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	nodes, err := nodesHeader.Open()
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	defer nodes.Close()
+	names, err := namesHeader.Open()
+	if err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	defer names.Close()
+
+	summary, err := h.taxonomyUseCases.ImportTaxonomy(c.Request.Context(), nodes, names)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, summary, "Taxonomy imported successfully")
+}
+
+// SearchTaxa godoc
+// @Summary Search taxa by name
+// @Description Find taxa whose scientific name, synonym or common name contains q; exact matches and scientific names come first
+// @Tags taxonomy
+// @Produce json
+// @Param q query string true "Name or part of a name"
+// @Param rank query string false "Only taxa of this rank (species, genus, ...)"
+// @Param limit query int false "Maximum number of matches (at most 500)" default(50)
+// @Param offset query int false "Number of matches to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/taxonomy [get]
+func (h *TaxonomyHandler) SearchTaxa(c *gin.Context) {
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	matches, err := h.taxonomyUseCases.SearchTaxa(c.Request.Context(), c.Query("q"), c.Query("rank"), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, matches, "Taxa retrieved successfully")
+}
+
+// GetTaxon godoc
+// @Summary Get a taxon
+// @Description Get an NCBI taxon with all its names
+// @Tags taxonomy
+// @Produce json
+// @Param id path int true "NCBI taxon ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/taxonomy/{id} [get]
+func (h *TaxonomyHandler) GetTaxon(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	taxon, err := h.taxonomyUseCases.GetTaxon(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, taxon, "Taxon retrieved successfully")
+}
+
+// GetLineage godoc
+// @Summary Get the lineage of a taxon
+// @Description Get the taxa from the root of the taxonomy down to the given taxon
+// @Tags taxonomy
+// @Produce json
+// @Param id path int true "NCBI taxon ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/taxonomy/{id}/lineage [get]
+func (h *TaxonomyHandler) GetLineage(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	lineage, err := h.taxonomyUseCases.GetLineage(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, lineage, "Lineage retrieved successfully")
+}
+
+// GetChildren godoc
+// @Summary Get the children of a taxon
+// @Description Get the direct children of a taxon, by scientific name
+// @Tags taxonomy
+// @Produce json
+// @Param id path int true "NCBI taxon ID"
+// @Param limit query int false "Maximum number of taxa (at most 500)" default(50)
+// @Param offset query int false "Number of taxa to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/taxonomy/{id}/children [get]
+func (h *TaxonomyHandler) GetChildren(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	children, err := h.taxonomyUseCases.GetChildren(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, children, "Child taxa retrieved successfully")
+}
+
+// GetTaxonProteins godoc
+// @Summary Get the proteins of a taxon
+// @Description Get the proteins linked to a taxon or any taxon below it, e.g. 40674 for all mammals
+// @Tags taxonomy
+// @Produce json
+// @Param id path int true "NCBI taxon ID"
+// @Param limit query int false "Page size" default(10)
+// @Param offset query int false "Number of proteins to skip" default(0)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/taxonomy/{id}/proteins [get]
+func (h *TaxonomyHandler) GetTaxonProteins(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	limit, offset, ok := queryPaging(c)
+	if !ok {
+		return
+	}
+	proteins, err := h.taxonomyUseCases.GetTaxonProteins(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, proteins, "Proteins retrieved successfully")
+}
+
+// LinkProteins godoc
+// @Summary Link proteins to taxa by organism name
+// @Description Set the taxon of every protein that has an organism name but no taxon, matching scientific names first and then synonyms and common names. Names shared by several taxa are not used.
+// @Tags taxonomy
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/taxonomy/link [post]
+func (h *TaxonomyHandler) LinkProteins(c *gin.Context) {
+	linked, err := h.taxonomyUseCases.LinkProteins(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, gin.H{"linked": linked}, "Proteins linked to taxa")
+}
+
+// LinkProtein godoc
+// @Summary Link a protein to a taxon
+// @Description Set the NCBI taxon of a protein; its organism name is filled from the taxon when empty
+// @Tags taxonomy
+// @Accept json
+// @Produce json
+// @Param id path string true "Protein ID"
+// @Param taxon body LinkTaxonRequest true "Taxon"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/proteins/{id}/taxon [put]
+func (h *TaxonomyHandler) LinkProtein(c *gin.Context) {
+	var req LinkTaxonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, err, http.StatusBadRequest)
+		return
+	}
+	protein, err := h.taxonomyUseCases.LinkProtein(c.Request.Context(), c.Param("id"), req.TaxonID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	respondSuccess(c, protein, "Protein linked to taxon")
+}
+
+func (h *TaxonomyHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInput):
+		respondError(c, err, http.StatusBadRequest)
+	case err == usecases.ErrProteinNotFound, err == usecases.ErrTaxonNotFound:
+		respondError(c, err, http.StatusNotFound)
+	case errors.Is(err, entities.ErrProteinDeleted):
+		respondError(c, err, http.StatusGone)
+	case errors.Is(err, entities.ErrVersionConflict):
+		respondError(c, err, http.StatusConflict)
+	default:
+		respondError(c, err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errMissingUploadFile = errors.New("multipart upload has no \"file\" part")
+
+// uploadReader returns the uploaded file as a stream: the "file" part of a
+// multipart/form-data request, or the raw request body otherwise. Multipart parts are
+// read directly from the connection rather than buffered to disk.
+func uploadReader(c *gin.Context) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return c.Request.Body, nil
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errMissingUploadFile
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package usecases
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"strings"
+	"time"
+)
+
+var ErrFeatureNotFound = errors.New("feature not found")
+
+const (
+	ImportSourceGFF3 = "GFF3"
+
+	defaultFeatureLimit = 100
+	maxFeatureLimit     = 1000
+
+	gff3BatchSize = 1000
+)
+
+// GFF3 attributes stored in their own feature columns rather than in Attributes.
+const (
+	gff3NoteAttribute     = "Note"
+	gff3EvidenceAttribute = "evidence"
+)
+
+// GFF3ImportOptions controls a GFF3 feature import. With Replace, the features a
+// source recorded earlier for a protein in the file are deleted first, so importing
+// the same file again does not duplicate them. Source names features whose source
+// column is "."; it defaults to GFF3.
+type GFF3ImportOptions struct {
+	Replace bool
+	DryRun  bool
+	Source  string
+}
+
+type AnnotationUseCases interface {
+	GetCrossReferences(ctx context.Context, proteinID string) ([]entities.CrossReference, error)
+	GetFeatures(ctx context.Context, proteinID string, filter *entities.FeatureFilter) ([]entities.ProteinFeature, error)
+	SearchFeatures(ctx context.Context, filter *entities.FeatureFilter) ([]entities.ProteinFeature, error)
+	CreateFeature(ctx context.Context, proteinID string, feature *entities.ProteinFeature) error
+	DeleteFeature(ctx context.Context, proteinID string, id int64) error
+	ImportGFF3(ctx context.Context, r io.Reader, opts GFF3ImportOptions) (*entities.ImportReport, error)
+	ExportGFF3(ctx context.Context, filter *entities.FeatureFilter, w io.Writer) error
+}
+
+type annotationUseCases struct {
+	proteinRepo    *repositories.ProteinRepositories
+	annotationRepo *repositories.AnnotationRepositories
+}
+
+func NewAnnotationUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	annotationRepo *repositories.AnnotationRepositories,
+) AnnotationUseCases {
+	return &annotationUseCases{
+		proteinRepo:    proteinRepo,
+		annotationRepo: annotationRepo,
+	}
+}
+
+func (uc *annotationUseCases) GetCrossReferences(ctx context.Context, proteinID string) ([]entities.CrossReference, error) {
+	if _, err := uc.checkProtein(ctx, proteinID); err != nil {
+		return nil, err
+	}
+	return uc.annotationRepo.GetCrossReferences(ctx, proteinID)
+}
+
+// GetFeatures returns all features of a protein matching the filter; its paging is
+// ignored.
+func (uc *annotationUseCases) GetFeatures(ctx context.Context, proteinID string, filter *entities.FeatureFilter) ([]entities.ProteinFeature, error) {
+	if _, err := uc.checkProtein(ctx, proteinID); err != nil {
+		return nil, err
+	}
+	query := entities.FeatureFilter{ProteinID: proteinID}
+	if filter != nil {
+		query.Type, query.Source, query.Start, query.End = filter.Type, filter.Source, filter.Start, filter.End
+	}
+	if err := validateFeatureRange(&query); err != nil {
+		return nil, err
+	}
+	return uc.annotationRepo.SearchFeatures(ctx, &query)
+}
+
+// SearchFeatures finds features across proteins, one page at a time.
+func (uc *annotationUseCases) SearchFeatures(ctx context.Context, filter *entities.FeatureFilter) ([]entities.ProteinFeature, error) {
+	if filter == nil || filter.Limit < 0 || filter.Offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if err := validateFeatureRange(filter); err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultFeatureLimit
+	}
+	filter.Limit = min(filter.Limit, maxFeatureLimit)
+	return uc.annotationRepo.SearchFeatures(ctx, filter)
+}
+
+func validateFeatureRange(filter *entities.FeatureFilter) error {
+	if (filter.Start != nil && *filter.Start < 1) || (filter.End != nil && *filter.End < 1) {
+		return ErrInvalidInput
+	}
+	if filter.Start != nil && filter.End != nil && *filter.Start > *filter.End {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// CreateFeature adds a feature to a protein. Its range must lie within the sequence.
+func (uc *annotationUseCases) CreateFeature(ctx context.Context, proteinID string, feature *entities.ProteinFeature) error {
+	protein, err := uc.checkProtein(ctx, proteinID)
+	if err != nil {
+		return err
+	}
+	feature.ID = 0
+	feature.ProteinID = protein.ID
+	feature.Type = strings.TrimSpace(feature.Type)
+	if feature.Type == "" {
+		return fmt.Errorf("%w: feature type is required", ErrInvalidInput)
+	}
+	length := 0
+	if protein.Length != nil {
+		length = *protein.Length
+	}
+	if err := validateFeaturePosition(feature, length); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	return uc.annotationRepo.CreateFeature(ctx, feature)
+}
+
+// validateFeaturePosition checks 1 <= start <= end <= length; a length of 0 is unknown.
+func validateFeaturePosition(feature *entities.ProteinFeature, length int) error {
+	if feature.Start < 1 || feature.End < feature.Start {
+		return fmt.Errorf("invalid feature range %d-%d", feature.Start, feature.End)
+	}
+	if length > 0 && feature.End > length {
+		return fmt.Errorf("feature %d-%d is outside the sequence of length %d", feature.Start, feature.End, length)
+	}
+	return nil
+}
+
+func (uc *annotationUseCases) DeleteFeature(ctx context.Context, proteinID string, id int64) error {
+	if _, err := uc.checkProtein(ctx, proteinID); err != nil {
+		return err
+	}
+	if err := uc.annotationRepo.DeleteFeature(ctx, proteinID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrFeatureNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ImportGFF3 reads the features of a GFF3 file, whose seqid column names the protein.
+// Features are counted one by one in the report; only skipped and failed lines are
+// listed. Lines for unknown proteins are skipped; malformed lines and features
+// outside the sequence fail without stopping the import.
+func (uc *annotationUseCases) ImportGFF3(ctx context.Context, r io.Reader, opts GFF3ImportOptions) (*entities.ImportReport, error) {
+	if opts.Source == "" {
+		opts.Source = ImportSourceGFF3
+	}
+	report := entities.NewImportReport()
+	report.DryRun = opts.DryRun
+	replaced := make(map[entities.FeatureSource]bool)
+
+	var batch []*formats.GFF3Feature
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() { batch = batch[:0] }()
+
+		ids := make([]string, 0, len(batch))
+		for _, f := range batch {
+			ids = append(ids, f.SeqID)
+		}
+		lengths, err := uc.annotationRepo.ProteinLengths(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		var features []entities.ProteinFeature
+		var lines []int
+		var replace []entities.FeatureSource
+		for _, f := range batch {
+			length, ok := lengths[f.SeqID]
+			if !ok {
+				report.Add(entities.ImportRecordResult{Line: f.Line, ID: f.SeqID, Status: entities.ImportSkipped, Reason: "unknown protein"})
+				continue
+			}
+			feature := featureFromGFF3(f, opts.Source)
+			if err := validateFeaturePosition(&feature, length); err != nil {
+				report.Add(entities.ImportRecordResult{Line: f.Line, ID: f.SeqID, Status: entities.ImportFailed, Error: err.Error()})
+				continue
+			}
+			key := entities.FeatureSource{ProteinID: feature.ProteinID, Source: feature.Source}
+			if opts.Replace && !replaced[key] {
+				replaced[key] = true
+				replace = append(replace, key)
+			}
+			features = append(features, feature)
+			lines = append(lines, f.Line)
+		}
+
+		if !opts.DryRun {
+			if err := uc.annotationRepo.SaveFeatures(ctx, features, replace); err != nil {
+				// The deletes were rolled back; a later batch must replace them again.
+				for _, key := range replace {
+					delete(replaced, key)
+				}
+				for i, feature := range features {
+					report.Add(entities.ImportRecordResult{Line: lines[i], ID: feature.ProteinID, Status: entities.ImportFailed, Error: err.Error()})
+				}
+				return nil
+			}
+		}
+		for range features {
+			report.Tally(entities.ImportCreated)
+		}
+		return nil
+	}
+
+	reader := formats.NewGFF3Reader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		f, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if !errors.Is(err, formats.ErrInvalidGFF3) {
+				return nil, err
+			}
+			report.Add(entities.ImportRecordResult{Status: entities.ImportFailed, Error: err.Error()})
+			continue
+		}
+		if batch = append(batch, f); len(batch) >= gff3BatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func featureFromGFF3(f *formats.GFF3Feature, defaultSource string) entities.ProteinFeature {
+	feature := entities.ProteinFeature{
+		ProteinID:   f.SeqID,
+		Type:        f.Type,
+		Start:       f.Start,
+		End:         f.End,
+		Score:       f.Score,
+		Description: f.Attribute(gff3NoteAttribute),
+		Evidence:    f.Attribute(gff3EvidenceAttribute),
+		Source:      f.Source,
+	}
+	if feature.Source == "" {
+		feature.Source = defaultSource
+	}
+	for key, values := range f.Attributes {
+		if key == gff3NoteAttribute || key == gff3EvidenceAttribute {
+			continue
+		}
+		if feature.Attributes == nil {
+			feature.Attributes = make(map[string][]string)
+		}
+		feature.Attributes[key] = values
+	}
+	return feature
+}
+
+// ExportGFF3 writes every feature matching the filter as GFF3, with a
+// ##sequence-region directive before the features of each protein. Features of
+// unknown position cannot be expressed in GFF3 and are left out. A positive filter
+// Limit caps the number of features read.
+func (uc *annotationUseCases) ExportGFF3(ctx context.Context, filter *entities.FeatureFilter, w io.Writer) error {
+	if filter == nil || filter.Limit < 0 {
+		return ErrInvalidInput
+	}
+	if err := validateFeatureRange(filter); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := formats.WriteGFF3Header(bw); err != nil {
+		return err
+	}
+	page := *filter
+	page.Offset = 0
+	written, current := 0, ""
+	for filter.Limit == 0 || written < filter.Limit {
+		page.Limit = gff3BatchSize
+		if filter.Limit > 0 {
+			page.Limit = min(page.Limit, filter.Limit-written)
+		}
+		features, err := uc.annotationRepo.SearchFeatures(ctx, &page)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(features))
+		for _, feature := range features {
+			ids = append(ids, feature.ProteinID)
+		}
+		lengths, err := uc.annotationRepo.ProteinLengths(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for i := range features {
+			if features[i].Start < 1 {
+				continue
+			}
+			if features[i].ProteinID != current {
+				current = features[i].ProteinID
+				if length := lengths[current]; length > 0 {
+					if err := formats.WriteGFF3SequenceRegion(bw, current, 1, length); err != nil {
+						return err
+					}
+				}
+			}
+			if err := formats.WriteGFF3Feature(bw, featureToGFF3(&features[i])); err != nil {
+				return err
+			}
+		}
+
+		written += len(features)
+		if len(features) < page.Limit {
+			break
+		}
+		page.Offset += len(features)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func featureToGFF3(feature *entities.ProteinFeature) *formats.GFF3Feature {
+	f := &formats.GFF3Feature{
+		SeqID:      feature.ProteinID,
+		Source:     feature.Source,
+		Type:       feature.Type,
+		Start:      feature.Start,
+		End:        feature.End,
+		Score:      feature.Score,
+		Attributes: make(map[string][]string, len(feature.Attributes)+2),
+	}
+	for key, values := range feature.Attributes {
+		f.Attributes[key] = values
+	}
+	if feature.Description != "" {
+		f.Attributes[gff3NoteAttribute] = []string{feature.Description}
+	}
+	if feature.Evidence != "" {
+		f.Attributes[gff3EvidenceAttribute] = strings.Split(feature.Evidence, ",")
+	}
+	return f
+}
+
+func (uc *annotationUseCases) checkProtein(ctx context.Context, proteinID string) (*entities.Protein, error) {
+	if strings.TrimSpace(proteinID) == "" {
+		return nil, ErrInvalidInput
+	}
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+	return protein, nil
+}
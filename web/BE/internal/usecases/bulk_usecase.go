@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"io"
+	"sort"
+)
+
+// ImportSourceBulk tags what a bulk create writes. Bulk items carry no features or
+// cross-references, so it never replaces those of a file import.
+const ImportSourceBulk = "bulk"
+
+const maxBulkBatchSize = 5000
+
+// BulkMode decides what a bulk create does with invalid items and stored IDs.
+type BulkMode string
+
+const (
+	// BulkAllOrNothing writes every item in one transaction, or none when any fails.
+	BulkAllOrNothing BulkMode = "all_or_nothing"
+	// BulkBestEffort writes the valid new items and reports the others as failed.
+	BulkBestEffort BulkMode = "best_effort"
+	// BulkUpsert updates stored proteins with the values of their items. Optional fields
+	// an item leaves out keep their stored value; upsert cannot clear them.
+	BulkUpsert BulkMode = "upsert"
+	// BulkSkipExisting keeps stored proteins and reports their items as skipped.
+	BulkSkipExisting BulkMode = "skip_existing"
+)
+
+// BulkProteinRequest is one item of a bulk create. Version is the version of the stored
+// protein the item updates in BulkUpsert mode; the item fails when the protein is at
+// another version. It is not checked when no protein is stored under the ID.
+type BulkProteinRequest struct {
+	ProteinCreateRequest
+	Version *int `json:"version,omitempty"`
+}
+
+// BulkOptions configure a bulk create. Items are written in batches of BatchSize,
+// each in one transaction; in BulkAllOrNothing mode all batches share a transaction.
+type BulkOptions struct {
+	Mode      BulkMode
+	BatchSize int
+	DryRun    bool
+}
+
+// importOptions maps a bulk mode onto the import pipeline.
+func (opts BulkOptions) importOptions() (ImportOptions, error) {
+	importOpts := ImportOptions{DryRun: opts.DryRun, BatchSize: opts.BatchSize}
+	switch opts.Mode {
+	case "", BulkAllOrNothing:
+		importOpts.Dedup, importOpts.AllOrNothing = DedupFail, true
+	case BulkBestEffort:
+		importOpts.Dedup = DedupFail
+	case BulkUpsert:
+		importOpts.Dedup = DedupUpdate
+	case BulkSkipExisting:
+		importOpts.Dedup = DedupSkip
+	default:
+		return ImportOptions{}, fmt.Errorf("%w: unknown mode %q", ErrInvalidInput, opts.Mode)
+	}
+	if opts.BatchSize < 0 || opts.BatchSize > maxBulkBatchSize {
+		return ImportOptions{}, fmt.Errorf("%w: batch_size must be between 1 and %d", ErrInvalidInput, maxBulkBatchSize)
+	}
+	return importOpts, importOpts.normalize()
+}
+
+// BulkCreateProteins creates proteins from a list of requests through the import
+// pipeline and reports every item by its position in the list, from 1. An item repeating
+// the ID of an earlier one is skipped, or failed in BulkAllOrNothing mode. Items of
+// proteins in the trash fail with entities.ErrProteinDeleted in every mode; the trashed
+// protein is left as it is.
+func (uc *importUseCases) BulkCreateProteins(ctx context.Context, requests []*BulkProteinRequest, opts BulkOptions) (*entities.ImportReport, error) {
+	if len(requests) == 0 {
+		return nil, ErrInvalidInput
+	}
+	importOpts, err := opts.importOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	i := 0
+	next := func() (*importCandidate, error) {
+		if i == len(requests) {
+			return nil, io.EOF
+		}
+		req := requests[i]
+		i++
+		candidate := &importCandidate{line: i}
+		if req == nil {
+			candidate.err = errors.New("item is null")
+			return candidate, nil
+		}
+		candidate.id = req.ID
+		protein, err := uc.proteinFromRequest(&req.ProteinCreateRequest)
+		if err != nil {
+			candidate.err = err
+			return candidate, nil
+		}
+		if req.Version != nil {
+			if *req.Version <= 0 {
+				candidate.err = fmt.Errorf("%w: version must be positive", ErrInvalidInput)
+				return candidate, nil
+			}
+			protein.Version = *req.Version
+		}
+		candidate.record = &entities.ProteinRecord{Protein: protein}
+		return candidate, nil
+	}
+
+	report, err := uc.run(ctx, ImportSourceBulk, next, importOpts)
+	if report != nil {
+		sort.SliceStable(report.Records, func(a, b int) bool {
+			return report.Records[a].Line < report.Records[b].Line
+		})
+	}
+	return report, err
+}
+
+// proteinFromRequest validates a create request and builds its protein, like
+// CreateProtein does.
+func (uc *importUseCases) proteinFromRequest(req *ProteinCreateRequest) (*entities.Protein, error) {
+	if err := uc.proteinService.ValidateSequence(req.Seq); err != nil {
+		return nil, err
+	}
+	protein, err := entities.NewProtein(req.ID, req.Name, req.Seq)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Gene != nil {
+		protein.SetGene(*req.Gene)
+	}
+	if req.Aliases != nil {
+		protein.SetAliases(req.Aliases)
+	}
+	if req.Taxo != nil {
+		protein.SetTaxonomy(*req.Taxo)
+	}
+	if req.TaxonID != nil {
+		protein.SetTaxonID(*req.TaxonID)
+	}
+	protein.CC = req.CC
+	protein.Domain = req.Domain
+	protein.Family = req.Family
+	protein.Function = req.Function
+
+	setDerivedProperties(protein, uc.proteinService)
+	return protein, nil
+}
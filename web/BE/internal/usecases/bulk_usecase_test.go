@@ -0,0 +1,267 @@
+package usecases
+
+import (
+	"context"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"go-crawler/web/BE/internal/domain/services"
+	"maps"
+	"testing"
+	"time"
+)
+
+// bulkStore stands in for the protein and import repositories. The proteins in
+// meanwhile are stored by another writer when the first write begins, after the dedup
+// policy was checked.
+type bulkStore struct {
+	repositories.IProteinRepository
+	repositories.ImportRepository
+	proteins  map[string]*storedProtein
+	meanwhile map[string]*storedProtein
+}
+
+type storedProtein struct {
+	version int
+	trashed bool
+}
+
+func (s *bulkStore) GetStoredVersions(ctx context.Context, ids []string) (map[string]*entities.Protein, error) {
+	stored := make(map[string]*entities.Protein)
+	for _, id := range ids {
+		if protein := s.proteins[id]; protein != nil {
+			stored[id] = &entities.Protein{ID: id, Version: protein.version}
+			if protein.trashed {
+				deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+				stored[id].DeletedAt = &deletedAt
+			}
+		}
+	}
+	return stored, nil
+}
+
+func (s *bulkStore) SaveRecords(ctx context.Context, records []*entities.ProteinRecord, source string, upsert bool, checkpoint *entities.ImportCheckpoint) (map[string]entities.SaveOutcome, error) {
+	outcomes := s.outcomes([][]*entities.ProteinRecord{records}, upsert)
+	for id, outcome := range outcomes {
+		s.store(id, outcome)
+	}
+	return outcomes, nil
+}
+
+// SaveBatches writes every record or, when one is left alone, none.
+func (s *bulkStore) SaveBatches(ctx context.Context, batches [][]*entities.ProteinRecord, source string, upsert bool) (map[string]entities.SaveOutcome, error) {
+	outcomes := s.outcomes(batches, upsert)
+	for _, outcome := range outcomes {
+		if outcome != entities.SaveCreated && outcome != entities.SaveUpdated {
+			return outcomes, entities.ErrImportRolledBack
+		}
+	}
+	for id, outcome := range outcomes {
+		s.store(id, outcome)
+	}
+	return outcomes, nil
+}
+
+// outcomes is what writing the batches would do to the stored proteins, once the
+// writes made meanwhile are in.
+func (s *bulkStore) outcomes(batches [][]*entities.ProteinRecord, upsert bool) map[string]entities.SaveOutcome {
+	maps.Copy(s.proteins, s.meanwhile)
+	s.meanwhile = nil
+
+	outcomes := make(map[string]entities.SaveOutcome)
+	for _, records := range batches {
+		for _, record := range records {
+			protein, stored := record.Protein, s.proteins[record.Protein.ID]
+			switch {
+			case stored == nil:
+				outcomes[protein.ID] = entities.SaveCreated
+			case stored.trashed:
+				outcomes[protein.ID] = entities.SaveTrashed
+			case !upsert:
+				outcomes[protein.ID] = entities.SaveExists
+			case protein.Version != 0 && protein.Version != stored.version:
+				outcomes[protein.ID] = entities.SaveVersionConflict
+			default:
+				outcomes[protein.ID] = entities.SaveUpdated
+			}
+		}
+	}
+	return outcomes
+}
+
+// store applies a write with outcome to the protein stored under id.
+func (s *bulkStore) store(id string, outcome entities.SaveOutcome) {
+	switch outcome {
+	case entities.SaveCreated:
+		s.proteins[id] = &storedProtein{version: 1}
+	case entities.SaveUpdated:
+		s.proteins[id].version++
+	}
+}
+
+// derivedPropertiesService computes the properties that need the Python helpers as
+// constants.
+type derivedPropertiesService struct {
+	services.ProteinDomainService
+}
+
+func (derivedPropertiesService) CalculateIsoelectricPoint(string) float64 { return 7 }
+func (derivedPropertiesService) CalculateHydrophobicity(string) float64   { return 0 }
+
+func TestBulkCreateProteins(t *testing.T) {
+	type item struct {
+		id      string
+		version int
+	}
+	type want struct {
+		status  entities.ImportStatus
+		message string
+	}
+	var (
+		created  = want{status: entities.ImportCreated}
+		updated  = want{entities.ImportUpdated, reasonMerged}
+		exists   = want{entities.ImportFailed, ErrProteinExists.Error()}
+		trashed  = want{entities.ImportFailed, entities.ErrProteinDeleted.Error()}
+		conflict = want{entities.ImportFailed, entities.ErrVersionConflict.Error()}
+	)
+	tests := []struct {
+		name       string
+		mode       BulkMode
+		dryRun     bool
+		meanwhile  map[string]*storedProtein
+		items      []item
+		want       []want
+		wantStored map[string]int
+	}{
+		{
+			name:       "all or nothing",
+			mode:       BulkAllOrNothing,
+			items:      []item{{id: "N1"}, {id: "N2"}},
+			want:       []want{created, created},
+			wantStored: map[string]int{"N1": 1, "N2": 1},
+		},
+		{
+			name:  "all or nothing with a stored ID",
+			mode:  BulkAllOrNothing,
+			items: []item{{id: "N1"}, {id: "P1"}},
+			want:  []want{{entities.ImportSkipped, reasonNotWritten}, exists},
+		},
+		{
+			name:  "all or nothing with a repeated ID",
+			mode:  BulkAllOrNothing,
+			items: []item{{id: "N1"}, {id: "N1"}},
+			want:  []want{{entities.ImportSkipped, reasonNotWritten}, {entities.ImportFailed, reasonDuplicateID}},
+		},
+		{
+			name:       "all or nothing with an ID stored meanwhile",
+			mode:       BulkAllOrNothing,
+			meanwhile:  map[string]*storedProtein{"N2": {version: 5}},
+			items:      []item{{id: "N1"}, {id: "N2"}},
+			want:       []want{{entities.ImportSkipped, reasonNotWritten}, exists},
+			wantStored: map[string]int{"N2": 5},
+		},
+		{
+			name:       "best effort",
+			mode:       BulkBestEffort,
+			items:      []item{{id: "N1"}, {id: "P1"}, {id: "N1"}, {id: "P2"}},
+			want:       []want{created, exists, {entities.ImportSkipped, reasonDuplicateID}, trashed},
+			wantStored: map[string]int{"N1": 1},
+		},
+		{
+			name:       "best effort with an ID stored meanwhile",
+			mode:       BulkBestEffort,
+			meanwhile:  map[string]*storedProtein{"N2": {version: 5}},
+			items:      []item{{id: "N1"}, {id: "N2"}},
+			want:       []want{created, exists},
+			wantStored: map[string]int{"N1": 1, "N2": 5},
+		},
+		{
+			name:       "skip existing",
+			mode:       BulkSkipExisting,
+			items:      []item{{id: "N1"}, {id: "P1"}, {id: "P2"}},
+			want:       []want{created, {entities.ImportSkipped, ErrProteinExists.Error()}, trashed},
+			wantStored: map[string]int{"N1": 1},
+		},
+		{
+			name:       "upsert",
+			mode:       BulkUpsert,
+			items:      []item{{id: "P1"}, {id: "N1", version: 4}},
+			want:       []want{updated, created},
+			wantStored: map[string]int{"P1": 2, "N1": 1},
+		},
+		{
+			name:       "upsert at the stored version",
+			mode:       BulkUpsert,
+			items:      []item{{id: "P1", version: 1}},
+			want:       []want{updated},
+			wantStored: map[string]int{"P1": 2},
+		},
+		{
+			name:  "upsert at another version",
+			mode:  BulkUpsert,
+			items: []item{{id: "P1", version: 2}},
+			want:  []want{conflict},
+		},
+		{
+			name:       "upsert of a protein changed meanwhile",
+			mode:       BulkUpsert,
+			meanwhile:  map[string]*storedProtein{"P1": {version: 2}},
+			items:      []item{{id: "P1", version: 1}},
+			want:       []want{conflict},
+			wantStored: map[string]int{"P1": 2},
+		},
+		{
+			name:  "upsert of a trashed protein",
+			mode:  BulkUpsert,
+			items: []item{{id: "P2"}},
+			want:  []want{trashed},
+		},
+		{
+			name:   "dry run",
+			mode:   BulkUpsert,
+			dryRun: true,
+			items:  []item{{id: "P1", version: 1}, {id: "N1"}},
+			want:   []want{updated, created},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &bulkStore{
+				proteins:  map[string]*storedProtein{"P1": {version: 1}, "P2": {version: 3, trashed: true}},
+				meanwhile: tt.meanwhile,
+			}
+			uc := NewImportUseCases(store, store, derivedPropertiesService{services.NewProteinService()})
+
+			requests := make([]*BulkProteinRequest, len(tt.items))
+			for i, item := range tt.items {
+				requests[i] = &BulkProteinRequest{ProteinCreateRequest: ProteinCreateRequest{ID: item.id, Name: "Kinase", Seq: []string{"MKVL"}}}
+				if item.version != 0 {
+					requests[i].Version = &item.version
+				}
+			}
+			report, err := uc.BulkCreateProteins(context.Background(), requests, BulkOptions{Mode: tt.mode, DryRun: tt.dryRun})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(report.Records) != len(tt.want) {
+				t.Fatalf("%d records, want %d: %+v", len(report.Records), len(tt.want), report.Records)
+			}
+			for i, record := range report.Records {
+				got := want{record.Status, record.Reason + record.Error}
+				if record.Line != i+1 || got != tt.want[i] {
+					t.Errorf("item %d: %+v, want %+v", i+1, record, tt.want[i])
+				}
+			}
+
+			wantStored := map[string]int{"P1": 1, "P2": 3}
+			maps.Copy(wantStored, tt.wantStored)
+			stored := make(map[string]int)
+			for id, protein := range store.proteins {
+				stored[id] = protein.version
+			}
+			if !maps.Equal(stored, wantStored) {
+				t.Errorf("stored versions %v, want %v", stored, wantStored)
+			}
+		})
+	}
+}
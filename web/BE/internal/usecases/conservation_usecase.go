@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"strings"
+)
+
+// ConservationRequest scores either an existing alignment (Alignment, in FASTA, Clustal
+// or Stockholm format) or the stored sequences of ProteinIDs, aligned to the first one.
+type ConservationRequest struct {
+	Alignment  string   `json:"alignment,omitempty"`
+	Format     string   `json:"format,omitempty"`
+	ProteinIDs []string `json:"protein_ids,omitempty"`
+	Weighted   *bool    `json:"weighted,omitempty"`
+}
+
+type ConservationUseCases interface {
+	ScoreConservation(ctx context.Context, req *ConservationRequest) (*entities.ConservationProfile, error)
+	ScoreAlignmentFile(ctx context.Context, format string, weighted bool, r io.Reader) (*entities.ConservationProfile, error)
+}
+
+type conservationUseCases struct {
+	proteinRepo         *repositories.ProteinRepositories
+	conservationService services.ConservationDomainService
+}
+
+func NewConservationUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	conservationService services.ConservationDomainService,
+) ConservationUseCases {
+	return &conservationUseCases{
+		proteinRepo:         proteinRepo,
+		conservationService: conservationService,
+	}
+}
+
+func (uc *conservationUseCases) ScoreConservation(ctx context.Context, req *ConservationRequest) (*entities.ConservationProfile, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	weighted := req.Weighted == nil || *req.Weighted
+
+	if strings.TrimSpace(req.Alignment) != "" {
+		return uc.ScoreAlignmentFile(ctx, req.Format, weighted, strings.NewReader(req.Alignment))
+	}
+	if len(req.ProteinIDs) < 2 {
+		return nil, ErrInvalidInput
+	}
+
+	proteins, err := uc.proteinRepo.GetByIDs(ctx, req.ProteinIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(proteins) != len(req.ProteinIDs) {
+		return nil, ErrProteinNotFound
+	}
+
+	sequences := make([]entities.AlignedSequence, len(proteins))
+	for i, protein := range proteins {
+		sequences[i] = entities.AlignedSequence{ID: protein.ID, Sequence: protein.GetFullSequence()}
+	}
+	return uc.conservationService.ScoreAlignment(uc.conservationService.AlignToReference(sequences), weighted)
+}
+
+func (uc *conservationUseCases) ScoreAlignmentFile(ctx context.Context, format string, weighted bool, r io.Reader) (*entities.ConservationProfile, error) {
+	rows, err := formats.ReadAlignment(r, strings.ToLower(strings.TrimSpace(format)))
+	if err != nil {
+		if errors.Is(err, formats.ErrUnsupportedAlignmentFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	return uc.conservationService.ScoreAlignment(rows, weighted)
+}
@@ -0,0 +1,580 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDiseaseNotFound            = errors.New("disease not found")
+	ErrDiseaseExists              = errors.New("disease already exists")
+	ErrDiseaseAssociationNotFound = errors.New("disease association not found")
+	ErrDiseaseAssociationExists   = errors.New("disease association already exists")
+)
+
+const (
+	ImportSourceDiseaseTSV = "TSV"
+	DiseaseSourceManual    = "manual"
+
+	defaultDiseaseLimit = 50
+	maxDiseaseLimit     = 500
+
+	diseaseBatchSize = 1000
+)
+
+// Column names accepted by the disease TSV import, after NormalizeTSVColumn. They
+// cover DisGeNET gene–disease files (geneSymbol, diseaseId, diseaseName, diseaseClass,
+// score, pmid, source) and OMIM-style morbid maps (Phenotype, Gene/Locus And Other
+// Related Symbols).
+var (
+	diseaseProteinColumns     = []string{"protein_id", "uniprot_id", "uniprot", "accession"}
+	diseaseGeneColumns        = []string{"gene_symbol", "gene_symbols", "approved_gene_symbol", "gene/locus_and_other_related_symbols", "gene"}
+	diseaseIDColumns          = []string{"disease_id", "phenotype_mim_number", "omim"}
+	diseaseNameColumns        = []string{"disease_name", "phenotype", "disease"}
+	diseaseDescriptionColumns = []string{"description", "disease_description"}
+	diseaseCategoryColumns    = []string{"disease_class", "disease_type", "category"}
+	diseaseSynonymColumns     = []string{"synonyms", "disease_synonyms"}
+	diseaseEvidenceColumns    = []string{"evidence_type", "evidence"}
+	diseaseScoreColumns       = []string{"score"}
+	diseaseReferenceColumns   = []string{"pmids", "pmid", "references"}
+	diseaseSourceColumns      = []string{"source"}
+)
+
+var (
+	// omimPhenotype matches the phenotype column of OMIM's morbidmap, e.g.
+	// "Breast-ovarian cancer, familial, 1, 604370 (3)".
+	omimPhenotype = regexp.MustCompile(`^(.*?),\s*(\d{6})\s*\(\d\)\s*$`)
+	umlsCUI       = regexp.MustCompile(`^C\d{7}$`)
+	mimNumber     = regexp.MustCompile(`^\d{6}$`)
+)
+
+// DiseaseImportOptions controls a TSV import. EvidenceType and Source apply to lines
+// without an evidence or source column.
+type DiseaseImportOptions struct {
+	EvidenceType string
+	Source       string
+	DryRun       bool
+}
+
+type DiseaseRequest struct {
+	ID          string   `json:"id" validate:"required"`
+	Name        string   `json:"name" validate:"required"`
+	Description string   `json:"description,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Source      string   `json:"source,omitempty"`
+	Synonyms    []string `json:"synonyms,omitempty"`
+}
+
+type DiseaseAssociationRequest struct {
+	DiseaseID    string   `json:"disease_id" validate:"required"`
+	EvidenceType string   `json:"evidence_type" validate:"required"`
+	Source       string   `json:"source,omitempty"`
+	Score        *float64 `json:"score,omitempty"`
+	References   []string `json:"references,omitempty"`
+}
+
+type DiseaseUseCases interface {
+	SearchDiseases(ctx context.Context, filter *entities.DiseaseFilter) ([]*entities.Disease, error)
+	GetDisease(ctx context.Context, id string) (*entities.Disease, error)
+	CreateDisease(ctx context.Context, req *DiseaseRequest) (*entities.Disease, error)
+	UpdateDisease(ctx context.Context, id string, req *DiseaseRequest) (*entities.Disease, error)
+	DeleteDisease(ctx context.Context, id string) error
+	GetDiseaseProteins(ctx context.Context, id string, limit, offset int) ([]entities.DiseaseAssociation, error)
+	GetProteinDiseases(ctx context.Context, proteinID string) ([]entities.DiseaseAssociation, error)
+	CreateAssociation(ctx context.Context, proteinID string, req *DiseaseAssociationRequest) (*entities.DiseaseAssociation, error)
+	DeleteAssociation(ctx context.Context, proteinID string, id int64) error
+	ImportTSV(ctx context.Context, r io.Reader, opts DiseaseImportOptions) (*entities.ImportReport, error)
+}
+
+type diseaseUseCases struct {
+	proteinRepo *repositories.ProteinRepositories
+	diseaseRepo *repositories.DiseaseRepositories
+}
+
+func NewDiseaseUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	diseaseRepo *repositories.DiseaseRepositories,
+) DiseaseUseCases {
+	return &diseaseUseCases{
+		proteinRepo: proteinRepo,
+		diseaseRepo: diseaseRepo,
+	}
+}
+
+func (uc *diseaseUseCases) SearchDiseases(ctx context.Context, filter *entities.DiseaseFilter) ([]*entities.Disease, error) {
+	if filter == nil || filter.Limit < 0 || filter.Offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultDiseaseLimit
+	}
+	filter.Limit = min(filter.Limit, maxDiseaseLimit)
+	filter.Query = strings.TrimSpace(filter.Query)
+	return uc.diseaseRepo.SearchDiseases(ctx, filter)
+}
+
+func (uc *diseaseUseCases) GetDisease(ctx context.Context, id string) (*entities.Disease, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrInvalidInput
+	}
+	disease, err := uc.diseaseRepo.GetDisease(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDiseaseNotFound
+		}
+		return nil, err
+	}
+	return disease, nil
+}
+
+func (uc *diseaseUseCases) CreateDisease(ctx context.Context, req *DiseaseRequest) (*entities.Disease, error) {
+	disease, err := diseaseFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if existing, _ := uc.diseaseRepo.GetDisease(ctx, disease.ID); existing != nil {
+		return nil, ErrDiseaseExists
+	}
+	disease.Created, disease.Updated = time.Now(), time.Now()
+	if err := uc.diseaseRepo.CreateDisease(ctx, disease); err != nil {
+		return nil, err
+	}
+	return disease, nil
+}
+
+// UpdateDisease replaces a disease's name, description, category, source and
+// synonyms; the ID in the request is ignored.
+func (uc *diseaseUseCases) UpdateDisease(ctx context.Context, id string, req *DiseaseRequest) (*entities.Disease, error) {
+	existing, err := uc.GetDisease(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req != nil {
+		req.ID = existing.ID
+	}
+	disease, err := diseaseFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	disease.Created, disease.Updated = existing.Created, time.Now()
+	if err := uc.diseaseRepo.UpdateDisease(ctx, disease); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDiseaseNotFound
+		}
+		return nil, err
+	}
+	return disease, nil
+}
+
+func diseaseFromRequest(req *DiseaseRequest) (*entities.Disease, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	disease := &entities.Disease{
+		ID:          normalizeDiseaseID(req.ID),
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Category:    strings.TrimSpace(req.Category),
+		Source:      strings.TrimSpace(req.Source),
+		Synonyms:    cleanSynonyms(req.Synonyms, req.Name),
+	}
+	if disease.ID == "" {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, entities.ErrInvalidDiseaseID)
+	}
+	if disease.Name == "" {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, entities.ErrInvalidDiseaseName)
+	}
+	return disease, nil
+}
+
+func (uc *diseaseUseCases) DeleteDisease(ctx context.Context, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return ErrInvalidInput
+	}
+	if err := uc.diseaseRepo.DeleteDisease(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrDiseaseNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetDiseaseProteins pages through the proteins associated with a disease.
+func (uc *diseaseUseCases) GetDiseaseProteins(ctx context.Context, id string, limit, offset int) ([]entities.DiseaseAssociation, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	disease, err := uc.GetDisease(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = defaultDiseaseLimit
+	}
+	return uc.diseaseRepo.GetDiseaseAssociations(ctx, disease.ID, min(limit, maxDiseaseLimit), offset)
+}
+
+func (uc *diseaseUseCases) GetProteinDiseases(ctx context.Context, proteinID string) ([]entities.DiseaseAssociation, error) {
+	if err := uc.checkProtein(ctx, proteinID); err != nil {
+		return nil, err
+	}
+	return uc.diseaseRepo.GetProteinAssociations(ctx, proteinID)
+}
+
+// CreateAssociation links a protein to a stored disease; its source is "manual" unless
+// given.
+func (uc *diseaseUseCases) CreateAssociation(ctx context.Context, proteinID string, req *DiseaseAssociationRequest) (*entities.DiseaseAssociation, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := uc.checkProtein(ctx, proteinID); err != nil {
+		return nil, err
+	}
+	disease, err := uc.GetDisease(ctx, normalizeDiseaseID(req.DiseaseID))
+	if err != nil {
+		return nil, err
+	}
+
+	association := &entities.DiseaseAssociation{
+		ProteinID:    proteinID,
+		DiseaseID:    disease.ID,
+		DiseaseName:  disease.Name,
+		EvidenceType: strings.ToLower(strings.TrimSpace(req.EvidenceType)),
+		Source:       strings.TrimSpace(req.Source),
+		Score:        req.Score,
+		References:   req.References,
+		Created:      time.Now(),
+	}
+	if association.Source == "" {
+		association.Source = DiseaseSourceManual
+	}
+	if err := validateDiseaseAssociation(association); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.diseaseRepo.GetProteinAssociations(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.DiseaseID == association.DiseaseID && other.Source == association.Source {
+			return nil, ErrDiseaseAssociationExists
+		}
+	}
+	if err := uc.diseaseRepo.CreateAssociation(ctx, association); err != nil {
+		return nil, err
+	}
+	return association, nil
+}
+
+func validateDiseaseAssociation(association *entities.DiseaseAssociation) error {
+	if !entities.IsDiseaseEvidenceType(association.EvidenceType) {
+		return fmt.Errorf("%w: unknown evidence type %q", ErrInvalidInput, association.EvidenceType)
+	}
+	if association.Score != nil && (*association.Score < 0 || *association.Score > 1) {
+		return fmt.Errorf("%w: score must be between 0 and 1", ErrInvalidInput)
+	}
+	return nil
+}
+
+func (uc *diseaseUseCases) DeleteAssociation(ctx context.Context, proteinID string, id int64) error {
+	if err := uc.checkProtein(ctx, proteinID); err != nil {
+		return err
+	}
+	if err := uc.diseaseRepo.DeleteAssociation(ctx, proteinID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrDiseaseAssociationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// diseaseImportLine is a parsed TSV line waiting for its proteins to be resolved.
+type diseaseImportLine struct {
+	line        int
+	key         string
+	proteinID   string
+	genes       []string
+	disease     *entities.Disease
+	association entities.DiseaseAssociation
+}
+
+// ImportTSV imports diseases and protein–disease associations from a tab-separated
+// file with a header line. Proteins are named by a protein ID or UniProtKB accession
+// column, or else by gene symbol, which associates every protein of the gene. Lines
+// whose proteins are unknown are skipped; an association already recorded for the same
+// protein, disease and source is updated.
+func (uc *diseaseUseCases) ImportTSV(ctx context.Context, r io.Reader, opts DiseaseImportOptions) (*entities.ImportReport, error) {
+	if opts.EvidenceType == "" {
+		opts.EvidenceType = entities.DiseaseEvidenceCurated
+	}
+	if opts.Source == "" {
+		opts.Source = ImportSourceDiseaseTSV
+	}
+	if !entities.IsDiseaseEvidenceType(opts.EvidenceType) {
+		return nil, fmt.Errorf("%w: unknown evidence type %q", ErrInvalidInput, opts.EvidenceType)
+	}
+
+	report := entities.NewImportReport()
+	report.DryRun = opts.DryRun
+
+	var batch []*diseaseImportLine
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() { batch = batch[:0] }()
+
+		var ids, genes []string
+		for _, line := range batch {
+			if line.proteinID != "" {
+				ids = append(ids, line.proteinID)
+			}
+			genes = append(genes, line.genes...)
+		}
+		proteins, err := uc.proteinRepo.ResolveIDs(ctx, "UniProtKB", ids)
+		if err != nil {
+			return err
+		}
+		geneProteins, err := uc.proteinRepo.ResolveGenes(ctx, genes)
+		if err != nil {
+			return err
+		}
+
+		diseases := make(map[string]*entities.Disease)
+		var diseaseOrder []*entities.Disease
+		var associations []entities.DiseaseAssociation
+		var lines []*diseaseImportLine
+		for _, line := range batch {
+			var proteinIDs []string
+			if line.proteinID != "" {
+				if id, ok := proteins[line.proteinID]; ok {
+					proteinIDs = []string{id}
+				}
+			} else {
+				// A morbid map lists the approved symbol first; use the first known one.
+				for _, gene := range line.genes {
+					if proteinIDs = geneProteins[gene]; len(proteinIDs) > 0 {
+						break
+					}
+				}
+			}
+			if len(proteinIDs) == 0 {
+				report.Add(entities.ImportRecordResult{Line: line.line, ID: line.key, Status: entities.ImportSkipped, Reason: "unknown protein"})
+				continue
+			}
+
+			if known, ok := diseases[line.disease.ID]; ok {
+				known.Synonyms = cleanSynonyms(append(known.Synonyms, line.disease.Synonyms...), known.Name)
+			} else {
+				diseases[line.disease.ID] = line.disease
+				diseaseOrder = append(diseaseOrder, line.disease)
+			}
+			for _, proteinID := range proteinIDs {
+				association := line.association
+				association.ProteinID = proteinID
+				associations = append(associations, association)
+				lines = append(lines, line)
+			}
+		}
+
+		if !opts.DryRun {
+			if err := uc.diseaseRepo.SaveImport(ctx, diseaseOrder, dedupeAssociations(associations)); err != nil {
+				for i, association := range associations {
+					report.Add(entities.ImportRecordResult{Line: lines[i].line, ID: association.ProteinID, Status: entities.ImportFailed, Error: err.Error()})
+				}
+				return nil
+			}
+		}
+		for range associations {
+			report.Tally(entities.ImportCreated)
+		}
+		return nil
+	}
+
+	reader := formats.NewTSVReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if errors.Is(err, formats.ErrInvalidTSV) {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+			}
+			return nil, err
+		}
+
+		line, err := diseaseLineFromTSV(record, opts)
+		if err != nil {
+			report.Add(entities.ImportRecordResult{Line: record.Line, ID: record.Get(diseaseIDColumns...), Status: entities.ImportFailed, Error: err.Error()})
+			continue
+		}
+		if batch = append(batch, line); len(batch) >= diseaseBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// diseaseLineFromTSV reads the disease and association of one line. An OMIM phenotype
+// such as "Breast cancer, 114480 (3)" yields both the name and the ID.
+func diseaseLineFromTSV(record *formats.TSVRecord, opts DiseaseImportOptions) (*diseaseImportLine, error) {
+	name := record.Get(diseaseNameColumns...)
+	id := record.Get(diseaseIDColumns...)
+	if m := omimPhenotype.FindStringSubmatch(name); m != nil {
+		name = strings.TrimSpace(m[1])
+		if id == "" {
+			id = m[2]
+		}
+	}
+	id = normalizeDiseaseID(id)
+	if id == "" {
+		return nil, entities.ErrInvalidDiseaseID
+	}
+	if name == "" {
+		return nil, entities.ErrInvalidDiseaseName
+	}
+
+	line := &diseaseImportLine{
+		line:      record.Line,
+		proteinID: record.Get(diseaseProteinColumns...),
+		disease: &entities.Disease{
+			ID:          id,
+			Name:        name,
+			Description: record.Get(diseaseDescriptionColumns...),
+			Category:    record.Get(diseaseCategoryColumns...),
+			Source:      opts.Source,
+			Synonyms:    cleanSynonyms(splitList(record.Get(diseaseSynonymColumns...)), name),
+			Created:     time.Now(),
+			Updated:     time.Now(),
+		},
+	}
+	if line.proteinID == "" {
+		line.genes = splitList(record.Get(diseaseGeneColumns...))
+		if len(line.genes) == 0 {
+			return nil, errors.New("no protein or gene column")
+		}
+		line.key = line.genes[0]
+	} else {
+		line.key = line.proteinID
+	}
+
+	association := entities.DiseaseAssociation{
+		DiseaseID:    id,
+		EvidenceType: strings.ToLower(record.Get(diseaseEvidenceColumns...)),
+		Source:       record.Get(diseaseSourceColumns...),
+		References:   splitList(record.Get(diseaseReferenceColumns...)),
+		Created:      time.Now(),
+	}
+	if association.EvidenceType == "" {
+		association.EvidenceType = opts.EvidenceType
+	}
+	if association.Source == "" {
+		association.Source = opts.Source
+	}
+	if value := record.Get(diseaseScoreColumns...); value != "" {
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score %q", value)
+		}
+		association.Score = &score
+	}
+	if err := validateDiseaseAssociation(&association); err != nil {
+		return nil, err
+	}
+	line.association = association
+	return line, nil
+}
+
+// dedupeAssociations keeps the last association per protein, disease and source, as
+// one upsert statement cannot touch the same row twice.
+func dedupeAssociations(associations []entities.DiseaseAssociation) []entities.DiseaseAssociation {
+	type key struct{ protein, disease, source string }
+	index := make(map[key]int, len(associations))
+	deduped := associations[:0:0]
+	for _, association := range associations {
+		k := key{association.ProteinID, association.DiseaseID, association.Source}
+		if i, ok := index[k]; ok {
+			deduped[i] = association
+			continue
+		}
+		index[k] = len(deduped)
+		deduped = append(deduped, association)
+	}
+	return deduped
+}
+
+// normalizeDiseaseID prefixes bare OMIM numbers and UMLS CUIs (as used by DisGeNET);
+// other IDs are kept as given.
+func normalizeDiseaseID(id string) string {
+	id = strings.TrimSpace(id)
+	switch {
+	case mimNumber.MatchString(id):
+		return "OMIM:" + id
+	case umlsCUI.MatchString(id):
+		return "UMLS:" + id
+	}
+	return id
+}
+
+// splitList splits a "|", ";" or ","-separated cell.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == '|' || r == ';' || r == ',' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// cleanSynonyms trims and deduplicates synonyms, dropping those equal to the name.
+func cleanSynonyms(synonyms []string, name string) []string {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(name)): true}
+	var cleaned []string
+	for _, synonym := range synonyms {
+		synonym = strings.TrimSpace(synonym)
+		if synonym == "" || seen[strings.ToLower(synonym)] {
+			continue
+		}
+		seen[strings.ToLower(synonym)] = true
+		cleaned = append(cleaned, synonym)
+	}
+	return cleaned
+}
+
+func (uc *diseaseUseCases) checkProtein(ctx context.Context, proteinID string) error {
+	if strings.TrimSpace(proteinID) == "" {
+		return ErrInvalidInput
+	}
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return err
+	}
+	if protein == nil {
+		return ErrProteinNotFound
+	}
+	return nil
+}
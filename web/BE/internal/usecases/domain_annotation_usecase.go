@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"strings"
+	"time"
+)
+
+var ErrNoHMMModels = errors.New("no profile HMMs are loaded")
+
+const defaultAnnotationBatchSize = 200
+
+type DomainAnnotationUseCases interface {
+	ListModels(ctx context.Context) []*entities.ProfileHMM
+	GetProteinDomains(ctx context.Context, proteinID string) ([]entities.DomainHit, error)
+	AnnotateProtein(ctx context.Context, proteinID string) ([]entities.DomainHit, error)
+	AnnotateAll(ctx context.Context, batchSize int, progress func(scanned int)) (*entities.DomainAnnotationSummary, error)
+}
+
+type domainAnnotationUseCases struct {
+	proteinRepo   *repositories.ProteinRepositories
+	domainHitRepo *repositories.DomainHitRepositories
+	hmmService    services.HMMDomainService
+	models        []*entities.ProfileHMM
+	evalue        float64
+}
+
+func NewDomainAnnotationUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	domainHitRepo *repositories.DomainHitRepositories,
+	hmmService services.HMMDomainService,
+	models []*entities.ProfileHMM,
+	evalue float64,
+) DomainAnnotationUseCases {
+	return &domainAnnotationUseCases{
+		proteinRepo:   proteinRepo,
+		domainHitRepo: domainHitRepo,
+		hmmService:    hmmService,
+		models:        models,
+		evalue:        evalue,
+	}
+}
+
+func (uc *domainAnnotationUseCases) ListModels(ctx context.Context) []*entities.ProfileHMM {
+	return uc.models
+}
+
+func (uc *domainAnnotationUseCases) GetProteinDomains(ctx context.Context, proteinID string) ([]entities.DomainHit, error) {
+	if strings.TrimSpace(proteinID) == "" {
+		return nil, ErrInvalidInput
+	}
+
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+	return uc.domainHitRepo.GetByProtein(ctx, protein.ID)
+}
+
+func (uc *domainAnnotationUseCases) AnnotateProtein(ctx context.Context, proteinID string) ([]entities.DomainHit, error) {
+	if strings.TrimSpace(proteinID) == "" {
+		return nil, ErrInvalidInput
+	}
+	if len(uc.models) == 0 {
+		return nil, ErrNoHMMModels
+	}
+
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+	return uc.annotate(ctx, protein)
+}
+
+// AnnotateAll re-annotates every stored protein against the loaded models, replacing
+// existing hits protein by protein.
+func (uc *domainAnnotationUseCases) AnnotateAll(ctx context.Context, batchSize int, progress func(scanned int)) (*entities.DomainAnnotationSummary, error) {
+	if len(uc.models) == 0 {
+		return nil, ErrNoHMMModels
+	}
+	if batchSize <= 0 {
+		batchSize = defaultAnnotationBatchSize
+	}
+
+	summary := &entities.DomainAnnotationSummary{Models: len(uc.models), StartedAt: time.Now()}
+	err := uc.proteinRepo.ForEachBatch(ctx, batchSize, func(proteins []*entities.Protein) error {
+		for _, protein := range proteins {
+			hits, err := uc.annotate(ctx, protein)
+			if err != nil {
+				return err
+			}
+			summary.ProteinsScanned++
+			summary.Hits += len(hits)
+			if len(hits) > 0 {
+				summary.ProteinsAnnotated++
+			}
+		}
+		if progress != nil {
+			progress(summary.ProteinsScanned)
+		}
+		return ctx.Err()
+	})
+	summary.FinishedAt = time.Now()
+	return summary, err
+}
+
+func (uc *domainAnnotationUseCases) annotate(ctx context.Context, protein *entities.Protein) ([]entities.DomainHit, error) {
+	hits := uc.hmmService.ScanSequence(uc.models, protein.GetFullSequence(), uc.evalue)
+	for i := range hits {
+		hits[i].ProteinID = protein.ID
+	}
+	if err := uc.domainHitRepo.ReplaceForProtein(ctx, protein.ID, hits); err != nil {
+		return nil, err
+	}
+	if hits == nil {
+		hits = []entities.DomainHit{}
+	}
+	return hits, nil
+}
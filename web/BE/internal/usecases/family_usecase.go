@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"strings"
+)
+
+var (
+	ErrFamilyNotFound = errors.New("protein family not found")
+	ErrFamilyExists   = errors.New("protein family already exists")
+)
+
+type FamilyUseCases interface {
+	ListFamilies(ctx context.Context, query string, limit, offset int) ([]*entities.ProteinFamily, error)
+	GetFamily(ctx context.Context, id int) (*entities.ProteinFamily, error)
+	CreateFamily(ctx context.Context, req *GeneRequest) (*entities.ProteinFamily, error)
+	UpdateFamily(ctx context.Context, id int, req *GeneRequest) (*entities.ProteinFamily, error)
+	DeleteFamily(ctx context.Context, id int) error
+	GetFamilyProteins(ctx context.Context, id, limit, offset int) (*entities.PaginatedProteins, error)
+}
+
+type familyUseCases struct {
+	proteinRepo *repositories.ProteinRepositories
+	familyRepo  *repositories.ProteinFamilyRepositories
+}
+
+func NewFamilyUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	familyRepo *repositories.ProteinFamilyRepositories,
+) FamilyUseCases {
+	return &familyUseCases{
+		proteinRepo: proteinRepo,
+		familyRepo:  familyRepo,
+	}
+}
+
+func (uc *familyUseCases) ListFamilies(ctx context.Context, query string, limit, offset int) ([]*entities.ProteinFamily, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = defaultGeneLimit
+	}
+	return uc.familyRepo.List(ctx, strings.TrimSpace(query), min(limit, maxGeneLimit), offset)
+}
+
+func (uc *familyUseCases) GetFamily(ctx context.Context, id int) (*entities.ProteinFamily, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	family, err := uc.familyRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, err
+	}
+	return family, nil
+}
+
+func (uc *familyUseCases) CreateFamily(ctx context.Context, req *GeneRequest) (*entities.ProteinFamily, error) {
+	name, err := geneName(req)
+	if err != nil {
+		return nil, err
+	}
+	if existing, _ := uc.familyRepo.GetByName(ctx, name); existing != nil {
+		return nil, ErrFamilyExists
+	}
+
+	family := &entities.ProteinFamily{Name: name}
+	if err := uc.familyRepo.Create(ctx, family); err != nil {
+		return nil, err
+	}
+	return family, nil
+}
+
+// UpdateFamily renames a family, and with it the family of every linked protein.
+func (uc *familyUseCases) UpdateFamily(ctx context.Context, id int, req *GeneRequest) (*entities.ProteinFamily, error) {
+	name, err := geneName(req)
+	if err != nil {
+		return nil, err
+	}
+	family, err := uc.GetFamily(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing, _ := uc.familyRepo.GetByName(ctx, name); existing != nil && existing.ID != id {
+		return nil, ErrFamilyExists
+	}
+
+	family.Name = name
+	if err := uc.familyRepo.Update(ctx, family); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, err
+	}
+	return family, nil
+}
+
+// DeleteFamily deletes a family no protein links to; it returns entities.ErrFamilyInUse
+// while proteins, in the trash or not, still do.
+func (uc *familyUseCases) DeleteFamily(ctx context.Context, id int) error {
+	if id <= 0 {
+		return ErrInvalidInput
+	}
+	if err := uc.familyRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrFamilyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (uc *familyUseCases) GetFamilyProteins(ctx context.Context, id, limit, offset int) (*entities.PaginatedProteins, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if _, err := uc.GetFamily(ctx, id); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = 10
+	}
+	filter := &entities.ProteinFilter{FamilyID: &id, Limit: min(limit, maxMemberLimit), Offset: offset, OrderBy: "id", OrderDirection: "ASC"}
+	return uc.proteinRepo.Search(ctx, filter)
+}
@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// errExportLimitReached stops the export walk once the requested number of records is written.
+var errExportLimitReached = errors.New("export limit reached")
+
+const (
+	ImportSourceFASTA = "FASTA"
+
+	FASTAIDEntryName = "entry_name"
+	FASTAIDAccession = "accession"
+
+	DefaultFASTAHeaderTemplate = "{id} {name}"
+	DefaultFASTALineWidth      = 60
+
+	fastaExportBatchSize = 500
+)
+
+// FASTAImportOptions controls how FASTA headers map to proteins. IDField selects the
+// UniProt entry name (P53_HUMAN, the default) or the accession (P04637) as protein ID.
+type FASTAImportOptions struct {
+	ImportOptions
+	IDField string
+}
+
+// FASTAExportOptions controls the exported records. HeaderTemplate may use the
+// placeholders {id}, {name}, {gene}, {taxo}, {family}, {length}, {mw} and {pi}.
+type FASTAExportOptions struct {
+	LineWidth      int
+	HeaderTemplate string
+}
+
+type FASTAUseCases interface {
+	ImportFASTA(ctx context.Context, r io.Reader, opts FASTAImportOptions) (*entities.ImportReport, error)
+	ExportFASTA(ctx context.Context, filter *entities.ProteinFilter, opts FASTAExportOptions, w io.Writer) error
+}
+
+type fastaUseCases struct {
+	importPipeline
+	proteinRepo    *repositories.ProteinRepositories
+	proteinService services.ProteinDomainService
+}
+
+func NewFASTAUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	importRepo *repositories.ImportRepositories,
+	proteinService services.ProteinDomainService,
+) FASTAUseCases {
+	return &fastaUseCases{
+		importPipeline: importPipeline{proteinRepo: proteinRepo, importRepo: importRepo},
+		proteinRepo:    proteinRepo,
+		proteinService: proteinService,
+	}
+}
+
+// ImportFASTA streams a multi-FASTA file through the shared import pipeline. Only the
+// columns a FASTA header carries are set, so updating a protein keeps its annotations.
+func (uc *fastaUseCases) ImportFASTA(ctx context.Context, r io.Reader, opts FASTAImportOptions) (*entities.ImportReport, error) {
+	switch opts.IDField {
+	case "":
+		opts.IDField = FASTAIDEntryName
+	case FASTAIDEntryName, FASTAIDAccession:
+	default:
+		return nil, ErrInvalidInput
+	}
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	reader := formats.NewFASTAReader(r)
+	next := func() (*importCandidate, error) {
+		record, err := reader.Next()
+		if err != nil {
+			return nil, err
+		}
+		protein, err := uc.proteinFromFASTA(record, opts)
+		if err != nil {
+			return &importCandidate{line: record.Line, id: record.ID(), err: err}, nil
+		}
+		return &importCandidate{line: record.Line, id: protein.ID, record: &entities.ProteinRecord{Protein: protein}}, nil
+	}
+	return uc.run(ctx, ImportSourceFASTA, next, opts.ImportOptions)
+}
+
+func (uc *fastaUseCases) proteinFromFASTA(record *formats.FASTARecord, opts FASTAImportOptions) (*entities.Protein, error) {
+	header := formats.ParseUniProtHeader(record.Header)
+	id := header.EntryName
+	if opts.IDField == FASTAIDAccession {
+		id = header.Accession
+	}
+
+	protein, err := newImportedProtein(id, header.ProteinName, record.Sequence, uc.proteinService)
+	if err != nil {
+		return nil, err
+	}
+	protein.SetGene(header.Gene)
+	protein.SetTaxonomy(header.Organism)
+	if taxonID, err := strconv.Atoi(header.TaxonID); err == nil {
+		protein.SetTaxonID(taxonID)
+	}
+
+	setDerivedProperties(protein, uc.proteinService)
+	return protein, nil
+}
+
+// ExportFASTA writes every protein matching the filter (not only the first page) as
+// FASTA. A positive filter Limit caps the number of records.
+func (uc *fastaUseCases) ExportFASTA(ctx context.Context, filter *entities.ProteinFilter, opts FASTAExportOptions, w io.Writer) error {
+	if filter == nil || opts.LineWidth < 0 {
+		return ErrInvalidInput
+	}
+	if opts.HeaderTemplate == "" {
+		opts.HeaderTemplate = DefaultFASTAHeaderTemplate
+	}
+	if err := parseFilterExpr(filter); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	written := 0
+	err := uc.proteinRepo.ForEachMatch(ctx, filter, fastaExportBatchSize, func(proteins []*entities.Protein) error {
+		for _, protein := range proteins {
+			if filter.Limit > 0 && written >= filter.Limit {
+				return errExportLimitReached
+			}
+			if err := formats.WriteFASTARecord(bw, renderFASTAHeader(opts.HeaderTemplate, protein), protein.GetFullSequence(), opts.LineWidth); err != nil {
+				return err
+			}
+			written++
+		}
+		return ctx.Err()
+	})
+	if err != nil && err != errExportLimitReached {
+		return err
+	}
+	return bw.Flush()
+}
+
+func renderFASTAHeader(template string, protein *entities.Protein) string {
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	number := func(f *float64, prec int) string {
+		if f == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*f, 'f', prec, 64)
+	}
+	length := ""
+	if protein.Length != nil {
+		length = strconv.Itoa(*protein.Length)
+	}
+
+	header := strings.NewReplacer(
+		"{id}", protein.ID,
+		"{name}", protein.Name,
+		"{gene}", value(protein.Gene),
+		"{taxo}", value(protein.Taxo),
+		"{family}", value(protein.Family),
+		"{length}", length,
+		"{mw}", number(protein.MW, 1),
+		"{pi}", number(protein.PI, 2),
+	).Replace(template)
+	// Empty placeholders must not leave a line break or runs of spaces behind.
+	header = strings.NewReplacer("\n", " ", "\r", " ").Replace(header)
+	return strings.Join(strings.Fields(header), " ")
+}
@@ -0,0 +1,407 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	ErrGOTermNotFound       = errors.New("GO term not found")
+	ErrGOAnnotationNotFound = errors.New("GO annotation not found")
+)
+
+const (
+	ImportSourceGAF    = "GAF"
+	GOAnnotationManual = "manual"
+
+	defaultGOTermLimit = 50
+	maxGOTermLimit     = 500
+
+	gafBatchSize = 1000
+)
+
+// GAFImportOptions controls a GAF import. With Replace, the GAF annotations stored
+// earlier for each protein in the file are deleted first, so importing a newer
+// release of the same file does not duplicate them.
+type GAFImportOptions struct {
+	Replace bool
+	DryRun  bool
+}
+
+type GeneOntologyUseCases interface {
+	ImportOntology(ctx context.Context, r io.Reader) (*entities.OntologyImportSummary, error)
+	SearchTerms(ctx context.Context, q, namespace string, limit, offset int) ([]*entities.GOTerm, error)
+	GetTerm(ctx context.Context, id string) (*entities.GOTerm, error)
+	GetAncestors(ctx context.Context, id string) ([]*entities.GOTerm, error)
+	GetDescendants(ctx context.Context, id string) ([]*entities.GOTerm, error)
+	GetTermProteins(ctx context.Context, id string, limit, offset int) (*entities.PaginatedProteins, error)
+	ImportGAF(ctx context.Context, r io.Reader, opts GAFImportOptions) (*entities.ImportReport, error)
+	GetProteinAnnotations(ctx context.Context, proteinID string) ([]entities.GOAnnotation, error)
+	CreateAnnotation(ctx context.Context, proteinID string, annotation *entities.GOAnnotation) error
+	DeleteAnnotation(ctx context.Context, proteinID string, id int64) error
+}
+
+type geneOntologyUseCases struct {
+	proteinRepo  *repositories.ProteinRepositories
+	ontologyRepo *repositories.OntologyRepositories
+}
+
+func NewGeneOntologyUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	ontologyRepo *repositories.OntologyRepositories,
+) GeneOntologyUseCases {
+	return &geneOntologyUseCases{
+		proteinRepo:  proteinRepo,
+		ontologyRepo: ontologyRepo,
+	}
+}
+
+// ImportOntology loads the [Term] stanzas of an OBO file (go.obo or go-basic.obo).
+// Relationships to terms outside the file are dropped, and the stored graph is
+// replaced by the file's.
+func (uc *geneOntologyUseCases) ImportOntology(ctx context.Context, r io.Reader) (*entities.OntologyImportSummary, error) {
+	summary := &entities.OntologyImportSummary{StartedAt: time.Now()}
+	reader := formats.NewOBOReader(r)
+
+	var terms []*entities.GOTerm
+	var relationships []entities.GORelationship
+	for {
+		stanza, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if errors.Is(err, formats.ErrInvalidOBO) {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+			}
+			return nil, err
+		}
+		if stanza.Type != "Term" {
+			continue
+		}
+
+		term, termRelationships := termFromOBO(stanza, reader.Header)
+		if term == nil {
+			continue
+		}
+		terms = append(terms, term)
+		relationships = append(relationships, termRelationships...)
+		if term.Obsolete {
+			summary.Obsolete++
+		}
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: no [Term] stanzas", ErrInvalidInput)
+	}
+
+	known := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		known[term.ID] = true
+	}
+	kept := relationships[:0]
+	for _, rel := range relationships {
+		if known[rel.ParentID] {
+			kept = append(kept, rel)
+		}
+	}
+
+	if err := uc.ontologyRepo.SaveOntology(ctx, terms, kept); err != nil {
+		return nil, err
+	}
+	if versions := reader.Header["data-version"]; len(versions) > 0 {
+		summary.DataVersion = versions[0]
+	}
+	summary.Terms, summary.Relationships = len(terms), len(kept)
+	summary.FinishedAt = time.Now()
+	return summary, nil
+}
+
+// termFromOBO reads a [Term] stanza; stanzas without an ID or name yield nil.
+func termFromOBO(stanza *formats.OBOStanza, header map[string][]string) (*entities.GOTerm, []entities.GORelationship) {
+	term := &entities.GOTerm{
+		ID:         stanza.Value("id"),
+		Name:       stanza.Value("name"),
+		Namespace:  stanza.Value("namespace"),
+		AltIDs:     stanza.Tags["alt_id"],
+		Obsolete:   stanza.Value("is_obsolete") == "true",
+		ReplacedBy: stanza.Value("replaced_by"),
+	}
+	if term.ID == "" || term.Name == "" {
+		return nil, nil
+	}
+	if term.Namespace == "" && len(header["default-namespace"]) > 0 {
+		term.Namespace = header["default-namespace"][0]
+	}
+	term.Definition, _ = formats.OBOQuoted(stanza.Value("def"))
+	for _, synonym := range stanza.Tags["synonym"] {
+		if text, _ := formats.OBOQuoted(synonym); text != "" {
+			term.Synonyms = append(term.Synonyms, text)
+		}
+	}
+
+	var relationships []entities.GORelationship
+	for _, parent := range stanza.Tags["is_a"] {
+		if fields := strings.Fields(parent); len(fields) > 0 {
+			relationships = append(relationships, entities.GORelationship{TermID: term.ID, Relation: entities.GORelationIsA, ParentID: fields[0]})
+		}
+	}
+	for _, relationship := range stanza.Tags["relationship"] {
+		if fields := strings.Fields(relationship); len(fields) >= 2 {
+			relationships = append(relationships, entities.GORelationship{TermID: term.ID, Relation: fields[0], ParentID: fields[1]})
+		}
+	}
+	return term, relationships
+}
+
+func (uc *geneOntologyUseCases) SearchTerms(ctx context.Context, q, namespace string, limit, offset int) ([]*entities.GOTerm, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = defaultGOTermLimit
+	}
+	return uc.ontologyRepo.SearchTerms(ctx, strings.TrimSpace(q), namespace, min(limit, maxGOTermLimit), offset)
+}
+
+// GetTerm returns a term, by ID, alternative ID or name, with its direct parents and children.
+func (uc *geneOntologyUseCases) GetTerm(ctx context.Context, id string) (*entities.GOTerm, error) {
+	term, err := uc.getTerm(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if term.Parents, term.Children, err = uc.ontologyRepo.GetRelatedTerms(ctx, term.ID); err != nil {
+		return nil, err
+	}
+	return term, nil
+}
+
+func (uc *geneOntologyUseCases) GetAncestors(ctx context.Context, id string) ([]*entities.GOTerm, error) {
+	term, err := uc.getTerm(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.ontologyRepo.GetAncestors(ctx, term.ID)
+}
+
+func (uc *geneOntologyUseCases) GetDescendants(ctx context.Context, id string) ([]*entities.GOTerm, error) {
+	term, err := uc.getTerm(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.ontologyRepo.GetDescendants(ctx, term.ID)
+}
+
+// GetTermProteins pages through the proteins annotated to a term or its descendants;
+// id may be a GO ID or an exact term name such as "kinase activity".
+func (uc *geneOntologyUseCases) GetTermProteins(ctx context.Context, id string, limit, offset int) (*entities.PaginatedProteins, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	term, err := uc.getTerm(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = 10
+	}
+	return uc.proteinRepo.Search(ctx, &entities.ProteinFilter{GOTerm: &term.ID, Limit: limit, Offset: offset, OrderBy: "id", OrderDirection: "ASC"})
+}
+
+// ImportGAF stores the annotations of a GAF 2.x file. Object IDs are matched to
+// protein IDs or, failing that, to cross-references of the line's database (UniProtKB
+// accessions); GO IDs may be alternative IDs. Annotations are counted one by one;
+// only skipped and failed lines are listed in the report.
+func (uc *geneOntologyUseCases) ImportGAF(ctx context.Context, r io.Reader, opts GAFImportOptions) (*entities.ImportReport, error) {
+	report := entities.NewImportReport()
+	report.DryRun = opts.DryRun
+	replaced := make(map[string]bool)
+
+	var batch []*formats.GAFAnnotation
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() { batch = batch[:0] }()
+
+		objectIDs := make(map[string][]string)
+		termIDs := make([]string, 0, len(batch))
+		for _, a := range batch {
+			objectIDs[a.DB] = append(objectIDs[a.DB], a.ObjectID)
+			termIDs = append(termIDs, a.GOID)
+		}
+		proteins := make(map[string]map[string]string, len(objectIDs))
+		for db, ids := range objectIDs {
+			resolved, err := uc.proteinRepo.ResolveIDs(ctx, db, ids)
+			if err != nil {
+				return err
+			}
+			proteins[db] = resolved
+		}
+		terms, err := uc.ontologyRepo.ResolveTermIDs(ctx, termIDs)
+		if err != nil {
+			return err
+		}
+
+		var annotations []entities.GOAnnotation
+		var lines []int
+		var replace []string
+		for _, a := range batch {
+			proteinID, ok := proteins[a.DB][a.ObjectID]
+			if !ok {
+				report.Add(entities.ImportRecordResult{Line: a.Line, ID: a.ObjectID, Status: entities.ImportSkipped, Reason: "unknown protein"})
+				continue
+			}
+			termID, ok := terms[a.GOID]
+			if !ok {
+				report.Add(entities.ImportRecordResult{Line: a.Line, ID: a.ObjectID, Status: entities.ImportFailed, Error: "unknown GO term " + a.GOID})
+				continue
+			}
+			if !entities.IsGOEvidenceCode(a.EvidenceCode) {
+				report.Add(entities.ImportRecordResult{Line: a.Line, ID: a.ObjectID, Status: entities.ImportFailed, Error: "unknown evidence code " + a.EvidenceCode})
+				continue
+			}
+			if opts.Replace && !replaced[proteinID] {
+				replaced[proteinID] = true
+				replace = append(replace, proteinID)
+			}
+			annotations = append(annotations, entities.GOAnnotation{
+				ProteinID:    proteinID,
+				TermID:       termID,
+				EvidenceCode: a.EvidenceCode,
+				Qualifier:    strings.Join(a.Qualifiers, "|"),
+				Negated:      a.Negated(),
+				Reference:    strings.Join(a.References, "|"),
+				WithFrom:     strings.Join(a.WithFrom, "|"),
+				AssignedBy:   a.AssignedBy,
+				Source:       ImportSourceGAF,
+				Created:      time.Now(),
+			})
+			lines = append(lines, a.Line)
+		}
+
+		if !opts.DryRun {
+			if err := uc.ontologyRepo.SaveAnnotations(ctx, annotations, ImportSourceGAF, replace); err != nil {
+				// The deletes were rolled back; a later batch must replace them again.
+				for _, id := range replace {
+					delete(replaced, id)
+				}
+				for i, annotation := range annotations {
+					report.Add(entities.ImportRecordResult{Line: lines[i], ID: annotation.ProteinID, Status: entities.ImportFailed, Error: err.Error()})
+				}
+				return nil
+			}
+		}
+		for range annotations {
+			report.Tally(entities.ImportCreated)
+		}
+		return nil
+	}
+
+	reader := formats.NewGAFReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		a, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if !errors.Is(err, formats.ErrInvalidGAF) {
+				return nil, err
+			}
+			report.Add(entities.ImportRecordResult{Status: entities.ImportFailed, Error: err.Error()})
+			continue
+		}
+		if batch = append(batch, a); len(batch) >= gafBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (uc *geneOntologyUseCases) GetProteinAnnotations(ctx context.Context, proteinID string) ([]entities.GOAnnotation, error) {
+	if err := uc.checkProtein(ctx, proteinID); err != nil {
+		return nil, err
+	}
+	return uc.ontologyRepo.GetProteinAnnotations(ctx, proteinID)
+}
+
+// CreateAnnotation adds an annotation by hand; its source is "manual" unless given.
+func (uc *geneOntologyUseCases) CreateAnnotation(ctx context.Context, proteinID string, annotation *entities.GOAnnotation) error {
+	if err := uc.checkProtein(ctx, proteinID); err != nil {
+		return err
+	}
+	annotation.EvidenceCode = strings.ToUpper(strings.TrimSpace(annotation.EvidenceCode))
+	if !entities.IsGOEvidenceCode(annotation.EvidenceCode) {
+		return fmt.Errorf("%w: unknown evidence code %q", ErrInvalidInput, annotation.EvidenceCode)
+	}
+	term, err := uc.getTerm(ctx, annotation.TermID)
+	if err != nil {
+		return err
+	}
+
+	annotation.ID = 0
+	annotation.ProteinID = proteinID
+	annotation.TermID, annotation.TermName, annotation.Namespace = term.ID, term.Name, term.Namespace
+	if annotation.Source == "" {
+		annotation.Source = GOAnnotationManual
+	}
+	annotation.Created = time.Now()
+	return uc.ontologyRepo.CreateAnnotation(ctx, annotation)
+}
+
+func (uc *geneOntologyUseCases) DeleteAnnotation(ctx context.Context, proteinID string, id int64) error {
+	if err := uc.checkProtein(ctx, proteinID); err != nil {
+		return err
+	}
+	if err := uc.ontologyRepo.DeleteAnnotation(ctx, proteinID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrGOAnnotationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (uc *geneOntologyUseCases) getTerm(ctx context.Context, id string) (*entities.GOTerm, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrInvalidInput
+	}
+	term, err := uc.ontologyRepo.GetTerm(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGOTermNotFound
+		}
+		return nil, err
+	}
+	return term, nil
+}
+
+func (uc *geneOntologyUseCases) checkProtein(ctx context.Context, proteinID string) error {
+	if strings.TrimSpace(proteinID) == "" {
+		return ErrInvalidInput
+	}
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return err
+	}
+	if protein == nil {
+		return ErrProteinNotFound
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"strings"
+)
+
+var (
+	ErrGeneNotFound = errors.New("gene not found")
+	ErrGeneExists   = errors.New("gene already exists")
+)
+
+const (
+	defaultGeneLimit = 50
+	maxGeneLimit     = 500
+	maxMemberLimit   = 100
+)
+
+// GeneRequest names a gene; it is also used for protein families.
+type GeneRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type GeneUseCases interface {
+	ListGenes(ctx context.Context, query string, limit, offset int) ([]*entities.Gene, error)
+	GetGene(ctx context.Context, id int) (*entities.Gene, error)
+	CreateGene(ctx context.Context, req *GeneRequest) (*entities.Gene, error)
+	UpdateGene(ctx context.Context, id int, req *GeneRequest) (*entities.Gene, error)
+	DeleteGene(ctx context.Context, id int) error
+	GetGeneProteins(ctx context.Context, id, limit, offset int) (*entities.PaginatedProteins, error)
+}
+
+type geneUseCases struct {
+	proteinRepo *repositories.ProteinRepositories
+	geneRepo    *repositories.GeneRepositories
+}
+
+func NewGeneUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	geneRepo *repositories.GeneRepositories,
+) GeneUseCases {
+	return &geneUseCases{
+		proteinRepo: proteinRepo,
+		geneRepo:    geneRepo,
+	}
+}
+
+func (uc *geneUseCases) ListGenes(ctx context.Context, query string, limit, offset int) ([]*entities.Gene, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = defaultGeneLimit
+	}
+	return uc.geneRepo.List(ctx, strings.TrimSpace(query), min(limit, maxGeneLimit), offset)
+}
+
+func (uc *geneUseCases) GetGene(ctx context.Context, id int) (*entities.Gene, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	gene, err := uc.geneRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGeneNotFound
+		}
+		return nil, err
+	}
+	return gene, nil
+}
+
+func (uc *geneUseCases) CreateGene(ctx context.Context, req *GeneRequest) (*entities.Gene, error) {
+	name, err := geneName(req)
+	if err != nil {
+		return nil, err
+	}
+	if existing, _ := uc.geneRepo.GetByName(ctx, name); existing != nil {
+		return nil, ErrGeneExists
+	}
+
+	gene := &entities.Gene{Name: name}
+	if err := uc.geneRepo.Create(ctx, gene); err != nil {
+		return nil, err
+	}
+	return gene, nil
+}
+
+// UpdateGene renames a gene, and with it the gene of every linked protein.
+func (uc *geneUseCases) UpdateGene(ctx context.Context, id int, req *GeneRequest) (*entities.Gene, error) {
+	name, err := geneName(req)
+	if err != nil {
+		return nil, err
+	}
+	gene, err := uc.GetGene(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing, _ := uc.geneRepo.GetByName(ctx, name); existing != nil && existing.ID != id {
+		return nil, ErrGeneExists
+	}
+
+	gene.Name = name
+	if err := uc.geneRepo.Update(ctx, gene); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGeneNotFound
+		}
+		return nil, err
+	}
+	return gene, nil
+}
+
+// DeleteGene deletes a gene no protein links to; it returns entities.ErrGeneInUse while
+// proteins, in the trash or not, still do.
+func (uc *geneUseCases) DeleteGene(ctx context.Context, id int) error {
+	if id <= 0 {
+		return ErrInvalidInput
+	}
+	if err := uc.geneRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrGeneNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (uc *geneUseCases) GetGeneProteins(ctx context.Context, id, limit, offset int) (*entities.PaginatedProteins, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if _, err := uc.GetGene(ctx, id); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = 10
+	}
+	filter := &entities.ProteinFilter{GeneID: &id, Limit: min(limit, maxMemberLimit), Offset: offset, OrderBy: "id", OrderDirection: "ASC"}
+	return uc.proteinRepo.Search(ctx, filter)
+}
+
+func geneName(req *GeneRequest) (string, error) {
+	if req == nil || strings.TrimSpace(req.Name) == "" {
+		return "", ErrInvalidInput
+	}
+	return strings.TrimSpace(req.Name), nil
+}
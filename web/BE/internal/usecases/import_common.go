@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"strings"
+)
+
+// setDerivedProperties fills the values computed from the sequence (MW, pI, GRAVY).
+func setDerivedProperties(protein *entities.Protein, proteinService services.ProteinDomainService) {
+	fullSeq := protein.GetFullSequence()
+	protein.SetMolecularWeight(proteinService.CalculateMolecularWeight(fullSeq))
+
+	pi := proteinService.CalculateIsoelectricPoint(fullSeq)
+	protein.PI = &pi
+
+	hydro := proteinService.CalculateHydrophobicity(fullSeq)
+	protein.HydrophobicityGravy = &hydro
+}
+
+// optionalText returns nil for blank text, matching how absent optional columns are stored.
+func optionalText(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// newImportedProtein validates an imported sequence and creates the protein; a blank
+// name falls back to the ID.
+func newImportedProtein(id, name, sequence string, proteinService services.ProteinDomainService) (*entities.Protein, error) {
+	if sequence == "" {
+		return nil, entities.ErrSequenceTooShort
+	}
+	if strings.TrimSpace(name) == "" {
+		name = id
+	}
+	seq := []string{strings.ToUpper(sequence)}
+	if err := proteinService.ValidateSequence(seq); err != nil {
+		return nil, err
+	}
+	return entities.NewProtein(id, name, seq)
+}
+
+// validateImportRecord checks what NewProtein cannot: feature positions must lie on the
+// sequence (0 marks an unknown position) and cross-references need a database and an
+// accession.
+func validateImportRecord(record *entities.ProteinRecord) error {
+	length := len(record.Protein.GetFullSequence())
+	for _, feature := range record.Features {
+		if feature.Start < 0 || feature.End < 0 || feature.Start > length || feature.End > length ||
+			(feature.Start > 0 && feature.End > 0 && feature.Start > feature.End) {
+			return fmt.Errorf("feature %s at %d..%d lies outside the %d-residue sequence", feature.Type, feature.Start, feature.End, length)
+		}
+	}
+	for _, ref := range record.CrossReferences {
+		if strings.TrimSpace(ref.Database) == "" || strings.TrimSpace(ref.Accession) == "" {
+			return fmt.Errorf("cross-reference %q:%q is incomplete", ref.Database, ref.Accession)
+		}
+	}
+	return nil
+}
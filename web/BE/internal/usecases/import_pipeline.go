@@ -0,0 +1,296 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"io"
+	"time"
+)
+
+// DedupPolicy decides what happens to an imported record whose ID is already stored.
+// Whatever the policy, a record whose protein is in the trash fails with
+// entities.ErrProteinDeleted and the trashed protein is left alone; it is restored
+// through the trash, not by an import.
+type DedupPolicy string
+
+const (
+	// DedupSkip keeps the stored protein and reports the record as skipped.
+	DedupSkip DedupPolicy = "skip"
+	// DedupUpdate overwrites the imported columns, cross-references and features. It
+	// merges rather than replaces: annotations such as gene or function that the record
+	// leaves empty keep their stored value, so an update cannot clear them. A record
+	// whose protein has a Version fails with entities.ErrVersionConflict when the
+	// stored protein is at another version.
+	DedupUpdate DedupPolicy = "update"
+	// DedupFail reports the record as failed.
+	DedupFail DedupPolicy = "fail"
+)
+
+const (
+	reasonDuplicateID = "duplicate ID in input"
+	reasonNotWritten  = "not written, other records failed"
+	reasonMerged      = "updated the stored protein, keeping annotations the record leaves out"
+)
+
+// ImportOptions are shared by every file import. DryRun parses, validates and checks
+// the dedup policy against the database without writing anything. AllOrNothing writes
+// every batch in one transaction, and nothing at all when any record fails, a repeated
+// ID included.
+type ImportOptions struct {
+	Dedup        DedupPolicy
+	DryRun       bool
+	AllOrNothing bool
+	BatchSize    int
+}
+
+func (opts *ImportOptions) normalize() error {
+	switch opts.Dedup {
+	case "":
+		opts.Dedup = DedupSkip
+	case DedupSkip, DedupUpdate, DedupFail:
+	default:
+		return ErrInvalidInput
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+	return nil
+}
+
+// importCandidate is one record read by an import source. err is set when the record
+// could not be mapped to a protein; it is reported as failed.
+type importCandidate struct {
+	line   int
+	id     string
+	record *entities.ProteinRecord
+	err    error
+}
+
+// importSource yields candidates and io.EOF at the end of the input. Any other error
+// means the file itself is malformed and ends the import.
+type importSource func() (*importCandidate, error)
+
+// importWrite is a batch after the dedup policy: the records to write and their results.
+type importWrite struct {
+	records []*entities.ProteinRecord
+	results []entities.ImportRecordResult
+}
+
+// importPipeline runs the format-independent part of an import: validation, in-file
+// duplicate detection, the dedup policy against stored proteins and batched writes.
+type importPipeline struct {
+	proteinRepo repositories.IProteinRepository
+	importRepo  repositories.ImportRepository
+}
+
+// run drains next and returns a report listing every record. Invalid records and
+// failed batches do not stop the import; a malformed file is reported as a failed
+// record after those already read. A record repeating the ID of an earlier one is
+// skipped, or failed with AllOrNothing. With AllOrNothing the batches are held until
+// the end and written together.
+func (p *importPipeline) run(ctx context.Context, source string, next importSource, opts ImportOptions) (*entities.ImportReport, error) {
+	report := entities.NewImportReport()
+	report.DryRun = opts.DryRun
+	seen := make(map[string]bool)
+	var batch []*importCandidate
+	var held []importWrite
+
+	flush := func() {
+		write := p.resolve(ctx, batch, opts, report)
+		batch = batch[:0]
+		if opts.AllOrNothing {
+			if len(write.results) > 0 {
+				held = append(held, write)
+			}
+			return
+		}
+		p.write(ctx, write, source, opts, report)
+	}
+
+	for {
+		candidate, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			flush()
+			report.Add(entities.ImportRecordResult{Status: entities.ImportFailed, Error: err.Error()})
+			p.writeAll(ctx, held, source, opts, report)
+			report.FinishedAt = time.Now()
+			return report, nil
+		}
+
+		if candidate.err == nil {
+			candidate.err = validateImportRecord(candidate.record)
+		}
+		if candidate.err != nil {
+			report.Add(entities.ImportRecordResult{Line: candidate.line, ID: candidate.id, Status: entities.ImportFailed, Error: candidate.err.Error()})
+			continue
+		}
+		id := candidate.record.Protein.ID
+		if seen[id] {
+			result := entities.ImportRecordResult{Line: candidate.line, ID: id, Status: entities.ImportSkipped, Reason: reasonDuplicateID}
+			if opts.AllOrNothing {
+				result.Status, result.Reason, result.Error = entities.ImportFailed, "", reasonDuplicateID
+			}
+			report.Add(result)
+			continue
+		}
+		seen[id] = true
+
+		batch = append(batch, candidate)
+		if len(batch) >= opts.BatchSize {
+			flush()
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+	}
+
+	flush()
+	p.writeAll(ctx, held, source, opts, report)
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// resolve applies the dedup policy to a batch. Skipped and failed records are reported
+// at once; the others are returned to be written.
+func (p *importPipeline) resolve(ctx context.Context, batch []*importCandidate, opts ImportOptions, report *entities.ImportReport) importWrite {
+	var write importWrite
+	if len(batch) == 0 {
+		return write
+	}
+
+	ids := make([]string, len(batch))
+	for i, candidate := range batch {
+		ids[i] = candidate.record.Protein.ID
+	}
+	stored, err := p.proteinRepo.GetStoredVersions(ctx, ids)
+	if err != nil {
+		for _, candidate := range batch {
+			report.Add(entities.ImportRecordResult{Line: candidate.line, ID: candidate.record.Protein.ID, Status: entities.ImportFailed, Error: err.Error()})
+		}
+		return write
+	}
+
+	for _, candidate := range batch {
+		protein := candidate.record.Protein
+		result := entities.ImportRecordResult{Line: candidate.line, ID: protein.ID}
+		setOutcome(&result, expectedOutcome(protein, stored[protein.ID], opts.Dedup), opts.Dedup)
+		if result.Status == entities.ImportSkipped || result.Status == entities.ImportFailed {
+			report.Add(result)
+			continue
+		}
+		write.records = append(write.records, candidate.record)
+		write.results = append(write.results, result)
+	}
+	return write
+}
+
+// expectedOutcome is what writing protein under dedup should do, given the protein
+// stored under its ID or nil.
+func expectedOutcome(protein, stored *entities.Protein, dedup DedupPolicy) entities.SaveOutcome {
+	switch {
+	case stored == nil:
+		return entities.SaveCreated
+	case stored.DeletedAt != nil:
+		return entities.SaveTrashed
+	case dedup != DedupUpdate:
+		return entities.SaveExists
+	case protein.Version != 0 && protein.Version != stored.Version:
+		return entities.SaveVersionConflict
+	}
+	return entities.SaveUpdated
+}
+
+// setOutcome reports what writing a record did, or would do, to the stored protein.
+func setOutcome(result *entities.ImportRecordResult, outcome entities.SaveOutcome, dedup DedupPolicy) {
+	result.Status, result.Reason, result.Error = entities.ImportFailed, "", ""
+	switch outcome {
+	case entities.SaveCreated:
+		result.Status = entities.ImportCreated
+	case entities.SaveUpdated:
+		result.Status, result.Reason = entities.ImportUpdated, reasonMerged
+	case entities.SaveExists:
+		if dedup == DedupSkip {
+			result.Status, result.Reason = entities.ImportSkipped, ErrProteinExists.Error()
+		} else {
+			result.Error = ErrProteinExists.Error()
+		}
+	case entities.SaveVersionConflict:
+		result.Error = entities.ErrVersionConflict.Error()
+	case entities.SaveTrashed:
+		result.Error = entities.ErrProteinDeleted.Error()
+	}
+}
+
+// write writes the records of a batch in one transaction and reports what the write
+// did to each, which may differ from what resolve expected when another writer got
+// there first. When the write fails every record of the batch is reported as failed.
+func (p *importPipeline) write(ctx context.Context, write importWrite, source string, opts ImportOptions, report *entities.ImportReport) {
+	if !opts.DryRun && len(write.records) > 0 {
+		outcomes, err := p.importRepo.SaveRecords(ctx, write.records, source, opts.Dedup == DedupUpdate, nil)
+		if err != nil {
+			failWrite(write, err)
+		} else {
+			for i := range write.results {
+				setOutcome(&write.results[i], outcomes[write.results[i].ID], opts.Dedup)
+			}
+		}
+	}
+	for _, result := range write.results {
+		report.Add(result)
+	}
+}
+
+// writeAll writes the batches held by an all-or-nothing import in one transaction, or
+// reports them as not written when a record has failed. When a record cannot be written
+// after all, it is reported as failed and the others as not written. When the write
+// fails every record is reported as failed.
+func (p *importPipeline) writeAll(ctx context.Context, held []importWrite, source string, opts ImportOptions, report *entities.ImportReport) {
+	if report.Failed > 0 {
+		for _, write := range held {
+			for i := range write.results {
+				write.results[i].Status, write.results[i].Reason = entities.ImportSkipped, reasonNotWritten
+			}
+		}
+	} else if !opts.DryRun && len(held) > 0 {
+		batches := make([][]*entities.ProteinRecord, len(held))
+		for i, write := range held {
+			batches[i] = write.records
+		}
+		outcomes, err := p.importRepo.SaveBatches(ctx, batches, source, opts.Dedup == DedupUpdate)
+		for _, write := range held {
+			switch {
+			case errors.Is(err, entities.ErrImportRolledBack):
+				for i := range write.results {
+					result := &write.results[i]
+					if outcome := outcomes[result.ID]; outcome == entities.SaveCreated || outcome == entities.SaveUpdated {
+						result.Status, result.Reason = entities.ImportSkipped, reasonNotWritten
+					} else {
+						setOutcome(result, outcome, opts.Dedup)
+					}
+				}
+			case err != nil:
+				failWrite(write, err)
+			default:
+				for i := range write.results {
+					setOutcome(&write.results[i], outcomes[write.results[i].ID], opts.Dedup)
+				}
+			}
+		}
+	}
+	for _, write := range held {
+		for _, result := range write.results {
+			report.Add(result)
+		}
+	}
+}
+
+func failWrite(write importWrite, err error) {
+	for i := range write.results {
+		write.results[i].Status, write.results[i].Reason, write.results[i].Error = entities.ImportFailed, "", err.Error()
+	}
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImportSourceGenPept = "GenPept"
+
+	FormatUniProtXML = "uniprot_xml"
+	FormatGenPept    = "genpept"
+)
+
+// genPeptWholeChainFeatures span the whole record and describe the entry itself rather
+// than a region of the sequence.
+var genPeptWholeChainFeatures = map[string]bool{
+	"source": true, "Protein": true, "CDS": true, "gene": true,
+}
+
+type ImportUseCases interface {
+	ImportRecords(ctx context.Context, format string, r io.Reader, opts ImportOptions) (*entities.ImportReport, error)
+	BulkCreateProteins(ctx context.Context, requests []*BulkProteinRequest, opts BulkOptions) (*entities.ImportReport, error)
+}
+
+type importUseCases struct {
+	importPipeline
+	proteinService services.ProteinDomainService
+}
+
+func NewImportUseCases(
+	proteinRepo repositories.IProteinRepository,
+	importRepo repositories.ImportRepository,
+	proteinService services.ProteinDomainService,
+) ImportUseCases {
+	return &importUseCases{
+		importPipeline: importPipeline{proteinRepo: proteinRepo, importRepo: importRepo},
+		proteinService: proteinService,
+	}
+}
+
+// ImportRecords streams a UniProt XML or GenPept file through the shared import
+// pipeline. UniProt XML entries replace the features and cross-references of earlier
+// UniProt flat-file imports, as both come from the same source.
+func (uc *importUseCases) ImportRecords(ctx context.Context, format string, r io.Reader, opts ImportOptions) (*entities.ImportReport, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatUniProtXML:
+		reader := formats.NewUniProtXMLReader(r)
+		next := func() (*importCandidate, error) {
+			entry, err := reader.Next()
+			if err != nil {
+				return nil, err
+			}
+			candidate := &importCandidate{line: entry.Line, id: entry.EntryName}
+			if candidate.id == "" && len(entry.Accessions) > 0 {
+				candidate.id = entry.Accessions[0]
+			}
+			candidate.record, candidate.err = recordFromUniProtEntry(entry, uc.proteinService)
+			return candidate, nil
+		}
+		return uc.run(ctx, ImportSourceUniProt, next, opts)
+	case FormatGenPept:
+		reader := formats.NewGenPeptReader(r)
+		next := func() (*importCandidate, error) {
+			record, err := reader.Next()
+			if err != nil {
+				return nil, err
+			}
+			candidate := &importCandidate{line: record.Line, id: record.Accession}
+			candidate.record, candidate.err = uc.recordFromGenPept(record)
+			return candidate, nil
+		}
+		return uc.run(ctx, ImportSourceGenPept, next, opts)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// recordFromGenPept maps a GenPept record onto a protein keyed by its accession. Name
+// comes from the Protein /product (or the DEFINITION without the organism), Gene from
+// the CDS or gene feature and Domain from the Region names; every /db_xref becomes a
+// cross-reference.
+func (uc *importUseCases) recordFromGenPept(gp *formats.GenPeptRecord) (*entities.ProteinRecord, error) {
+	name := strings.TrimSpace(gp.Definition)
+	if i := strings.LastIndex(name, " ["); i > 0 && strings.HasSuffix(name, "]") {
+		name = name[:i]
+	}
+	var function string
+	if protein := gp.Feature("Protein"); protein != nil {
+		if product := protein.Qualifier("product"); product != "" {
+			name = product
+		}
+		function = protein.Qualifier("function")
+	}
+
+	protein, err := newImportedProtein(gp.Accession, name, gp.Sequence, uc.proteinService)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"CDS", "gene"} {
+		if feature := gp.Feature(key); feature != nil && feature.Qualifier("gene") != "" {
+			protein.SetGene(feature.Qualifier("gene"))
+			break
+		}
+	}
+	organism := gp.Organism
+	if source := gp.Feature("source"); organism == "" && source != nil {
+		organism = source.Qualifier("organism")
+	}
+	protein.SetTaxonomy(organism)
+	if taxonID, err := strconv.Atoi(gp.TaxonID()); err == nil {
+		protein.SetTaxonID(taxonID)
+	}
+	protein.Function = optionalText(function)
+
+	record := &entities.ProteinRecord{
+		Protein:    protein,
+		Accessions: []string{gp.Accession},
+		TaxonID:    gp.TaxonID(),
+	}
+	version := gp.Version
+	if version == "" {
+		version = gp.Accession
+	}
+	record.CrossReferences = append(record.CrossReferences, entities.CrossReference{ProteinID: protein.ID, Database: ImportSourceGenPept, Accession: version})
+
+	seenRef := make(map[string]bool)
+	var domains []string
+	seenDomain := make(map[string]bool)
+	for _, feature := range gp.Features {
+		for _, ref := range feature.Qualifiers["db_xref"] {
+			db, accession, ok := strings.Cut(ref, ":")
+			if !ok || db == "taxon" || seenRef[ref] {
+				continue
+			}
+			seenRef[ref] = true
+			record.CrossReferences = append(record.CrossReferences, entities.CrossReference{ProteinID: protein.ID, Database: db, Accession: accession})
+		}
+		if genPeptWholeChainFeatures[feature.Key] {
+			continue
+		}
+
+		description := ""
+		for _, key := range []string{"region_name", "product", "site_type", "bond_type", "note"} {
+			if description = feature.Qualifier(key); description != "" {
+				break
+			}
+		}
+		evidence := feature.Qualifier("experiment")
+		if evidence == "" {
+			evidence = feature.Qualifier("inference")
+		}
+		record.Features = append(record.Features, entities.ProteinFeature{
+			ProteinID:   protein.ID,
+			Type:        feature.Key,
+			Start:       feature.Start,
+			End:         feature.End,
+			Description: description,
+			Evidence:    evidence,
+			Source:      ImportSourceGenPept,
+		})
+		if region := feature.Qualifier("region_name"); feature.Key == "Region" && region != "" && !seenDomain[region] {
+			seenDomain[region] = true
+			domains = append(domains, region)
+		}
+	}
+	protein.Domain = optionalText(strings.Join(domains, "; "))
+
+	setDerivedProperties(protein, uc.proteinService)
+	return record, nil
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/jobs"
+	"strings"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+type JobUseCases interface {
+	GetJob(ctx context.Context, id string) (*entities.Job, error)
+}
+
+type jobUseCases struct {
+	jobManager *jobs.Manager
+}
+
+func NewJobUseCases(jobManager *jobs.Manager) JobUseCases {
+	return &jobUseCases{jobManager: jobManager}
+}
+
+func (uc *jobUseCases) GetJob(ctx context.Context, id string) (*entities.Job, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrInvalidInput
+	}
+
+	job, ok := uc.jobManager.Get(id)
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
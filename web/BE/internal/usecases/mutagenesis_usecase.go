@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/jobs"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"strings"
+)
+
+const JobKindSaturationScan = "saturation_scan"
+
+type SaturationScanRequest struct {
+	Start  int    `json:"start,omitempty"`
+	End    int    `json:"end,omitempty"`
+	Metric string `json:"metric,omitempty"`
+}
+
+type MutagenesisUseCases interface {
+	StartSaturationScan(ctx context.Context, proteinID string, req *SaturationScanRequest) (*entities.Job, error)
+}
+
+type mutagenesisUseCases struct {
+	proteinRepo        *repositories.ProteinRepositories
+	mutagenesisService services.MutagenesisDomainService
+	jobManager         *jobs.Manager
+}
+
+func NewMutagenesisUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	mutagenesisService services.MutagenesisDomainService,
+	jobManager *jobs.Manager,
+) MutagenesisUseCases {
+	return &mutagenesisUseCases{
+		proteinRepo:        proteinRepo,
+		mutagenesisService: mutagenesisService,
+		jobManager:         jobManager,
+	}
+}
+
+func (uc *mutagenesisUseCases) StartSaturationScan(ctx context.Context, proteinID string, req *SaturationScanRequest) (*entities.Job, error) {
+	if strings.TrimSpace(proteinID) == "" || req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+
+	sequence := protein.GetFullSequence()
+	start, end := req.Start, req.End
+	if start == 0 {
+		start = 1
+	}
+	if end == 0 {
+		end = len(sequence)
+	}
+	if start < 1 || end > len(sequence) || start > end {
+		return nil, services.ErrInvalidRegion
+	}
+
+	// Surface bad sequences and metrics now rather than as a failed job.
+	if err := uc.mutagenesisService.ValidateScan(sequence, req.Metric); err != nil {
+		return nil, err
+	}
+
+	job := uc.jobManager.Submit(JobKindSaturationScan, func(ctx context.Context, progress func(completed, total int)) (interface{}, error) {
+		scan, err := uc.mutagenesisService.SaturationScan(sequence, start, end, req.Metric, progress)
+		if err != nil {
+			return nil, err
+		}
+		scan.ProteinID = protein.ID
+		return scan, nil
+	})
+	return job, nil
+}
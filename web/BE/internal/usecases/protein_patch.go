@@ -0,0 +1,293 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/jsonpatch"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Media types of the patches PatchProtein applies.
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+var (
+	ErrUnsupportedPatch = errors.New("unsupported patch type, expected " + MergePatchMediaType + " or " + JSONPatchMediaType)
+	ErrPatchConflict    = errors.New("patch does not apply to the protein")
+)
+
+// patchableProteinFields are the fields of a protein a patch may set, in the order
+// they are applied: aliases are told apart from the name and gene as they are then.
+var patchableProteinFields = []string{
+	"name", "gene", "aliases", "seq", "taxo", "taxon_id", "cc", "domain", "family",
+	"bio_process", "function", "n_interactors", "nc_7_4", "d_rank", "l_rank", "f_rank",
+}
+
+// derivedProteinFields are worked out rather than set, with the reason a patch may not
+// set them.
+var derivedProteinFields = map[string]string{
+	"id":                   "it identifies the protein",
+	"length":               "it is computed from seq",
+	"mw":                   "it is computed from seq",
+	"pi":                   "it is computed from seq",
+	"hydrophobicity_gravy": "it is computed from seq",
+	"gene_id":              "it follows gene",
+	"family_id":            "it follows family",
+	"created":              "it is set by the server",
+	"updated":              "it is set by the server",
+	"version":              "it is set by the server",
+	"deleted_at":           "it is set by the server",
+}
+
+// PatchProtein applies a JSON Merge Patch or a JSON Patch, by its media type, to the
+// JSON of a protein. Only patchable fields may change; a null or removed field is
+// cleared, and the values computed from a patched sequence are computed again. Like
+// an update, it only applies to the protein at one of the ifMatch versions.
+func (uc *proteinUseCases) PatchProtein(ctx context.Context, id, mediaType string, patch []byte, ifMatch []int) (*entities.Protein, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrInvalidInput
+	}
+
+	protein, err := uc.proteinRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+	if err := checkVersion(protein, ifMatch); err != nil {
+		return nil, err
+	}
+
+	doc, err := proteinFields(protein)
+	if err != nil {
+		return nil, err
+	}
+	// The JSON of a protein leaves unset fields out. In the patched document they are
+	// null members, so that a patch can test, replace or remove them.
+	for _, field := range patchableProteinFields {
+		if _, ok := doc[field]; !ok {
+			doc[field] = nil
+		}
+	}
+	patchedDoc, fields, err := applyPatch(doc, mediaType, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(patchedDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patched protein: %w", err)
+	}
+	var patched entities.Protein
+	if err := json.Unmarshal(data, &patched); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("%w: %s must be %s", ErrInvalidInput, typeErr.Field, jsonTypeName(typeErr.Type))
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	for _, field := range patchableProteinFields {
+		if fields[field] {
+			if err := uc.setPatchedField(protein, &patched, field); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	protein.Updated = time.Now()
+	if err := uc.proteinRepo.Update(ctx, protein); err != nil {
+		return nil, err
+	}
+
+	// The write links the gene and family again, so the protein is read back.
+	protein, err = uc.proteinRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+	return protein, nil
+}
+
+// applyPatch applies a patch to the JSON of a protein and returns the result with the
+// top-level fields the patch sets, after checking that they may be set.
+func applyPatch(doc map[string]any, mediaType string, patch []byte) (any, map[string]bool, error) {
+	fields := map[string]bool{}
+
+	switch mediaType {
+	case MergePatchMediaType:
+		var members map[string]any
+		if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+			return nil, nil, fmt.Errorf("%w: a merge patch of a protein must be a JSON object", ErrInvalidInput)
+		}
+		for field := range members {
+			fields[field] = true
+		}
+		if err := checkPatchedFields(fields); err != nil {
+			return nil, nil, err
+		}
+		return jsonpatch.MergePatch(doc, members), fields, nil
+
+	case JSONPatchMediaType:
+		var ops []jsonpatch.Operation
+		if err := json.Unmarshal(patch, &ops); err != nil {
+			return nil, nil, fmt.Errorf("%w: a JSON patch must be an array of operations: %v", ErrInvalidInput, err)
+		}
+		for i, op := range ops {
+			targets, err := op.Targets()
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidInput, i, err)
+			}
+			for _, target := range targets {
+				if len(target) == 0 {
+					return nil, nil, fmt.Errorf("%w: operation %d sets the whole protein, patch its fields instead", ErrInvalidInput, i)
+				}
+				fields[target[0]] = true
+			}
+		}
+		if err := checkPatchedFields(fields); err != nil {
+			return nil, nil, err
+		}
+		patched, err := jsonpatch.Apply(doc, ops)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, nil, fmt.Errorf("%w: %v", ErrPatchConflict, err)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		return patched, fields, nil
+
+	default:
+		return nil, nil, ErrUnsupportedPatch
+	}
+}
+
+func checkPatchedFields(fields map[string]bool) error {
+	for field := range fields {
+		if reason, ok := derivedProteinFields[field]; ok {
+			return fmt.Errorf("%w: %s cannot be patched, %s", ErrInvalidInput, field, reason)
+		}
+		if !slices.Contains(patchableProteinFields, field) {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidInput, field)
+		}
+	}
+	return nil
+}
+
+// setPatchedField checks a patched field and sets it on the protein.
+func (uc *proteinUseCases) setPatchedField(protein, patched *entities.Protein, field string) error {
+	switch field {
+	case "name":
+		name := strings.TrimSpace(patched.Name)
+		if name == "" {
+			return fmt.Errorf("%w: name cannot be empty", ErrInvalidInput)
+		}
+		protein.Name = name
+	case "gene":
+		protein.Gene = trimmedText(patched.Gene)
+	case "aliases":
+		protein.SetAliases(patched.Aliases)
+	case "seq":
+		if err := uc.proteinService.ValidateSequence(patched.Seq); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		if err := protein.UpdateSequence(patched.Seq); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		setDerivedProperties(protein, uc.proteinService)
+	case "taxo":
+		protein.Taxo = trimmedText(patched.Taxo)
+	case "taxon_id":
+		if patched.TaxonID != nil && *patched.TaxonID <= 0 {
+			return fmt.Errorf("%w: taxon_id must be a positive NCBI taxon ID", ErrInvalidInput)
+		}
+		protein.TaxonID = patched.TaxonID
+	case "cc":
+		protein.CC = trimmedText(patched.CC)
+	case "domain":
+		protein.Domain = trimmedText(patched.Domain)
+	case "family":
+		protein.Family = trimmedText(patched.Family)
+	case "bio_process":
+		protein.BioProcess = trimmedText(patched.BioProcess)
+	case "function":
+		protein.Function = trimmedText(patched.Function)
+	case "n_interactors":
+		if patched.NInteractors != nil && *patched.NInteractors < 0 {
+			return fmt.Errorf("%w: n_interactors cannot be negative", ErrInvalidInput)
+		}
+		protein.NInteractors = patched.NInteractors
+	case "nc_7_4":
+		// numeric(8,4)
+		if patched.NC74 != nil && math.Abs(*patched.NC74) >= 1e4 {
+			return fmt.Errorf("%w: nc_7_4 must be between -9999.9999 and 9999.9999", ErrInvalidInput)
+		}
+		protein.NC74 = patched.NC74
+	case "d_rank":
+		if patched.DRank != nil && *patched.DRank < 0 {
+			return fmt.Errorf("%w: d_rank cannot be negative", ErrInvalidInput)
+		}
+		protein.DRank = patched.DRank
+	case "l_rank":
+		rank, err := patchedRank(field, patched.LRank)
+		if err != nil {
+			return err
+		}
+		protein.LRank = rank
+	case "f_rank":
+		rank, err := patchedRank(field, patched.FRank)
+		if err != nil {
+			return err
+		}
+		protein.FRank = rank
+	}
+	return nil
+}
+
+// patchedRank checks a patched text rank, which is a varchar(100).
+func patchedRank(field string, rank *string) (*string, error) {
+	rank = trimmedText(rank)
+	if rank != nil && len([]rune(*rank)) > 100 {
+		return nil, fmt.Errorf("%w: %s is longer than 100 characters", ErrInvalidInput, field)
+	}
+	return rank, nil
+}
+
+// trimmedText trims a text field, clearing it when nothing is left.
+func trimmedText(text *string) *string {
+	if text == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*text)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return "an integer"
+	case reflect.Float64, reflect.Float32:
+		return "a number"
+	case reflect.Slice:
+		return "an array"
+	default:
+		return "a " + t.String()
+	}
+}
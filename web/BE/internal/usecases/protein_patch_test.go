@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"testing"
+)
+
+// geneStore stands in for the protein repository with one protein, P12345. Like the
+// triggers, an update at the stored version bumps it and links the gene, here always
+// to gene 7.
+type geneStore struct {
+	repositories.IProteinRepository
+	gene    *string
+	version int
+}
+
+func (s *geneStore) GetByID(ctx context.Context, id string) (*entities.Protein, error) {
+	if id != "P12345" {
+		return nil, nil
+	}
+	protein := &entities.Protein{ID: id, Name: "Kinase", Gene: s.gene, Seq: []string{"MKVL"}, Version: s.version}
+	if s.gene != nil {
+		geneID := 7
+		protein.GeneID = &geneID
+	}
+	return protein, nil
+}
+
+func (s *geneStore) Update(ctx context.Context, protein *entities.Protein) error {
+	if protein.ID != "P12345" || protein.Version != s.version {
+		return entities.ErrVersionConflict
+	}
+	s.gene = protein.Gene
+	s.version++
+	protein.Version = s.version
+	return nil
+}
+
+func TestPatchProtein(t *testing.T) {
+	tp53 := "TP53"
+	tests := []struct {
+		name      string
+		gene      *string
+		mediaType string
+		patch     string
+		ifMatch   []int
+		wantErr   error
+		wantGene  *string
+	}{
+		{name: "replace unset field", mediaType: JSONPatchMediaType, patch: `[{"op":"replace","path":"/gene","value":"TP53"}]`, wantGene: &tp53},
+		{name: "test unset field", mediaType: JSONPatchMediaType, patch: `[{"op":"test","path":"/gene","value":null},{"op":"add","path":"/gene","value":"TP53"}]`, wantGene: &tp53},
+		{name: "remove unset field", mediaType: JSONPatchMediaType, patch: `[{"op":"remove","path":"/gene"}]`},
+		{name: "remove set field", gene: &tp53, mediaType: JSONPatchMediaType, patch: `[{"op":"remove","path":"/gene"}]`},
+		{name: "merge sets field", mediaType: MergePatchMediaType, patch: `{"gene":"TP53"}`, wantGene: &tp53},
+		{name: "merge clears field", gene: &tp53, mediaType: MergePatchMediaType, patch: `{"gene":null}`},
+		{name: "at the read version", mediaType: MergePatchMediaType, patch: `{"gene":"TP53"}`, ifMatch: []int{1}, wantGene: &tp53},
+		{name: "at a stale version", mediaType: MergePatchMediaType, patch: `{"gene":"TP53"}`, ifMatch: []int{0}, wantErr: entities.ErrVersionConflict},
+		{name: "failed test", gene: &tp53, mediaType: JSONPatchMediaType, patch: `[{"op":"test","path":"/gene","value":null}]`, wantErr: ErrPatchConflict},
+		{name: "derived field", mediaType: JSONPatchMediaType, patch: `[{"op":"replace","path":"/length","value":5}]`, wantErr: ErrInvalidInput},
+		{name: "unknown field", mediaType: MergePatchMediaType, patch: `{"colour":"red"}`, wantErr: ErrInvalidInput},
+		{name: "unsupported type", mediaType: "application/json", patch: `{"gene":"TP53"}`, wantErr: ErrUnsupportedPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &geneStore{gene: tt.gene, version: 1}
+			uc := NewProteinUseCases(store, nil, nil, nil, nil, nil)
+
+			protein, err := uc.PatchProtein(context.Background(), "P12345", tt.mediaType, []byte(tt.patch), tt.ifMatch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if store.version != 1 {
+					t.Errorf("refused patch wrote version %d", store.version)
+				}
+				return
+			}
+
+			if protein.Version != 2 {
+				t.Errorf("version %d, want 2", protein.Version)
+			}
+			switch {
+			case tt.wantGene == nil && (protein.Gene != nil || protein.GeneID != nil):
+				t.Errorf("gene %v (%v), want none", protein.Gene, protein.GeneID)
+			case tt.wantGene != nil && (protein.Gene == nil || *protein.Gene != *tt.wantGene):
+				t.Errorf("gene %v, want %s", protein.Gene, *tt.wantGene)
+			case tt.wantGene != nil && (protein.GeneID == nil || *protein.GeneID != 7):
+				t.Errorf("gene ID %v, want the linked gene 7", protein.GeneID)
+			}
+		})
+	}
+}
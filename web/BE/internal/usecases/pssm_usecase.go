@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPSSMNotFound      = errors.New("pssm not found")
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	ErrNoProfileProteins = errors.New("no proteins found to build the profile from")
+)
+
+const (
+	FormatJSON  = "json"
+	FormatASCII = "ascii"
+)
+
+const (
+	defaultProfileHitLimit = 50
+	profileScanBatchSize   = 500
+)
+
+type PSSMBuildRequest struct {
+	Name              string   `json:"name" validate:"required"`
+	Description       *string  `json:"description,omitempty"`
+	ProteinIDs        []string `json:"protein_ids,omitempty"`
+	Family            *string  `json:"family,omitempty"`
+	Sequences         []string `json:"sequences,omitempty"`
+	PseudocountWeight float64  `json:"pseudocount_weight,omitempty"`
+}
+
+type PSSMScoreRequest struct {
+	Sequence []string `json:"sequence" validate:"required"`
+}
+
+type PSSMUseCases interface {
+	BuildPSSM(ctx context.Context, req *PSSMBuildRequest) (*entities.PSSM, error)
+	ImportPSSM(ctx context.Context, name, format string, r io.Reader) (*entities.PSSM, error)
+	ExportPSSM(ctx context.Context, id int64, format string, w io.Writer) error
+	GetPSSM(ctx context.Context, id int64) (*entities.PSSM, error)
+	ListPSSMs(ctx context.Context) ([]*entities.PSSM, error)
+	DeletePSSM(ctx context.Context, id int64) error
+	ScoreSequence(ctx context.Context, id int64, req *PSSMScoreRequest) (*entities.PSSMScore, error)
+	SearchProteins(ctx context.Context, id int64, minScore *float64, limit int) ([]entities.PSSMHit, error)
+	CheckFamily(ctx context.Context, id int64, family string, minScore *float64, limit int) (*entities.FamilyProfileCheck, error)
+}
+
+type pssmUseCases struct {
+	pssmRepo       *repositories.PSSMRepositories
+	proteinRepo    *repositories.ProteinRepositories
+	profileService services.ProfileDomainService
+}
+
+func NewPSSMUseCases(
+	pssmRepo *repositories.PSSMRepositories,
+	proteinRepo *repositories.ProteinRepositories,
+	profileService services.ProfileDomainService,
+) PSSMUseCases {
+	return &pssmUseCases{
+		pssmRepo:       pssmRepo,
+		proteinRepo:    proteinRepo,
+		profileService: profileService,
+	}
+}
+
+func (uc *pssmUseCases) BuildPSSM(ctx context.Context, req *PSSMBuildRequest) (*entities.PSSM, error) {
+	if req == nil || strings.TrimSpace(req.Name) == "" {
+		return nil, ErrInvalidInput
+	}
+
+	var proteins []*entities.Protein
+	var err error
+	switch {
+	case len(req.ProteinIDs) > 0:
+		proteins, err = uc.proteinRepo.GetByIDs(ctx, req.ProteinIDs)
+	case req.Family != nil && strings.TrimSpace(*req.Family) != "":
+		proteins, err = uc.proteinRepo.GetByFamily(ctx, strings.TrimSpace(*req.Family))
+	case len(req.Sequences) == 0:
+		return nil, ErrInvalidInput
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sequences := make([]string, 0, len(proteins)+len(req.Sequences))
+	sourceIDs := make([]string, 0, len(proteins))
+	for _, protein := range proteins {
+		sequences = append(sequences, protein.GetFullSequence())
+		sourceIDs = append(sourceIDs, protein.ID)
+	}
+	sequences = append(sequences, req.Sequences...)
+	if len(sequences) == 0 {
+		return nil, ErrNoProfileProteins
+	}
+
+	pssm, err := uc.profileService.BuildPSSM(sequences, req.PseudocountWeight)
+	if err != nil {
+		return nil, err
+	}
+	pssm.Name = strings.TrimSpace(req.Name)
+	pssm.Description = req.Description
+	pssm.Family = req.Family
+	pssm.SourceIDs = sourceIDs
+
+	if err := uc.pssmRepo.Create(ctx, pssm); err != nil {
+		return nil, err
+	}
+	return pssm, nil
+}
+
+func (uc *pssmUseCases) ImportPSSM(ctx context.Context, name, format string, r io.Reader) (*entities.PSSM, error) {
+	var pssm *entities.PSSM
+	switch strings.ToLower(format) {
+	case FormatJSON, "":
+		pssm = &entities.PSSM{}
+		if err := json.NewDecoder(r).Decode(pssm); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+	case FormatASCII:
+		var err error
+		pssm, err = formats.ReadPSIBlastPSSM(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if strings.TrimSpace(name) != "" {
+		pssm.Name = strings.TrimSpace(name)
+	}
+	pssm.ID = 0
+	if pssm.Length == 0 {
+		pssm.Length = len(pssm.Scores)
+	}
+	if err := pssm.Validate(); err != nil {
+		return nil, err
+	}
+	pssm.Created = time.Now()
+	pssm.Updated = time.Now()
+
+	if err := uc.pssmRepo.Create(ctx, pssm); err != nil {
+		return nil, err
+	}
+	return pssm, nil
+}
+
+func (uc *pssmUseCases) ExportPSSM(ctx context.Context, id int64, format string, w io.Writer) error {
+	pssm, err := uc.GetPSSM(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(format) {
+	case FormatJSON, "":
+		return json.NewEncoder(w).Encode(pssm)
+	case FormatASCII:
+		return formats.WritePSIBlastPSSM(w, pssm)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+func (uc *pssmUseCases) GetPSSM(ctx context.Context, id int64) (*entities.PSSM, error) {
+	pssm, err := uc.pssmRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPSSMNotFound
+		}
+		return nil, err
+	}
+	return pssm, nil
+}
+
+func (uc *pssmUseCases) ListPSSMs(ctx context.Context) ([]*entities.PSSM, error) {
+	return uc.pssmRepo.GetAll(ctx)
+}
+
+func (uc *pssmUseCases) DeletePSSM(ctx context.Context, id int64) error {
+	if _, err := uc.GetPSSM(ctx, id); err != nil {
+		return err
+	}
+	return uc.pssmRepo.Delete(ctx, id)
+}
+
+func (uc *pssmUseCases) ScoreSequence(ctx context.Context, id int64, req *PSSMScoreRequest) (*entities.PSSMScore, error) {
+	if req == nil || len(req.Sequence) == 0 {
+		return nil, ErrInvalidInput
+	}
+
+	pssm, err := uc.GetPSSM(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.profileService.ScoreSequence(pssm, strings.Join(req.Sequence, ""))
+}
+
+// SearchProteins scans every stored protein for windows scoring at least minScore bits.
+// When minScore is nil, half of the profile's maximum attainable score is used.
+func (uc *pssmUseCases) SearchProteins(ctx context.Context, id int64, minScore *float64, limit int) ([]entities.PSSMHit, error) {
+	pssm, err := uc.GetPSSM(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := profileThreshold(pssm, minScore)
+	hits, err := uc.scanProteins(ctx, pssm, threshold, func(*entities.Protein) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	return topHits(hits, limit), nil
+}
+
+// CheckFamily re-scores the members of a curated family against the profile and lists
+// non-members that score above the threshold as candidate family members.
+func (uc *pssmUseCases) CheckFamily(ctx context.Context, id int64, family string, minScore *float64, limit int) (*entities.FamilyProfileCheck, error) {
+	pssm, err := uc.GetPSSM(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	family = strings.TrimSpace(family)
+	if family == "" && pssm.Family != nil {
+		family = *pssm.Family
+	}
+	if family == "" {
+		return nil, ErrInvalidInput
+	}
+
+	threshold := profileThreshold(pssm, minScore)
+	check := &entities.FamilyProfileCheck{
+		Family:       family,
+		Threshold:    threshold,
+		Confirmed:    []entities.PSSMHit{},
+		Questionable: []entities.PSSMHit{},
+	}
+
+	members, err := uc.proteinRepo.GetByFamily(ctx, family)
+	if err != nil {
+		return nil, err
+	}
+	for _, protein := range members {
+		hit := entities.PSSMHit{ProteinID: protein.ID, Name: protein.Name, Family: protein.Family}
+		score, err := uc.profileService.ScoreSequence(pssm, protein.GetFullSequence())
+		if err == nil {
+			hit = profileHit(protein, score)
+		}
+		if err == nil && score.Score >= threshold {
+			check.Confirmed = append(check.Confirmed, hit)
+		} else {
+			check.Questionable = append(check.Questionable, hit)
+		}
+	}
+
+	candidates, err := uc.scanProteins(ctx, pssm, threshold, func(protein *entities.Protein) bool {
+		return protein.Family == nil || !strings.EqualFold(*protein.Family, family)
+	})
+	if err != nil {
+		return nil, err
+	}
+	check.Candidates = topHits(candidates, limit)
+
+	return check, nil
+}
+
+func (uc *pssmUseCases) scanProteins(ctx context.Context, pssm *entities.PSSM, threshold float64, include func(*entities.Protein) bool) ([]entities.PSSMHit, error) {
+	hits := []entities.PSSMHit{}
+	err := uc.proteinRepo.ForEachBatch(ctx, profileScanBatchSize, func(proteins []*entities.Protein) error {
+		for _, protein := range proteins {
+			if !include(protein) {
+				continue
+			}
+			score, err := uc.profileService.ScoreSequence(pssm, protein.GetFullSequence())
+			if err != nil {
+				continue
+			}
+			if score.Score >= threshold {
+				hits = append(hits, profileHit(protein, score))
+			}
+		}
+		return ctx.Err()
+	})
+	return hits, err
+}
+
+func profileHit(protein *entities.Protein, score *entities.PSSMScore) entities.PSSMHit {
+	hit := score.PSSMHit
+	hit.ProteinID = protein.ID
+	hit.Name = protein.Name
+	hit.Family = protein.Family
+	return hit
+}
+
+func profileThreshold(pssm *entities.PSSM, minScore *float64) float64 {
+	if minScore != nil {
+		return *minScore
+	}
+	return pssm.MaxScore() / 2
+}
+
+func topHits(hits []entities.PSSMHit, limit int) []entities.PSSMHit {
+	if limit <= 0 {
+		limit = defaultProfileHitLimit
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
@@ -0,0 +1,234 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"go-crawler/web/BE/internal/domain/services"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("protein revision not found")
+
+const (
+	defaultRevisionLimit = 20
+	maxRevisionLimit     = 100
+)
+
+// diffIgnoredFields are left out of the field changes of a revision diff: the
+// timestamps and version change with every write, and the sequence gets a
+// residue-level diff.
+var diffIgnoredFields = map[string]bool{
+	"created":    true,
+	"updated":    true,
+	"deleted_at": true,
+	"version":    true,
+	"seq":        true,
+}
+
+type RevisionUseCases interface {
+	ListRevisions(ctx context.Context, proteinID string, limit, offset int) (*entities.PaginatedRevisions, error)
+	GetRevision(ctx context.Context, proteinID string, revision int) (*entities.ProteinRevision, error)
+	DiffRevisions(ctx context.Context, proteinID string, from, to int) (*entities.RevisionDiff, error)
+	RevertProtein(ctx context.Context, proteinID string, revision int, ifMatch []int) (*entities.ProteinRevision, error)
+}
+
+type revisionUseCases struct {
+	proteinRepo  repositories.IProteinRepository
+	revisionRepo repositories.RevisionRepository
+}
+
+func NewRevisionUseCases(
+	proteinRepo repositories.IProteinRepository,
+	revisionRepo repositories.RevisionRepository,
+) RevisionUseCases {
+	return &revisionUseCases{
+		proteinRepo:  proteinRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+// ListRevisions returns a page of the history of a protein, newest first. The history
+// outlives the protein, so purged proteins still have one.
+func (uc *revisionUseCases) ListRevisions(ctx context.Context, proteinID string, limit, offset int) (*entities.PaginatedRevisions, error) {
+	if strings.TrimSpace(proteinID) == "" || limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = defaultRevisionLimit
+	}
+	limit = min(limit, maxRevisionLimit)
+
+	revisions, total, err := uc.revisionRepo.List(ctx, proteinID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, ErrProteinNotFound
+	}
+
+	page := &entities.PaginatedRevisions{
+		Revisions: make([]entities.ProteinRevision, len(revisions)),
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	for i, revision := range revisions {
+		page.Revisions[i] = *revision
+	}
+	return page, nil
+}
+
+func (uc *revisionUseCases) GetRevision(ctx context.Context, proteinID string, revision int) (*entities.ProteinRevision, error) {
+	if strings.TrimSpace(proteinID) == "" || revision <= 0 {
+		return nil, ErrInvalidInput
+	}
+	found, err := uc.revisionRepo.Get(ctx, proteinID, revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return found, nil
+}
+
+// DiffRevisions compares two revisions of a protein. A to of 0 is the latest revision
+// and a from of 0 the one before to.
+func (uc *revisionUseCases) DiffRevisions(ctx context.Context, proteinID string, from, to int) (*entities.RevisionDiff, error) {
+	if strings.TrimSpace(proteinID) == "" || from < 0 || to < 0 {
+		return nil, ErrInvalidInput
+	}
+	if to == 0 {
+		latest, err := uc.revisionRepo.Latest(ctx, proteinID)
+		if err != nil {
+			return nil, err
+		}
+		if latest == 0 {
+			return nil, ErrProteinNotFound
+		}
+		to = latest
+	}
+	if from == 0 {
+		from = max(to-1, 1)
+	}
+
+	older, err := uc.GetRevision(ctx, proteinID, from)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := uc.GetRevision(ctx, proteinID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffSnapshots(older.Snapshot, newer.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	diff := &entities.RevisionDiff{
+		ProteinID: proteinID,
+		From:      from,
+		To:        to,
+		Changes:   changes,
+	}
+	if seqFrom, seqTo := older.Snapshot.GetFullSequence(), newer.Snapshot.GetFullSequence(); seqFrom != seqTo {
+		diff.Sequence = services.DiffSequences(seqFrom, seqTo)
+	}
+	return diff, nil
+}
+
+// RevertProtein writes the snapshot of an earlier revision back over a protein, which
+// records a new revision rather than rewriting the history. It returns the revision
+// the revert left; reverting to the current state leaves none and returns the latest.
+// Like an update, it only applies to the protein at one of the ifMatch versions.
+func (uc *revisionUseCases) RevertProtein(ctx context.Context, proteinID string, revision int, ifMatch []int) (*entities.ProteinRevision, error) {
+	target, err := uc.GetRevision(ctx, proteinID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+	if err := checkVersion(protein, ifMatch); err != nil {
+		return nil, err
+	}
+
+	reverted := *target.Snapshot
+	reverted.ID = protein.ID
+	reverted.Created = protein.Created
+	reverted.Version = protein.Version
+	reverted.Updated = time.Now()
+
+	change := entities.ChangeFrom(ctx)
+	note := fmt.Sprintf("revert to revision %d", revision)
+	if change.Note != "" {
+		note += ": " + change.Note
+	}
+	change.Note = note
+	if err := uc.proteinRepo.Update(entities.WithChange(ctx, change), &reverted); err != nil {
+		return nil, err
+	}
+
+	latest, err := uc.revisionRepo.Latest(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.GetRevision(ctx, proteinID, latest)
+}
+
+// diffSnapshots lists the fields that differ between two snapshots, by their JSON
+// names and in name order. A field left out of the JSON of one snapshot is null.
+func diffSnapshots(older, newer *entities.Protein) ([]entities.FieldChange, error) {
+	olderFields, err := proteinFields(older)
+	if err != nil {
+		return nil, err
+	}
+	newerFields, err := proteinFields(newer)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(olderFields)+len(newerFields))
+	for name := range olderFields {
+		names[name] = true
+	}
+	for name := range newerFields {
+		names[name] = true
+	}
+
+	changes := []entities.FieldChange{}
+	for name := range names {
+		if diffIgnoredFields[name] || reflect.DeepEqual(olderFields[name], newerFields[name]) {
+			continue
+		}
+		changes = append(changes, entities.FieldChange{Field: name, From: olderFields[name], To: newerFields[name]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// proteinFields returns the members of the JSON of a protein, by their names.
+func proteinFields(protein *entities.Protein) (map[string]any, error) {
+	data, err := json.Marshal(protein)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode protein: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode protein: %w", err)
+	}
+	return fields, nil
+}
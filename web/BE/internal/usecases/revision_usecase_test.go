@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"testing"
+)
+
+// revisionStore stands in for the protein and revision repositories with one protein,
+// P12345. Like the triggers, an update at the stored version bumps it and records a
+// revision with the change note of the context.
+type revisionStore struct {
+	repositories.IProteinRepository
+	protein   entities.Protein
+	revisions []*entities.ProteinRevision
+}
+
+func newRevisionStore() *revisionStore {
+	s := &revisionStore{protein: entities.Protein{ID: "P12345", Name: "Kinase", Seq: []string{"MKVL"}}}
+	for _, name := range []string{"Kinase", "Renamed kinase"} {
+		s.protein.Name = name
+		s.record("")
+	}
+	return s
+}
+
+func (s *revisionStore) record(note string) {
+	s.protein.Version++
+	snapshot := s.protein
+	revision := &entities.ProteinRevision{ProteinID: snapshot.ID, Revision: len(s.revisions) + 1, Action: "update", Snapshot: &snapshot}
+	if note != "" {
+		revision.Note = &note
+	}
+	s.revisions = append(s.revisions, revision)
+}
+
+func (s *revisionStore) GetByID(ctx context.Context, id string) (*entities.Protein, error) {
+	if id != s.protein.ID {
+		return nil, nil
+	}
+	protein := s.protein
+	return &protein, nil
+}
+
+func (s *revisionStore) Update(ctx context.Context, protein *entities.Protein) error {
+	if protein.ID != s.protein.ID || protein.Version != s.protein.Version {
+		return entities.ErrVersionConflict
+	}
+	s.protein = *protein
+	s.record(entities.ChangeFrom(ctx).Note)
+	protein.Version = s.protein.Version
+	return nil
+}
+
+func (s *revisionStore) List(ctx context.Context, proteinID string, limit, offset int) ([]*entities.ProteinRevision, int, error) {
+	var page []*entities.ProteinRevision
+	for i := len(s.revisions) - 1 - offset; i >= 0 && len(page) < limit; i-- {
+		page = append(page, s.revisions[i])
+	}
+	return page, len(s.revisions), nil
+}
+
+func (s *revisionStore) Get(ctx context.Context, proteinID string, revision int) (*entities.ProteinRevision, error) {
+	if proteinID != s.protein.ID || revision < 1 || revision > len(s.revisions) {
+		return nil, sql.ErrNoRows
+	}
+	return s.revisions[revision-1], nil
+}
+
+func (s *revisionStore) Latest(ctx context.Context, proteinID string) (int, error) {
+	if proteinID != s.protein.ID {
+		return 0, nil
+	}
+	return len(s.revisions), nil
+}
+
+func TestRevertProtein(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  []int
+		note     string
+		wantErr  error
+		wantNote string
+	}{
+		{name: "at the read version", ifMatch: []int{2}, wantNote: "revert to revision 1"},
+		{name: "at any version", wantNote: "revert to revision 1"},
+		{name: "with a change note", note: "typo", wantNote: "revert to revision 1: typo"},
+		{name: "at a stale version", ifMatch: []int{1}, wantErr: entities.ErrVersionConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRevisionStore()
+			uc := NewRevisionUseCases(store, store)
+
+			ctx := entities.WithChange(context.Background(), entities.Change{Note: tt.note})
+			revision, err := uc.RevertProtein(ctx, "P12345", 1, tt.ifMatch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if store.protein.Version != 2 || len(store.revisions) != 2 {
+					t.Fatalf("refused revert wrote version %d with %d revisions", store.protein.Version, len(store.revisions))
+				}
+				return
+			}
+
+			if revision.Revision != 3 || revision.Snapshot.Name != "Kinase" {
+				t.Errorf("revert recorded revision %d with name %q, want 3 with %q", revision.Revision, revision.Snapshot.Name, "Kinase")
+			}
+			if revision.Note == nil || *revision.Note != tt.wantNote {
+				t.Errorf("note %v, want %q", revision.Note, tt.wantNote)
+			}
+			if store.protein.Name != "Kinase" || store.protein.Version != 3 {
+				t.Errorf("protein is %q at version %d, want %q at 3", store.protein.Name, store.protein.Version, "Kinase")
+			}
+		})
+	}
+}
@@ -0,0 +1,484 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/response"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"go-crawler/web/BE/internal/infrastructure/storage"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrStructureNotFound = errors.New("structure not found")
+	ErrChainNotFound     = errors.New("structure chain not found")
+	ErrStructureExists   = errors.New("structure file already uploaded")
+	ErrChainMismatch     = errors.New("chain sequence does not match the protein")
+	ErrFileTooLarge      = errors.New("file exceeds the maximum upload size")
+)
+
+// A chain is only linked when its modelled residues align to the protein with at
+// least this identity and cover at least this fraction of the chain; expression tags
+// and short loops may stay unaligned.
+const (
+	minChainLinkIdentity = 0.9
+	minChainLinkCoverage = 0.5
+)
+
+// Contact maps default to the usual 8 Å residue contact over all residue pairs.
+const (
+	defaultContactThreshold = 8.0
+	maxContactThreshold     = 30.0
+	defaultMinSeparation    = 1
+)
+
+// Atoms a contact map can be computed between.
+const (
+	ContactAtomCA = "ca"
+	ContactAtomCB = "cb"
+)
+
+// StructureUpload describes an uploaded file. ChainProteins links chain IDs to stored
+// proteins; the key "*" links every chain not listed otherwise.
+type StructureUpload struct {
+	FileName      string
+	Format        string
+	ChainProteins map[string]string
+}
+
+type StructureUseCases interface {
+	UploadStructure(ctx context.Context, r io.Reader, upload StructureUpload) (*entities.StructureEntry, error)
+	ListStructures(ctx context.Context) ([]*entities.StructureEntry, error)
+	GetStructure(ctx context.Context, id int64) (*entities.StructureEntry, error)
+	OpenStructureFile(ctx context.Context, id int64) (io.ReadCloser, *entities.StructureEntry, error)
+	DeleteStructure(ctx context.Context, id int64) error
+	LinkChain(ctx context.Context, id int64, chainID, proteinID string) (*entities.StructureChain, error)
+	GetCATrace(ctx context.Context, id int64, chainID string) (*response.CATrace, error)
+	GetProteinStructures(ctx context.Context, proteinID string) ([]*entities.StructureChain, error)
+	CompareChains(ctx context.Context, id1 int64, chainID1 string, id2 int64, chainID2 string) (*response.StructureComparison, error)
+	GetContactMap(ctx context.Context, id int64, chainID, atom string, threshold float64, minSeparation int) (*entities.ContactMap, error)
+}
+
+type structureUseCases struct {
+	structureRepo    *repositories.StructureRepositories
+	proteinRepo      *repositories.ProteinRepositories
+	store            *storage.FileStore
+	structureService services.StructureDomainService
+}
+
+func NewStructureUseCases(
+	structureRepo *repositories.StructureRepositories,
+	proteinRepo *repositories.ProteinRepositories,
+	store *storage.FileStore,
+	structureService services.StructureDomainService,
+) StructureUseCases {
+	return &structureUseCases{
+		structureRepo:    structureRepo,
+		proteinRepo:      proteinRepo,
+		store:            store,
+		structureService: structureService,
+	}
+}
+
+// UploadStructure stores the file, parses its first model and saves every chain with
+// its SEQRES and ATOM sequences and CA trace. Chains named in upload.ChainProteins are
+// linked to their protein by aligning the ATOM sequence to the protein sequence. The
+// same file uploaded twice returns ErrStructureExists with the stored entry.
+func (uc *structureUseCases) UploadStructure(ctx context.Context, r io.Reader, upload StructureUpload) (*entities.StructureEntry, error) {
+	format := strings.ToLower(upload.Format)
+	if format == "" {
+		format = formats.StructureFormatFromName(upload.FileName)
+	}
+	switch format {
+	case "", formats.StructurePDB, formats.StructureMMCIF:
+	case "cif":
+		format = formats.StructureMMCIF
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	path, checksum, size, err := uc.store.Save(r, filepath.Ext(upload.FileName))
+	if err != nil {
+		if errors.Is(err, storage.ErrFileTooLarge) {
+			return nil, ErrFileTooLarge
+		}
+		return nil, err
+	}
+	existing, err := uc.structureRepo.GetByChecksum(ctx, checksum)
+	if err == nil {
+		return existing, ErrStructureExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	entry, err := uc.parseStoredFile(ctx, path, format, upload)
+	if err != nil {
+		uc.store.Delete(path)
+		return nil, err
+	}
+	entry.FileName = filepath.Base(upload.FileName)
+	entry.Path, entry.Checksum, entry.FileSize = path, checksum, size
+	entry.Created = time.Now()
+
+	if err := uc.structureRepo.Create(ctx, entry); err != nil {
+		// A concurrent upload of the same file owns the stored copy now.
+		if existing, lookupErr := uc.structureRepo.GetByChecksum(ctx, checksum); lookupErr == nil {
+			return existing, ErrStructureExists
+		}
+		uc.store.Delete(path)
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (uc *structureUseCases) parseStoredFile(ctx context.Context, path, format string, upload StructureUpload) (*entities.StructureEntry, error) {
+	f, err := uc.store.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	structure, err := formats.ReadStructure(f, format)
+	if err != nil {
+		if errors.Is(err, formats.ErrInvalidStructure) || errors.Is(err, entities.ErrNoStructureChains) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		return nil, err
+	}
+
+	entry := &entities.StructureEntry{
+		PDBID:      structure.PDBID,
+		Title:      structure.Title,
+		Method:     structure.Method,
+		Resolution: structure.Resolution,
+		Format:     structure.Format,
+	}
+	for id := range upload.ChainProteins {
+		if id != "*" && structure.Chain(id) == nil {
+			return nil, fmt.Errorf("%w: chain %s", ErrChainNotFound, id)
+		}
+	}
+
+	for i := range structure.Chains {
+		chain := newStructureChain(&structure.Chains[i])
+		if chain == nil {
+			continue
+		}
+		proteinID, ok := upload.ChainProteins[chain.ChainID]
+		if !ok {
+			proteinID, ok = upload.ChainProteins["*"]
+		}
+		if ok {
+			if err := uc.linkChain(ctx, chain, proteinID); err != nil {
+				return nil, fmt.Errorf("chain %s: %w", chain.ChainID, err)
+			}
+		}
+		entry.Chains = append(entry.Chains, chain)
+	}
+	return entry, nil
+}
+
+// newStructureChain keeps the modelled amino acids of a chain; chains of only water or
+// ligands yield nil.
+func newStructureChain(chain *entities.Chain) *entities.StructureChain {
+	residues := chain.AminoAcids()
+	if len(residues) == 0 {
+		return nil
+	}
+
+	stored := &entities.StructureChain{
+		ChainID:        chain.ID,
+		SeqResSequence: chain.SeqResSequence(),
+		AtomSequence:   chain.AtomSequence(),
+		ResidueNumbers: make([]string, len(residues)),
+		CACoords:       make([]float64, 0, 3*len(residues)),
+	}
+	for i := range residues {
+		stored.ResidueNumbers[i] = residues[i].Label()
+		ca := residues[i].CA()
+		stored.CACoords = append(stored.CACoords, ca.X, ca.Y, ca.Z)
+	}
+	return stored
+}
+
+// linkChain aligns the chain's ATOM sequence locally to the protein sequence and maps
+// each modelled residue to its 1-based protein position.
+func (uc *structureUseCases) linkChain(ctx context.Context, chain *entities.StructureChain, proteinID string) error {
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return err
+	}
+	if protein == nil {
+		return ErrProteinNotFound
+	}
+	sequence := strings.ToUpper(protein.GetFullSequence())
+
+	alignment := services.AlignSequences(chain.AtomSequence, sequence, services.LocalAlignment)
+	mapping := services.MapAlignedPositions(alignment, len(chain.AtomSequence))
+	positions := make([]int, len(mapping))
+	aligned := 0
+	for i, j := range mapping {
+		if j >= 0 {
+			positions[i] = j + 1
+			aligned++
+		}
+	}
+	coverage := float64(aligned) / float64(len(chain.AtomSequence))
+	if alignment.Identity < minChainLinkIdentity || coverage < minChainLinkCoverage {
+		return fmt.Errorf("%w: identity %.2f, coverage %.2f", ErrChainMismatch, alignment.Identity, coverage)
+	}
+
+	chain.ProteinID = &protein.ID
+	chain.ProteinPositions = positions
+	chain.Identity = alignment.Identity
+	chain.Coverage = coverage
+	return nil
+}
+
+func (uc *structureUseCases) ListStructures(ctx context.Context) ([]*entities.StructureEntry, error) {
+	return uc.structureRepo.GetAll(ctx)
+}
+
+func (uc *structureUseCases) GetStructure(ctx context.Context, id int64) (*entities.StructureEntry, error) {
+	entry, err := uc.structureRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStructureNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (uc *structureUseCases) OpenStructureFile(ctx context.Context, id int64) (io.ReadCloser, *entities.StructureEntry, error) {
+	entry, err := uc.GetStructure(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := uc.store.Open(entry.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, entry, nil
+}
+
+// DeleteStructure removes the record first, so a failure never leaves a record whose
+// file is gone.
+func (uc *structureUseCases) DeleteStructure(ctx context.Context, id int64) error {
+	entry, err := uc.GetStructure(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.structureRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return uc.store.Delete(entry.Path)
+}
+
+func (uc *structureUseCases) LinkChain(ctx context.Context, id int64, chainID, proteinID string) (*entities.StructureChain, error) {
+	if strings.TrimSpace(proteinID) == "" {
+		return nil, ErrInvalidInput
+	}
+	chain, err := uc.getChain(ctx, id, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.linkChain(ctx, chain, proteinID); err != nil {
+		return nil, err
+	}
+	if err := uc.structureRepo.UpdateChainLink(ctx, chain); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+func (uc *structureUseCases) GetCATrace(ctx context.Context, id int64, chainID string) (*response.CATrace, error) {
+	entry, err := uc.GetStructure(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := uc.getChain(ctx, id, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &response.CATrace{
+		StructureID:      entry.ID,
+		PDBID:            entry.PDBID,
+		ChainID:          chain.ChainID,
+		ProteinID:        chain.ProteinID,
+		Sequence:         chain.AtomSequence,
+		Residues:         chain.ResidueNumbers,
+		ProteinPositions: chain.ProteinPositions,
+		Points:           make([]response.StructurePoint, len(chain.CACoords)/3),
+	}
+	for i := range trace.Points {
+		trace.Points[i] = response.StructurePoint{X: chain.CACoords[3*i], Y: chain.CACoords[3*i+1], Z: chain.CACoords[3*i+2]}
+	}
+	return trace, nil
+}
+
+func (uc *structureUseCases) GetProteinStructures(ctx context.Context, proteinID string) ([]*entities.StructureChain, error) {
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+	return uc.structureRepo.GetChainsByProtein(ctx, proteinID)
+}
+
+func (uc *structureUseCases) CompareChains(ctx context.Context, id1 int64, chainID1 string, id2 int64, chainID2 string) (*response.StructureComparison, error) {
+	mobile, err := uc.getChain(ctx, id1, chainID1)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uc.getChain(ctx, id2, chainID2)
+	if err != nil {
+		return nil, err
+	}
+	return compareStructureChains(uc.structureService, mobile, target)
+}
+
+// GetContactMap lists the residue pairs of a chain closer than threshold. CA contacts
+// use the stored trace; CB contacts re-read the stored file, with CA standing in for
+// glycine and residues whose side chain is not modelled.
+func (uc *structureUseCases) GetContactMap(ctx context.Context, id int64, chainID, atom string, threshold float64, minSeparation int) (*entities.ContactMap, error) {
+	atom = strings.ToLower(atom)
+	if atom == "" {
+		atom = ContactAtomCA
+	}
+	if threshold == 0 {
+		threshold = defaultContactThreshold
+	}
+	if minSeparation == 0 {
+		minSeparation = defaultMinSeparation
+	}
+	if (atom != ContactAtomCA && atom != ContactAtomCB) || threshold < 0 || threshold > maxContactThreshold || minSeparation < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	chain, err := uc.getChain(ctx, id, chainID)
+	if err != nil {
+		return nil, err
+	}
+	coords := chain.CAPoints()
+	if atom == ContactAtomCB {
+		if coords, err = uc.readCBCoords(ctx, id, chain); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entities.ContactMap{
+		StructureID:   id,
+		ChainID:       chain.ChainID,
+		Atom:          atom,
+		Threshold:     threshold,
+		MinSeparation: minSeparation,
+		Sequence:      chain.AtomSequence,
+		Residues:      chain.ResidueNumbers,
+		Contacts:      uc.structureService.Contacts(coords, threshold, minSeparation),
+	}, nil
+}
+
+func (uc *structureUseCases) readCBCoords(ctx context.Context, id int64, chain *entities.StructureChain) ([][3]float64, error) {
+	f, entry, err := uc.OpenStructureFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	structure, err := formats.ReadStructure(f, entry.Format)
+	if err != nil {
+		return nil, err
+	}
+	parsed := structure.Chain(chain.ChainID)
+	if parsed == nil {
+		return nil, ErrChainNotFound
+	}
+	residues := parsed.AminoAcids()
+	if len(residues) != len(chain.ResidueNumbers) {
+		return nil, fmt.Errorf("chain %s of structure %d no longer matches its stored file", chain.ChainID, id)
+	}
+
+	coords := make([][3]float64, len(residues))
+	for i := range residues {
+		atom := residues[i].Atom("CB")
+		if atom == nil {
+			atom = residues[i].CA()
+		}
+		coords[i] = [3]float64{atom.X, atom.Y, atom.Z}
+	}
+	return coords, nil
+}
+
+// compareStructureChains pairs the CA atoms of two chains by locally aligning their
+// modelled sequences, then superposes the first chain onto the second.
+func compareStructureChains(service services.StructureDomainService, mobile, target *entities.StructureChain) (*response.StructureComparison, error) {
+	alignment := services.AlignSequences(mobile.AtomSequence, target.AtomSequence, services.LocalAlignment)
+	mapping := services.MapAlignedPositions(alignment, len(mobile.AtomSequence))
+
+	mobilePoints, targetPoints := mobile.CAPoints(), target.CAPoints()
+	var pairs [][2]int
+	var mobileAligned, targetAligned [][3]float64
+	for i, j := range mapping {
+		if j >= 0 {
+			pairs = append(pairs, [2]int{i, j})
+			mobileAligned = append(mobileAligned, mobilePoints[i])
+			targetAligned = append(targetAligned, targetPoints[j])
+		}
+	}
+
+	fit, err := service.Superpose(mobileAligned, targetAligned, len(targetPoints))
+	if err != nil {
+		return nil, fmt.Errorf("%w: chains %s and %s: %v", ErrChainMismatch, mobile.ChainID, target.ChainID, err)
+	}
+
+	comparison := &response.StructureComparison{
+		StructureID1:     mobile.StructureID,
+		ChainID1:         mobile.ChainID,
+		ProteinID1:       mobile.ProteinID,
+		StructureID2:     target.StructureID,
+		ChainID2:         target.ChainID,
+		ProteinID2:       target.ProteinID,
+		SequenceIdentity: alignment.Identity,
+		AlignedPairs:     fit.AlignedPairs,
+		RMSD:             fit.RMSD,
+		TMScore:          fit.TMScore,
+		GDTTS:            fit.GDTTS,
+		Rotation:         fit.Rotation,
+		Translation:      fit.Translation,
+		Pairs:            pairs,
+		Mobile:           make([]response.StructurePoint, len(mobilePoints)),
+		Target:           make([]response.StructurePoint, len(targetPoints)),
+	}
+	for i, p := range mobilePoints {
+		p = fit.Apply(p)
+		comparison.Mobile[i] = response.StructurePoint{X: p[0], Y: p[1], Z: p[2]}
+	}
+	for i, p := range targetPoints {
+		comparison.Target[i] = response.StructurePoint{X: p[0], Y: p[1], Z: p[2]}
+	}
+	return comparison, nil
+}
+
+func (uc *structureUseCases) getChain(ctx context.Context, id int64, chainID string) (*entities.StructureChain, error) {
+	chain, err := uc.structureRepo.GetChain(ctx, id, chainID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrChainNotFound
+		}
+		return nil, err
+	}
+	return chain, nil
+}
@@ -0,0 +1,241 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"strings"
+	"time"
+)
+
+var ErrTaxonNotFound = errors.New("taxon not found")
+
+const (
+	defaultTaxonLimit = 50
+	maxTaxonLimit     = 500
+
+	taxonomyBatchSize = 5000
+)
+
+type TaxonomyUseCases interface {
+	ImportTaxonomy(ctx context.Context, nodes, names io.Reader) (*entities.TaxonomyImportSummary, error)
+	SearchTaxa(ctx context.Context, q, rank string, limit, offset int) ([]entities.TaxonMatch, error)
+	GetTaxon(ctx context.Context, id int) (*entities.Taxon, error)
+	GetLineage(ctx context.Context, id int) ([]*entities.Taxon, error)
+	GetChildren(ctx context.Context, id, limit, offset int) ([]*entities.Taxon, error)
+	GetTaxonProteins(ctx context.Context, id, limit, offset int) (*entities.PaginatedProteins, error)
+	LinkProtein(ctx context.Context, proteinID string, taxonID int) (*entities.Protein, error)
+	LinkProteins(ctx context.Context) (int, error)
+}
+
+type taxonomyUseCases struct {
+	proteinRepo  *repositories.ProteinRepositories
+	taxonomyRepo *repositories.TaxonomyRepositories
+}
+
+func NewTaxonomyUseCases(
+	proteinRepo *repositories.ProteinRepositories,
+	taxonomyRepo *repositories.TaxonomyRepositories,
+) TaxonomyUseCases {
+	return &taxonomyUseCases{
+		proteinRepo:  proteinRepo,
+		taxonomyRepo: taxonomyRepo,
+	}
+}
+
+// ImportTaxonomy loads nodes.dmp and names.dmp of an NCBI taxdump. Nodes are upserted
+// batch by batch and the stored names are replaced, so an interrupted import is
+// repaired by running it again.
+func (uc *taxonomyUseCases) ImportTaxonomy(ctx context.Context, nodes, names io.Reader) (*entities.TaxonomyImportSummary, error) {
+	summary := &entities.TaxonomyImportSummary{StartedAt: time.Now()}
+
+	nodeReader := formats.NewTaxNodeReader(nodes)
+	taxa := make([]*entities.Taxon, 0, taxonomyBatchSize)
+	for {
+		node, err := nodeReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, taxdumpError(err)
+		}
+		taxa = append(taxa, &entities.Taxon{
+			ID:          node.TaxID,
+			ParentID:    node.ParentID,
+			Rank:        node.Rank,
+			DivisionID:  node.DivisionID,
+			GeneticCode: node.GeneticCode,
+		})
+		if len(taxa) == taxonomyBatchSize {
+			if err := uc.taxonomyRepo.SaveTaxa(ctx, taxa); err != nil {
+				return nil, err
+			}
+			summary.Nodes += len(taxa)
+			taxa = taxa[:0]
+		}
+	}
+	if err := uc.taxonomyRepo.SaveTaxa(ctx, taxa); err != nil {
+		return nil, err
+	}
+	summary.Nodes += len(taxa)
+	if summary.Nodes == 0 {
+		return nil, fmt.Errorf("%w: nodes.dmp is empty", ErrInvalidInput)
+	}
+
+	if err := uc.taxonomyRepo.DeleteTaxonNames(ctx); err != nil {
+		return nil, err
+	}
+	nameReader := formats.NewTaxNameReader(names)
+	batch := make([]entities.TaxonName, 0, taxonomyBatchSize)
+	for {
+		name, err := nameReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, taxdumpError(err)
+		}
+		batch = append(batch, entities.TaxonName{
+			TaxonID:    name.TaxID,
+			Name:       name.Name,
+			UniqueName: name.UniqueName,
+			NameClass:  name.NameClass,
+		})
+		if len(batch) == taxonomyBatchSize {
+			if err := uc.taxonomyRepo.SaveTaxonNames(ctx, batch); err != nil {
+				return nil, err
+			}
+			summary.Names += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if err := uc.taxonomyRepo.SaveTaxonNames(ctx, batch); err != nil {
+		return nil, err
+	}
+	summary.Names += len(batch)
+
+	if err := uc.taxonomyRepo.RefreshScientificNames(ctx); err != nil {
+		return nil, err
+	}
+	summary.FinishedAt = time.Now()
+	return summary, nil
+}
+
+func taxdumpError(err error) error {
+	if errors.Is(err, formats.ErrInvalidTaxdump) {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	return err
+}
+
+// SearchTaxa finds taxa by scientific name, synonym or common name.
+func (uc *taxonomyUseCases) SearchTaxa(ctx context.Context, q, rank string, limit, offset int) ([]entities.TaxonMatch, error) {
+	q = strings.TrimSpace(q)
+	if q == "" || limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = defaultTaxonLimit
+	}
+	return uc.taxonomyRepo.SearchNames(ctx, q, strings.TrimSpace(rank), min(limit, maxTaxonLimit), offset)
+}
+
+// GetTaxon returns a taxon with all its names.
+func (uc *taxonomyUseCases) GetTaxon(ctx context.Context, id int) (*entities.Taxon, error) {
+	taxon, err := uc.getTaxon(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if taxon.Names, err = uc.taxonomyRepo.GetTaxonNames(ctx, id); err != nil {
+		return nil, err
+	}
+	return taxon, nil
+}
+
+// GetLineage returns the path from the root of the taxonomy down to the taxon.
+func (uc *taxonomyUseCases) GetLineage(ctx context.Context, id int) ([]*entities.Taxon, error) {
+	if _, err := uc.getTaxon(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.taxonomyRepo.GetLineage(ctx, id)
+}
+
+func (uc *taxonomyUseCases) GetChildren(ctx context.Context, id, limit, offset int) ([]*entities.Taxon, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if _, err := uc.getTaxon(ctx, id); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = defaultTaxonLimit
+	}
+	return uc.taxonomyRepo.GetChildren(ctx, id, min(limit, maxTaxonLimit), offset)
+}
+
+// GetTaxonProteins pages through the proteins of a taxon and all taxa below it.
+func (uc *taxonomyUseCases) GetTaxonProteins(ctx context.Context, id, limit, offset int) (*entities.PaginatedProteins, error) {
+	if limit < 0 || offset < 0 {
+		return nil, ErrInvalidInput
+	}
+	if _, err := uc.getTaxon(ctx, id); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = 10
+	}
+	return uc.proteinRepo.Search(ctx, &entities.ProteinFilter{TaxonID: &id, Limit: limit, Offset: offset, OrderBy: "id", OrderDirection: "ASC"})
+}
+
+// LinkProtein sets the taxon of a protein. The organism name is set from the taxon's
+// scientific name when the protein has none.
+func (uc *taxonomyUseCases) LinkProtein(ctx context.Context, proteinID string, taxonID int) (*entities.Protein, error) {
+	if strings.TrimSpace(proteinID) == "" {
+		return nil, ErrInvalidInput
+	}
+	taxon, err := uc.getTaxon(ctx, taxonID)
+	if err != nil {
+		return nil, err
+	}
+	protein, err := uc.proteinRepo.GetByID(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	if protein == nil {
+		return nil, ErrProteinNotFound
+	}
+
+	protein.SetTaxonID(taxon.ID)
+	if protein.Taxo == nil {
+		protein.SetTaxonomy(taxon.ScientificName)
+	}
+	if err := uc.proteinRepo.Update(ctx, protein); err != nil {
+		return nil, err
+	}
+	return protein, nil
+}
+
+// LinkProteins links the proteins that have an organism name but no taxon, by
+// unambiguous scientific name, synonym or common name.
+func (uc *taxonomyUseCases) LinkProteins(ctx context.Context) (int, error) {
+	return uc.taxonomyRepo.LinkProteins(ctx)
+}
+
+func (uc *taxonomyUseCases) getTaxon(ctx context.Context, id int) (*entities.Taxon, error) {
+	if id <= 0 {
+		return nil, ErrInvalidInput
+	}
+	taxon, err := uc.taxonomyRepo.GetTaxon(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTaxonNotFound
+		}
+		return nil, err
+	}
+	return taxon, nil
+}
@@ -0,0 +1,265 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/services"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ImportSourceUniProt = "UniProt"
+	FormatUniProtDat    = "uniprot_dat"
+
+	defaultImportBatchSize = 500
+)
+
+type UniProtImportOptions struct {
+	BatchSize int
+	// Restart ignores a stored checkpoint and imports the file from the beginning.
+	Restart bool
+}
+
+type UniProtUseCases interface {
+	ImportDatFile(ctx context.Context, path string, opts UniProtImportOptions, progress func(report *entities.ImportReport)) (*entities.ImportReport, error)
+}
+
+type uniProtUseCases struct {
+	importRepo     *repositories.ImportRepositories
+	proteinService services.ProteinDomainService
+}
+
+func NewUniProtUseCases(
+	importRepo *repositories.ImportRepositories,
+	proteinService services.ProteinDomainService,
+) UniProtUseCases {
+	return &uniProtUseCases{
+		importRepo:     importRepo,
+		proteinService: proteinService,
+	}
+}
+
+// ImportDatFile streams a UniProtKB flat file into the database, upserting proteins with
+// their cross-references and features in batches of opts.BatchSize. Each batch commits
+// together with a checkpoint, so running the import again after an interruption resumes
+// after the last committed batch. The checkpoint is discarded once the file is done, or
+// when the file has changed size since it was written.
+//
+// Entries that fail validation are listed in the report; other entries are only counted.
+// Entries of proteins in the trash are not written and count as failed.
+func (uc *uniProtUseCases) ImportDatFile(ctx context.Context, path string, opts UniProtImportOptions, progress func(report *entities.ImportReport)) (*entities.ImportReport, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+
+	source, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &entities.ImportCheckpoint{Source: source, Format: FormatUniProtDat, FileSize: info.Size()}
+	if !opts.Restart {
+		stored, err := uc.importRepo.GetCheckpoint(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil && stored.Format == FormatUniProtDat && stored.FileSize == info.Size() {
+			checkpoint = stored
+		}
+	}
+	if _, err := f.Seek(checkpoint.Offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	report := entities.NewImportReport()
+	reader := formats.NewUniProtDatReader(f, checkpoint.Offset)
+	batch := make(map[string]*entities.ProteinRecord)
+	var order []string
+
+	flush := func(offset int64) error {
+		records := make([]*entities.ProteinRecord, len(order))
+		for i, id := range order {
+			records[i] = batch[id]
+		}
+		checkpoint.Offset = offset
+		checkpoint.Records += len(records)
+		outcomes, err := uc.importRepo.SaveRecords(ctx, records, ImportSourceUniProt, true, checkpoint)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			switch outcomes[record.Protein.ID] {
+			case entities.SaveCreated:
+				report.Tally(entities.ImportCreated)
+			case entities.SaveUpdated:
+				report.Tally(entities.ImportUpdated)
+			default:
+				report.Tally(entities.ImportFailed)
+			}
+		}
+		batch = make(map[string]*entities.ProteinRecord)
+		order = order[:0]
+		if progress != nil {
+			progress(report)
+		}
+		return nil
+	}
+
+	lastOffset := checkpoint.Offset
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Keep what was committed; the checkpoint lets the import resume once the file is fixed.
+			if len(order) > 0 {
+				if flushErr := flush(lastOffset); flushErr != nil {
+					return report, flushErr
+				}
+			}
+			report.FinishedAt = time.Now()
+			if errors.Is(err, formats.ErrInvalidUniProtEntry) {
+				return report, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+			}
+			return report, err
+		}
+		lastOffset = entry.EndOffset
+
+		record, err := recordFromUniProtEntry(entry, uc.proteinService)
+		if err == nil {
+			err = validateImportRecord(record)
+		}
+		if err != nil {
+			report.Add(entities.ImportRecordResult{Line: entry.Line, ID: entry.EntryName, Status: entities.ImportFailed, Error: err.Error()})
+			continue
+		}
+		if _, ok := batch[record.Protein.ID]; !ok {
+			order = append(order, record.Protein.ID)
+		}
+		batch[record.Protein.ID] = record
+
+		if len(order) >= opts.BatchSize {
+			if err := flush(lastOffset); err != nil {
+				return report, err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+	}
+
+	if len(order) > 0 {
+		if err := flush(lastOffset); err != nil {
+			return report, err
+		}
+	}
+	if err := uc.importRepo.DeleteCheckpoint(ctx, source); err != nil {
+		return report, err
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// recordFromUniProtEntry maps a flat-file or XML entry onto a protein: CC from
+// SUBCELLULAR LOCATION, Function from FUNCTION, Family from SIMILARITY and Domain from
+// the DOMAIN feature names.
+func recordFromUniProtEntry(entry *formats.UniProtEntry, proteinService services.ProteinDomainService) (*entities.ProteinRecord, error) {
+	protein, err := newImportedProtein(entry.EntryName, entry.ProteinName, entry.Sequence, proteinService)
+	if err != nil {
+		return nil, err
+	}
+	protein.SetGene(entry.Gene)
+	protein.SetAliases(entry.Aliases)
+	protein.SetTaxonomy(entry.Organism)
+	if taxonID, err := strconv.Atoi(entry.TaxonID); err == nil {
+		protein.SetTaxonID(taxonID)
+	}
+	protein.CC = optionalText(subcellularLocations(entry.Comments["SUBCELLULAR LOCATION"]))
+	protein.Function = optionalText(strings.Join(entry.Comments["FUNCTION"], " "))
+	protein.Family = optionalText(similarityFamilies(entry.Comments["SIMILARITY"]))
+	setDerivedProperties(protein, proteinService)
+
+	record := &entities.ProteinRecord{
+		Protein:    protein,
+		Accessions: entry.Accessions,
+		TaxonID:    entry.TaxonID,
+	}
+	for _, acc := range entry.Accessions {
+		record.CrossReferences = append(record.CrossReferences, entities.CrossReference{ProteinID: protein.ID, Database: "UniProtKB", Accession: acc})
+	}
+	for _, ref := range entry.CrossRefs {
+		record.CrossReferences = append(record.CrossReferences, entities.CrossReference{
+			ProteinID:  protein.ID,
+			Database:   ref.Database,
+			Accession:  ref.ID,
+			Properties: ref.Properties,
+		})
+	}
+
+	var domains []string
+	seenDomain := make(map[string]bool)
+	for _, feature := range entry.Features {
+		record.Features = append(record.Features, entities.ProteinFeature{
+			ProteinID:   protein.ID,
+			Type:        feature.Type,
+			Start:       feature.Start,
+			End:         feature.End,
+			Description: feature.Description,
+			Evidence:    feature.Evidence,
+			Source:      ImportSourceUniProt,
+		})
+		if feature.Type == "DOMAIN" && feature.Description != "" && !seenDomain[feature.Description] {
+			seenDomain[feature.Description] = true
+			domains = append(domains, feature.Description)
+		}
+	}
+	protein.Domain = optionalText(strings.Join(domains, "; "))
+	return record, nil
+}
+
+// subcellularLocations keeps the location list of each comment and drops the free-text
+// Note= part.
+func subcellularLocations(comments []string) string {
+	locations := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		if i := strings.Index(comment, "Note="); i >= 0 {
+			comment = comment[:i]
+		}
+		if comment = strings.TrimSpace(comment); comment != "" {
+			locations = append(locations, comment)
+		}
+	}
+	return strings.Join(locations, " ")
+}
+
+// similarityFamilies turns "Belongs to the p53 family." into "p53 family".
+func similarityFamilies(comments []string) string {
+	families := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		comment = strings.TrimPrefix(strings.TrimSpace(comment), "Belongs to the ")
+		comment = strings.TrimRight(comment, ". ")
+		if comment != "" {
+			families = append(families, comment)
+		}
+	}
+	return strings.Join(families, "; ")
+}
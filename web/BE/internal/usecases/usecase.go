@@ -2,14 +2,48 @@ package usecases
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"go-crawler/web/BE/internal/domain/entities"
+	"go-crawler/web/BE/internal/domain/querylang"
+	"go-crawler/web/BE/internal/domain/repositories"
+	"go-crawler/web/BE/internal/domain/response"
 	"go-crawler/web/BE/internal/domain/services"
-	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"slices"
 	"strings"
 	"time"
 )
 
+// Suggestions for searches that found nothing go down to a lower similarity than fuzzy
+// matching, so that e.g. a transposition in a short gene symbol still finds it.
+const (
+	suggestionMinSimilarity = 0.15
+	maxSuggestions          = 5
+	defaultCompletions      = 10
+	maxCompletions          = 50
+)
+
+// Facet defaults: every facet, the 10 largest families, genes and taxa, 100-residue
+// length buckets and pI buckets of one unit. Narrow widths are refused so that a
+// histogram stays at most a few thousand buckets.
+var defaultFacetFields = []string{entities.FacetFamily, entities.FacetGene, entities.FacetTaxon, entities.FacetLength, entities.FacetPI}
+
+const (
+	defaultFacetSize   = 10
+	maxFacetSize       = 100
+	defaultLengthWidth = 100
+	minLengthWidth     = 10
+	defaultPIWidth     = 1.0
+	minPIWidth         = 0.05
+)
+
+// Related data GetProteinByID can embed in a protein.
+const (
+	ProteinIncludeFeatures = "features"
+	ProteinIncludeDiseases = "diseases"
+)
+
 var (
 	ErrProteinNotFound = errors.New("protein not found")
 	ErrInvalidInput    = errors.New("invalid input parameters")
@@ -21,7 +55,9 @@ type ProteinCreateRequest struct {
 	Name     string  `json:"name" validate:"required"`
 	Seq      []string `json:"seq" validate:"required"`
 	Gene     *string `json:"gene,omitempty"`
+	Aliases  []string `json:"aliases,omitempty"`
 	Taxo     *string `json:"taxo,omitempty"`
+	TaxonID  *int    `json:"taxon_id,omitempty"`
 	CC       *string `json:"cc,omitempty"`
 	Domain   *string `json:"domain,omitempty"`
 	Family   *string `json:"family,omitempty"`
@@ -32,7 +68,9 @@ type ProteinUpdateRequest struct {
 	Name     *string `json:"name,omitempty"`
 	Seq      []string  `json:"seq,omitempty"`
 	Gene     *string `json:"gene,omitempty"`
+	Aliases  []string `json:"aliases,omitempty"`
 	Taxo     *string `json:"taxo,omitempty"`
+	TaxonID  *int    `json:"taxon_id,omitempty"`
 	CC       *string `json:"cc,omitempty"`
 	Domain   *string `json:"domain,omitempty"`
 	Family   *string `json:"family,omitempty"`
@@ -40,15 +78,19 @@ type ProteinUpdateRequest struct {
 }
 
 type ComparisonRequest struct {
-	ProteinID1 string `json:"protein_id_1" validate:"required"`
-	ProteinID2 string `json:"protein_id_2" validate:"required"`
+	ProteinID1       string `json:"protein_id_1" validate:"required"`
+	ProteinID2       string `json:"protein_id_2" validate:"required"`
+	IncludeStructure bool   `json:"include_structure"`
 }
 
+// ComparisonResponse carries a structural comparison when IncludeStructure was
+// requested and both proteins have a linked structure chain.
 type ComparisonResponse struct {
-	Protein1   *entities.Protein `json:"protein_1"`
-	Protein2   *entities.Protein `json:"protein_2"`
-	Similarity float64           `json:"similarity"`
-	ComparedAt time.Time         `json:"compared_at"`
+	Protein1   *entities.Protein             `json:"protein_1"`
+	Protein2   *entities.Protein             `json:"protein_2"`
+	Similarity float64                       `json:"similarity"`
+	Structure  *response.StructureComparison `json:"structure,omitempty"`
+	ComparedAt time.Time                     `json:"compared_at"`
 }
 
 type SequenceAnalysisRequest struct {
@@ -65,28 +107,45 @@ type SequenceAnalysisResponse struct {
 
 type ProteinUseCases interface {
 	SearchProteins(ctx context.Context, filter *entities.ProteinFilter) (*entities.PaginatedProteins, error)
-	GetProteinByID(ctx context.Context, id string) (*entities.Protein, error)
+	AutocompleteProteins(ctx context.Context, prefix string, limit int) ([]entities.SearchSuggestion, error)
+	GetProteinFacets(ctx context.Context, filter *entities.ProteinFilter, opts *entities.ProteinFacetOptions) (*entities.ProteinFacets, error)
+	GetProteinByID(ctx context.Context, id string, include ...string) (*entities.Protein, error)
 	CreateProtein(ctx context.Context, req *ProteinCreateRequest) error
-	UpdateProtein(ctx context.Context, id string, req *ProteinUpdateRequest) error
-	DeleteProtein(ctx context.Context, id string) error
+	UpdateProtein(ctx context.Context, id string, req *ProteinUpdateRequest, ifMatch []int) (*entities.Protein, error)
+	PatchProtein(ctx context.Context, id, mediaType string, patch []byte, ifMatch []int) (*entities.Protein, error)
+	DeleteProtein(ctx context.Context, id string, ifMatch []int) error
+	ListDeletedProteins(ctx context.Context, limit, offset int) (*entities.PaginatedProteins, error)
+	RestoreProtein(ctx context.Context, id string) error
+	PurgeDeletedProteins(ctx context.Context, retention time.Duration) (int, error)
 	CompareProteins(ctx context.Context, req *ComparisonRequest) (*ComparisonResponse, error)
 	AnalyzeSequence(ctx context.Context, req *SequenceAnalysisRequest) (*SequenceAnalysisResponse, error)
-	GetProteinStats(ctx context.Context) (*entities.ProteinStats, error)
-	BulkCreateProteins(ctx context.Context, requests []*ProteinCreateRequest) error
+	GetProteinStats(ctx context.Context, rank string) (*entities.ProteinStats, error)
 }
 
 type proteinUseCases struct {
-	proteinRepo    *repositories.ProteinRepositories
-	proteinService services.ProteinDomainService
+	proteinRepo      repositories.IProteinRepository
+	structureRepo    repositories.StructureRepository
+	annotationRepo   repositories.AnnotationRepository
+	diseaseRepo      repositories.DiseaseRepository
+	proteinService   services.ProteinDomainService
+	structureService services.StructureDomainService
 }
 
 func NewProteinUseCases(
-	proteinRepo *repositories.ProteinRepositories,
+	proteinRepo repositories.IProteinRepository,
+	structureRepo repositories.StructureRepository,
+	annotationRepo repositories.AnnotationRepository,
+	diseaseRepo repositories.DiseaseRepository,
 	proteinService services.ProteinDomainService,
+	structureService services.StructureDomainService,
 ) ProteinUseCases {
 	return &proteinUseCases{
-		proteinRepo:    proteinRepo,
-		proteinService: proteinService,
+		proteinRepo:      proteinRepo,
+		structureRepo:    structureRepo,
+		annotationRepo:   annotationRepo,
+		diseaseRepo:      diseaseRepo,
+		proteinService:   proteinService,
+		structureService: structureService,
 	}
 }
 
@@ -101,14 +160,134 @@ func (uc *proteinUseCases) SearchProteins(ctx context.Context, filter *entities.
 	if filter.Limit > 100 {
 		filter.Limit = 100
 	}
+	if filter.MinSimilarity != nil && (*filter.MinSimilarity < 0 || *filter.MinSimilarity > 1) {
+		return nil, fmt.Errorf("%w: min_similarity must be between 0 and 1", ErrInvalidInput)
+	}
+	switch filter.Count {
+	case "", entities.CountExact, entities.CountEstimated, entities.CountNone:
+	default:
+		return nil, fmt.Errorf("%w: count must be exact, estimated or none", ErrInvalidInput)
+	}
+	if err := parseFilterExpr(filter); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.proteinRepo.Search(ctx, filter)
+	if errors.Is(err, entities.ErrInvalidCursor) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	firstPage := filter.Cursor == "" && filter.Offset == 0
+	if term := searchedText(filter); len(result.Proteins) == 0 && firstPage && term != "" {
+		result.Suggestions, err = uc.proteinRepo.Suggest(ctx, term, suggestionMinSimilarity, maxSuggestions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// parseFilterExpr parses the filter expression of a ProteinFilter into its Where tree.
+func parseFilterExpr(filter *entities.ProteinFilter) error {
+	if filter.Expr == nil || filter.Where != nil {
+		return nil
+	}
+	where, err := querylang.Parse(*filter.Expr, entities.ProteinQueryFields)
+	if err != nil {
+		return fmt.Errorf("%w: filter: %v", ErrInvalidInput, err)
+	}
+	filter.Where = where
+	return nil
+}
+
+// searchedText is the free text of a filter that "did you mean" suggestions are
+// offered for, or "" when the filter has none.
+func searchedText(filter *entities.ProteinFilter) string {
+	for _, text := range []*string{filter.Fuzzy, filter.Query, filter.Name, filter.Gene} {
+		if text != nil && strings.TrimSpace(*text) != "" {
+			return strings.TrimSpace(*text)
+		}
+	}
+	return ""
+}
+
+// GetProteinFacets counts the proteins matching the filter by family, gene, taxon,
+// length and pI, as chosen by opts; unset options take the defaults above.
+func (uc *proteinUseCases) GetProteinFacets(ctx context.Context, filter *entities.ProteinFilter, opts *entities.ProteinFacetOptions) (*entities.ProteinFacets, error) {
+	if filter == nil || opts == nil {
+		return nil, ErrInvalidInput
+	}
+	if filter.MinSimilarity != nil && (*filter.MinSimilarity < 0 || *filter.MinSimilarity > 1) {
+		return nil, fmt.Errorf("%w: min_similarity must be between 0 and 1", ErrInvalidInput)
+	}
+	if err := parseFilterExpr(filter); err != nil {
+		return nil, err
+	}
+
+	if len(opts.Fields) == 0 {
+		opts.Fields = defaultFacetFields
+	}
+	seen := make(map[string]bool)
+	var fields []string
+	for _, field := range opts.Fields {
+		if !slices.Contains(defaultFacetFields, field) {
+			return nil, fmt.Errorf("%w: unknown facet %q (available: %s)", ErrInvalidInput, field, strings.Join(defaultFacetFields, ", "))
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	opts.Fields = fields
 
-	return uc.proteinRepo.Search(ctx, filter)
+	switch {
+	case opts.Size == 0:
+		opts.Size = defaultFacetSize
+	case opts.Size < 0:
+		return nil, fmt.Errorf("%w: facet size must be positive", ErrInvalidInput)
+	}
+	opts.Size = min(opts.Size, maxFacetSize)
+	if opts.LengthWidth == 0 {
+		opts.LengthWidth = defaultLengthWidth
+	}
+	if opts.LengthWidth < minLengthWidth {
+		return nil, fmt.Errorf("%w: length bucket width must be at least %d", ErrInvalidInput, minLengthWidth)
+	}
+	if opts.PIWidth == 0 {
+		opts.PIWidth = defaultPIWidth
+	}
+	if opts.PIWidth < minPIWidth {
+		return nil, fmt.Errorf("%w: pI bucket width must be at least %g", ErrInvalidInput, minPIWidth)
+	}
+
+	return uc.proteinRepo.Facets(ctx, filter, opts)
+}
+
+// AutocompleteProteins completes a prefix typed in a search box to stored protein
+// names, genes and aliases.
+func (uc *proteinUseCases) AutocompleteProteins(ctx context.Context, prefix string, limit int) ([]entities.SearchSuggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return []entities.SearchSuggestion{}, nil
+	}
+	if limit <= 0 {
+		limit = defaultCompletions
+	}
+	return uc.proteinRepo.Complete(ctx, prefix, min(limit, maxCompletions))
 }
 
-func (uc *proteinUseCases) GetProteinByID(ctx context.Context, id string) (*entities.Protein, error) {
+// GetProteinByID returns a protein with the related data named in include embedded.
+func (uc *proteinUseCases) GetProteinByID(ctx context.Context, id string, include ...string) (*entities.Protein, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, ErrInvalidInput
 	}
+	for _, name := range include {
+		if name != ProteinIncludeFeatures && name != ProteinIncludeDiseases {
+			return nil, fmt.Errorf("%w: unknown include %q", ErrInvalidInput, name)
+		}
+	}
 
 	protein, err := uc.proteinRepo.GetByID(ctx, id)
 	if err != nil {
@@ -117,6 +296,19 @@ func (uc *proteinUseCases) GetProteinByID(ctx context.Context, id string) (*enti
 	if protein == nil {
 		return nil, ErrProteinNotFound
 	}
+
+	for _, name := range include {
+		switch name {
+		case ProteinIncludeFeatures:
+			if protein.Features, err = uc.annotationRepo.GetFeatures(ctx, protein.ID); err != nil {
+				return nil, err
+			}
+		case ProteinIncludeDiseases:
+			if protein.Diseases, err = uc.diseaseRepo.GetProteinAssociations(ctx, protein.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return protein, nil
 }
 
@@ -129,7 +321,10 @@ func (uc *proteinUseCases) CreateProtein(ctx context.Context, req *ProteinCreate
 		return err
 	}
 
-	existing, _ := uc.proteinRepo.GetByID(ctx, req.ID)
+	existing, err := uc.proteinRepo.GetByID(ctx, req.ID)
+	if errors.Is(err, entities.ErrProteinDeleted) {
+		return err
+	}
 	if existing != nil {
 		return ErrProteinExists
 	}
@@ -142,9 +337,15 @@ func (uc *proteinUseCases) CreateProtein(ctx context.Context, req *ProteinCreate
 	if req.Gene != nil {
 		protein.SetGene(*req.Gene)
 	}
+	if req.Aliases != nil {
+		protein.SetAliases(req.Aliases)
+	}
 	if req.Taxo != nil {
 		protein.SetTaxonomy(*req.Taxo)
 	}
+	if req.TaxonID != nil {
+		protein.SetTaxonID(*req.TaxonID)
+	}
 	if req.CC != nil {
 		protein.CC = req.CC
 	}
@@ -171,17 +372,22 @@ func (uc *proteinUseCases) CreateProtein(ctx context.Context, req *ProteinCreate
 	return uc.proteinRepo.Create(ctx, protein)
 }
 
-func (uc *proteinUseCases) UpdateProtein(ctx context.Context, id string, req *ProteinUpdateRequest) error {
+// UpdateProtein applies the update to the protein if it is still at one of the ifMatch
+// versions; nil ifMatch applies it to whatever version is read.
+func (uc *proteinUseCases) UpdateProtein(ctx context.Context, id string, req *ProteinUpdateRequest, ifMatch []int) (*entities.Protein, error) {
 	if strings.TrimSpace(id) == "" || req == nil {
-		return ErrInvalidInput
+		return nil, ErrInvalidInput
 	}
 
 	protein, err := uc.proteinRepo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if protein == nil {
-		return ErrProteinNotFound
+		return nil, ErrProteinNotFound
+	}
+	if err := checkVersion(protein, ifMatch); err != nil {
+		return nil, err
 	}
 
 	if req.Name != nil {
@@ -189,10 +395,10 @@ func (uc *proteinUseCases) UpdateProtein(ctx context.Context, id string, req *Pr
 	}
 	if len(req.Seq) > 0 {
 		if err := uc.proteinService.ValidateSequence(req.Seq); err != nil {
-			return err
+			return nil, err
 		}
 		if err := protein.UpdateSequence(req.Seq); err != nil {
-			return err
+			return nil, err
 		}
 
 		fullSeq := protein.GetFullSequence()
@@ -208,9 +414,15 @@ func (uc *proteinUseCases) UpdateProtein(ctx context.Context, id string, req *Pr
 	if req.Gene != nil {
 		protein.SetGene(*req.Gene)
 	}
+	if req.Aliases != nil {
+		protein.SetAliases(req.Aliases)
+	}
 	if req.Taxo != nil {
 		protein.SetTaxonomy(*req.Taxo)
 	}
+	if req.TaxonID != nil {
+		protein.SetTaxonID(*req.TaxonID)
+	}
 	if req.CC != nil {
 		protein.CC = req.CC
 	}
@@ -225,10 +437,13 @@ func (uc *proteinUseCases) UpdateProtein(ctx context.Context, id string, req *Pr
 	}
 
 	protein.Updated = time.Now()
-	return uc.proteinRepo.Update(ctx, protein)
+	if err := uc.proteinRepo.Update(ctx, protein); err != nil {
+		return nil, err
+	}
+	return protein, nil
 }
 
-func (uc *proteinUseCases) DeleteProtein(ctx context.Context, id string) error {
+func (uc *proteinUseCases) DeleteProtein(ctx context.Context, id string, ifMatch []int) error {
 	if strings.TrimSpace(id) == "" {
 		return ErrInvalidInput
 	}
@@ -240,8 +455,71 @@ func (uc *proteinUseCases) DeleteProtein(ctx context.Context, id string) error {
 	if existing == nil {
 		return ErrProteinNotFound
 	}
+	if err := checkVersion(existing, ifMatch); err != nil {
+		return err
+	}
+
+	return uc.proteinRepo.Delete(ctx, id, existing.Version)
+}
+
+// checkVersion fails with entities.ErrVersionConflict unless the protein is at one of
+// the ifMatch versions; nil ifMatch matches any version. The repository checks the
+// version again as it writes, for writes made after this read.
+func checkVersion(protein *entities.Protein, ifMatch []int) error {
+	if ifMatch != nil && !slices.Contains(ifMatch, protein.Version) {
+		return entities.ErrVersionConflict
+	}
+	return nil
+}
+
+// ListDeletedProteins pages through the trash, most recently deleted first.
+func (uc *proteinUseCases) ListDeletedProteins(ctx context.Context, limit, offset int) (*entities.PaginatedProteins, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	deleted, total, err := uc.proteinRepo.ListDeleted(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	proteins := make([]entities.Protein, len(deleted))
+	for i, protein := range deleted {
+		proteins[i] = *protein
+	}
+	return &entities.PaginatedProteins{
+		Proteins: proteins,
+		Total:    &total,
+		Limit:    limit,
+		Offset:   offset,
+		HasMore:  offset+len(proteins) < total,
+	}, nil
+}
+
+// RestoreProtein takes a protein out of the trash, annotations and all.
+func (uc *proteinUseCases) RestoreProtein(ctx context.Context, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return ErrInvalidInput
+	}
+	err := uc.proteinRepo.Restore(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrProteinNotFound
+	}
+	return err
+}
 
-	return uc.proteinRepo.Delete(ctx, id)
+// PurgeDeletedProteins deletes for good the proteins that have been in the trash for
+// longer than the retention period.
+func (uc *proteinUseCases) PurgeDeletedProteins(ctx context.Context, retention time.Duration) (int, error) {
+	if retention < 0 {
+		return 0, ErrInvalidInput
+	}
+	return uc.proteinRepo.Purge(ctx, time.Now().Add(-retention))
 }
 
 func (uc *proteinUseCases) CompareProteins(ctx context.Context, req *ComparisonRequest) (*ComparisonResponse, error) {
@@ -250,12 +528,18 @@ func (uc *proteinUseCases) CompareProteins(ctx context.Context, req *ComparisonR
 	}
 
 	protein1, err := uc.proteinRepo.GetByID(ctx, req.ProteinID1)
-	if err != nil || protein1 == nil {
+	if err != nil {
+		return nil, err
+	}
+	if protein1 == nil {
 		return nil, ErrProteinNotFound
 	}
 
 	protein2, err := uc.proteinRepo.GetByID(ctx, req.ProteinID2)
-	if err != nil || protein2 == nil {
+	if err != nil {
+		return nil, err
+	}
+	if protein2 == nil {
 		return nil, ErrProteinNotFound
 	}
 
@@ -264,12 +548,59 @@ func (uc *proteinUseCases) CompareProteins(ctx context.Context, req *ComparisonR
 		return nil, err
 	}
 
-	return &ComparisonResponse{
+	result := &ComparisonResponse{
 		Protein1:   protein1,
 		Protein2:   protein2,
 		Similarity: similarity,
 		ComparedAt: time.Now(),
-	}, nil
+	}
+	if req.IncludeStructure {
+		if result.Structure, err = uc.compareStructures(ctx, protein1.ID, protein2.ID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// compareStructures superposes the linked chain of each protein that models the most
+// of it. It returns nil when either protein has no linked chain or the chains share
+// too few aligned residues to superpose.
+func (uc *proteinUseCases) compareStructures(ctx context.Context, proteinID1, proteinID2 string) (*response.StructureComparison, error) {
+	chain1, err := uc.bestStructureChain(ctx, proteinID1)
+	if err != nil || chain1 == nil {
+		return nil, err
+	}
+	chain2, err := uc.bestStructureChain(ctx, proteinID2)
+	if err != nil || chain2 == nil {
+		return nil, err
+	}
+
+	comparison, err := compareStructureChains(uc.structureService, chain1, chain2)
+	if errors.Is(err, ErrChainMismatch) {
+		return nil, nil
+	}
+	return comparison, err
+}
+
+func (uc *proteinUseCases) bestStructureChain(ctx context.Context, proteinID string) (*entities.StructureChain, error) {
+	chains, err := uc.structureRepo.GetChainsByProtein(ctx, proteinID)
+	if err != nil {
+		return nil, err
+	}
+	var best *entities.StructureChain
+	bestAligned := 0
+	for _, chain := range chains {
+		aligned := 0
+		for _, position := range chain.ProteinPositions {
+			if position > 0 {
+				aligned++
+			}
+		}
+		if aligned > bestAligned {
+			best, bestAligned = chain, aligned
+		}
+	}
+	return best, nil
 }
 
 func (uc *proteinUseCases) AnalyzeSequence(ctx context.Context, req *SequenceAnalysisRequest) (*SequenceAnalysisResponse, error) {
@@ -292,62 +623,16 @@ func (uc *proteinUseCases) AnalyzeSequence(ctx context.Context, req *SequenceAna
 	}, nil
 }
 
-func (uc *proteinUseCases) GetProteinStats(ctx context.Context) (*entities.ProteinStats, error) {
-	return uc.proteinRepo.GetStats(ctx)
-}
-
-func (uc *proteinUseCases) BulkCreateProteins(ctx context.Context, requests []*ProteinCreateRequest) error {
-	if len(requests) == 0 {
-		return ErrInvalidInput
+// GetProteinStats returns the summary statistics; with a rank (species, genus, family,
+// ...) the proteins are also counted by their ancestor taxon of that rank.
+func (uc *proteinUseCases) GetProteinStats(ctx context.Context, rank string) (*entities.ProteinStats, error) {
+	stats, err := uc.proteinRepo.GetStats(ctx)
+	if err != nil || rank == "" {
+		return stats, err
 	}
-
-	proteins := make([]*entities.Protein, 0, len(requests))
-
-	for _, req := range requests {
-		if req == nil {
-			continue
-		}
-
-		if err := uc.proteinService.ValidateSequence(req.Seq); err != nil {
-			return err
-		}
-
-		protein, err := entities.NewProtein(req.ID, req.Name, req.Seq)
-		if err != nil {
-			return err
-		}
-
-		if req.Gene != nil {
-			protein.SetGene(*req.Gene)
-		}
-		if req.Taxo != nil {
-			protein.SetTaxonomy(*req.Taxo)
-		}
-		if req.CC != nil {
-			protein.CC = req.CC
-		}
-		if req.Domain != nil {
-			protein.Domain = req.Domain
-		}
-		if req.Family != nil {
-			protein.Family = req.Family
-		}
-		if req.Function != nil {
-			protein.Function = req.Function
-		}
-
-		fullSeq := protein.GetFullSequence()
-		mw := uc.proteinService.CalculateMolecularWeight(fullSeq)
-		protein.SetMolecularWeight(mw)
-
-		pi := uc.proteinService.CalculateIsoelectricPoint(fullSeq)
-		protein.PI = &pi
-
-		hydro := uc.proteinService.CalculateHydrophobicity(fullSeq)
-		protein.HydrophobicityGravy = &hydro
-
-		proteins = append(proteins, protein)
+	stats.Rank = strings.ToLower(strings.TrimSpace(rank))
+	if stats.ByRank, err = uc.proteinRepo.GetRankCounts(ctx, stats.Rank); err != nil {
+		return nil, err
 	}
-
-	return uc.proteinRepo.BulkCreate(ctx, proteins)
+	return stats, nil
 }
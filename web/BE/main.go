@@ -32,7 +32,10 @@ import (
 	"go-crawler/web/BE/internal/domain/services"
 	"go-crawler/web/BE/internal/infrastructure/config"
 	"go-crawler/web/BE/internal/infrastructure/database"
+	"go-crawler/web/BE/internal/infrastructure/formats"
+	"go-crawler/web/BE/internal/infrastructure/jobs"
 	"go-crawler/web/BE/internal/infrastructure/repositories"
+	"go-crawler/web/BE/internal/infrastructure/storage"
 	"go-crawler/web/BE/internal/interfaces/handlers"
 	"go-crawler/web/BE/internal/usecases"
 
@@ -60,14 +63,73 @@ func main() {
 	mlHandler := handlers.NewMLHandler(mlServiceURL)
 	
 	// Initialize dependencies
-	proteinHandler := handlers.NewProteinHandler(usecases.NewProteinUseCases(repositories.NewProteinRepository(db.Conn), services.NewProteinService()))
+	proteinRepo := repositories.NewProteinRepository(db.Conn)
+	proteinService := services.NewProteinService()
+	structureRepo := repositories.NewStructureRepository(db.Conn)
+	structureService := services.NewStructureService()
+	annotationRepo := repositories.NewAnnotationRepository(db.Conn)
+	diseaseRepo := repositories.NewDiseaseRepository(db.Conn)
+	jobManager := jobs.NewManager(time.Hour)
+
+	proteinUseCases := usecases.NewProteinUseCases(proteinRepo, structureRepo, annotationRepo, diseaseRepo, proteinService, structureService)
+	proteinHandler := handlers.NewProteinHandler(proteinUseCases, cfg.Server.RequireIfMatch)
+	jobHandler := handlers.NewJobHandler(usecases.NewJobUseCases(jobManager))
+	mutagenesisHandler := handlers.NewMutagenesisHandler(usecases.NewMutagenesisUseCases(proteinRepo, services.NewMutagenesisService(proteinService), jobManager))
+	pssmHandler := handlers.NewPSSMHandler(usecases.NewPSSMUseCases(repositories.NewPSSMRepository(db.Conn), proteinRepo, services.NewProfileService()))
+
+	// Profile HMMs are read from local files only; annotation is disabled when none load
+	hmmModels, err := formats.LoadHMMDirectory(cfg.HMM.ModelDir)
+	if err != nil {
+		log.Printf("Failed to load profile HMMs from %s: %v", cfg.HMM.ModelDir, err)
+	}
+	domainUseCases := usecases.NewDomainAnnotationUseCases(proteinRepo, repositories.NewDomainHitRepository(db.Conn), services.NewHMMService(), hmmModels, cfg.HMM.EValue)
+	domainHandler := handlers.NewDomainHandler(domainUseCases)
+	importRepo := repositories.NewImportRepository(db.Conn)
+	fastaHandler := handlers.NewFASTAHandler(usecases.NewFASTAUseCases(proteinRepo, importRepo, proteinService))
+	importHandler := handlers.NewImportHandler(usecases.NewImportUseCases(proteinRepo, importRepo, proteinService))
+	annotationHandler := handlers.NewAnnotationHandler(usecases.NewAnnotationUseCases(proteinRepo, annotationRepo))
+	uniProtUseCases := usecases.NewUniProtUseCases(importRepo, proteinService)
+	goUseCases := usecases.NewGeneOntologyUseCases(proteinRepo, repositories.NewOntologyRepository(db.Conn))
+	goHandler := handlers.NewGeneOntologyHandler(goUseCases)
+	taxonomyUseCases := usecases.NewTaxonomyUseCases(proteinRepo, repositories.NewTaxonomyRepository(db.Conn))
+	taxonomyHandler := handlers.NewTaxonomyHandler(taxonomyUseCases)
+	diseaseUseCases := usecases.NewDiseaseUseCases(proteinRepo, diseaseRepo)
+	diseaseHandler := handlers.NewDiseaseHandler(diseaseUseCases)
+	geneHandler := handlers.NewGeneHandler(usecases.NewGeneUseCases(proteinRepo, repositories.NewGeneRepository(db.Conn)))
+	familyHandler := handlers.NewFamilyHandler(usecases.NewFamilyUseCases(proteinRepo, repositories.NewProteinFamilyRepository(db.Conn)))
+	revisionHandler := handlers.NewRevisionHandler(usecases.NewRevisionUseCases(proteinRepo, repositories.NewRevisionRepository(db.Conn)), cfg.Server.RequireIfMatch)
+	structureStore, err := storage.NewFileStore(cfg.Storage.StructureDir, int64(cfg.Storage.MaxStructureSizeMB)<<20)
+	if err != nil {
+		log.Fatal(err)
+	}
+	structureHandler := handlers.NewStructureHandler(usecases.NewStructureUseCases(structureRepo, proteinRepo, structureStore, structureService))
+	conservationHandler := handlers.NewConservationHandler(usecases.NewConservationUseCases(proteinRepo, services.NewConservationService()))
+
+	// Admin commands run instead of the server
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:], db, cfg, proteinUseCases, domainUseCases, uniProtUseCases, goUseCases, taxonomyUseCases, diseaseUseCases); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Apply pending schema migrations when enabled; replicas take turns via an advisory lock
+	if cfg.Database.AutoMigrate {
+		group, err := db.Migrate(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !group.IsZero() {
+			log.Printf("Applied migrations: %s", group)
+		}
+	}
 
 	// Setup Gin router
 	gin.SetMode(cfg.Server.Mode)
 	router := gin.Default()
 
 	// Setup routes
-	api.SetUpRoutes(router, proteinHandler, mlHandler)
+	api.SetUpRoutes(router, proteinHandler, mlHandler, jobHandler, mutagenesisHandler, pssmHandler, domainHandler, conservationHandler, fastaHandler, importHandler, annotationHandler, structureHandler, goHandler, taxonomyHandler, diseaseHandler, geneHandler, familyHandler, revisionHandler)
 
 	// Setup Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -91,6 +153,13 @@ func main() {
 		}
 	}()
 
+	// Purge proteins that have been in the trash past the retention period
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	if cfg.Trash.PurgeIntervalMinutes > 0 {
+		go purgeTrashEvery(purgeCtx, proteinUseCases, trashRetention(cfg.Trash), time.Duration(cfg.Trash.PurgeIntervalMinutes)*time.Minute)
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -52,9 +52,43 @@ function initializeSearch() {
                 performSearch();
             }
         });
+
+        initializeAutocomplete(searchInput);
     }
 }
 
+/**
+ * Offer stored protein names, genes and aliases as the user types
+ */
+function initializeAutocomplete(searchInput) {
+    const datalist = document.createElement('datalist');
+    datalist.id = 'search-suggestions';
+    searchInput.insertAdjacentElement('afterend', datalist);
+    searchInput.setAttribute('list', datalist.id);
+    searchInput.setAttribute('autocomplete', 'off');
+
+    searchInput.addEventListener('input', debounce(async function() {
+        const prefix = this.value.trim();
+        if (prefix.length < 2) {
+            datalist.replaceChildren();
+            return;
+        }
+
+        try {
+            const result = await autocompleteProteins(prefix, 10);
+            const completions = result?.data || [];
+            datalist.replaceChildren(...completions.map(completion => {
+                const option = document.createElement('option');
+                option.value = completion.text;
+                option.label = `${completion.field} · ${completion.proteins} protein${completion.proteins === 1 ? '' : 's'}`;
+                return option;
+            }));
+        } catch (error) {
+            console.error('Autocomplete failed:', error);
+        }
+    }, 150));
+}
+
 /**
  * Perform search with backend integration - Always call backend first
  */
@@ -76,7 +110,8 @@ async function performSearch(query = '') {
             if (query.startsWith('P') || query.includes('_') || query.includes('A0A')) {
                 filters.id = query;
             } else {
-                filters.name = query;
+                // Typo-tolerant match on name, gene and aliases
+                filters.fuzzy = query;
             }
         }
 
@@ -87,7 +122,7 @@ async function performSearch(query = '') {
         if (proteinsData && proteinsData.data) {
             // Backend returned data
             const proteins = Array.isArray(proteinsData.data) ? proteinsData.data : proteinsData.data.proteins || [];
-            displaySearchResults(proteins);
+            displaySearchResults(proteins, proteinsData.data.suggestions || []);
             updateResultCount(proteins.length);
             showNotification('Search completed from database', 'success');
             return;
@@ -161,9 +196,9 @@ function updateSearchQuery(query) {
 }
 
 /**
- * Display search results
+ * Display search results, with "did you mean" suggestions when nothing matched
  */
-function displaySearchResults(proteins) {
+function displaySearchResults(proteins, suggestions = []) {
     const tbody = document.querySelector('#protein-table-body');
     if (!tbody) return;
 
@@ -172,9 +207,11 @@ function displaySearchResults(proteins) {
             <tr>
                 <td colspan="8" class="text-center py-8 text-gray-500">
                     No proteins found matching your search criteria.
+                    <div id="did-you-mean" class="mt-2 hidden">Did you mean: </div>
                 </td>
             </tr>
         `;
+        showSuggestions(suggestions);
         return;
     }
 
@@ -208,6 +245,36 @@ function displaySearchResults(proteins) {
     attachSaveButtonListeners();
 }
 
+/**
+ * Render suggestions as links that search for the suggested term
+ */
+function showSuggestions(suggestions) {
+    const container = document.getElementById('did-you-mean');
+    if (!container || suggestions.length === 0) return;
+
+    suggestions.forEach((suggestion, i) => {
+        if (i > 0) {
+            container.append(', ');
+        }
+        const link = document.createElement('a');
+        link.href = '#';
+        link.className = 'text-primary font-medium hover:underline';
+        link.textContent = suggestion.text;
+        link.title = `${suggestion.field}, ${suggestion.proteins} protein${suggestion.proteins === 1 ? '' : 's'}`;
+        link.addEventListener('click', function(e) {
+            e.preventDefault();
+            const searchInput = document.getElementById('search-input');
+            if (searchInput) {
+                searchInput.value = suggestion.text;
+            }
+            performSearch(suggestion.text);
+        });
+        container.append(link);
+    });
+    container.append('?');
+    container.classList.remove('hidden');
+}
+
 /**
  * Update result count
  */
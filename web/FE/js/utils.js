@@ -185,6 +185,14 @@ async function searchProteins(filters = {}) {
     return await apiCall(`/proteins${queryString ? '?' + queryString : ''}`);
 }
 
+/**
+ * Complete a typed prefix to protein names, genes and aliases
+ */
+async function autocompleteProteins(prefix, limit = 10) {
+    const params = new URLSearchParams({ q: prefix, limit });
+    return await apiCall(`/proteins/autocomplete?${params.toString()}`);
+}
+
 /**
  * Update protein by ID
  */